@@ -0,0 +1,58 @@
+#!/bin/bash
+
+set -euo pipefail
+
+# Source the common script
+source ./scripts/common.sh
+
+SERVICES=(
+    adservice
+    cartservice
+    checkoutservice
+    emailservice
+    frontend
+    orderservice
+    paymentservice
+    productcatalogservice
+    recommendationservice
+    shippingservice
+)
+
+TAG="main"
+
+build_image() {
+    local service="$1"
+    log "Building kurtosistech/$service:$TAG..."
+    # The context is src/ so that the libs module and the other modules replaced in go.mod are included
+    run_command_with_spinner docker build -f "./src/$service/Dockerfile" -t "kurtosistech/$service:$TAG" ./src || log_error "Failed to build the image of $service"
+}
+
+main() {
+    local services=()
+    while [ $# -gt 0 ]; do
+        case "$1" in
+            --verbose)
+                VERBOSE=true
+                log "Verbose mode enabled."
+                ;;
+            --tag)
+                shift
+                TAG="$1"
+                ;;
+            *)
+                services+=("$1")
+                ;;
+        esac
+        shift
+    done
+
+    if [ ${#services[@]} -eq 0 ]; then
+        services=("${SERVICES[@]}")
+    fi
+
+    for service in "${services[@]}"; do
+        build_image "$service"
+    done
+}
+
+main "$@"
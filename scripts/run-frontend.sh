@@ -8,6 +8,11 @@ source ./scripts/common.sh
 run_frontend() {
     export CARTSERVICEHOST="cartservice"
     export PRODUCTCATALOGSERVICEHOST="productcatalogservice"
+    export CHECKOUTSERVICEHOST="checkoutservice"
+    export SHIPPINGSERVICEHOST="shippingservice"
+    export RECOMMENDATIONSERVICEHOST="recommendationservice"
+    export ADSERVICEHOST="adservice"
+    export ORDERSERVICEHOST="orderservice"
     cd ./src/frontend
     go build -o frontend
     ./frontend
@@ -0,0 +1,129 @@
+// Package ads serves the contextual ads, picked by the categories of the products they're shown with, and counts the
+// clicks on them
+package ads
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+
+	adservice_rest_types "github.com/kurtosis-tech/new-obd/src/adservice/api/http_rest/types"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxLimit is the maximum number of ads returned by ListAds
+const MaxLimit = 10
+
+type Service struct {
+	inventory []Ad
+	byID      map[string]Ad
+
+	mutex sync.Mutex
+	// clicks are counted in memory, they're lost when the service stops
+	clicks map[string]int64
+
+	shuffle func(n int, swap func(i, j int))
+}
+
+func NewService(inventory []Ad) *Service {
+	byID := make(map[string]Ad, len(inventory))
+	for _, ad := range inventory {
+		byID[ad.ID] = ad
+	}
+	return &Service{
+		inventory: inventory,
+		byID:      byID,
+		clicks:    map[string]int64{},
+		shuffle:   rand.Shuffle,
+	}
+}
+
+// ListAds returns up to limit ads sharing a category with the given ones, in a random order. When no ad matches, the
+// ads are picked from the whole inventory so there's always something to show.
+func (s *Service) ListAds(ctx context.Context, categories []string, limit int) (*adservice_rest_types.ListAdsResponse, error) {
+	if limit < 1 || limit > MaxLimit {
+		return nil, newInvalidArgumentError("the limit must be between 1 and %d, got %d", MaxLimit, limit)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	candidates := s.matchingAds(categories)
+	if len(candidates) == 0 {
+		candidates = append([]Ad{}, s.inventory...)
+	}
+	s.shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+
+	response := &adservice_rest_types.ListAdsResponse{Ads: []adservice_rest_types.Ad{}}
+	for _, ad := range candidates {
+		if len(response.Ads) == limit {
+			break
+		}
+		response.Ads = append(response.Ads, adservice_rest_types.Ad{
+			Id:          ad.ID,
+			Text:        ad.Text,
+			RedirectUrl: ad.RedirectURL,
+		})
+	}
+	return response, nil
+}
+
+// Click counts a click on the ad and returns the page it advertises
+func (s *Service) Click(ctx context.Context, adID string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	ad, found := s.byID[adID]
+	if !found {
+		return "", newAdError(ErrNotFound, fmt.Errorf("there's no ad '%s'", adID))
+	}
+
+	s.mutex.Lock()
+	s.clicks[adID]++
+	s.mutex.Unlock()
+
+	logrus.Infof("Ad '%s' was clicked, redirecting to '%s'", adID, ad.RedirectURL)
+	return ad.RedirectURL, nil
+}
+
+// Clicks returns the number of clicks on every ad of the inventory, by ad ID
+func (s *Service) Clicks() *adservice_rest_types.ListClicksResponse {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	response := &adservice_rest_types.ListClicksResponse{Clicks: make([]adservice_rest_types.AdClicks, 0, len(s.inventory))}
+	for _, ad := range s.inventory {
+		response.Clicks = append(response.Clicks, adservice_rest_types.AdClicks{
+			AdId:   ad.ID,
+			Clicks: s.clicks[ad.ID],
+		})
+	}
+	sort.Slice(response.Clicks, func(i, j int) bool {
+		return response.Clicks[i].AdId < response.Clicks[j].AdId
+	})
+	return response
+}
+
+// matchingAds returns the ads sharing at least one category with the given ones, the categories are case-insensitive
+func (s *Service) matchingAds(categories []string) []Ad {
+	wanted := make(map[string]struct{}, len(categories))
+	for _, category := range categories {
+		wanted[strings.ToLower(strings.TrimSpace(category))] = struct{}{}
+	}
+
+	matching := []Ad{}
+	for _, ad := range s.inventory {
+		for _, category := range ad.Categories {
+			if _, found := wanted[strings.ToLower(category)]; found {
+				matching = append(matching, ad)
+				break
+			}
+		}
+	}
+	return matching
+}
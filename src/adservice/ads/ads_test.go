@@ -0,0 +1,104 @@
+package ads
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService() *Service {
+	service := NewService([]Ad{
+		{ID: "sunglasses-sale", Text: "Sunglasses for half price", RedirectURL: "/product/sunglasses", Categories: []string{"accessories"}},
+		{ID: "watch-sale", Text: "A watch for every wrist", RedirectURL: "/product/watch", Categories: []string{"accessories"}},
+		{ID: "loafers-sale", Text: "Loafers for the summer", RedirectURL: "/product/loafers", Categories: []string{"footwear"}},
+		{ID: "mug-sale", Text: "Mugs for the coffee lovers", RedirectURL: "/product/mug", Categories: []string{"kitchen"}},
+	})
+	// keep the inventory order so the ads are predictable
+	service.shuffle = func(n int, swap func(i, j int)) {}
+	return service
+}
+
+func listAdIDs(t *testing.T, service *Service, categories []string, limit int) []string {
+	response, err := service.ListAds(context.Background(), categories, limit)
+	require.NoError(t, err)
+	ids := []string{}
+	for _, ad := range response.Ads {
+		ids = append(ids, ad.Id)
+	}
+	return ids
+}
+
+func TestListAdsReturnsTheAdsOfTheCategories(t *testing.T) {
+	service := newTestService()
+
+	require.Equal(t, []string{"sunglasses-sale", "watch-sale"}, listAdIDs(t, service, []string{"Accessories"}, 5))
+	require.Equal(t, []string{"sunglasses-sale", "watch-sale", "loafers-sale"}, listAdIDs(t, service, []string{"footwear", "accessories"}, 3))
+}
+
+func TestListAdsFallsBackToTheWholeInventory(t *testing.T) {
+	service := newTestService()
+
+	require.Equal(t, []string{"sunglasses-sale", "watch-sale", "loafers-sale"}, listAdIDs(t, service, []string{"unknown"}, 3))
+	require.Equal(t, []string{"sunglasses-sale"}, listAdIDs(t, service, nil, 1))
+}
+
+func TestListAdsRejectsAnInvalidLimit(t *testing.T) {
+	service := newTestService()
+
+	for _, limit := range []int{0, MaxLimit + 1} {
+		_, err := service.ListAds(context.Background(), nil, limit)
+		require.ErrorIs(t, err, ErrInvalidArgument)
+	}
+}
+
+func TestClickCountsTheClicksAndReturnsTheRedirectURL(t *testing.T) {
+	service := newTestService()
+
+	for i := 0; i < 2; i++ {
+		redirectURL, err := service.Click(context.Background(), "watch-sale")
+		require.NoError(t, err)
+		require.Equal(t, "/product/watch", redirectURL)
+	}
+	_, err := service.Click(context.Background(), "mug-sale")
+	require.NoError(t, err)
+
+	clicks := map[string]int64{}
+	for _, adClicks := range service.Clicks().Clicks {
+		clicks[adClicks.AdId] = adClicks.Clicks
+	}
+	require.Equal(t, map[string]int64{"loafers-sale": 0, "mug-sale": 1, "sunglasses-sale": 0, "watch-sale": 2}, clicks)
+}
+
+func TestClickFailsForAnUnknownAd(t *testing.T) {
+	service := newTestService()
+
+	_, err := service.Click(context.Background(), "unknown")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLoadInventoryRejectsAnInvalidInventory(t *testing.T) {
+	for name, content := range map[string]string{
+		"empty":        `{"ads": []}`,
+		"duplicate ID": `{"ads": [{"id": "a", "text": "A", "redirect_url": "/a"}, {"id": "a", "text": "B", "redirect_url": "/b"}]}`,
+		"missing text": `{"ads": [{"id": "a", "redirect_url": "/a"}]}`,
+		"missing URL":  `{"ads": [{"id": "a", "text": "A"}]}`,
+		"invalid JSON": `{"ads": [`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ads.json")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			_, err := LoadInventory(path)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadInventoryLoadsTheDefaultInventory(t *testing.T) {
+	inventory, err := LoadInventory(filepath.Join("..", "data", "ads.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, inventory)
+}
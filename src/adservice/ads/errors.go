@@ -0,0 +1,34 @@
+package ads
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of errors returned by the service, check them with errors.Is
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrNotFound        = errors.New("not found")
+)
+
+// adError tags the cause with one of the error kinds
+type adError struct {
+	kind  error
+	cause error
+}
+
+func newAdError(kind error, cause error) error {
+	return &adError{kind: kind, cause: cause}
+}
+
+func newInvalidArgumentError(format string, args ...interface{}) error {
+	return newAdError(ErrInvalidArgument, fmt.Errorf(format, args...))
+}
+
+func (e *adError) Error() string {
+	return fmt.Sprintf("%s: %s", e.kind.Error(), e.cause.Error())
+}
+
+func (e *adError) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
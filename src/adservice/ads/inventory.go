@@ -0,0 +1,63 @@
+package ads
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Ad is an ad of the inventory, shown with the products of its categories
+type Ad struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	// RedirectURL is the page the ad advertises, e.g. /product/OLJCESPC7Z
+	RedirectURL string   `json:"redirect_url"`
+	Categories  []string `json:"categories"`
+}
+
+// inventoryFile is the format of the inventory file, like the products.json of the product catalog
+type inventoryFile struct {
+	Ads []Ad `json:"ads"`
+}
+
+// LoadInventory reads the ads from a JSON file, an invalid ad fails the whole file so it's noticed at startup
+func LoadInventory(path string) ([]Ad, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "An error occurred reading the ad inventory '%s'", path)
+	}
+
+	var inventory inventoryFile
+	if err := json.Unmarshal(content, &inventory); err != nil {
+		return nil, errors.Wrapf(err, "An error occurred parsing the ad inventory '%s'", path)
+	}
+	if err := validateInventory(inventory.Ads); err != nil {
+		return nil, errors.Wrapf(err, "The ad inventory '%s' is invalid", path)
+	}
+	return inventory.Ads, nil
+}
+
+func validateInventory(inventory []Ad) error {
+	if len(inventory) == 0 {
+		return errors.New("there are no ads")
+	}
+	ids := map[string]struct{}{}
+	for i, ad := range inventory {
+		if ad.ID == "" {
+			return errors.Errorf("ad #%d has no ID", i)
+		}
+		if _, found := ids[ad.ID]; found {
+			return errors.Errorf("there are several ads with ID '%s'", ad.ID)
+		}
+		ids[ad.ID] = struct{}{}
+		if strings.TrimSpace(ad.Text) == "" {
+			return errors.Errorf("ad '%s' has no text", ad.ID)
+		}
+		if ad.RedirectURL == "" {
+			return errors.Errorf("ad '%s' has no redirect URL", ad.ID)
+		}
+	}
+	return nil
+}
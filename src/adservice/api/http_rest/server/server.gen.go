@@ -0,0 +1,628 @@
+// Package adservice_server_rest_server provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.1-0.20240604070534-2f0ff757704b DO NOT EDIT.
+package adservice_server_rest_server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	. "github.com/kurtosis-tech/new-obd/src/adservice/api/http_rest/types"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List ads
+	// (GET /ads)
+	GetAds(ctx echo.Context, params GetAdsParams) error
+	// Click ad
+	// (GET /ads/{id}/click)
+	GetAdsIdClick(ctx echo.Context, id Id) error
+	// List clicks
+	// (GET /clicks)
+	GetClicks(ctx echo.Context) error
+	// Health check endpoint
+	// (GET /health)
+	GetHealth(ctx echo.Context) error
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx echo.Context) error
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetAds converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAds(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdsParams
+	// ------------- Optional query parameter "categories" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "categories", ctx.QueryParams(), &params.Categories)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter categories: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetAds(ctx, params)
+	return err
+}
+
+// GetAdsIdClick converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAdsIdClick(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetAdsIdClick(ctx, id)
+	return err
+}
+
+// GetClicks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetClicks(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetClicks(ctx)
+	return err
+}
+
+// GetHealth converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealth(ctx)
+	return err
+}
+
+// GetHealthLive converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthLive(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthLive(ctx)
+	return err
+}
+
+// GetHealthReady converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthReady(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthReady(ctx)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/ads", wrapper.GetAds)
+	router.GET(baseURL+"/ads/:id/click", wrapper.GetAdsIdClick)
+	router.GET(baseURL+"/clicks", wrapper.GetClicks)
+	router.GET(baseURL+"/health", wrapper.GetHealth)
+	router.GET(baseURL+"/health/live", wrapper.GetHealthLive)
+	router.GET(baseURL+"/health/ready", wrapper.GetHealthReady)
+
+}
+
+type NotOkJSONResponse ResponseInfo
+
+type GetAdsRequestObject struct {
+	Params GetAdsParams
+}
+
+type GetAdsResponseObject interface {
+	VisitGetAdsResponse(w http.ResponseWriter) error
+}
+
+type GetAds200JSONResponse ListAdsResponse
+
+func (response GetAds200JSONResponse) VisitGetAdsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdsdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetAdsdefaultJSONResponse) VisitGetAdsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAdsIdClickRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type GetAdsIdClickResponseObject interface {
+	VisitGetAdsIdClickResponse(w http.ResponseWriter) error
+}
+
+type GetAdsIdClick302ResponseHeaders struct {
+	Location string
+}
+
+type GetAdsIdClick302Response struct {
+	Headers GetAdsIdClick302ResponseHeaders
+}
+
+func (response GetAdsIdClick302Response) VisitGetAdsIdClickResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(302)
+	return nil
+}
+
+type GetAdsIdClickdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetAdsIdClickdefaultJSONResponse) VisitGetAdsIdClickResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetClicksRequestObject struct {
+}
+
+type GetClicksResponseObject interface {
+	VisitGetClicksResponse(w http.ResponseWriter) error
+}
+
+type GetClicks200JSONResponse ListClicksResponse
+
+func (response GetClicks200JSONResponse) VisitGetClicksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClicksdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetClicksdefaultJSONResponse) VisitGetClicksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthRequestObject struct {
+}
+
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
+}
+
+type GetHealth200JSONResponse HealthResponse
+
+func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthdefaultJSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthLiveRequestObject struct {
+}
+
+type GetHealthLiveResponseObject interface {
+	VisitGetHealthLiveResponse(w http.ResponseWriter) error
+}
+
+type GetHealthLive200JSONResponse HealthResponse
+
+func (response GetHealthLive200JSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthLivedefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthLivedefaultJSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthReadyRequestObject struct {
+}
+
+type GetHealthReadyResponseObject interface {
+	VisitGetHealthReadyResponse(w http.ResponseWriter) error
+}
+
+type GetHealthReady200JSONResponse HealthResponse
+
+func (response GetHealthReady200JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReady503JSONResponse HealthResponse
+
+func (response GetHealthReady503JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReadydefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthReadydefaultJSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List ads
+	// (GET /ads)
+	GetAds(ctx context.Context, request GetAdsRequestObject) (GetAdsResponseObject, error)
+	// Click ad
+	// (GET /ads/{id}/click)
+	GetAdsIdClick(ctx context.Context, request GetAdsIdClickRequestObject) (GetAdsIdClickResponseObject, error)
+	// List clicks
+	// (GET /clicks)
+	GetClicks(ctx context.Context, request GetClicksRequestObject) (GetClicksResponseObject, error)
+	// Health check endpoint
+	// (GET /health)
+	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx context.Context, request GetHealthLiveRequestObject) (GetHealthLiveResponseObject, error)
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx context.Context, request GetHealthReadyRequestObject) (GetHealthReadyResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetAds operation middleware
+func (sh *strictHandler) GetAds(ctx echo.Context, params GetAdsParams) error {
+	var request GetAdsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAds(ctx.Request().Context(), request.(GetAdsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAds")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetAdsResponseObject); ok {
+		return validResponse.VisitGetAdsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetAdsIdClick operation middleware
+func (sh *strictHandler) GetAdsIdClick(ctx echo.Context, id Id) error {
+	var request GetAdsIdClickRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdsIdClick(ctx.Request().Context(), request.(GetAdsIdClickRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdsIdClick")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetAdsIdClickResponseObject); ok {
+		return validResponse.VisitGetAdsIdClickResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetClicks operation middleware
+func (sh *strictHandler) GetClicks(ctx echo.Context) error {
+	var request GetClicksRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetClicks(ctx.Request().Context(), request.(GetClicksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetClicks")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetClicksResponseObject); ok {
+		return validResponse.VisitGetClicksResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealth operation middleware
+func (sh *strictHandler) GetHealth(ctx echo.Context) error {
+	var request GetHealthRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealth(ctx.Request().Context(), request.(GetHealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealth")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthResponseObject); ok {
+		return validResponse.VisitGetHealthResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthLive operation middleware
+func (sh *strictHandler) GetHealthLive(ctx echo.Context) error {
+	var request GetHealthLiveRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthLive(ctx.Request().Context(), request.(GetHealthLiveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthLive")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthLiveResponseObject); ok {
+		return validResponse.VisitGetHealthLiveResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthReady operation middleware
+func (sh *strictHandler) GetHealthReady(ctx echo.Context) error {
+	var request GetHealthReadyRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthReady(ctx.Request().Context(), request.(GetHealthReadyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthReady")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthReadyResponseObject); ok {
+		return validResponse.VisitGetHealthReadyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/8xY8W/bthL+Vw5876G/KLaapC2egWEw0q7LFiRFkqJAu6JgxJPFRiJV8uTECPy/D0fJ",
+	"lmUpTrCsW4GikCjy7rvv7nifcycSW5TWoCEvJneilE4WSOjCm1b8v0KfOF2StkZMhFSglYiE5pdSUiYi",
+	"YWSBYiLCusNvlXaoxIRchZHwSYaFZDuFvD1BM6NMTF4eRqLQZvX6PBK0KNmEJ6fNTCyXS7bkS2s8Biin",
+	"ls6u+SGxhtAQP8qyzHUiGdj4q2d0dxvu/uswFRPxn3Eb4bj+6sfnjeljk9raWTfI9wZvS0wIFaBz1gne",
+	"0hxm29NATOlsiY40bpCFt7Io8xBKZWa59B79npc5il6MHKHSDhP6Urm8zzRlCKWcIfCDVCDVnL159FFY",
+	"SnKdXHvwma1yBTMLlDlbzTIYS+XHd1otx2ELkIUrhMRWhpBT1GIcl86qKqHx2clvR28u3h29+jiEk/CW",
+	"usFN51qSddAGCSm/yhxH8CL+H9g0HfVNLTfr41NdMMH4Fhef1yft1VdMiEFM1VEIuM+8VF/+AvnJ2lpq",
+	"XSFJTIQ29PKw3asN4QxdD3btb21hCOxrLNEoNMniKMPkuo+5LqvJXR9XLonPfSl8J6SD6GGcq07cOCaU",
+	"JHkl/SAFniRVXTfCXj+YtuBkfbqDeIiLX1HmlK1ark9FwgyFJ01Y+Id6d5va5dqldE4u7ovr/bvBytYF",
+	"epJF2d28H+8f7sWv9vb/fxnHk/Dvo9hIgJKEe3x2kKseAyfa01T5+ymQ6vHxT1U/5F6BDmeCcdRNtCMb",
+	"67Z4JJra4IOYdjRL5zbuA7IKO11aaUMH+4PlX6D3coaDfVUvPG4uXPLe7QiCgdZHVCPbFdBl4xJNVbCF",
+	"N+fnZ+ciEsenv5yJSHyYnp8en77dMLE5/nTDRnconL+5uEyrHKbvjsGXmOi0mYDh/uWxMFXg0c11ghFo",
+	"euah8qh4BsiK7N4MDTpJYXqgIbh4/fszD9LUh9Dtea14VqhQ2prq235tUkRijs7XWOLR81HM8doSjSy1",
+	"mIiDUTw6EFEQBiF746a0Z0gDwSBVzniQyq/hJ5JwZp3mKYej2SgsWoMebBqem5EFV6jNDOYab1BFoA1I",
+	"cNIoW4B1Ct0IPmRowFgenYWkJEPfc2Ad3PAuytAhSIe8f3NDPXp9+FTq5BoVpM4WYf0mszmCNnM0ZN2C",
+	"xx3XbcjGsRIT8Ra560XUUVWfhsZ865ITFdA20f/cfvpJJgl6H57/qOJ4/+XGt9RaukHpVsrsW4Vu0Uqz",
+	"dqfYlGTrFr+nW9peHkJdyFtdVAWYqrhCx/lhqlxIKqp7kOS60NQBoTCVVU5BBm6OuNDjjQ8xeR4Hydi8",
+	"DMzoz1uKcT+O/za9uH2BD0jGiypkh3vTrbdFbXDDDtaIx7XAZbu+KgrpFmIS7mvmNCxvqbp7m+qIdZ4H",
+	"WetDsGatH42ClcoKdbYWmJo2xOUIpgYqc23sjeFjmm0dxof31fdxPQH6ZT4Ub7tlrJXo5ewg3h+6JWrM",
+	"Hcg9TSwikaFUzS+XE1vnuZvi3i371ASFyEGqOkHt6Nx52zH0tmXqQ5wmnKNbcFDNRbe+WcBrk9QhN9cw",
+	"eJKOUA3mpBnI37kdtnTEP9kRDc+B8yyIy0dxXm+FWhyuSG4IHSSyFq7fk8gtaTxEYpNw7Rv8iydzWDuF",
+	"ILsBjSqtNrTJ5jjXc3yQUnsNN5nOcTWUOdfhsrjiNRuYRWABhZ48axFQFr15Ro1rPqdWYl6j35GDEwb0",
+	"o+RBBnqeXslzNEzZjjw4lGpx/0XPB32Pxq3KDsQ7LK0jD8bys1QL0ClIs2j2FpBKnXuWQ7pzmMP1WUXE",
+	"UkvZG7MjR+cB7I+SpDpKsuAwQT1HICfTVCectxfxwb8DqqV/GNiTCor518MVxfuCuh/Sn6245x8VIhLh",
+	"j1EiIyr9ZMyqo/4qlp+Xfw4AeFd2YS8UAAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
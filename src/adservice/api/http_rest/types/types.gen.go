@@ -0,0 +1,80 @@
+// Package adservice_rest_types provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.1-0.20240604070534-2f0ff757704b DO NOT EDIT.
+package adservice_rest_types
+
+import (
+	"time"
+)
+
+// Defines values for ResponseType.
+const (
+	ERROR   ResponseType = "ERROR"
+	INFO    ResponseType = "INFO"
+	WARNING ResponseType = "WARNING"
+)
+
+// Ad defines model for Ad.
+type Ad struct {
+	Id string `json:"id"`
+
+	// RedirectUrl the page the ad advertises, the clicks should go through /ads/{id}/click to be counted
+	RedirectUrl string `json:"redirect_url"`
+	Text        string `json:"text"`
+}
+
+// AdClicks defines model for AdClicks.
+type AdClicks struct {
+	AdId   string `json:"ad_id"`
+	Clicks int64  `json:"clicks"`
+}
+
+// DependencyCheck defines model for DependencyCheck.
+type DependencyCheck struct {
+	Error     *string `json:"error,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+}
+
+// HealthResponse defines model for HealthResponse.
+type HealthResponse struct {
+	Checks    *[]DependencyCheck `json:"checks,omitempty"`
+	Status    *string            `json:"status,omitempty"`
+	Timestamp *time.Time         `json:"timestamp,omitempty"`
+}
+
+// ListAdsResponse defines model for ListAdsResponse.
+type ListAdsResponse struct {
+	Ads []Ad `json:"ads"`
+}
+
+// ListClicksResponse defines model for ListClicksResponse.
+type ListClicksResponse struct {
+	Clicks []AdClicks `json:"clicks"`
+}
+
+// ResponseInfo defines model for ResponseInfo.
+type ResponseInfo struct {
+	Code    uint32       `json:"code"`
+	Message string       `json:"message"`
+	Type    ResponseType `json:"type"`
+}
+
+// ResponseType defines model for ResponseType.
+type ResponseType string
+
+// Id defines model for id.
+type Id = string
+
+// NotOk defines model for NotOk.
+type NotOk = ResponseInfo
+
+// GetAdsParams defines parameters for GetAds.
+type GetAdsParams struct {
+	// Categories the categories to match, e.g. ?categories=accessories&categories=footwear
+	Categories *[]string `form:"categories,omitempty" json:"categories,omitempty"`
+
+	// Limit the maximum number of ads returned
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/configloader"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+)
+
+const (
+	defaultHost        = "0.0.0.0"
+	defaultPort uint16 = 8010
+
+	defaultShutdownTimeout = 15 * time.Second
+	// the readiness probes of the manifests run every 10s
+	defaultDrainDelay = 10 * time.Second
+
+	// defaultInventoryFile is relative to the working directory, like the catalog of the product catalog service
+	defaultInventoryFile = "data/ads.json"
+)
+
+// Config is the configuration of the ad service, run it with --help to list the settings
+type Config struct {
+	Host string
+	Port uint16
+	// ShutdownTimeout is the deadline to drain the in-flight requests when the service stops
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long the service keeps serving after it stops reporting ready, before it shuts down
+	DrainDelay time.Duration
+	// InventoryFile is the JSON file of the ads, see ads.LoadInventory
+	InventoryFile string
+	Tracing       tracing.Config
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Host:            defaultHost,
+		Port:            defaultPort,
+		ShutdownTimeout: defaultShutdownTimeout,
+		DrainDelay:      defaultDrainDelay,
+		InventoryFile:   defaultInventoryFile,
+		Tracing:         tracing.DefaultConfig(),
+	}
+}
+
+// Address is the address the REST API server listens on
+func (cfg *Config) Address() string {
+	return net.JoinHostPort(cfg.Host, strconv.Itoa(int(cfg.Port)))
+}
+
+var settings = append([]setting{
+	{
+		Key:         "host",
+		EnvVar:      "HOST",
+		Description: "IP the REST API server listens on",
+		Get:         func(cfg *Config) string { return cfg.Host },
+		Set:         func(cfg *Config, value string) error { cfg.Host = value; return nil },
+	},
+	{
+		Key:         "port",
+		EnvVar:      "PORT",
+		Description: "port the REST API server listens on",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.Port)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.Port = port
+			return err
+		},
+	},
+	{
+		Key:         "shutdown-timeout",
+		EnvVar:      "SHUTDOWN_TIMEOUT",
+		Description: "deadline to drain the in-flight requests when the service stops",
+		Get:         func(cfg *Config) string { return cfg.ShutdownTimeout.String() },
+		Set: func(cfg *Config, value string) error {
+			timeout, err := configloader.ParseDuration(value)
+			cfg.ShutdownTimeout = timeout
+			return err
+		},
+	},
+	{
+		Key:         "drain-delay",
+		EnvVar:      "DRAIN_DELAY",
+		Description: "time the service keeps serving after it stops reporting ready on shutdown, at least the readiness probe period",
+		Get:         func(cfg *Config) string { return cfg.DrainDelay.String() },
+		Set: func(cfg *Config, value string) error {
+			delay, err := configloader.ParseDuration(value)
+			cfg.DrainDelay = delay
+			return err
+		},
+	},
+	{
+		Key:         "inventory-file",
+		EnvVar:      "INVENTORY_FILE",
+		Description: "JSON file of the ad inventory, with the text, the redirect URL and the categories of every ad",
+		Get:         func(cfg *Config) string { return cfg.InventoryFile },
+		Set:         func(cfg *Config, value string) error { cfg.InventoryFile = strings.TrimSpace(value); return nil },
+	},
+}, tracing.Settings(func(cfg *Config) *tracing.Config { return &cfg.Tracing })...)
+
+func (cfg *Config) validate() []string {
+	var violations []string
+	if net.ParseIP(cfg.Host) == nil {
+		violations = append(violations, fmt.Sprintf("'host' must be an IP address, got '%s'", cfg.Host))
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		violations = append(violations, fmt.Sprintf("'shutdown-timeout' must be positive, got '%s'", cfg.ShutdownTimeout))
+	}
+	if cfg.DrainDelay < 0 {
+		violations = append(violations, fmt.Sprintf("'drain-delay' can't be negative, got '%s'", cfg.DrainDelay))
+	}
+	if cfg.InventoryFile == "" {
+		violations = append(violations, "'inventory-file' is required")
+	}
+	violations = append(violations, cfg.Tracing.Validate()...)
+	return violations
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/kurtosis-tech/new-obd/src/adservice/ads"
+	"github.com/pkg/errors"
+)
+
+// statusCodeForError returns the status code of the errors returned by the handlers, based on the ads error kinds
+func statusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, ads.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, ads.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/adservice/ads"
+	adservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/adservice/api/http_rest/server"
+	adservice_rest_types "github.com/kurtosis-tech/new-obd/src/adservice/api/http_rest/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	healthStatusOk           = "ok"
+	healthStatusShuttingDown = "shutting down"
+
+	// defaultAdLimit is the default of the limit parameter in the API spec
+	defaultAdLimit = 1
+)
+
+type Server struct {
+	Ads *ads.Service
+	// shuttingDown is a pointer so every copy of the server (the handlers have value receivers) sees the same flag
+	shuttingDown *atomic.Bool
+}
+
+func NewServer(adService *ads.Service) Server {
+	return Server{Ads: adService, shuttingDown: &atomic.Bool{}}
+}
+
+// MarkShuttingDown makes the readiness check fail, so the service stops receiving traffic while it shuts down
+func (s Server) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s Server) GetHealth(ctx context.Context, request adservice_server_rest_server.GetHealthRequestObject) (adservice_server_rest_server.GetHealthResponseObject, error) {
+
+	status := healthStatusOk
+	now := time.Now()
+
+	response := adservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return adservice_server_rest_server.GetHealth200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthLive(ctx context.Context, request adservice_server_rest_server.GetHealthLiveRequestObject) (adservice_server_rest_server.GetHealthLiveResponseObject, error) {
+	status := healthStatusOk
+	now := time.Now()
+
+	response := adservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return adservice_server_rest_server.GetHealthLive200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthReady(ctx context.Context, request adservice_server_rest_server.GetHealthReadyRequestObject) (adservice_server_rest_server.GetHealthReadyResponseObject, error) {
+	now := time.Now()
+
+	if s.shuttingDown.Load() {
+		status := healthStatusShuttingDown
+		response := adservice_rest_types.HealthResponse{
+			Status:    &status,
+			Timestamp: &now,
+		}
+		return adservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+
+	// the inventory is loaded at startup and the clicks are counted in memory, there's no dependency to check
+	checks := []adservice_rest_types.DependencyCheck{}
+	status := healthStatusOk
+
+	response := adservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+		Checks:    &checks,
+	}
+
+	return adservice_server_rest_server.GetHealthReady200JSONResponse(response), nil
+}
+
+func (s Server) GetAds(ctx context.Context, request adservice_server_rest_server.GetAdsRequestObject) (adservice_server_rest_server.GetAdsResponseObject, error) {
+	var categories []string
+	if request.Params.Categories != nil {
+		categories = *request.Params.Categories
+	}
+	limit := defaultAdLimit
+	if request.Params.Limit != nil {
+		limit = int(*request.Params.Limit)
+	}
+	logrus.Infof("List ads request - Categories: %v, Limit: %d", categories, limit)
+	response, err := s.Ads.ListAds(ctx, categories, limit)
+	if err != nil {
+		return nil, err
+	}
+	return adservice_server_rest_server.GetAds200JSONResponse(*response), nil
+}
+
+func (s Server) GetAdsIdClick(ctx context.Context, request adservice_server_rest_server.GetAdsIdClickRequestObject) (adservice_server_rest_server.GetAdsIdClickResponseObject, error) {
+	redirectURL, err := s.Ads.Click(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return adservice_server_rest_server.GetAdsIdClick302Response{
+		Headers: adservice_server_rest_server.GetAdsIdClick302ResponseHeaders{Location: redirectURL},
+	}, nil
+}
+
+func (s Server) GetClicks(ctx context.Context, request adservice_server_rest_server.GetClicksRequestObject) (adservice_server_rest_server.GetClicksResponseObject, error) {
+	return adservice_server_rest_server.GetClicks200JSONResponse(*s.Ads.Clicks()), nil
+}
@@ -90,6 +90,9 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// DeleteAdminFlowsFlowId request
+	DeleteAdminFlowsFlowId(ctx context.Context, flowId FlowId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// PostCartWithBody request with any body
 	PostCartWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -101,8 +104,39 @@ type ClientInterface interface {
 	// GetCartUserId request
 	GetCartUserId(ctx context.Context, userId UserId, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// DeleteCartUserIdItemsProductId request
+	DeleteCartUserIdItemsProductId(ctx context.Context, userId UserId, productId ProductId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutCartUserIdItemsProductIdWithBody request with any body
+	PutCartUserIdItemsProductIdWithBody(ctx context.Context, userId UserId, productId ProductId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutCartUserIdItemsProductId(ctx context.Context, userId UserId, productId ProductId, body PutCartUserIdItemsProductIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostCartUserIdMergeWithBody request with any body
+	PostCartUserIdMergeWithBody(ctx context.Context, userId UserId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostCartUserIdMerge(ctx context.Context, userId UserId, body PostCartUserIdMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetHealth request
 	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealthLive request
+	GetHealthLive(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealthReady request
+	GetHealthReady(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) DeleteAdminFlowsFlowId(ctx context.Context, flowId FlowId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAdminFlowsFlowIdRequest(c.Server, flowId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) PostCartWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -153,6 +187,66 @@ func (c *Client) GetCartUserId(ctx context.Context, userId UserId, reqEditors ..
 	return c.Client.Do(req)
 }
 
+func (c *Client) DeleteCartUserIdItemsProductId(ctx context.Context, userId UserId, productId ProductId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteCartUserIdItemsProductIdRequest(c.Server, userId, productId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutCartUserIdItemsProductIdWithBody(ctx context.Context, userId UserId, productId ProductId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutCartUserIdItemsProductIdRequestWithBody(c.Server, userId, productId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutCartUserIdItemsProductId(ctx context.Context, userId UserId, productId ProductId, body PutCartUserIdItemsProductIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutCartUserIdItemsProductIdRequest(c.Server, userId, productId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostCartUserIdMergeWithBody(ctx context.Context, userId UserId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostCartUserIdMergeRequestWithBody(c.Server, userId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostCartUserIdMerge(ctx context.Context, userId UserId, body PostCartUserIdMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostCartUserIdMergeRequest(c.Server, userId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetHealthRequest(c.Server)
 	if err != nil {
@@ -165,6 +259,64 @@ func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetHealthLive(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthLiveRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHealthReady(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthReadyRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewDeleteAdminFlowsFlowIdRequest generates requests for DeleteAdminFlowsFlowId
+func NewDeleteAdminFlowsFlowIdRequest(server string, flowId FlowId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "flow_id", runtime.ParamLocationPath, flowId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/flows/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewPostCartRequest calls the generic PostCart builder with application/json body
 func NewPostCartRequest(server string, body PostCartJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -273,6 +425,148 @@ func NewGetCartUserIdRequest(server string, userId UserId) (*http.Request, error
 	return req, nil
 }
 
+// NewDeleteCartUserIdItemsProductIdRequest generates requests for DeleteCartUserIdItemsProductId
+func NewDeleteCartUserIdItemsProductIdRequest(server string, userId UserId, productId ProductId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "product_id", runtime.ParamLocationPath, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cart/%s/items/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutCartUserIdItemsProductIdRequest calls the generic PutCartUserIdItemsProductId builder with application/json body
+func NewPutCartUserIdItemsProductIdRequest(server string, userId UserId, productId ProductId, body PutCartUserIdItemsProductIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutCartUserIdItemsProductIdRequestWithBody(server, userId, productId, "application/json", bodyReader)
+}
+
+// NewPutCartUserIdItemsProductIdRequestWithBody generates requests for PutCartUserIdItemsProductId with any type of body
+func NewPutCartUserIdItemsProductIdRequestWithBody(server string, userId UserId, productId ProductId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "product_id", runtime.ParamLocationPath, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cart/%s/items/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostCartUserIdMergeRequest calls the generic PostCartUserIdMerge builder with application/json body
+func NewPostCartUserIdMergeRequest(server string, userId UserId, body PostCartUserIdMergeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostCartUserIdMergeRequestWithBody(server, userId, "application/json", bodyReader)
+}
+
+// NewPostCartUserIdMergeRequestWithBody generates requests for PostCartUserIdMerge with any type of body
+func NewPostCartUserIdMergeRequestWithBody(server string, userId UserId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "user_id", runtime.ParamLocationPath, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/cart/%s/merge", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewGetHealthRequest generates requests for GetHealth
 func NewGetHealthRequest(server string) (*http.Request, error) {
 	var err error
@@ -300,6 +594,60 @@ func NewGetHealthRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewGetHealthLiveRequest generates requests for GetHealthLive
+func NewGetHealthLiveRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health/live")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHealthReadyRequest generates requests for GetHealthReady
+func NewGetHealthReadyRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health/ready")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -343,6 +691,9 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// DeleteAdminFlowsFlowIdWithResponse request
+	DeleteAdminFlowsFlowIdWithResponse(ctx context.Context, flowId FlowId, reqEditors ...RequestEditorFn) (*DeleteAdminFlowsFlowIdResponse, error)
+
 	// PostCartWithBodyWithResponse request with any body
 	PostCartWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCartResponse, error)
 
@@ -354,19 +705,176 @@ type ClientWithResponsesInterface interface {
 	// GetCartUserIdWithResponse request
 	GetCartUserIdWithResponse(ctx context.Context, userId UserId, reqEditors ...RequestEditorFn) (*GetCartUserIdResponse, error)
 
-	// GetHealthWithResponse request
-	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+	// DeleteCartUserIdItemsProductIdWithResponse request
+	DeleteCartUserIdItemsProductIdWithResponse(ctx context.Context, userId UserId, productId ProductId, reqEditors ...RequestEditorFn) (*DeleteCartUserIdItemsProductIdResponse, error)
+
+	// PutCartUserIdItemsProductIdWithBodyWithResponse request with any body
+	PutCartUserIdItemsProductIdWithBodyWithResponse(ctx context.Context, userId UserId, productId ProductId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutCartUserIdItemsProductIdResponse, error)
+
+	PutCartUserIdItemsProductIdWithResponse(ctx context.Context, userId UserId, productId ProductId, body PutCartUserIdItemsProductIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutCartUserIdItemsProductIdResponse, error)
+
+	// PostCartUserIdMergeWithBodyWithResponse request with any body
+	PostCartUserIdMergeWithBodyWithResponse(ctx context.Context, userId UserId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCartUserIdMergeResponse, error)
+
+	PostCartUserIdMergeWithResponse(ctx context.Context, userId UserId, body PostCartUserIdMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostCartUserIdMergeResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// GetHealthLiveWithResponse request
+	GetHealthLiveWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthLiveResponse, error)
+
+	// GetHealthReadyWithResponse request
+	GetHealthReadyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthReadyResponse, error)
+}
+
+type DeleteAdminFlowsFlowIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PurgeFlowResponse
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAdminFlowsFlowIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAdminFlowsFlowIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostCartResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r PostCartResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostCartResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteCartUserIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteCartUserIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteCartUserIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCartUserIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Cart
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCartUserIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCartUserIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteCartUserIdItemsProductIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteCartUserIdItemsProductIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteCartUserIdItemsProductIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutCartUserIdItemsProductIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSONDefault  *NotOk
+}
+
+// Status returns HTTPResponse.Status
+func (r PutCartUserIdItemsProductIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutCartUserIdItemsProductIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
-type PostCartResponse struct {
+type PostCartUserIdMergeResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *map[string]interface{}
+	JSON200      *Cart
 	JSONDefault  *NotOk
 }
 
 // Status returns HTTPResponse.Status
-func (r PostCartResponse) Status() string {
+func (r PostCartUserIdMergeResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -374,22 +882,22 @@ func (r PostCartResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostCartResponse) StatusCode() int {
+func (r PostCartUserIdMergeResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type DeleteCartUserIdResponse struct {
+type GetHealthResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *map[string]interface{}
+	JSON200      *HealthResponse
 	JSONDefault  *NotOk
 }
 
 // Status returns HTTPResponse.Status
-func (r DeleteCartUserIdResponse) Status() string {
+func (r GetHealthResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -397,22 +905,22 @@ func (r DeleteCartUserIdResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r DeleteCartUserIdResponse) StatusCode() int {
+func (r GetHealthResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetCartUserIdResponse struct {
+type GetHealthLiveResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *Cart
+	JSON200      *HealthResponse
 	JSONDefault  *NotOk
 }
 
 // Status returns HTTPResponse.Status
-func (r GetCartUserIdResponse) Status() string {
+func (r GetHealthLiveResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -420,22 +928,23 @@ func (r GetCartUserIdResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetCartUserIdResponse) StatusCode() int {
+func (r GetHealthLiveResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetHealthResponse struct {
+type GetHealthReadyResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *HealthResponse
+	JSON503      *HealthResponse
 	JSONDefault  *NotOk
 }
 
 // Status returns HTTPResponse.Status
-func (r GetHealthResponse) Status() string {
+func (r GetHealthReadyResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -443,13 +952,22 @@ func (r GetHealthResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetHealthResponse) StatusCode() int {
+func (r GetHealthReadyResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
+// DeleteAdminFlowsFlowIdWithResponse request returning *DeleteAdminFlowsFlowIdResponse
+func (c *ClientWithResponses) DeleteAdminFlowsFlowIdWithResponse(ctx context.Context, flowId FlowId, reqEditors ...RequestEditorFn) (*DeleteAdminFlowsFlowIdResponse, error) {
+	rsp, err := c.DeleteAdminFlowsFlowId(ctx, flowId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAdminFlowsFlowIdResponse(rsp)
+}
+
 // PostCartWithBodyWithResponse request with arbitrary body returning *PostCartResponse
 func (c *ClientWithResponses) PostCartWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCartResponse, error) {
 	rsp, err := c.PostCartWithBody(ctx, contentType, body, reqEditors...)
@@ -485,6 +1003,49 @@ func (c *ClientWithResponses) GetCartUserIdWithResponse(ctx context.Context, use
 	return ParseGetCartUserIdResponse(rsp)
 }
 
+// DeleteCartUserIdItemsProductIdWithResponse request returning *DeleteCartUserIdItemsProductIdResponse
+func (c *ClientWithResponses) DeleteCartUserIdItemsProductIdWithResponse(ctx context.Context, userId UserId, productId ProductId, reqEditors ...RequestEditorFn) (*DeleteCartUserIdItemsProductIdResponse, error) {
+	rsp, err := c.DeleteCartUserIdItemsProductId(ctx, userId, productId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteCartUserIdItemsProductIdResponse(rsp)
+}
+
+// PutCartUserIdItemsProductIdWithBodyWithResponse request with arbitrary body returning *PutCartUserIdItemsProductIdResponse
+func (c *ClientWithResponses) PutCartUserIdItemsProductIdWithBodyWithResponse(ctx context.Context, userId UserId, productId ProductId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutCartUserIdItemsProductIdResponse, error) {
+	rsp, err := c.PutCartUserIdItemsProductIdWithBody(ctx, userId, productId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutCartUserIdItemsProductIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutCartUserIdItemsProductIdWithResponse(ctx context.Context, userId UserId, productId ProductId, body PutCartUserIdItemsProductIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PutCartUserIdItemsProductIdResponse, error) {
+	rsp, err := c.PutCartUserIdItemsProductId(ctx, userId, productId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutCartUserIdItemsProductIdResponse(rsp)
+}
+
+// PostCartUserIdMergeWithBodyWithResponse request with arbitrary body returning *PostCartUserIdMergeResponse
+func (c *ClientWithResponses) PostCartUserIdMergeWithBodyWithResponse(ctx context.Context, userId UserId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostCartUserIdMergeResponse, error) {
+	rsp, err := c.PostCartUserIdMergeWithBody(ctx, userId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostCartUserIdMergeResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostCartUserIdMergeWithResponse(ctx context.Context, userId UserId, body PostCartUserIdMergeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostCartUserIdMergeResponse, error) {
+	rsp, err := c.PostCartUserIdMerge(ctx, userId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostCartUserIdMergeResponse(rsp)
+}
+
 // GetHealthWithResponse request returning *GetHealthResponse
 func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
 	rsp, err := c.GetHealth(ctx, reqEditors...)
@@ -494,6 +1055,57 @@ func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEdit
 	return ParseGetHealthResponse(rsp)
 }
 
+// GetHealthLiveWithResponse request returning *GetHealthLiveResponse
+func (c *ClientWithResponses) GetHealthLiveWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthLiveResponse, error) {
+	rsp, err := c.GetHealthLive(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthLiveResponse(rsp)
+}
+
+// GetHealthReadyWithResponse request returning *GetHealthReadyResponse
+func (c *ClientWithResponses) GetHealthReadyWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthReadyResponse, error) {
+	rsp, err := c.GetHealthReady(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthReadyResponse(rsp)
+}
+
+// ParseDeleteAdminFlowsFlowIdResponse parses an HTTP response from a DeleteAdminFlowsFlowIdWithResponse call
+func ParseDeleteAdminFlowsFlowIdResponse(rsp *http.Response) (*DeleteAdminFlowsFlowIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAdminFlowsFlowIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PurgeFlowResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParsePostCartResponse parses an HTTP response from a PostCartWithResponse call
 func ParsePostCartResponse(rsp *http.Response) (*PostCartResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -593,6 +1205,105 @@ func ParseGetCartUserIdResponse(rsp *http.Response) (*GetCartUserIdResponse, err
 	return response, nil
 }
 
+// ParseDeleteCartUserIdItemsProductIdResponse parses an HTTP response from a DeleteCartUserIdItemsProductIdWithResponse call
+func ParseDeleteCartUserIdItemsProductIdResponse(rsp *http.Response) (*DeleteCartUserIdItemsProductIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteCartUserIdItemsProductIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutCartUserIdItemsProductIdResponse parses an HTTP response from a PutCartUserIdItemsProductIdWithResponse call
+func ParsePutCartUserIdItemsProductIdResponse(rsp *http.Response) (*PutCartUserIdItemsProductIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutCartUserIdItemsProductIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostCartUserIdMergeResponse parses an HTTP response from a PostCartUserIdMergeWithResponse call
+func ParsePostCartUserIdMergeResponse(rsp *http.Response) (*PostCartUserIdMergeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostCartUserIdMergeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Cart
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
 func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -625,3 +1336,76 @@ func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
 
 	return response, nil
 }
+
+// ParseGetHealthLiveResponse parses an HTTP response from a GetHealthLiveWithResponse call
+func ParseGetHealthLiveResponse(rsp *http.Response) (*GetHealthLiveResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthLiveResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthReadyResponse parses an HTTP response from a GetHealthReadyWithResponse call
+func ParseGetHealthReadyResponse(rsp *http.Response) (*GetHealthReadyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthReadyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest HealthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest NotOk
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
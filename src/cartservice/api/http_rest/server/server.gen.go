@@ -24,6 +24,9 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Purge flow
+	// (DELETE /admin/flows/{flow_id})
+	DeleteAdminFlowsFlowId(ctx echo.Context, flowId FlowId) error
 	// Add item
 	// (POST /cart)
 	PostCart(ctx echo.Context) error
@@ -33,9 +36,24 @@ type ServerInterface interface {
 	// Get cart
 	// (GET /cart/{user_id})
 	GetCartUserId(ctx echo.Context, userId UserId) error
+	// Remove item
+	// (DELETE /cart/{user_id}/items/{product_id})
+	DeleteCartUserIdItemsProductId(ctx echo.Context, userId UserId, productId ProductId) error
+	// Update item quantity
+	// (PUT /cart/{user_id}/items/{product_id})
+	PutCartUserIdItemsProductId(ctx echo.Context, userId UserId, productId ProductId) error
+	// Merge carts
+	// (POST /cart/{user_id}/merge)
+	PostCartUserIdMerge(ctx echo.Context, userId UserId) error
 	// Health check endpoint
 	// (GET /health)
 	GetHealth(ctx echo.Context) error
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx echo.Context) error
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -43,6 +61,24 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// DeleteAdminFlowsFlowId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteAdminFlowsFlowId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "flow_id" -------------
+	var flowId FlowId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", ctx.Param("flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter flow_id: %s", err))
+	}
+
+	ctx.Set(AdminTokenScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteAdminFlowsFlowId(ctx, flowId)
+	return err
+}
+
 // PostCart converts echo context to params.
 func (w *ServerInterfaceWrapper) PostCart(ctx echo.Context) error {
 	var err error
@@ -84,6 +120,70 @@ func (w *ServerInterfaceWrapper) GetCartUserId(ctx echo.Context) error {
 	return err
 }
 
+// DeleteCartUserIdItemsProductId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteCartUserIdItemsProductId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", ctx.Param("user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// ------------- Path parameter "product_id" -------------
+	var productId ProductId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", ctx.Param("product_id"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter product_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteCartUserIdItemsProductId(ctx, userId, productId)
+	return err
+}
+
+// PutCartUserIdItemsProductId converts echo context to params.
+func (w *ServerInterfaceWrapper) PutCartUserIdItemsProductId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", ctx.Param("user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// ------------- Path parameter "product_id" -------------
+	var productId ProductId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "product_id", ctx.Param("product_id"), &productId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter product_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutCartUserIdItemsProductId(ctx, userId, productId)
+	return err
+}
+
+// PostCartUserIdMerge converts echo context to params.
+func (w *ServerInterfaceWrapper) PostCartUserIdMerge(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "user_id" -------------
+	var userId UserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", ctx.Param("user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostCartUserIdMerge(ctx, userId)
+	return err
+}
+
 // GetHealth converts echo context to params.
 func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
 	var err error
@@ -93,6 +193,24 @@ func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
 	return err
 }
 
+// GetHealthLive converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthLive(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthLive(ctx)
+	return err
+}
+
+// GetHealthReady converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthReady(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthReady(ctx)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -121,15 +239,50 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.DELETE(baseURL+"/admin/flows/:flow_id", wrapper.DeleteAdminFlowsFlowId)
 	router.POST(baseURL+"/cart", wrapper.PostCart)
 	router.DELETE(baseURL+"/cart/:user_id", wrapper.DeleteCartUserId)
 	router.GET(baseURL+"/cart/:user_id", wrapper.GetCartUserId)
+	router.DELETE(baseURL+"/cart/:user_id/items/:product_id", wrapper.DeleteCartUserIdItemsProductId)
+	router.PUT(baseURL+"/cart/:user_id/items/:product_id", wrapper.PutCartUserIdItemsProductId)
+	router.POST(baseURL+"/cart/:user_id/merge", wrapper.PostCartUserIdMerge)
 	router.GET(baseURL+"/health", wrapper.GetHealth)
+	router.GET(baseURL+"/health/live", wrapper.GetHealthLive)
+	router.GET(baseURL+"/health/ready", wrapper.GetHealthReady)
 
 }
 
 type NotOkJSONResponse ResponseInfo
 
+type DeleteAdminFlowsFlowIdRequestObject struct {
+	FlowId FlowId `json:"flow_id"`
+}
+
+type DeleteAdminFlowsFlowIdResponseObject interface {
+	VisitDeleteAdminFlowsFlowIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteAdminFlowsFlowId200JSONResponse PurgeFlowResponse
+
+func (response DeleteAdminFlowsFlowId200JSONResponse) VisitDeleteAdminFlowsFlowIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminFlowsFlowIddefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response DeleteAdminFlowsFlowIddefaultJSONResponse) VisitDeleteAdminFlowsFlowIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type PostCartRequestObject struct {
 	Body *PostCartJSONRequestBody
 }
@@ -217,6 +370,97 @@ func (response GetCartUserIddefaultJSONResponse) VisitGetCartUserIdResponse(w ht
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type DeleteCartUserIdItemsProductIdRequestObject struct {
+	UserId    UserId    `json:"user_id"`
+	ProductId ProductId `json:"product_id"`
+}
+
+type DeleteCartUserIdItemsProductIdResponseObject interface {
+	VisitDeleteCartUserIdItemsProductIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteCartUserIdItemsProductId200JSONResponse map[string]interface{}
+
+func (response DeleteCartUserIdItemsProductId200JSONResponse) VisitDeleteCartUserIdItemsProductIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteCartUserIdItemsProductIddefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response DeleteCartUserIdItemsProductIddefaultJSONResponse) VisitDeleteCartUserIdItemsProductIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutCartUserIdItemsProductIdRequestObject struct {
+	UserId    UserId    `json:"user_id"`
+	ProductId ProductId `json:"product_id"`
+	Body      *PutCartUserIdItemsProductIdJSONRequestBody
+}
+
+type PutCartUserIdItemsProductIdResponseObject interface {
+	VisitPutCartUserIdItemsProductIdResponse(w http.ResponseWriter) error
+}
+
+type PutCartUserIdItemsProductId200JSONResponse map[string]interface{}
+
+func (response PutCartUserIdItemsProductId200JSONResponse) VisitPutCartUserIdItemsProductIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutCartUserIdItemsProductIddefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PutCartUserIdItemsProductIddefaultJSONResponse) VisitPutCartUserIdItemsProductIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostCartUserIdMergeRequestObject struct {
+	UserId UserId `json:"user_id"`
+	Body   *PostCartUserIdMergeJSONRequestBody
+}
+
+type PostCartUserIdMergeResponseObject interface {
+	VisitPostCartUserIdMergeResponse(w http.ResponseWriter) error
+}
+
+type PostCartUserIdMerge200JSONResponse Cart
+
+func (response PostCartUserIdMerge200JSONResponse) VisitPostCartUserIdMergeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostCartUserIdMergedefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostCartUserIdMergedefaultJSONResponse) VisitPostCartUserIdMergeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type GetHealthRequestObject struct {
 }
 
@@ -245,8 +489,76 @@ func (response GetHealthdefaultJSONResponse) VisitGetHealthResponse(w http.Respo
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type GetHealthLiveRequestObject struct {
+}
+
+type GetHealthLiveResponseObject interface {
+	VisitGetHealthLiveResponse(w http.ResponseWriter) error
+}
+
+type GetHealthLive200JSONResponse HealthResponse
+
+func (response GetHealthLive200JSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthLivedefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthLivedefaultJSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthReadyRequestObject struct {
+}
+
+type GetHealthReadyResponseObject interface {
+	VisitGetHealthReadyResponse(w http.ResponseWriter) error
+}
+
+type GetHealthReady200JSONResponse HealthResponse
+
+func (response GetHealthReady200JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReady503JSONResponse HealthResponse
+
+func (response GetHealthReady503JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReadydefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthReadydefaultJSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Purge flow
+	// (DELETE /admin/flows/{flow_id})
+	DeleteAdminFlowsFlowId(ctx context.Context, request DeleteAdminFlowsFlowIdRequestObject) (DeleteAdminFlowsFlowIdResponseObject, error)
 	// Add item
 	// (POST /cart)
 	PostCart(ctx context.Context, request PostCartRequestObject) (PostCartResponseObject, error)
@@ -256,9 +568,24 @@ type StrictServerInterface interface {
 	// Get cart
 	// (GET /cart/{user_id})
 	GetCartUserId(ctx context.Context, request GetCartUserIdRequestObject) (GetCartUserIdResponseObject, error)
+	// Remove item
+	// (DELETE /cart/{user_id}/items/{product_id})
+	DeleteCartUserIdItemsProductId(ctx context.Context, request DeleteCartUserIdItemsProductIdRequestObject) (DeleteCartUserIdItemsProductIdResponseObject, error)
+	// Update item quantity
+	// (PUT /cart/{user_id}/items/{product_id})
+	PutCartUserIdItemsProductId(ctx context.Context, request PutCartUserIdItemsProductIdRequestObject) (PutCartUserIdItemsProductIdResponseObject, error)
+	// Merge carts
+	// (POST /cart/{user_id}/merge)
+	PostCartUserIdMerge(ctx context.Context, request PostCartUserIdMergeRequestObject) (PostCartUserIdMergeResponseObject, error)
 	// Health check endpoint
 	// (GET /health)
 	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx context.Context, request GetHealthLiveRequestObject) (GetHealthLiveResponseObject, error)
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx context.Context, request GetHealthReadyRequestObject) (GetHealthReadyResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -273,6 +600,31 @@ type strictHandler struct {
 	middlewares []StrictMiddlewareFunc
 }
 
+// DeleteAdminFlowsFlowId operation middleware
+func (sh *strictHandler) DeleteAdminFlowsFlowId(ctx echo.Context, flowId FlowId) error {
+	var request DeleteAdminFlowsFlowIdRequestObject
+
+	request.FlowId = flowId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAdminFlowsFlowId(ctx.Request().Context(), request.(DeleteAdminFlowsFlowIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAdminFlowsFlowId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteAdminFlowsFlowIdResponseObject); ok {
+		return validResponse.VisitDeleteAdminFlowsFlowIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // PostCart operation middleware
 func (sh *strictHandler) PostCart(ctx echo.Context) error {
 	var request PostCartRequestObject
@@ -352,6 +704,95 @@ func (sh *strictHandler) GetCartUserId(ctx echo.Context, userId UserId) error {
 	return nil
 }
 
+// DeleteCartUserIdItemsProductId operation middleware
+func (sh *strictHandler) DeleteCartUserIdItemsProductId(ctx echo.Context, userId UserId, productId ProductId) error {
+	var request DeleteCartUserIdItemsProductIdRequestObject
+
+	request.UserId = userId
+	request.ProductId = productId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteCartUserIdItemsProductId(ctx.Request().Context(), request.(DeleteCartUserIdItemsProductIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteCartUserIdItemsProductId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteCartUserIdItemsProductIdResponseObject); ok {
+		return validResponse.VisitDeleteCartUserIdItemsProductIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PutCartUserIdItemsProductId operation middleware
+func (sh *strictHandler) PutCartUserIdItemsProductId(ctx echo.Context, userId UserId, productId ProductId) error {
+	var request PutCartUserIdItemsProductIdRequestObject
+
+	request.UserId = userId
+	request.ProductId = productId
+
+	var body PutCartUserIdItemsProductIdJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutCartUserIdItemsProductId(ctx.Request().Context(), request.(PutCartUserIdItemsProductIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutCartUserIdItemsProductId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutCartUserIdItemsProductIdResponseObject); ok {
+		return validResponse.VisitPutCartUserIdItemsProductIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostCartUserIdMerge operation middleware
+func (sh *strictHandler) PostCartUserIdMerge(ctx echo.Context, userId UserId) error {
+	var request PostCartUserIdMergeRequestObject
+
+	request.UserId = userId
+
+	var body PostCartUserIdMergeJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostCartUserIdMerge(ctx.Request().Context(), request.(PostCartUserIdMergeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostCartUserIdMerge")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostCartUserIdMergeResponseObject); ok {
+		return validResponse.VisitPostCartUserIdMergeResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetHealth operation middleware
 func (sh *strictHandler) GetHealth(ctx echo.Context) error {
 	var request GetHealthRequestObject
@@ -375,23 +816,85 @@ func (sh *strictHandler) GetHealth(ctx echo.Context) error {
 	return nil
 }
 
+// GetHealthLive operation middleware
+func (sh *strictHandler) GetHealthLive(ctx echo.Context) error {
+	var request GetHealthLiveRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthLive(ctx.Request().Context(), request.(GetHealthLiveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthLive")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthLiveResponseObject); ok {
+		return validResponse.VisitGetHealthLiveResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthReady operation middleware
+func (sh *strictHandler) GetHealthReady(ctx echo.Context) error {
+	var request GetHealthReadyRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthReady(ctx.Request().Context(), request.(GetHealthReadyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthReady")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthReadyResponseObject); ok {
+		return validResponse.VisitGetHealthReadyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9RV34sbNxD+V8S0kBfZu/UVSvft2lyvpnB3+O4oNBxBlca2Eq+kk0Yhxuz/XiStY6+9",
-	"adKftLAPWmk08803M592IG3rrEFDAZodOOFFi4Q+/8WA/rVWaakwSK8daWugyQdMK+Cg068TtAYORrTY",
-	"H77Ohx6fo/aooCEfkUOQa2xFckdbl0wDeW1W0HVdMg7OmoA58o2l27dpIa0hNJSWwrmNliJBqN6EhGN3",
-	"5PFLj0to4IvqkFBVTkO16F3PzdKWYMN0Hg2+dygJFUPvrYdk0l9Ovi+VmhO2C3yOGDIW561DT7qg1YTt",
-	"pzB8LzwlJ9DxY15PieD7HfvrG5SUrNPN8ZjDxedG7wMI78X2z6GZ9/kOETlvVZQ07orDcxSGNG3T4dL6",
-	"VhA0oA1dzOBDGG0IV+jHI/+IYkPrfS3P4wcSFPMK34vWbdLtx7uD8wMU0i0GEq0bGs/q2deT+pvJ7NuH",
-	"um7y9wvwA1glCCfp7rnPMbyDrjtDK63CARXxY1xwaDEEscJRWsvG5/X/Q7Its7YfzFfFwSEGL8iefieh",
-	"hz4kmtgmD1eLxe0COMxvfrgFDj9fLm7mN9dHLo7HXPdsDCdwcXX/sIwbdnk3Z8Gh1Mt+0tnSekZrZKnv",
-	"WED/TkvkTNOLwGJAxcgyEclOVmjQC0ImNxoNsfuXP70ITBiVL6GfBK2Q5dxSA1Cu+LFT4PAOfSh46ulX",
-	"0zrlbB0a4TQ0cDGtpxfAs9jlClZyP5i2iEIqbwY9V9DAnQ2UR7fQjYG+s2r7t2naiSZ1w7ImvT3V1Fld",
-	"/6HoJ/U/l837KCWGkOq2DwTZaCnihj6WwAdMVRH5rLWxbYXfQpOklmU5TduZ4WrXC1RX2maDhOdsv8z7",
-	"ie/HgH6ucqEOb9mrcSwHk2qvgt3T/5G2q9bRluWG7DiscKQfr5H+C/R86pX6txi7Rur5So22zm9L8tRz",
-	"dyJPSNGbkIWomLLy2jC7zJu9hkyBn7Ne3i34B3k7eRnHGCz4mA49/u1fJrAEZXKN8i1Do5zVpq9eUdzS",
-	"V0Mcx4KbxB44RL+BBtZELjRVnvi9IHdP3W8BAAD//7SS+pagCgAA",
+	"H4sIAAAAAAAC/9xYbW8buRH+KwRbIC2wlhQ7vbb65iY5V7g7x5BtFKhhGNRyVmK8S27IWTuqof9eDLna",
+	"d9u6yOm5B/iDvBySD59n3sgHHpssNxo0Oj594LmwIgME6/9LUnN/oyT9lOBiq3JURvMp/0lYqbRIGVkw",
+	"JXnEFX3PBa54xLXIgE+r6RG38KVQFiSfoi0g4i5eQSZo3T9aSPiU/2Fc4xiHUTf+MTX3M8k3m4jn1sgi",
+	"xkEw5dijMBpzvxXJWViiBFM4sINIaOBRGNtZ34rh0oH1ADa0gsuNduBVOjX46ZZ+xEYjaKSfIs9TFQvC",
+	"Nf7sCNzDjtvMy6VnOjFhs/YZLzV8zSFGkAysNdYzUk6mtY+lnCFkc/hSgPNYcmtysKgCWoWQPYfhvbBI",
+	"i/A22Tux06T3qkG63/c64rjOgU+5WXyGGGkD2mwYZvvHroDLDYS1Yt05QDnk0Cq99FgH0cxKitqI2iGw",
+	"o7dG/EshNCpc+3g2NhPIp1xpPDrkEc+UVlmR8enbCorSCEuwPSZbUVQtOkToB8hBS9Dx+v0K4tv+SYLf",
+	"9PmIeCqQ5t0ExuGryPIU+PQoakH/4R3vw93GWWMalwLFQjjgUX8rhwKL9jbc3PYtOzT4TarZLcRDXJQp",
+	"bPrAM/H1Z9BLXPHp28O/eeqr/wfg/RNEiqttMPY5jIna3R20q8mAnw4Rcnk2RB2qDByKLG8bH04O3x1M",
+	"/npw+PeLyWTq//7NG8pJgXBAcwdJ7lH3C9glUDg8mkqcKWwMN/tliM4iQyLW8dTW8Yd3z8p4VtglkBM8",
+	"rqSEFBDkTaVjO9/iCpgusgVYZhIWC4vMW7JyHt8pNhqVfMeK2+SoLuRtsENktepH322NhFYmKrapqI85",
+	"A+fEEgYTRfiwWyW7INvukfwC9R5RQPbUgS7KLUFTwrziH+fzT3Me8dnpj594xP91PD+dnZ40lqjRXubk",
+	"+0+WxWaaHnAAuGdbi4j9B6xhFjJzB47R8LYDSqzJ/Adyk45jtPP95Nl8/2SKL2Pp16Y1yjIQF1bh+pxE",
+	"CkcXMlP6wtyC7h/+YgXMjzMkAwoBOp8De6diiJgDZEozhS6YHQQzB4hKL0esng9a5kZpMrTApHJikYJk",
+	"9yug6W8c0wZp3oiX7QwBX4CwYGvvXCHmoSlSpXu34c4/nl8kRcqOz2bM5RCrpGzCWGKsR04JrYbvNy4c",
+	"SIaGiQLNwRI0WIHA4lSBRnb+4ac3jgkt/SSwB05JYN5ZKRGjz7zNRXnE78C6gGcyejuakGAmBy1yxaf8",
+	"aDQZHfHIN6ee/rHnZ0wx7sYPZahv6szUP+UH/90xkaaVt5VJ6d4qRNBssfYjNri72+pGq0fM6Biqf5ly",
+	"LFFauRXIoBdV7FRpWle/QbYAllMWlaQMhYwndCYrIMeEnzKXK9NX1LrGXA1nidpkXJ6Zb647nfXhZPJi",
+	"fXW/Egw01+dFHINz5EK2MiOjRBQpPrZFhXkcrgLNOPPnb0bY1TUd0xVZJuyaT0OF8kr4eeN42w6bkKba",
+	"jJ8Zh+9Dcim1/YeR6xcjqXN52Gw23dvSZk+JOqnsu0pQcXwspY+PmuHxQ9lwdAJtyL+J7zLh/lrPLjfZ",
+	"37N/E9o+ZjmuQy3bRHwJA/54Avga6Hnubvi/YuwEsOSr72hjn6HHD/VF7skkP3+uvyirl2DaHJicqaRl",
+	"qkJBVbqyfyx91/pR6Lu62f5mNaNnTWsO/k9DI6hTJpWI5wX2FTwHDPJtW7ltFa400g0xhZRKL9vjSd0Z",
+	"NWzZGtB3JL4FpUkKt/JXWynn29S+6GcFvgrFX7549bv830v9Cifz3lYpPJhiMrBLaHYPbWy/VBkldIvb",
+	"bt5fwYNv/QlGy5H/KrTR68wULgyYhAnmwFFny3AlkH0uHLLULJcgmdJ/Zkqj8TMJzhvX9usib/qnAtcJ",
+	"BhfWFBbI1RcGV5H3cchyb93BOeDXxjUc2z9e7FmPXt5De08q38FBX6gURp5x70+yagH2cmJ/eL+UC767",
+	"8k9stFjZWnQLIBZWB+WDKQtPZJ1LaN8VTgDD8x3/jlx2HgiHWA34KBUH/Ou9OQybMv/4WN2om2yOU3UH",
+	"z1Jqbtn9SqWwDUASn2DSlZxuwsRsfXmkRoNJA45ug2Frmie3T5oK3BMa/EyAXosOwtOzrwp0JE2UPaGD",
+	"BSHXjwrhH4Fdj8be84pCZiE3FkMT4BelUi/0tpnIWCJU6pixTLUm03HdqvDvMEyae/2ERnMP9rWIFE6J",
+	"hlmIQd0BQyuSRMWk218mR78NqJr+YWB7dpNCqmGPIjv/8BQKWMeNGu9O9ObFI17YtHwnc9Oxbw/Kcb65",
+	"3vx3AGW58ZluHgAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
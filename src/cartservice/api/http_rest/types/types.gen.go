@@ -7,6 +7,10 @@ import (
 	"time"
 )
 
+const (
+	AdminTokenScopes = "adminToken.Scopes"
+)
+
 // Defines values for ResponseType.
 const (
 	ERROR   ResponseType = "ERROR"
@@ -16,8 +20,8 @@ const (
 
 // AddItemRequest defines model for AddItemRequest.
 type AddItemRequest struct {
-	Item   *CartItem `json:"item,omitempty"`
-	UserId *string   `json:"user_id,omitempty"`
+	Item   CartItem `json:"item"`
+	UserId UserId   `json:"user_id"`
 }
 
 // Cart defines model for Cart.
@@ -28,14 +32,41 @@ type Cart struct {
 
 // CartItem defines model for CartItem.
 type CartItem struct {
-	ProductId *string `json:"product_id,omitempty"`
-	Quantity  *int32  `json:"quantity,omitempty"`
+	ProductId ProductId `json:"product_id"`
+	Quantity  int32     `json:"quantity"`
+}
+
+// DependencyCheck defines model for DependencyCheck.
+type DependencyCheck struct {
+	Error     *string `json:"error,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
 }
 
+// FlowId defines model for FlowId.
+type FlowId = string
+
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
-	Status    *string    `json:"status,omitempty"`
-	Timestamp *time.Time `json:"timestamp,omitempty"`
+	Checks    *[]DependencyCheck `json:"checks,omitempty"`
+	Status    *string            `json:"status,omitempty"`
+	Timestamp *time.Time         `json:"timestamp,omitempty"`
+}
+
+// MergeCartRequest defines model for MergeCartRequest.
+type MergeCartRequest struct {
+	SourceUserId UserId `json:"source_user_id"`
+}
+
+// ProductId defines model for ProductId.
+type ProductId = string
+
+// PurgeFlowResponse defines model for PurgeFlowResponse.
+type PurgeFlowResponse struct {
+	// DeletedItems the number of cart items deleted
+	DeletedItems int64  `json:"deleted_items"`
+	FlowId       FlowId `json:"flow_id"`
 }
 
 // ResponseInfo defines model for ResponseInfo.
@@ -48,7 +79,13 @@ type ResponseInfo struct {
 // ResponseType defines model for ResponseType.
 type ResponseType string
 
-// UserId defines model for user_id.
+// UpdateItemRequest defines model for UpdateItemRequest.
+type UpdateItemRequest struct {
+	// Quantity the new quantity, zero removes the product from the cart
+	Quantity int32 `json:"quantity"`
+}
+
+// UserId defines model for UserId.
 type UserId = string
 
 // NotOk defines model for NotOk.
@@ -56,3 +93,9 @@ type NotOk = ResponseInfo
 
 // PostCartJSONRequestBody defines body for PostCart for application/json ContentType.
 type PostCartJSONRequestBody = AddItemRequest
+
+// PutCartUserIdItemsProductIdJSONRequestBody defines body for PutCartUserIdItemsProductId for application/json ContentType.
+type PutCartUserIdItemsProductIdJSONRequestBody = UpdateItemRequest
+
+// PostCartUserIdMergeJSONRequestBody defines body for PostCartUserIdMerge for application/json ContentType.
+type PostCartUserIdMergeJSONRequestBody = MergeCartRequest
@@ -0,0 +1,487 @@
+// Package cartstoretest contains the conformance suite that every cartstore.CartStore implementation is tested against
+package cartstoretest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	cartservice_rest_types "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/cartservice/cartstore"
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sunglassesProductID = "OLJCESPC7Z"
+	tankTopProductID    = "66VCHSJNUP"
+	watchProductID      = "1YMWWN1N4O"
+
+	concurrentWriters        = 10
+	itemsPerConcurrentWriter = 20
+)
+
+// Factory returns the CartStore under test, it's called once per test case so implementations
+// can either return a fresh store or a shared one (every test case uses its own user IDs)
+type Factory func(t *testing.T) cartstore.CartStore
+
+var userIDsCounter uint64
+
+// RunCartStoreConformance runs the whole CartStore contract against the stores returned by the factory
+func RunCartStoreConformance(t *testing.T, factory Factory) {
+	t.Run("GetCartOfUnknownUserIsEmpty", func(t *testing.T) {
+		testGetCartOfUnknownUserIsEmpty(t, factory(t))
+	})
+	t.Run("AddItem", func(t *testing.T) {
+		testAddItem(t, factory(t))
+	})
+	t.Run("AddItemMergesQuantities", func(t *testing.T) {
+		testAddItemMergesQuantities(t, factory(t))
+	})
+	t.Run("AddItemRejectsInvalidArguments", func(t *testing.T) {
+		testAddItemRejectsInvalidArguments(t, factory(t))
+	})
+	t.Run("UpdateItemQuantity", func(t *testing.T) {
+		testUpdateItemQuantity(t, factory(t))
+	})
+	t.Run("UpdateItemQuantityToZeroRemovesTheItem", func(t *testing.T) {
+		testUpdateItemQuantityToZeroRemovesTheItem(t, factory(t))
+	})
+	t.Run("UpdateItemQuantityRejectsNegativeQuantities", func(t *testing.T) {
+		testUpdateItemQuantityRejectsNegativeQuantities(t, factory(t))
+	})
+	t.Run("RemoveItem", func(t *testing.T) {
+		testRemoveItem(t, factory(t))
+	})
+	t.Run("UserIsolation", func(t *testing.T) {
+		testUserIsolation(t, factory(t))
+	})
+	t.Run("EmptyCart", func(t *testing.T) {
+		testEmptyCart(t, factory(t))
+	})
+	t.Run("EmptyCartOfUnknownUser", func(t *testing.T) {
+		testEmptyCartOfUnknownUser(t, factory(t))
+	})
+	t.Run("MergeCart", func(t *testing.T) {
+		testMergeCart(t, factory(t))
+	})
+	t.Run("MergeCartRejectsInvalidArguments", func(t *testing.T) {
+		testMergeCartRejectsInvalidArguments(t, factory(t))
+	})
+	t.Run("ConcurrentWriters", func(t *testing.T) {
+		testConcurrentWriters(t, factory(t))
+	})
+	t.Run("CancelledContext", func(t *testing.T) {
+		testCancelledContext(t, factory(t))
+	})
+	t.Run("Ping", func(t *testing.T) {
+		testPing(t, factory(t))
+	})
+	t.Run("FlowIsolation", func(t *testing.T) {
+		testFlowIsolation(t, factory(t))
+	})
+	t.Run("PurgeFlow", func(t *testing.T) {
+		testPurgeFlow(t, factory(t))
+	})
+}
+
+func testGetCartOfUnknownUserIsEmpty(t *testing.T, store cartstore.CartStore) {
+	userID := newUserID(t)
+
+	cart, err := store.GetCart(context.Background(), userID)
+	require.NoError(t, err)
+	require.NotNil(t, cart)
+	require.NotNil(t, cart.UserId)
+	require.Equal(t, userID, *cart.UserId)
+	require.NotNil(t, cart.Items)
+	require.Empty(t, *cart.Items)
+}
+
+func testAddItem(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	userID := newUserID(t)
+
+	require.NoError(t, store.AddItem(ctx, userID, sunglassesProductID, 1))
+	require.NoError(t, store.AddItem(ctx, userID, tankTopProductID, 3))
+
+	requireCart(t, store, userID, map[string]int32{
+		sunglassesProductID: 1,
+		tankTopProductID:    3,
+	})
+}
+
+func testAddItemMergesQuantities(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	userID := newUserID(t)
+
+	require.NoError(t, store.AddItem(ctx, userID, sunglassesProductID, 1))
+	require.NoError(t, store.AddItem(ctx, userID, sunglassesProductID, 2))
+	require.NoError(t, store.AddItem(ctx, userID, watchProductID, 1))
+	require.NoError(t, store.AddItem(ctx, userID, sunglassesProductID, 4))
+
+	requireCart(t, store, userID, map[string]int32{
+		sunglassesProductID: 7,
+		watchProductID:      1,
+	})
+}
+
+func testAddItemRejectsInvalidArguments(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	userID := newUserID(t)
+
+	require.ErrorIs(t, store.AddItem(ctx, userID, sunglassesProductID, 0), cartstore.ErrInvalidArgument)
+	require.ErrorIs(t, store.AddItem(ctx, userID, sunglassesProductID, -1), cartstore.ErrInvalidArgument)
+	require.ErrorIs(t, store.AddItem(ctx, userID, "", 1), cartstore.ErrInvalidArgument)
+	require.ErrorIs(t, store.AddItem(ctx, "", sunglassesProductID, 1), cartstore.ErrInvalidArgument)
+
+	requireCart(t, store, userID, map[string]int32{})
+}
+
+func testUpdateItemQuantity(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	userID := newUserID(t)
+
+	require.NoError(t, store.AddItem(ctx, userID, sunglassesProductID, 3))
+	require.NoError(t, store.UpdateItemQuantity(ctx, userID, sunglassesProductID, 1))
+	// updating a product that is not in the cart adds it
+	require.NoError(t, store.UpdateItemQuantity(ctx, userID, watchProductID, 2))
+
+	requireCart(t, store, userID, map[string]int32{
+		sunglassesProductID: 1,
+		watchProductID:      2,
+	})
+}
+
+func testUpdateItemQuantityToZeroRemovesTheItem(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	userID := newUserID(t)
+
+	require.NoError(t, store.AddItem(ctx, userID, sunglassesProductID, 3))
+	require.NoError(t, store.AddItem(ctx, userID, watchProductID, 1))
+	require.NoError(t, store.UpdateItemQuantity(ctx, userID, sunglassesProductID, 0))
+
+	requireCart(t, store, userID, map[string]int32{
+		watchProductID: 1,
+	})
+}
+
+func testUpdateItemQuantityRejectsNegativeQuantities(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	userID := newUserID(t)
+
+	require.NoError(t, store.AddItem(ctx, userID, sunglassesProductID, 3))
+	require.ErrorIs(t, store.UpdateItemQuantity(ctx, userID, sunglassesProductID, -1), cartstore.ErrInvalidArgument)
+
+	requireCart(t, store, userID, map[string]int32{
+		sunglassesProductID: 3,
+	})
+}
+
+func testRemoveItem(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	userID := newUserID(t)
+	otherUserID := newUserID(t)
+
+	require.NoError(t, store.AddItem(ctx, userID, sunglassesProductID, 3))
+	require.NoError(t, store.AddItem(ctx, userID, watchProductID, 1))
+	require.NoError(t, store.AddItem(ctx, otherUserID, sunglassesProductID, 2))
+
+	require.NoError(t, store.RemoveItem(ctx, userID, sunglassesProductID))
+	// removing a product that is not in the cart is a no-op
+	require.NoError(t, store.RemoveItem(ctx, userID, tankTopProductID))
+
+	requireCart(t, store, userID, map[string]int32{
+		watchProductID: 1,
+	})
+	requireCart(t, store, otherUserID, map[string]int32{
+		sunglassesProductID: 2,
+	})
+}
+
+func testUserIsolation(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	firstUserID := newUserID(t)
+	secondUserID := newUserID(t)
+
+	require.NoError(t, store.AddItem(ctx, firstUserID, sunglassesProductID, 1))
+	require.NoError(t, store.AddItem(ctx, secondUserID, sunglassesProductID, 5))
+	require.NoError(t, store.AddItem(ctx, secondUserID, watchProductID, 2))
+
+	requireCart(t, store, firstUserID, map[string]int32{
+		sunglassesProductID: 1,
+	})
+	requireCart(t, store, secondUserID, map[string]int32{
+		sunglassesProductID: 5,
+		watchProductID:      2,
+	})
+}
+
+func testEmptyCart(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	firstUserID := newUserID(t)
+	secondUserID := newUserID(t)
+
+	require.NoError(t, store.AddItem(ctx, firstUserID, sunglassesProductID, 1))
+	require.NoError(t, store.AddItem(ctx, firstUserID, tankTopProductID, 2))
+	require.NoError(t, store.AddItem(ctx, secondUserID, watchProductID, 3))
+
+	require.NoError(t, store.EmptyCart(ctx, firstUserID))
+
+	requireCart(t, store, firstUserID, map[string]int32{})
+	requireCart(t, store, secondUserID, map[string]int32{
+		watchProductID: 3,
+	})
+
+	// the cart can be used again after being emptied
+	require.NoError(t, store.AddItem(ctx, firstUserID, sunglassesProductID, 2))
+	requireCart(t, store, firstUserID, map[string]int32{
+		sunglassesProductID: 2,
+	})
+}
+
+func testEmptyCartOfUnknownUser(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	unknownUserID := newUserID(t)
+	otherUserID := newUserID(t)
+
+	require.NoError(t, store.AddItem(ctx, otherUserID, watchProductID, 1))
+
+	require.NoError(t, store.EmptyCart(ctx, unknownUserID))
+
+	requireCart(t, store, unknownUserID, map[string]int32{})
+	requireCart(t, store, otherUserID, map[string]int32{
+		watchProductID: 1,
+	})
+}
+
+func testMergeCart(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	sourceUserID := newUserID(t)
+	targetUserID := newUserID(t)
+	otherUserID := newUserID(t)
+	flowCtx := flow.ContextWithID(context.Background(), newFlowID(t))
+
+	require.NoError(t, store.AddItem(ctx, sourceUserID, sunglassesProductID, 2))
+	require.NoError(t, store.AddItem(ctx, sourceUserID, watchProductID, 1))
+	require.NoError(t, store.AddItem(ctx, targetUserID, sunglassesProductID, 1))
+	require.NoError(t, store.AddItem(ctx, targetUserID, tankTopProductID, 3))
+	require.NoError(t, store.AddItem(ctx, otherUserID, watchProductID, 4))
+	require.NoError(t, store.AddItem(flowCtx, sourceUserID, tankTopProductID, 1))
+
+	require.NoError(t, store.MergeCart(ctx, sourceUserID, targetUserID))
+
+	requireCart(t, store, targetUserID, map[string]int32{
+		sunglassesProductID: 3,
+		tankTopProductID:    3,
+		watchProductID:      1,
+	})
+	requireCart(t, store, sourceUserID, map[string]int32{})
+	requireCart(t, store, otherUserID, map[string]int32{
+		watchProductID: 4,
+	})
+	// only the carts of the flow in the context are merged
+	requireCartInFlow(t, store, flowCtx, sourceUserID, map[string]int32{
+		tankTopProductID: 1,
+	})
+
+	// merging an empty cart is a no-op
+	require.NoError(t, store.MergeCart(ctx, sourceUserID, targetUserID))
+	requireCart(t, store, targetUserID, map[string]int32{
+		sunglassesProductID: 3,
+		tankTopProductID:    3,
+		watchProductID:      1,
+	})
+}
+
+func testMergeCartRejectsInvalidArguments(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	userID := newUserID(t)
+
+	require.NoError(t, store.AddItem(ctx, userID, sunglassesProductID, 1))
+
+	require.ErrorIs(t, store.MergeCart(ctx, userID, userID), cartstore.ErrInvalidArgument)
+	require.ErrorIs(t, store.MergeCart(ctx, "", userID), cartstore.ErrInvalidArgument)
+	require.ErrorIs(t, store.MergeCart(ctx, userID, ""), cartstore.ErrInvalidArgument)
+
+	requireCart(t, store, userID, map[string]int32{
+		sunglassesProductID: 1,
+	})
+}
+
+func testConcurrentWriters(t *testing.T, store cartstore.CartStore) {
+	ctx := context.Background()
+	sharedUserID := newUserID(t)
+
+	writerUserIDs := make([]string, concurrentWriters)
+	for i := range writerUserIDs {
+		writerUserIDs[i] = newUserID(t)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, concurrentWriters*itemsPerConcurrentWriter*2)
+	)
+	for _, writerUserID := range writerUserIDs {
+		wg.Add(1)
+		go func(writerUserID string) {
+			defer wg.Done()
+			for i := 0; i < itemsPerConcurrentWriter; i++ {
+				errs <- store.AddItem(ctx, sharedUserID, sunglassesProductID, 1)
+				errs <- store.AddItem(ctx, writerUserID, tankTopProductID, 1)
+			}
+		}(writerUserID)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	requireCart(t, store, sharedUserID, map[string]int32{
+		sunglassesProductID: concurrentWriters * itemsPerConcurrentWriter,
+	})
+	for _, writerUserID := range writerUserIDs {
+		requireCart(t, store, writerUserID, map[string]int32{
+			tankTopProductID: itemsPerConcurrentWriter,
+		})
+	}
+}
+
+func testCancelledContext(t *testing.T, store cartstore.CartStore) {
+	userID := newUserID(t)
+
+	require.NoError(t, store.AddItem(context.Background(), userID, watchProductID, 1))
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.Error(t, store.AddItem(cancelledCtx, userID, sunglassesProductID, 1))
+	require.Error(t, store.UpdateItemQuantity(cancelledCtx, userID, watchProductID, 5))
+	require.Error(t, store.RemoveItem(cancelledCtx, userID, watchProductID))
+	require.Error(t, store.EmptyCart(cancelledCtx, userID))
+	require.Error(t, store.MergeCart(cancelledCtx, userID, newUserID(t)))
+	_, err := store.GetCart(cancelledCtx, userID)
+	require.Error(t, err)
+
+	// none of the calls with the cancelled context changed the cart
+	requireCart(t, store, userID, map[string]int32{
+		watchProductID: 1,
+	})
+}
+
+func testPing(t *testing.T, store cartstore.CartStore) {
+	require.NoError(t, store.Ping(context.Background()))
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.Error(t, store.Ping(cancelledCtx))
+}
+
+func testFlowIsolation(t *testing.T, store cartstore.CartStore) {
+	baselineCtx := context.Background()
+	flowCtx := flow.ContextWithID(context.Background(), newFlowID(t))
+	otherFlowCtx := flow.ContextWithID(context.Background(), newFlowID(t))
+	userID := newUserID(t)
+
+	require.NoError(t, store.AddItem(baselineCtx, userID, sunglassesProductID, 1))
+	require.NoError(t, store.AddItem(flowCtx, userID, sunglassesProductID, 2))
+	require.NoError(t, store.AddItem(flowCtx, userID, watchProductID, 1))
+	require.NoError(t, store.AddItem(otherFlowCtx, userID, tankTopProductID, 4))
+
+	require.NoError(t, store.UpdateItemQuantity(flowCtx, userID, sunglassesProductID, 5))
+	require.NoError(t, store.RemoveItem(flowCtx, userID, tankTopProductID))
+	require.NoError(t, store.EmptyCart(otherFlowCtx, userID))
+
+	requireCartInFlow(t, store, baselineCtx, userID, map[string]int32{
+		sunglassesProductID: 1,
+	})
+	requireCartInFlow(t, store, flowCtx, userID, map[string]int32{
+		sunglassesProductID: 5,
+		watchProductID:      1,
+	})
+	requireCartInFlow(t, store, otherFlowCtx, userID, map[string]int32{})
+}
+
+func testPurgeFlow(t *testing.T, store cartstore.CartStore) {
+	flowID := newFlowID(t)
+	flowCtx := flow.ContextWithID(context.Background(), flowID)
+	otherFlowCtx := flow.ContextWithID(context.Background(), newFlowID(t))
+	userID := newUserID(t)
+	otherUserID := newUserID(t)
+
+	require.NoError(t, store.AddItem(context.Background(), userID, sunglassesProductID, 1))
+	require.NoError(t, store.AddItem(flowCtx, userID, sunglassesProductID, 2))
+	require.NoError(t, store.AddItem(flowCtx, userID, watchProductID, 1))
+	require.NoError(t, store.AddItem(flowCtx, otherUserID, tankTopProductID, 3))
+	require.NoError(t, store.AddItem(otherFlowCtx, userID, watchProductID, 1))
+
+	// the flow to purge is the one in the argument, not the one in the context
+	deletedItems, err := store.PurgeFlow(otherFlowCtx, flowID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), deletedItems)
+
+	requireCartInFlow(t, store, flowCtx, userID, map[string]int32{})
+	requireCartInFlow(t, store, flowCtx, otherUserID, map[string]int32{})
+	requireCart(t, store, userID, map[string]int32{
+		sunglassesProductID: 1,
+	})
+	requireCartInFlow(t, store, otherFlowCtx, userID, map[string]int32{
+		watchProductID: 1,
+	})
+
+	// purging a flow without items is a no-op and the baseline can't be purged
+	deletedItems, err = store.PurgeFlow(context.Background(), flowID)
+	require.NoError(t, err)
+	require.Zero(t, deletedItems)
+	_, err = store.PurgeFlow(context.Background(), flow.BaselineID)
+	require.ErrorIs(t, err, cartstore.ErrInvalidArgument)
+}
+
+// newUserID returns a user ID that is unique across the test run, so stores shared between test cases don't collide
+func newUserID(t *testing.T) string {
+	return fmt.Sprintf("%s-%d", t.Name(), atomic.AddUint64(&userIDsCounter, 1))
+}
+
+// newFlowID returns a flow ID that is unique across the test run, like newUserID
+func newFlowID(t *testing.T) string {
+	return fmt.Sprintf("flow-%s-%d", t.Name(), atomic.AddUint64(&userIDsCounter, 1))
+}
+
+// requireCart checks that the user's baseline cart has exactly one line per expected product with the expected quantity
+func requireCart(t *testing.T, store cartstore.CartStore, userID string, expectedQuantities map[string]int32) {
+	t.Helper()
+	requireCartInFlow(t, store, context.Background(), userID, expectedQuantities)
+}
+
+// requireCartInFlow is requireCart for the cart of the user in the flow of the context
+func requireCartInFlow(t *testing.T, store cartstore.CartStore, ctx context.Context, userID string, expectedQuantities map[string]int32) {
+	t.Helper()
+
+	cart, err := store.GetCart(ctx, userID)
+	require.NoError(t, err)
+	require.NotNil(t, cart)
+	require.NotNil(t, cart.Items)
+
+	items := *cart.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ProductId < items[j].ProductId
+	})
+
+	expectedItems := []cartservice_rest_types.CartItem{}
+	for productID, quantity := range expectedQuantities {
+		expectedItems = append(expectedItems, cartservice_rest_types.CartItem{
+			ProductId: productID,
+			Quantity:  quantity,
+		})
+	}
+	sort.Slice(expectedItems, func(i, j int) bool {
+		return expectedItems[i].ProductId < expectedItems[j].ProductId
+	})
+
+	require.Equal(t, expectedItems, items, "unexpected items in the cart of user '%s'", userID)
+}
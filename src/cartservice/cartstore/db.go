@@ -3,18 +3,20 @@ package cartstore
 import (
 	"context"
 	"fmt"
-	"net"
-	"time"
 
 	cartservice_rest_types "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Db struct {
 	db *gorm.DB
+	// Migrator applies the migrations embedded in the binary
+	*database.Migrator
 }
 
 func NewDb(
@@ -25,122 +27,146 @@ func NewDb(
 	name string,
 	port string,
 ) (*Db, error) {
-	var dsn string
-	if uri != "" {
-		dsn = uri
-	} else {
-		dsn = fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s", host, username, password, name, port)
-	}
-	maxRetries := 5
-	initialBackoff := 1 * time.Second
-	backoffMultiplier := 2.0
-
-	db, err := retryConnect(dsn, maxRetries, initialBackoff, backoffMultiplier)
+	db, err := database.Connect(database.DSN(uri, host, username, password, name, port))
 	if err != nil {
-		return nil, errors.Wrap(err, fmt.Sprintf("An error occurred opening the connection to the database with dsn %s", dsn))
+		return nil, err
 	}
 
-	logrus.Info("connected to database")
-
-	if err = db.AutoMigrate(&Item{}); err != nil {
-		return nil, errors.Wrap(err, "An error occurred migrating the database")
+	if err := database.RegisterQueryMetricsCallbacks(db); err != nil {
+		return nil, err
+	}
+	if err := database.RegisterQueryTracingCallbacks(db); err != nil {
+		return nil, err
 	}
 
 	return &Db{
-		db: db,
+		db:       db,
+		Migrator: database.NewMigrator(db, migrationsConfig),
 	}, nil
 }
 
-func retryConnect(dsn string, maxRetries int, initialBackoff time.Duration, backoffMultiplier float64) (*gorm.DB, error) {
-	var (
-		err error
-		db  *gorm.DB
-	)
-	backoff := initialBackoff
-
-	for i := 0; i < maxRetries; i++ {
-		// Need to change the resolver to resolve all addresses to use ipv4 instead of ipv6
-		net.DefaultResolver = &net.Resolver{
-			PreferGo: true,
-			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
-				d := net.Dialer{
-					Timeout: time.Millisecond * time.Duration(10000),
-				}
-				return d.DialContext(ctx, "tcp4", address)
-			},
-		}
-		logrus.Infof("Attempting to connecting to datbase with dsn: %v\n", dsn)
-		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
-		if err != nil {
-			logrus.Debugf("An error occurred opening the connection to the database with dsn %s", dsn)
-		} else {
-			return db, nil
-		}
-
-		// Log the error and wait before retrying
-		logrus.Debugf("Attempt %d failed: %v\n", i+1, err)
-		time.Sleep(backoff)
-
-		// Increase backoff duration
-		backoff = time.Duration(float64(backoff) * backoffMultiplier)
-	}
-
-	return nil, fmt.Errorf("connection to db failed after %d retries: %w", maxRetries, err)
+func (db *Db) Close() error {
+	return database.Close(db.db)
 }
 
-func (db *Db) Close() error {
+func (db *Db) Ping(ctx context.Context) error {
 	sqlDb, err := db.db.DB()
 	if err != nil {
-		return errors.Wrap(err, "An error occurred closing the database connection")
+		return errors.Wrap(err, "An error occurred getting the database connection")
 	}
 
-	if err = sqlDb.Close(); err != nil {
-		return errors.Wrap(err, "An error occurred closing the database connection")
+	if err = sqlDb.PingContext(ctx); err != nil {
+		return errors.Wrap(classifyDbError(err), "An error occurred pinging the database")
 	}
 
 	return nil
 }
 
 func (db *Db) AddItem(ctx context.Context, userID, productID string, quantity int32) error {
+	if err := validateUserAndProduct(userID, productID); err != nil {
+		return err
+	}
+	if quantity <= 0 {
+		return newInvalidArgumentError("invalid quantity %d for product '%s', it must be greater than zero", quantity, productID)
+	}
+
 	item := &Item{
+		FlowID:    flow.IDFromContext(ctx),
 		UserID:    userID,
 		ProductID: productID,
 		Quantity:  quantity,
 	}
 
-	result := db.db.WithContext(ctx).Create(item)
+	// adding a product that is already in the cart increments the quantity of the existing line
+	result := db.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "flow_id"}, {Name: "user_id"}, {Name: "product_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"quantity":   gorm.Expr("items.quantity + excluded.quantity"),
+			"updated_at": gorm.Expr("excluded.updated_at"),
+		}),
+	}).Create(item)
 	if result.Error != nil {
 		logrus.Infof("An error occurred creating the item in the dB. Error: %s", result.Error.Error())
-		return errors.Wrap(result.Error, fmt.Sprintf("An internal error has occurred creating the item '%+v'", item))
+		return errors.Wrap(classifyDbError(result.Error), fmt.Sprintf("An internal error has occurred creating the item '%+v'", item))
 	}
 	logrus.Debugf("Success! Stored item %+v in database", item)
 	return nil
 }
 
+func (db *Db) UpdateItemQuantity(ctx context.Context, userID, productID string, quantity int32) error {
+	if err := validateUserAndProduct(userID, productID); err != nil {
+		return err
+	}
+	if quantity < 0 {
+		return newInvalidArgumentError("invalid quantity %d for product '%s', it can't be negative", quantity, productID)
+	}
+	if quantity == 0 {
+		return db.RemoveItem(ctx, userID, productID)
+	}
+
+	item := &Item{
+		FlowID:    flow.IDFromContext(ctx),
+		UserID:    userID,
+		ProductID: productID,
+		Quantity:  quantity,
+	}
+
+	result := db.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "flow_id"}, {Name: "user_id"}, {Name: "product_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"quantity":   gorm.Expr("excluded.quantity"),
+			"updated_at": gorm.Expr("excluded.updated_at"),
+		}),
+	}).Create(item)
+	if result.Error != nil {
+		return errors.Wrap(classifyDbError(result.Error), fmt.Sprintf("An internal error has occurred updating the item '%+v'", item))
+	}
+	return nil
+}
+
+func (db *Db) RemoveItem(ctx context.Context, userID, productID string) error {
+	if err := validateUserAndProduct(userID, productID); err != nil {
+		return err
+	}
+
+	result := db.db.WithContext(ctx).Unscoped().Where("flow_id = ? AND user_id = ? AND product_id = ?", flow.IDFromContext(ctx), userID, productID).Delete(&Item{})
+	if result.Error != nil {
+		return errors.Wrap(classifyDbError(result.Error), fmt.Sprintf("An internal error has occurred while removing product '%s' from the cart", productID))
+	}
+	return nil
+}
+
 func (db *Db) EmptyCart(ctx context.Context, userID string) error {
-	result := db.db.WithContext(ctx).Where("1 = 1").Delete(&Item{})
+	if userID == "" {
+		return newInvalidArgumentError("the user ID can't be empty")
+	}
+
+	// hard delete the lines so they don't collide with the ones added to the cart later on
+	result := db.db.WithContext(ctx).Unscoped().Where("flow_id = ? AND user_id = ?", flow.IDFromContext(ctx), userID).Delete(&Item{})
 	if result.Error != nil {
-		return errors.Wrap(result.Error, fmt.Sprintf("An internal error has occurred while empty the cart"))
+		return errors.Wrap(classifyDbError(result.Error), "An internal error has occurred while empty the cart")
 	}
 	return nil
 }
 
 func (db *Db) GetCart(ctx context.Context, userID string) (*cartservice_rest_types.Cart, error) {
+	if userID == "" {
+		return nil, newInvalidArgumentError("the user ID can't be empty")
+	}
+
 	var items []Item
 
-	result := db.db.WithContext(ctx).Where("user_id = ?", userID).Find(&items)
+	result := db.db.WithContext(ctx).Where("flow_id = ? AND user_id = ?", flow.IDFromContext(ctx), userID).Order("id").Find(&items)
 	if result.Error != nil {
-		return nil, errors.Wrap(result.Error, fmt.Sprintf("An internal error has occurred while getting the cart"))
+		return nil, errors.Wrap(classifyDbError(result.Error), "An internal error has occurred while getting the cart")
 	}
 
 	cartItems := []cartservice_rest_types.CartItem{}
 
 	for _, item := range items {
-		prodId := item.ProductID
-		quan := item.Quantity
 		cartItemObj := cartservice_rest_types.CartItem{
-			ProductId: &prodId,
-			Quantity:  &quan,
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
 		}
 		cartItems = append(cartItems, cartItemObj)
 	}
@@ -152,3 +178,69 @@ func (db *Db) GetCart(ctx context.Context, userID string) (*cartservice_rest_typ
 
 	return cart, nil
 }
+
+func (db *Db) MergeCart(ctx context.Context, sourceUserID, targetUserID string) error {
+	if err := validateMergedUsers(sourceUserID, targetUserID); err != nil {
+		return err
+	}
+	flowID := flow.IDFromContext(ctx)
+
+	// in a transaction, so a failure halfway through doesn't leave the items in both carts
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sourceItems []Item
+		if result := tx.Where("flow_id = ? AND user_id = ?", flowID, sourceUserID).Order("id").Find(&sourceItems); result.Error != nil {
+			return result.Error
+		}
+
+		for _, sourceItem := range sourceItems {
+			item := &Item{
+				FlowID:    flowID,
+				UserID:    targetUserID,
+				ProductID: sourceItem.ProductID,
+				Quantity:  sourceItem.Quantity,
+			}
+			result := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "flow_id"}, {Name: "user_id"}, {Name: "product_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"quantity":   gorm.Expr("items.quantity + excluded.quantity"),
+					"updated_at": gorm.Expr("excluded.updated_at"),
+				}),
+			}).Create(item)
+			if result.Error != nil {
+				return result.Error
+			}
+		}
+
+		return tx.Unscoped().Where("flow_id = ? AND user_id = ?", flowID, sourceUserID).Delete(&Item{}).Error
+	})
+	if err != nil {
+		return errors.Wrap(classifyDbError(err), fmt.Sprintf("An internal error has occurred merging the cart of user '%s' into the cart of user '%s'", sourceUserID, targetUserID))
+	}
+	return nil
+}
+
+func (db *Db) PurgeFlow(ctx context.Context, flowID string) (int64, error) {
+	if flowID == flow.BaselineID {
+		return 0, newInvalidArgumentError("the baseline flow can't be purged")
+	}
+
+	result := db.db.WithContext(ctx).Unscoped().Where("flow_id = ?", flowID).Delete(&Item{})
+	if result.Error != nil {
+		return 0, errors.Wrap(classifyDbError(result.Error), fmt.Sprintf("An internal error has occurred while purging the items of flow '%s'", flowID))
+	}
+	logrus.Infof("Purged %d items of flow '%s'", result.RowsAffected, flowID)
+	return result.RowsAffected, nil
+}
+
+// classifyDbError tags the database errors with the matching error kind, the rest are returned untouched
+func classifyDbError(err error) error {
+	switch database.ClassifyError(err) {
+	case database.ConflictError:
+		return newStoreError(ErrConflict, err)
+	case database.InvalidArgumentError:
+		return newStoreError(ErrInvalidArgument, err)
+	case database.UnavailableError:
+		return newStoreError(ErrUnavailable, err)
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package cartstore_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/cartservice/cartstore"
+	"github.com/kurtosis-tech/new-obd/src/cartservice/cartstore/cartstoretest"
+	"github.com/stretchr/testify/require"
+)
+
+// the Postgres tests only run when this environment variable contains the DSN of a database they can write to
+const testPostgresDSNEnvVarKey = "CARTSERVICE_TEST_POSTGRES_DSN"
+
+func newTestDb(t *testing.T) *cartstore.Db {
+	dsn := os.Getenv(testPostgresDSNEnvVarKey)
+	if dsn == "" {
+		t.Skipf("Skipping Postgres cart store test because the '%s' environment variable is not set", testPostgresDSNEnvVarKey)
+	}
+
+	db, err := cartstore.NewDb(dsn, "", "", "", "", "")
+	require.NoError(t, err)
+	require.NoError(t, db.MigrateUp(context.Background()))
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	return db
+}
+
+func newTestUserID(name string) string {
+	return fmt.Sprintf("%s-%d", name, time.Now().UnixNano())
+}
+
+func TestDbEmptyCartOnlyRemovesTheUserItems(t *testing.T) {
+	db := newTestDb(t)
+	ctx := context.Background()
+
+	firstUserID := newTestUserID("first-user")
+	secondUserID := newTestUserID("second-user")
+
+	require.NoError(t, db.AddItem(ctx, firstUserID, "OLJCESPC7Z", 1))
+	require.NoError(t, db.AddItem(ctx, secondUserID, "66VCHSJNUP", 2))
+	require.NoError(t, db.AddItem(ctx, secondUserID, "1YMWWN1N4O", 3))
+
+	require.NoError(t, db.EmptyCart(ctx, firstUserID))
+
+	firstUserCart, err := db.GetCart(ctx, firstUserID)
+	require.NoError(t, err)
+	require.Empty(t, *firstUserCart.Items)
+
+	secondUserCart, err := db.GetCart(ctx, secondUserID)
+	require.NoError(t, err)
+	require.Len(t, *secondUserCart.Items, 2)
+}
+
+func TestDbGetCartOnlyReturnsTheUserItems(t *testing.T) {
+	db := newTestDb(t)
+	ctx := context.Background()
+
+	firstUserID := newTestUserID("first-user")
+	secondUserID := newTestUserID("second-user")
+
+	require.NoError(t, db.AddItem(ctx, firstUserID, "OLJCESPC7Z", 1))
+	require.NoError(t, db.AddItem(ctx, secondUserID, "66VCHSJNUP", 2))
+
+	firstUserCart, err := db.GetCart(ctx, firstUserID)
+	require.NoError(t, err)
+	require.Equal(t, firstUserID, *firstUserCart.UserId)
+	require.Len(t, *firstUserCart.Items, 1)
+	require.Equal(t, "OLJCESPC7Z", (*firstUserCart.Items)[0].ProductId)
+	require.Equal(t, int32(1), (*firstUserCart.Items)[0].Quantity)
+
+	require.NoError(t, db.EmptyCart(ctx, secondUserID))
+
+	firstUserCart, err = db.GetCart(ctx, firstUserID)
+	require.NoError(t, err)
+	require.Len(t, *firstUserCart.Items, 1)
+}
+
+func TestDbConformance(t *testing.T) {
+	cartstoretest.RunCartStoreConformance(t, func(t *testing.T) cartstore.CartStore {
+		return newTestDb(t)
+	})
+}
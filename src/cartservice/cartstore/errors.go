@@ -0,0 +1,56 @@
+package cartstore
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of errors returned by the CartStore implementations, check them with errors.Is
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrConflict        = errors.New("conflict")
+	ErrUnavailable     = errors.New("unavailable")
+)
+
+// storeError tags the cause with one of the error kinds, so callers can classify it without knowing the implementation details
+type storeError struct {
+	kind  error
+	cause error
+}
+
+func newStoreError(kind error, cause error) error {
+	return &storeError{kind: kind, cause: cause}
+}
+
+func newInvalidArgumentError(format string, args ...interface{}) error {
+	return newStoreError(ErrInvalidArgument, fmt.Errorf(format, args...))
+}
+
+func (e *storeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.kind.Error(), e.cause.Error())
+}
+
+func (e *storeError) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
+
+func validateUserAndProduct(userID, productID string) error {
+	if userID == "" {
+		return newInvalidArgumentError("the user ID can't be empty")
+	}
+	if productID == "" {
+		return newInvalidArgumentError("the product ID can't be empty")
+	}
+	return nil
+}
+
+func validateMergedUsers(sourceUserID, targetUserID string) error {
+	if sourceUserID == "" || targetUserID == "" {
+		return newInvalidArgumentError("the user IDs can't be empty")
+	}
+	if sourceUserID == targetUserID {
+		return newInvalidArgumentError("the cart of user '%s' can't be merged into itself", sourceUserID)
+	}
+	return nil
+}
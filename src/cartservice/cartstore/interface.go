@@ -5,8 +5,32 @@ import (
 	cartservice_rest_types "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/types"
 )
 
+// CartStore persists the users' carts, every implementation has to honor this contract
+// (which is enforced by cartstoretest.RunCartStoreConformance):
+//   - carts are isolated per user, an operation on a user never reads or modifies another user's cart
+//   - carts are isolated per flow, an operation only reads and modifies the items of the flow in its context
+//     (see flow.ContextWithID), the same user has a different cart in each flow
+//   - a cart holds at most one line per product, adding a product that is already in the cart
+//     increments the quantity of the existing line
+//   - getting the cart of an unknown user returns an empty cart instead of an error
+//   - updating the quantity of a product sets it, adding the line if it's not in the cart and removing it if the quantity is zero
+//   - emptying a cart or removing a product that isn't in it is a no-op
+//   - the methods are safe for concurrent use and none of the writes are lost
+//   - the methods fail without side effects if the context is already cancelled
+//   - the errors are tagged with the error kinds in errors.go (e.g. ErrInvalidArgument for a non-positive quantity)
 type CartStore interface {
 	AddItem(ctx context.Context, userID, productID string, quantity int32) error
+	UpdateItemQuantity(ctx context.Context, userID, productID string, quantity int32) error
+	RemoveItem(ctx context.Context, userID, productID string) error
 	EmptyCart(ctx context.Context, userID string) error
 	GetCart(ctx context.Context, userID string) (*cartservice_rest_types.Cart, error)
+	// MergeCart moves the items of the source user's cart into the target user's cart, adding up the quantities of the
+	// products that are in both, and empties the source cart. Both carts are the ones of the flow in the context.
+	// Merging a cart into itself is rejected with ErrInvalidArgument.
+	MergeCart(ctx context.Context, sourceUserID, targetUserID string) error
+	// PurgeFlow deletes the items of every cart of the flow, whatever the flow in the context is, and returns how many
+	// items were deleted. Purging the baseline is rejected with ErrInvalidArgument.
+	PurgeFlow(ctx context.Context, flowID string) (int64, error)
+	// Ping checks that the store is able to serve requests, it's used by the readiness check
+	Ping(ctx context.Context) error
 }
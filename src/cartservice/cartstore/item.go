@@ -2,8 +2,11 @@ package cartstore
 
 import "gorm.io/gorm"
 
+// Item is a cart line, the items table schema is defined by the SQL files in the migrations directory
 type Item struct {
 	gorm.Model
+	// FlowID is the Kardinal flow that wrote the line, flow.BaselineID for the baseline
+	FlowID    string
 	UserID    string
 	ProductID string
 	Quantity  int32
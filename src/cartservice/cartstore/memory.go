@@ -0,0 +1,232 @@
+package cartstore
+
+import (
+	"context"
+	"sync"
+
+	cartservice_rest_types "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
+)
+
+// Memory is a CartStore implementation that keeps every cart in a process-local map,
+// it's useful to run the cart service without a database (e.g. locally or in unit tests)
+type Memory struct {
+	mu    sync.RWMutex
+	carts map[cartKey][]Item
+}
+
+// cartKey identifies the cart of a user in a flow
+type cartKey struct {
+	flowID string
+	userID string
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		carts: map[cartKey][]Item{},
+	}
+}
+
+func newCartKey(ctx context.Context, userID string) cartKey {
+	return cartKey{flowID: flow.IDFromContext(ctx), userID: userID}
+}
+
+// Ping never fails unless the context is done, the carts are always available
+func (m *Memory) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (m *Memory) AddItem(ctx context.Context, userID, productID string, quantity int32) error {
+	if err := validateUserAndProduct(userID, productID); err != nil {
+		return err
+	}
+	if quantity <= 0 {
+		return newInvalidArgumentError("invalid quantity %d for product '%s', it must be greater than zero", quantity, productID)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	key := newCartKey(ctx, userID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := m.carts[key]
+	for i := range items {
+		if items[i].ProductID == productID {
+			items[i].Quantity += quantity
+			return nil
+		}
+	}
+
+	item := Item{
+		FlowID:    key.flowID,
+		UserID:    userID,
+		ProductID: productID,
+		Quantity:  quantity,
+	}
+
+	m.carts[key] = append(items, item)
+	return nil
+}
+
+func (m *Memory) UpdateItemQuantity(ctx context.Context, userID, productID string, quantity int32) error {
+	if err := validateUserAndProduct(userID, productID); err != nil {
+		return err
+	}
+	if quantity < 0 {
+		return newInvalidArgumentError("invalid quantity %d for product '%s', it can't be negative", quantity, productID)
+	}
+	if quantity == 0 {
+		return m.RemoveItem(ctx, userID, productID)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	key := newCartKey(ctx, userID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := m.carts[key]
+	for i := range items {
+		if items[i].ProductID == productID {
+			items[i].Quantity = quantity
+			return nil
+		}
+	}
+
+	item := Item{
+		FlowID:    key.flowID,
+		UserID:    userID,
+		ProductID: productID,
+		Quantity:  quantity,
+	}
+
+	m.carts[key] = append(items, item)
+	return nil
+}
+
+func (m *Memory) RemoveItem(ctx context.Context, userID, productID string) error {
+	if err := validateUserAndProduct(userID, productID); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	key := newCartKey(ctx, userID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := m.carts[key]
+	for i := range items {
+		if items[i].ProductID == productID {
+			m.carts[key] = append(items[:i], items[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *Memory) EmptyCart(ctx context.Context, userID string) error {
+	if userID == "" {
+		return newInvalidArgumentError("the user ID can't be empty")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	key := newCartKey(ctx, userID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.carts, key)
+	return nil
+}
+
+func (m *Memory) GetCart(ctx context.Context, userID string) (*cartservice_rest_types.Cart, error) {
+	if userID == "" {
+		return nil, newInvalidArgumentError("the user ID can't be empty")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key := newCartKey(ctx, userID)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	cartItems := []cartservice_rest_types.CartItem{}
+
+	for _, item := range m.carts[key] {
+		cartItemObj := cartservice_rest_types.CartItem{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+		cartItems = append(cartItems, cartItemObj)
+	}
+
+	cart := &cartservice_rest_types.Cart{
+		UserId: &userID,
+		Items:  &cartItems,
+	}
+
+	return cart, nil
+}
+
+func (m *Memory) MergeCart(ctx context.Context, sourceUserID, targetUserID string) error {
+	if err := validateMergedUsers(sourceUserID, targetUserID); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	sourceKey := newCartKey(ctx, sourceUserID)
+	targetKey := newCartKey(ctx, targetUserID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	targetItems := m.carts[targetKey]
+	for _, sourceItem := range m.carts[sourceKey] {
+		merged := false
+		for i := range targetItems {
+			if targetItems[i].ProductID == sourceItem.ProductID {
+				targetItems[i].Quantity += sourceItem.Quantity
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			sourceItem.UserID = targetUserID
+			targetItems = append(targetItems, sourceItem)
+		}
+	}
+	if len(targetItems) > 0 {
+		m.carts[targetKey] = targetItems
+	}
+	delete(m.carts, sourceKey)
+	return nil
+}
+
+func (m *Memory) PurgeFlow(ctx context.Context, flowID string) (int64, error) {
+	if flowID == flow.BaselineID {
+		return 0, newInvalidArgumentError("the baseline flow can't be purged")
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deletedItems := int64(0)
+	for key, items := range m.carts {
+		if key.flowID == flowID {
+			deletedItems += int64(len(items))
+			delete(m.carts, key)
+		}
+	}
+	return deletedItems, nil
+}
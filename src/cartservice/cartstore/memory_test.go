@@ -0,0 +1,14 @@
+package cartstore_test
+
+import (
+	"testing"
+
+	"github.com/kurtosis-tech/new-obd/src/cartservice/cartstore"
+	"github.com/kurtosis-tech/new-obd/src/cartservice/cartstore/cartstoretest"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	cartstoretest.RunCartStoreConformance(t, func(t *testing.T) cartstore.CartStore {
+		return cartstore.NewMemory()
+	})
+}
@@ -0,0 +1,23 @@
+package cartstore
+
+import (
+	"embed"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+)
+
+// random key shared by every cartservice replica, so only one of them migrates the database at a time
+const migrationsAdvisoryLockKey int64 = 7_431_805_116_207_324_913
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+var migrationsConfig = database.MigrationsConfig{
+	FS:              migrationsFS,
+	AdvisoryLockKey: migrationsAdvisoryLockKey,
+}
+
+// Migrations returns the migrations embedded in the binary sorted by version
+func Migrations() ([]database.Migration, error) {
+	return database.Migrations(migrationsFS)
+}
@@ -0,0 +1,87 @@
+package cartstore_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kurtosis-tech/new-obd/src/cartservice/cartstore"
+	"github.com/stretchr/testify/require"
+)
+
+// the Kardinal manifests seeding the cart database, their schema has to match the migrations
+var seedScriptManifestPaths = []string{
+	filepath.Join("..", "..", "..", "obd-demo.yaml"),
+	filepath.Join("..", "..", "..", "template.yaml"),
+}
+
+func TestMigrationsAreSequential(t *testing.T) {
+	migrations, err := cartstore.Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		require.Equal(t, int64(i+1), migration.Version, "migration %s is out of sequence", migration.Name)
+		require.NotEmpty(t, migration.Checksum)
+	}
+}
+
+func TestSeedScriptsCreateTheMigrationsSchema(t *testing.T) {
+	migrations, err := cartstore.Migrations()
+	require.NoError(t, err)
+
+	for _, manifestPath := range seedScriptManifestPaths {
+		manifest, err := os.ReadFile(manifestPath)
+		require.NoError(t, err)
+		normalizedManifest := normalizeSQL(string(manifest))
+
+		for _, migration := range migrations {
+			for _, statement := range strings.Split(migration.UpSQL, ";") {
+				normalizedStatement := normalizeSQL(statement)
+				if !strings.HasPrefix(normalizedStatement, "CREATE ") {
+					continue
+				}
+				require.Contains(t, normalizedManifest, normalizedStatement, "the seed script in '%s' is out of sync with migration %d_%s", manifestPath, migration.Version, migration.Name)
+			}
+		}
+	}
+}
+
+func TestDbMigrateDownAndUp(t *testing.T) {
+	db := newTestDb(t)
+	ctx := context.Background()
+
+	migrations, err := cartstore.Migrations()
+	require.NoError(t, err)
+
+	require.NoError(t, db.MigrateDown(ctx, len(migrations)))
+	statuses, err := db.MigrationsStatus(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		require.Nil(t, status.AppliedAt, "migration %d_%s should be pending", status.Version, status.Name)
+	}
+
+	require.NoError(t, db.MigrateUp(ctx))
+	// applying the migrations again is a no-op
+	require.NoError(t, db.MigrateUp(ctx))
+	statuses, err = db.MigrationsStatus(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		require.NotNil(t, status.AppliedAt, "migration %d_%s should be applied", status.Version, status.Name)
+		require.True(t, status.ChecksumMatches)
+	}
+}
+
+// normalizeSQL drops the comments and collapses the whitespaces, so SQL embedded in YAML can be compared
+func normalizeSQL(sql string) string {
+	lines := []string{}
+	for _, line := range strings.Split(sql, "\n") {
+		if commentIndex := strings.Index(line, "--"); commentIndex >= 0 {
+			line = line[:commentIndex]
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+}
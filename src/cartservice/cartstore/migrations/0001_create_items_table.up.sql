@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS public.items(
+    id bigserial PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    user_id TEXT,
+    product_id TEXT,
+    quantity INTEGER
+);
+CREATE INDEX IF NOT EXISTS idx_items_deleted_at ON public.items (deleted_at);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS public.idx_items_user_id_product_id;
@@ -0,0 +1,8 @@
+-- soft deleted lines are not part of any cart anymore but they would still collide in the unique index
+DELETE FROM public.items WHERE deleted_at IS NOT NULL;
+-- collapse the lines of the same user and product into the oldest one
+UPDATE public.items SET quantity = merged.quantity, updated_at = NOW()
+FROM (SELECT MIN(id) AS id, SUM(quantity) AS quantity FROM public.items GROUP BY user_id, product_id HAVING COUNT(*) > 1) AS merged
+WHERE items.id = merged.id;
+DELETE FROM public.items WHERE id NOT IN (SELECT MIN(id) FROM public.items GROUP BY user_id, product_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_items_user_id_product_id ON public.items (user_id, product_id);
@@ -0,0 +1,5 @@
+-- the lines of the flows would collide with the baseline ones in the unique index
+DELETE FROM public.items WHERE flow_id <> '';
+DROP INDEX IF EXISTS public.idx_items_flow_id_user_id_product_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_items_user_id_product_id ON public.items (user_id, product_id);
+ALTER TABLE public.items DROP COLUMN IF EXISTS flow_id;
@@ -0,0 +1,4 @@
+-- the Kardinal flow that wrote the line, the existing lines and the ones written by the baseline have the empty flow
+ALTER TABLE public.items ADD COLUMN IF NOT EXISTS flow_id TEXT NOT NULL DEFAULT '';
+DROP INDEX IF EXISTS public.idx_items_user_id_product_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_items_flow_id_user_id_product_id ON public.items (flow_id, user_id, product_id);
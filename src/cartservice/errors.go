@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kurtosis-tech/new-obd/src/cartservice/cartstore"
+	"github.com/pkg/errors"
+)
+
+// statusCodeForError returns the status code of the errors returned by the handlers, based on the cartstore error kinds
+func statusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, cartstore.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, cartstore.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, cartstore.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, cartstore.ErrUnavailable), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
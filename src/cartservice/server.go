@@ -6,20 +6,38 @@ import (
 	cartservice_rest_types "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/types"
 	"github.com/kurtosis-tech/new-obd/src/cartservice/cartstore"
 	"github.com/sirupsen/logrus"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	healthStatusOk           = "ok"
+	healthStatusError        = "error"
+	healthStatusShuttingDown = "shutting down"
+
+	databaseDependencyName = "database"
+
+	readinessCheckTimeout = 2 * time.Second
+)
+
 type Server struct {
 	Store cartstore.CartStore
+	// shuttingDown is a pointer so every copy of the server (the handlers have value receivers) sees the same flag
+	shuttingDown *atomic.Bool
 }
 
 func NewServer(store cartstore.CartStore) Server {
-	return Server{Store: store}
+	return Server{Store: store, shuttingDown: &atomic.Bool{}}
+}
+
+// MarkShuttingDown makes the readiness check fail, so the service stops receiving traffic while it shuts down
+func (s Server) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
 }
 
 func (s Server) GetHealth(ctx context.Context, request cartservice_server_rest_server.GetHealthRequestObject) (cartservice_server_rest_server.GetHealthResponseObject, error) {
 
-	status := "ok"
+	status := healthStatusOk
 	now := time.Now()
 
 	response := cartservice_rest_types.HealthResponse{
@@ -30,9 +48,56 @@ func (s Server) GetHealth(ctx context.Context, request cartservice_server_rest_s
 	return cartservice_server_rest_server.GetHealth200JSONResponse(response), nil
 }
 
+func (s Server) GetHealthLive(ctx context.Context, request cartservice_server_rest_server.GetHealthLiveRequestObject) (cartservice_server_rest_server.GetHealthLiveResponseObject, error) {
+	status := healthStatusOk
+	now := time.Now()
+
+	response := cartservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return cartservice_server_rest_server.GetHealthLive200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthReady(ctx context.Context, request cartservice_server_rest_server.GetHealthReadyRequestObject) (cartservice_server_rest_server.GetHealthReadyResponseObject, error) {
+	now := time.Now()
+
+	if s.shuttingDown.Load() {
+		status := healthStatusShuttingDown
+		response := cartservice_rest_types.HealthResponse{
+			Status:    &status,
+			Timestamp: &now,
+		}
+		return cartservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+
+	checks := []cartservice_rest_types.DependencyCheck{
+		checkDependency(ctx, databaseDependencyName, s.Store.Ping),
+	}
+
+	status := healthStatusOk
+	for _, check := range checks {
+		if check.Status != healthStatusOk {
+			status = healthStatusError
+		}
+	}
+
+	response := cartservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+		Checks:    &checks,
+	}
+
+	if status != healthStatusOk {
+		return cartservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+	return cartservice_server_rest_server.GetHealthReady200JSONResponse(response), nil
+}
+
 func (s Server) PostCart(ctx context.Context, object cartservice_server_rest_server.PostCartRequestObject) (cartservice_server_rest_server.PostCartResponseObject, error) {
-	logrus.Infof("Post cart request - UserID: %s, ProductID: %s, Quantity: %d", *object.Body.UserId, *object.Body.Item.ProductId, *object.Body.Item.Quantity)
-	if err := s.Store.AddItem(ctx, *object.Body.UserId, *object.Body.Item.ProductId, *object.Body.Item.Quantity); err != nil {
+	logrus.Infof("Post cart request - UserID: %s, ProductID: %s, Quantity: %d", object.Body.UserId, object.Body.Item.ProductId, object.Body.Item.Quantity)
+	if err := s.Store.AddItem(ctx, object.Body.UserId, object.Body.Item.ProductId, object.Body.Item.Quantity); err != nil {
 		logrus.Infof("An error occurred storing the item in the store. Error: %s", err.Error())
 		return nil, err
 	}
@@ -59,3 +124,74 @@ func (s Server) DeleteCartUserId(ctx context.Context, request cartservice_server
 	}
 	return cartservice_server_rest_server.DeleteCartUserId200JSONResponse{}, nil
 }
+
+func (s Server) PutCartUserIdItemsProductId(ctx context.Context, request cartservice_server_rest_server.PutCartUserIdItemsProductIdRequestObject) (cartservice_server_rest_server.PutCartUserIdItemsProductIdResponseObject, error) {
+	logrus.Infof("Put cart item request - UserID: %s, ProductID: %s, Quantity: %d", request.UserId, request.ProductId, request.Body.Quantity)
+	if err := s.Store.UpdateItemQuantity(ctx, request.UserId, request.ProductId, request.Body.Quantity); err != nil {
+		logrus.Infof("An error occurred updating the item quantity in the store. Error: %s", err.Error())
+		return nil, err
+	}
+	return cartservice_server_rest_server.PutCartUserIdItemsProductId200JSONResponse{}, nil
+}
+
+func (s Server) DeleteCartUserIdItemsProductId(ctx context.Context, request cartservice_server_rest_server.DeleteCartUserIdItemsProductIdRequestObject) (cartservice_server_rest_server.DeleteCartUserIdItemsProductIdResponseObject, error) {
+	if err := s.Store.RemoveItem(ctx, request.UserId, request.ProductId); err != nil {
+		return nil, err
+	}
+	return cartservice_server_rest_server.DeleteCartUserIdItemsProductId200JSONResponse{}, nil
+}
+
+func (s Server) PostCartUserIdMerge(ctx context.Context, request cartservice_server_rest_server.PostCartUserIdMergeRequestObject) (cartservice_server_rest_server.PostCartUserIdMergeResponseObject, error) {
+	logrus.Infof("Merge cart request - SourceUserID: %s, UserID: %s", request.Body.SourceUserId, request.UserId)
+	if err := s.Store.MergeCart(ctx, request.Body.SourceUserId, request.UserId); err != nil {
+		logrus.Infof("An error occurred merging the carts in the store. Error: %s", err.Error())
+		return nil, err
+	}
+
+	cart, err := s.Store.GetCart(ctx, request.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := cartservice_rest_types.Cart{
+		UserId: cart.UserId,
+		Items:  cart.Items,
+	}
+
+	return cartservice_server_rest_server.PostCartUserIdMerge200JSONResponse(response), nil
+}
+
+func (s Server) DeleteAdminFlowsFlowId(ctx context.Context, request cartservice_server_rest_server.DeleteAdminFlowsFlowIdRequestObject) (cartservice_server_rest_server.DeleteAdminFlowsFlowIdResponseObject, error) {
+	deletedItems, err := s.Store.PurgeFlow(ctx, request.FlowId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := cartservice_rest_types.PurgeFlowResponse{
+		FlowId:       request.FlowId,
+		DeletedItems: deletedItems,
+	}
+
+	return cartservice_server_rest_server.DeleteAdminFlowsFlowId200JSONResponse(response), nil
+}
+
+// checkDependency runs the check with the readiness timeout and reports its status and latency
+func checkDependency(ctx context.Context, name string, check func(ctx context.Context) error) cartservice_rest_types.DependencyCheck {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	dependencyCheck := cartservice_rest_types.DependencyCheck{
+		Name:      name,
+		Status:    healthStatusOk,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		logrus.Warnf("The readiness check of dependency '%s' failed. Error: %s", name, err)
+		errMsg := err.Error()
+		dependencyCheck.Status = healthStatusError
+		dependencyCheck.Error = &errMsg
+	}
+	return dependencyCheck
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cartservice_rest_client "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/client"
+	cartservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/server"
+	cartservice_rest_types "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/cartservice/cartstore"
+	"github.com/kurtosis-tech/new-obd/src/libs/consts"
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
+	"github.com/kurtosis-tech/new-obd/src/libs/metrics"
+	"github.com/kurtosis-tech/new-obd/src/libs/rest"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUserID     = "test-user"
+	testProductID  = "OLJCESPC7Z"
+	testFlowID     = "dev-flow-1"
+	testAdminToken = "test-admin-token"
+)
+
+func withFlowID(flowID string) cartservice_rest_client.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set(consts.KardinalFlowIdHeaderKey, flowID)
+		return nil
+	}
+}
+
+func withAdminToken(token string) cartservice_rest_client.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		return nil
+	}
+}
+
+func newTestClient(t *testing.T, server Server) *cartservice_rest_client.ClientWithResponses {
+	echoRouter := echo.New()
+	echoRouter.HTTPErrorHandler = rest.NewResponseInfoHTTPErrorHandler(statusCodeForError)
+	echoRouter.Use(metrics.Middleware)
+
+	requestValidatorMiddleware, err := rest.NewRequestValidatorMiddleware(cartservice_server_rest_server.GetSwagger, testAdminToken)
+	require.NoError(t, err)
+	echoRouter.Use(requestValidatorMiddleware)
+	echoRouter.Use(flow.NewMiddleware([]string{consts.KardinalFlowIdHeaderKey}))
+
+	metrics.RegisterHandler(echoRouter)
+
+	strictMiddlewares := []cartservice_server_rest_server.StrictMiddlewareFunc{rest.NewErrorMappingMiddleware(statusCodeForError)}
+	cartservice_server_rest_server.RegisterHandlers(echoRouter, cartservice_server_rest_server.NewStrictHandler(server, strictMiddlewares))
+
+	httpServer := httptest.NewServer(echoRouter)
+	t.Cleanup(httpServer.Close)
+
+	client, err := cartservice_rest_client.NewClientWithResponses(httpServer.URL)
+	require.NoError(t, err)
+	return client
+}
+
+func TestPostCartAndGetCart(t *testing.T) {
+	client := newTestClient(t, NewServer(cartstore.NewMemory()))
+	ctx := context.Background()
+
+	quantity := int32(2)
+	postResponse, err := client.PostCartWithResponse(ctx, cartservice_rest_types.AddItemRequest{
+		UserId: testUserID,
+		Item: cartservice_rest_types.CartItem{
+			ProductId: testProductID,
+			Quantity:  quantity,
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, cartservice_rest_client.CheckResponse(postResponse, postResponse.JSONDefault))
+
+	getResponse, err := client.GetCartUserIdWithResponse(ctx, testUserID)
+	require.NoError(t, err)
+	require.NoError(t, cartservice_rest_client.CheckResponse(getResponse, getResponse.JSONDefault))
+	require.Len(t, *getResponse.JSON200.Items, 1)
+	require.Equal(t, quantity, (*getResponse.JSON200.Items)[0].Quantity)
+}
+
+func TestUpdateItemWithNegativeQuantityIsABadRequest(t *testing.T) {
+	client := newTestClient(t, NewServer(cartstore.NewMemory()))
+	ctx := context.Background()
+
+	response, err := client.PutCartUserIdItemsProductIdWithResponse(ctx, testUserID, testProductID, cartservice_rest_types.UpdateItemRequest{
+		Quantity: -1,
+	})
+	require.NoError(t, err)
+
+	err = cartservice_rest_client.CheckResponse(response, response.JSONDefault)
+	require.ErrorIs(t, err, cartservice_rest_client.ErrInvalidArgument)
+	require.Equal(t, http.StatusBadRequest, response.StatusCode())
+	require.NotNil(t, response.JSONDefault)
+	require.Equal(t, cartservice_rest_types.ERROR, response.JSONDefault.Type)
+	require.Equal(t, uint32(http.StatusBadRequest), response.JSONDefault.Code)
+}
+
+func TestPostCartWithMissingFieldsListsEveryViolation(t *testing.T) {
+	client := newTestClient(t, NewServer(cartstore.NewMemory()))
+	ctx := context.Background()
+
+	response, err := client.PostCartWithBodyWithResponse(ctx, echo.MIMEApplicationJSON, strings.NewReader(`{"item": {"quantity": 0}}`))
+	require.NoError(t, err)
+
+	err = cartservice_rest_client.CheckResponse(response, response.JSONDefault)
+	require.ErrorIs(t, err, cartservice_rest_client.ErrInvalidArgument)
+	require.NotNil(t, response.JSONDefault)
+	require.Equal(t, uint32(http.StatusBadRequest), response.JSONDefault.Code)
+	require.Contains(t, response.JSONDefault.Message, "user_id")
+	require.Contains(t, response.JSONDefault.Message, "item.product_id")
+	require.Contains(t, response.JSONDefault.Message, "item.quantity")
+}
+
+func TestHealthReadyChecksTheStoreAndFailsWhileShuttingDown(t *testing.T) {
+	server := NewServer(cartstore.NewMemory())
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	response, err := client.GetHealthReadyWithResponse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode())
+	require.NotNil(t, response.JSON200.Checks)
+	require.Len(t, *response.JSON200.Checks, 1)
+	require.Equal(t, databaseDependencyName, (*response.JSON200.Checks)[0].Name)
+	require.Equal(t, healthStatusOk, (*response.JSON200.Checks)[0].Status)
+
+	server.MarkShuttingDown()
+
+	response, err = client.GetHealthReadyWithResponse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, response.StatusCode())
+	require.Equal(t, healthStatusShuttingDown, *response.JSON503.Status)
+
+	liveResponse, err := client.GetHealthLiveWithResponse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, liveResponse.StatusCode())
+}
+
+func TestMetricsRecordTheRequestsByRouteAndStatus(t *testing.T) {
+	client := newTestClient(t, NewServer(cartstore.NewMemory()))
+	ctx := context.Background()
+
+	getResponse, err := client.GetCartUserIdWithResponse(ctx, testUserID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, getResponse.StatusCode())
+
+	putResponse, err := client.PutCartUserIdItemsProductIdWithResponse(ctx, testUserID, testProductID, cartservice_rest_types.UpdateItemRequest{
+		Quantity: -1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, putResponse.StatusCode())
+
+	recorder := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, metrics.Path, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	scraped := recorder.Body.String()
+	// the route is the path template, so the user and product IDs don't create new series
+	require.Contains(t, scraped, `http_request_duration_seconds_count{method="GET",route="/cart/:user_id",status="200"}`)
+	require.Contains(t, scraped, `http_request_duration_seconds_count{method="PUT",route="/cart/:user_id/items/:product_id",status="400"}`)
+}
+
+func TestFlowCartsAreIsolatedAndPurged(t *testing.T) {
+	client := newTestClient(t, NewServer(cartstore.NewMemory()))
+	ctx := context.Background()
+
+	for _, requestEditors := range [][]cartservice_rest_client.RequestEditorFn{nil, {withFlowID(testFlowID)}} {
+		postResponse, err := client.PostCartWithResponse(ctx, cartservice_rest_types.AddItemRequest{
+			UserId: testUserID,
+			Item: cartservice_rest_types.CartItem{
+				ProductId: testProductID,
+				Quantity:  1,
+			},
+		}, requestEditors...)
+		require.NoError(t, err)
+		require.NoError(t, cartservice_rest_client.CheckResponse(postResponse, postResponse.JSONDefault))
+	}
+
+	// the baseline doesn't see the items added by the flow
+	getResponse, err := client.GetCartUserIdWithResponse(ctx, testUserID)
+	require.NoError(t, err)
+	require.Len(t, *getResponse.JSON200.Items, 1)
+	require.Equal(t, int32(1), (*getResponse.JSON200.Items)[0].Quantity)
+
+	purgeResponse, err := client.DeleteAdminFlowsFlowIdWithResponse(ctx, testFlowID, withAdminToken(testAdminToken))
+	require.NoError(t, err)
+	require.NoError(t, cartservice_rest_client.CheckResponse(purgeResponse, purgeResponse.JSONDefault))
+	require.Equal(t, testFlowID, purgeResponse.JSON200.FlowId)
+	require.Equal(t, int64(1), purgeResponse.JSON200.DeletedItems)
+
+	flowGetResponse, err := client.GetCartUserIdWithResponse(ctx, testUserID, withFlowID(testFlowID))
+	require.NoError(t, err)
+	require.Empty(t, *flowGetResponse.JSON200.Items)
+
+	getResponse, err = client.GetCartUserIdWithResponse(ctx, testUserID)
+	require.NoError(t, err)
+	require.Len(t, *getResponse.JSON200.Items, 1)
+}
+
+func TestMergeCartReturnsTheMergedCart(t *testing.T) {
+	server := NewServer(cartstore.NewMemory())
+	client := newTestClient(t, server)
+	ctx := context.Background()
+	anonymousUserID := "anonymous-session"
+
+	require.NoError(t, server.Store.AddItem(ctx, anonymousUserID, testProductID, 2))
+	require.NoError(t, server.Store.AddItem(ctx, testUserID, testProductID, 1))
+
+	response, err := client.PostCartUserIdMergeWithResponse(ctx, testUserID, cartservice_rest_types.MergeCartRequest{
+		SourceUserId: anonymousUserID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, cartservice_rest_client.CheckResponse(response, response.JSONDefault))
+	require.Len(t, *response.JSON200.Items, 1)
+	require.Equal(t, int32(3), (*response.JSON200.Items)[0].Quantity)
+
+	// a cart can't be merged into itself
+	response, err = client.PostCartUserIdMergeWithResponse(ctx, testUserID, cartservice_rest_types.MergeCartRequest{
+		SourceUserId: testUserID,
+	})
+	require.NoError(t, err)
+	require.ErrorIs(t, cartservice_rest_client.CheckResponse(response, response.JSONDefault), cartservice_rest_client.ErrInvalidArgument)
+}
+
+func TestPurgeFlowRequiresTheAdminToken(t *testing.T) {
+	client := newTestClient(t, NewServer(cartstore.NewMemory()))
+	ctx := context.Background()
+
+	for _, requestEditors := range [][]cartservice_rest_client.RequestEditorFn{nil, {withAdminToken("wrong-token")}} {
+		purgeResponse, err := client.DeleteAdminFlowsFlowIdWithResponse(ctx, testFlowID, requestEditors...)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusUnauthorized, purgeResponse.StatusCode())
+	}
+}
@@ -0,0 +1,64 @@
+package checkoutservice_rest_client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	checkoutservice_rest_types "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/types"
+)
+
+// The kinds of errors returned by the checkout service, check them with errors.Is on the error returned by CheckResponse
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrPaymentDeclined = errors.New("payment declined")
+	ErrOutOfStock      = errors.New("out of stock")
+	ErrUnavailable     = errors.New("unavailable")
+)
+
+// ResponseError is a non-successful checkout service response, with the ResponseInfo sent by the service if there is one
+type ResponseError struct {
+	StatusCode int
+	Info       *checkoutservice_rest_types.ResponseInfo
+}
+
+// response is implemented by every <Operation>Response returned by ClientWithResponses
+type response interface {
+	StatusCode() int
+}
+
+// CheckResponse returns a *ResponseError if the response status code is not 2xx, nil otherwise
+// e.g. CheckResponse(resp, resp.JSONDefault)
+func CheckResponse(resp response, info *checkoutservice_rest_types.ResponseInfo) error {
+	statusCode := resp.StatusCode()
+	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	return &ResponseError{
+		StatusCode: statusCode,
+		Info:       info,
+	}
+}
+
+func (e *ResponseError) Error() string {
+	if e.Info != nil {
+		return fmt.Sprintf("checkout service responded with status code %d: %s", e.StatusCode, e.Info.Message)
+	}
+	return fmt.Sprintf("checkout service responded with status code %d", e.StatusCode)
+}
+
+// Is maps the status code to the error kinds, so errors.Is(err, ErrPaymentDeclined) works on a *ResponseError
+func (e *ResponseError) Is(target error) bool {
+	switch target {
+	case ErrInvalidArgument:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrPaymentDeclined:
+		return e.StatusCode == http.StatusPaymentRequired
+	case ErrOutOfStock:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusServiceUnavailable
+	default:
+		return false
+	}
+}
@@ -0,0 +1,469 @@
+// Package checkoutservice_server_rest_server provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.1-0.20240604070534-2f0ff757704b DO NOT EDIT.
+package checkoutservice_server_rest_server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	. "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/types"
+	"github.com/labstack/echo/v4"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Health check endpoint
+	// (GET /health)
+	GetHealth(ctx echo.Context) error
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx echo.Context) error
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx echo.Context) error
+	// Place order
+	// (POST /orders)
+	PostOrders(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetHealth converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealth(ctx)
+	return err
+}
+
+// GetHealthLive converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthLive(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthLive(ctx)
+	return err
+}
+
+// GetHealthReady converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthReady(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthReady(ctx)
+	return err
+}
+
+// PostOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOrders(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostOrders(ctx)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/health", wrapper.GetHealth)
+	router.GET(baseURL+"/health/live", wrapper.GetHealthLive)
+	router.GET(baseURL+"/health/ready", wrapper.GetHealthReady)
+	router.POST(baseURL+"/orders", wrapper.PostOrders)
+
+}
+
+type NotOkJSONResponse ResponseInfo
+
+type GetHealthRequestObject struct {
+}
+
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
+}
+
+type GetHealth200JSONResponse HealthResponse
+
+func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthdefaultJSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthLiveRequestObject struct {
+}
+
+type GetHealthLiveResponseObject interface {
+	VisitGetHealthLiveResponse(w http.ResponseWriter) error
+}
+
+type GetHealthLive200JSONResponse HealthResponse
+
+func (response GetHealthLive200JSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthLivedefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthLivedefaultJSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthReadyRequestObject struct {
+}
+
+type GetHealthReadyResponseObject interface {
+	VisitGetHealthReadyResponse(w http.ResponseWriter) error
+}
+
+type GetHealthReady200JSONResponse HealthResponse
+
+func (response GetHealthReady200JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReady503JSONResponse HealthResponse
+
+func (response GetHealthReady503JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReadydefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthReadydefaultJSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostOrdersRequestObject struct {
+	Body *PostOrdersJSONRequestBody
+}
+
+type PostOrdersResponseObject interface {
+	VisitPostOrdersResponse(w http.ResponseWriter) error
+}
+
+type PostOrders200JSONResponse Order
+
+func (response PostOrders200JSONResponse) VisitPostOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOrdersdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostOrdersdefaultJSONResponse) VisitPostOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Health check endpoint
+	// (GET /health)
+	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx context.Context, request GetHealthLiveRequestObject) (GetHealthLiveResponseObject, error)
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx context.Context, request GetHealthReadyRequestObject) (GetHealthReadyResponseObject, error)
+	// Place order
+	// (POST /orders)
+	PostOrders(ctx context.Context, request PostOrdersRequestObject) (PostOrdersResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetHealth operation middleware
+func (sh *strictHandler) GetHealth(ctx echo.Context) error {
+	var request GetHealthRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealth(ctx.Request().Context(), request.(GetHealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealth")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthResponseObject); ok {
+		return validResponse.VisitGetHealthResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthLive operation middleware
+func (sh *strictHandler) GetHealthLive(ctx echo.Context) error {
+	var request GetHealthLiveRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthLive(ctx.Request().Context(), request.(GetHealthLiveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthLive")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthLiveResponseObject); ok {
+		return validResponse.VisitGetHealthLiveResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthReady operation middleware
+func (sh *strictHandler) GetHealthReady(ctx echo.Context) error {
+	var request GetHealthReadyRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthReady(ctx.Request().Context(), request.(GetHealthReadyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthReady")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthReadyResponseObject); ok {
+		return validResponse.VisitGetHealthReadyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostOrders operation middleware
+func (sh *strictHandler) PostOrders(ctx echo.Context) error {
+	var request PostOrdersRequestObject
+
+	var body PostOrdersJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostOrders(ctx.Request().Context(), request.(PostOrdersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostOrders")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostOrdersResponseObject); ok {
+		return validResponse.VisitPostOrdersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/8xYbW/buhX+KwR3gXyhX2I79976W9be3QXrksBJMKBBZjDkscVGIlXyyIkX+L8PpCRb",
+	"smk7RTqsRYEo0uF5ec7Dw4d5pcJkudGg0dHxK7XgcqMdhF8uDV49+QdhNIJG/8jzPFWCozK699UZ7d85",
+	"kUDG/dMvFmZ0TP/S23jtlV9db1K5vtAzQ1erFaMSnLAq977omN5peMlBIEgC1hpLvUm12Ps+l9KCC4+5",
+	"NTlYVGWaQuHS/8yU/gx6jgkdnzKKyxzomDq0Ss/pilFhCo32LZYOOYK3i3yxADjlm1SOuPqPyqfCSDhq",
+	"umLUwrdCWZB0fL8diJVFbopoeH5Y+zKPX0GgD/uRW7xAyHbByq2RhcCpktECvxVcY4XnzNiMIx1TpXE4",
+	"oOswSiPMwe7k3HDdcBRNz4JU+JFbGelm+DYV3MqpWCyCAUcEq+mY/vu+3/nw8Dpko9UvNNbjxmJ4yZUN",
+	"RJ1mRmMSLSnjLyorMjo+HTDfoOqX3Vr3+l4Ct29Cq+1BF9kjhIXwwrM89aaj0XDQ+b1/etb59fRs0OmP",
+	"zkZlijVtBkPWYtHgGI0iEdkOwodLOwJqtL2FtaDF8mNF/PY+v7i5IqPB6W9EVGYk0Jg1gLi7+URZq+3n",
+	"nS8Pr8N40z9BDlqGgAmIp11KleMkRveUo183zVyrE0PWauevo2g7Nc+gtYxKjvyRO6B7hkrRDkPNEz3W",
+	"wRBkvbqVcQz6vwNPMalHbWR3eYTCk0LI3LGZvQ3tah2SW8uX++q6u44hgCoDhzzL28aD/mDU6f/WGXy4",
+	"7ffH4f8X2miA5AgdvzaK1Q4C/zQalpHCK7Ktp/GhslsEDp3Wxu0yGRMg/hMptEJHzIz4NzzzM5qRZ4VJ",
+	"eOF4BsSpuSbchRfBnLID0+hD/a8xlDqNl7tkLH1GU3xOTAqxHOkbaL49UFow1mFrhGKMvLIS7G4/IOMq",
+	"je7JNTHfxNDgPhx1EW4a/3HfUZfzZQYap2i5dlyEmbbPNOUC5JRja9AfICajLlF5rvS8qRUO1VGrm+ZS",
+	"YRweW1fSvbkKLRdP/mFPMWiQp9OcK/lm34Vbw3jI/s6BvZA7nFl3YeOIVf3fk/Y2BhE4a5606tnb1GYL",
+	"95I0Lpi+qwmqcnFwuNTabBunsJiVAWNJXvsSQqYT+FaAw91kv59sjcP9aOIb1bZimw283g91S3cYF7pe",
+	"T47vnb3v496GcO0kNgRsSOwGFDH8WxeYCE8ktOAo9gvBDJzj8/gVo3zxtqvUrbfdLjk42MRgdO8toeXG",
+	"z2Ttz5l7+sdkcjWhjF5c/u2KMvqv88nlxeWfDRebbCvYx69NmXo6+J0due34vVLB2D6wJn/c3M6KlJxf",
+	"XxCXg1Cz6rZJZsaGsysoEVMgcWAXSgAjCk8cKRxIgobwAk1nDhosRyAiVaCR3Hz6x4kjXMuwCGzHKQm1",
+	"7kSFQYhsO6aMLsC6Mq9+97TbD2dKDprnio7psNvvDkudmgQK9JKgvvzjHDBSG2BhdakBSlNSqqf6WK4C",
+	"d2kIU+psjy79E7BUdpS1r+iDfv+HXdC3tGPkin5T5keUq/IPQ0/CjBfp3iG5zrdX/j3Bu3VFlnF/F68E",
+	"Kwm6lICWuVEag02FZi9VCzgKqXkiz4lKIcCYWyPAOZ8mf/TvTNl2Ysu56TxliDTg9AlWof06WatdBe5A",
+	"Dz77hH6WPvAAz3u74EvSHrIDfbDA5XJvI8LmcTswbjE7AG8hNxYd0cY/c7kkaka4Xla2GZlxlTpiLFGt",
+	"xb5clxSISs+JNM/6QI8mIdmfpUlllWiIBQFqAQQtn82U8H076w//P0lt4I8n9i5CefzVXkYFRVj+OapS",
+	"V+0sr60SUN2VHNgTRwS3SJRuvarOckYshO1dLnBoxFNNu6ARGREJt/Pqe3nKe4eSES8r3cYynBCQ5ahq",
+	"Y26xS26rJ49aCjMkhUZTiARkzdBQEBHcz5NHIKXYZAS68y7hOjgnmPDgwZ8wZlbl6XcwEUbPUiWQhfhV",
+	"XOm/aViArdKX4QTkugpWufMRy3Bdct76ts4maGcI/gTXAtIUZIjkL4Slb7IwSoLc3VDXxuFV2a1SZYDD",
+	"vxq5/GGU3RW1q7agQVvA6n+4kUPs6FYphADnvBipg7NGs0vQ371RQv3EbJIoBYqj4/voiG3oE6+RKKOF",
+	"TemYJoi5G/d6ojKqbOjqYfXfAQC9NcMS5BcAAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,292 @@
+// Package checkout places the orders: it prices the user's cart, reserves the stock, charges the card, ships the items,
+// empties the cart and emails the confirmation
+package checkout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	checkoutservice_rest_types "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/libs/money"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// commitStockAttempts is how many times the stock of a paid order is committed before giving up, the product
+	// catalog service commits the reservations that expired in the meantime as long as their items are still there
+	commitStockAttempts       = 3
+	initialCommitStockBackoff = 200 * time.Millisecond
+)
+
+// CartService reads and empties the users' carts
+type CartService interface {
+	GetCart(ctx context.Context, userID string) ([]checkoutservice_rest_types.CartItem, error)
+	EmptyCart(ctx context.Context, userID string) error
+}
+
+// ProductCatalog returns the price of the products, in USD, and holds their stock while the orders are placed
+type ProductCatalog interface {
+	GetProductPrice(ctx context.Context, productID string) (checkoutservice_rest_types.Money, error)
+	// ReserveStock holds the stock of the items until the reservation is committed or released, or until it expires.
+	// Not having enough stock for any of the items is an ErrOutOfStock, and nothing is held then.
+	ReserveStock(ctx context.Context, reservationID string, items []checkoutservice_rest_types.CartItem) error
+	CommitStock(ctx context.Context, reservationID string) error
+	ReleaseStock(ctx context.Context, reservationID string) error
+}
+
+// CurrencyConverter converts the amounts into the user's currency
+type CurrencyConverter interface {
+	Convert(ctx context.Context, amount checkoutservice_rest_types.Money, toCurrencyCode string) (checkoutservice_rest_types.Money, error)
+}
+
+// PaymentProcessor charges the credit cards, a declined card is an ErrPaymentDeclined, and voids the charges of the
+// orders that couldn't be placed. The charges are identified by an idempotency key, the ID of the order they pay for:
+// charging a key again doesn't charge the card twice, and voiding a key that was never charged is a no-op.
+type PaymentProcessor interface {
+	Charge(ctx context.Context, idempotencyKey string, amount checkoutservice_rest_types.Money, card checkoutservice_rest_types.CreditCard) (string, error)
+	Void(ctx context.Context, idempotencyKey string) error
+}
+
+// Shipper quotes the shipping cost of the items, in USD, and ships them
+type Shipper interface {
+	Quote(ctx context.Context, address checkoutservice_rest_types.Address, items []checkoutservice_rest_types.CartItem) (checkoutservice_rest_types.Money, error)
+	ShipOrder(ctx context.Context, address checkoutservice_rest_types.Address, items []checkoutservice_rest_types.CartItem) (string, error)
+}
+
+// OrderStore persists the placed orders, they're saved once paid and then marked as shipped, or as cancelled if they
+// can't be shipped
+type OrderStore interface {
+	SaveOrder(ctx context.Context, order checkoutservice_rest_types.Order) error
+	MarkOrderShipped(ctx context.Context, orderID string, trackingID string) error
+	MarkOrderCancelled(ctx context.Context, orderID string) error
+	// Ping checks that the store is able to serve requests, it's used by the readiness check
+	Ping(ctx context.Context) error
+}
+
+// Notifier sends the order confirmations to the users
+type Notifier interface {
+	SendOrderConfirmation(ctx context.Context, order checkoutservice_rest_types.Order) error
+}
+
+type Service struct {
+	carts    CartService
+	catalog  ProductCatalog
+	currency CurrencyConverter
+	payments PaymentProcessor
+	shipper  Shipper
+	orders   OrderStore
+	notifier Notifier
+
+	now func() time.Time
+	// commitStockBackoff is the wait before the first retry of a failed commit, it doubles after every attempt
+	commitStockBackoff time.Duration
+}
+
+func NewService(
+	carts CartService,
+	catalog ProductCatalog,
+	currency CurrencyConverter,
+	payments PaymentProcessor,
+	shipper Shipper,
+	orders OrderStore,
+	notifier Notifier,
+) *Service {
+	return &Service{
+		carts:    carts,
+		catalog:  catalog,
+		currency: currency,
+		payments: payments,
+		shipper:  shipper,
+		orders:   orders,
+		notifier: notifier,
+		now:      time.Now,
+
+		commitStockBackoff: initialCommitStockBackoff,
+	}
+}
+
+// Ping checks the store of the orders, the other dependencies are checked by their own services
+func (s *Service) Ping(ctx context.Context) error {
+	return s.orders.Ping(ctx)
+}
+
+// PlaceOrder charges the user's cart, ships it and emails the confirmation. Nothing is charged if the cart can't be
+// priced or if the items aren't in stock, and the cart is only emptied once the order is placed, so a failed checkout
+// can be retried. The card is never charged for an order that isn't placed: the charge is voided if the order can't
+// be saved or shipped, and an order that can't be shipped is cancelled.
+func (s *Service) PlaceOrder(ctx context.Context, request checkoutservice_rest_types.PlaceOrderRequest) (*checkoutservice_rest_types.Order, error) {
+	cartItems, err := s.carts.GetCart(ctx, request.UserId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "An error occurred getting the cart of user '%s'", request.UserId)
+	}
+	if len(cartItems) == 0 {
+		return nil, newInvalidArgumentError("the cart of user '%s' is empty", request.UserId)
+	}
+
+	orderItems, err := s.priceItems(ctx, cartItems, request.UserCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	shippingQuote, err := s.shipper.Quote(ctx, request.Address, cartItems)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred quoting the shipping")
+	}
+	shippingCost, err := s.currency.Convert(ctx, shippingQuote, request.UserCurrency)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred converting the shipping cost")
+	}
+
+	sum := money.Money(shippingCost)
+	for _, orderItem := range orderItems {
+		if sum, err = money.Sum(sum, money.Money(orderItem.Cost)); err != nil {
+			return nil, errors.Wrap(err, "An error occurred adding up the order total")
+		}
+	}
+	total := checkoutservice_rest_types.Money(sum)
+
+	// the reservation is named after the order, so the stock it holds can be traced back to the order
+	orderID := uuid.NewString()
+	if err := s.catalog.ReserveStock(ctx, orderID, cartItems); err != nil {
+		return nil, errors.Wrapf(err, "An error occurred reserving the stock of order '%s'", orderID)
+	}
+
+	transactionID, err := s.payments.Charge(ctx, orderID, total, request.CreditCard)
+	if err != nil {
+		// a charge that timed out or whose answer was lost may have gone through, and there's no order for it, so
+		// it's voided before the checkout fails
+		if !errors.Is(err, ErrPaymentDeclined) && !errors.Is(err, ErrInvalidArgument) {
+			s.voidCharge(ctx, orderID)
+		}
+		s.releaseStock(ctx, orderID)
+		return nil, errors.Wrapf(err, "An error occurred charging the card for order '%s'", orderID)
+	}
+	logrus.Infof("Charged %d.%09d %s to user '%s', transaction '%s'", total.Units, total.Nanos, total.CurrencyCode, request.UserId, transactionID)
+
+	order := checkoutservice_rest_types.Order{
+		OrderId:              orderID,
+		UserId:               request.UserId,
+		Email:                string(request.Email),
+		ShippingCost:         shippingCost,
+		ShippingAddress:      request.Address,
+		Items:                orderItems,
+		TotalPaid:            total,
+		PaymentTransactionId: transactionID,
+		PlacedAt:             s.now(),
+	}
+	// saved before it's shipped, so there's a record of every charge even if the shipment fails. Without the record
+	// a retry would charge the card again, so the charge is voided and the checkout fails as if it was never paid.
+	if err := s.orders.SaveOrder(ctx, order); err != nil {
+		s.voidCharge(ctx, order.OrderId)
+		s.releaseStock(ctx, orderID)
+		return nil, errors.Wrapf(err, "An error occurred saving order '%s' paid with transaction '%s'", order.OrderId, transactionID)
+	}
+
+	// nothing would ever ship an order that can't be shipped now, so it's cancelled and the shopper isn't charged
+	trackingID, err := s.shipper.ShipOrder(ctx, request.Address, cartItems)
+	if err != nil {
+		s.cancelOrder(ctx, order.OrderId)
+		s.voidCharge(ctx, order.OrderId)
+		s.releaseStock(ctx, orderID)
+		return nil, errors.Wrapf(err, "An error occurred shipping order '%s'", order.OrderId)
+	}
+	order.ShippingTrackingId = trackingID
+
+	// the order is paid and shipped, so it goes on even if the stock can't be adjusted
+	s.commitStock(ctx, orderID)
+
+	// an order history that still shows the order as paid is only a nuisance for the user
+	if err := s.orders.MarkOrderShipped(ctx, order.OrderId, trackingID); err != nil {
+		logrus.Warnf("Order '%s' was placed but it couldn't be marked as shipped with tracking ID '%s'. Error: %s", order.OrderId, trackingID, err)
+	}
+	// a cart that can't be emptied is only a nuisance for the user too
+	if err := s.carts.EmptyCart(ctx, request.UserId); err != nil {
+		logrus.Warnf("Order '%s' was placed but the cart of user '%s' couldn't be emptied. Error: %s", order.OrderId, request.UserId, err)
+	}
+	// same for the confirmation, the order page shows the same details
+	if err := s.notifier.SendOrderConfirmation(ctx, order); err != nil {
+		logrus.Warnf("Order '%s' was placed but its confirmation couldn't be sent to '%s'. Error: %s", order.OrderId, order.Email, err)
+	}
+
+	logrus.Infof("Placed order '%s' for user '%s'", order.OrderId, request.UserId)
+	return &order, nil
+}
+
+// cancelOrder marks the saved order that couldn't be shipped as cancelled, if it fails the order history shows it as
+// paid until it's cancelled by hand
+func (s *Service) cancelOrder(ctx context.Context, orderID string) {
+	if err := s.orders.MarkOrderCancelled(context.WithoutCancel(ctx), orderID); err != nil {
+		logrus.Errorf("Order '%s' couldn't be shipped and it couldn't be marked as cancelled, it has to be cancelled by hand. Error: %s", orderID, err)
+	}
+}
+
+// voidCharge cancels the charge of an order that couldn't be placed, if it fails the charge has to be refunded by hand
+func (s *Service) voidCharge(ctx context.Context, orderID string) {
+	// even if the request was cancelled, the shopper giving up doesn't make the charge go away
+	if err := s.payments.Void(context.WithoutCancel(ctx), orderID); err != nil {
+		logrus.Errorf("Order '%s' couldn't be placed and its charge couldn't be voided, the charge with idempotency key '%s' has to be refunded. Error: %s", orderID, orderID, err)
+	}
+}
+
+// commitStock takes the items of the paid order out of the stock, retrying while the product catalog service is
+// unavailable. If it still fails, the items are back in stock once the reservation expires and may be sold twice.
+func (s *Service) commitStock(ctx context.Context, orderID string) {
+	// the order is paid, the shopper giving up doesn't make the items it bought available again
+	ctx = context.WithoutCancel(ctx)
+	backoff := s.commitStockBackoff
+
+	var err error
+	for attempt := 1; attempt <= commitStockAttempts; attempt++ {
+		if err = s.catalog.CommitStock(ctx, orderID); err == nil || !errors.Is(err, ErrUnavailable) {
+			break
+		}
+		if attempt < commitStockAttempts {
+			logrus.Debugf("Attempt %d to commit the stock of order '%s' failed, retrying in %s. Error: %s", attempt, orderID, backoff, err)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	if err != nil {
+		logrus.Errorf("Order '%s' was paid but the stock it reserved couldn't be committed, its items may be sold twice. Error: %s", orderID, err)
+	}
+}
+
+// releaseStock puts the items reserved for the order back in stock right away, if it fails they're back once the
+// reservation expires
+func (s *Service) releaseStock(ctx context.Context, orderID string) {
+	if err := s.catalog.ReleaseStock(ctx, orderID); err != nil {
+		logrus.Warnf("The stock reserved for order '%s' couldn't be released, it will be once the reservation expires. Error: %s", orderID, err)
+	}
+}
+
+// priceItems returns the cost of every cart line in the user's currency
+func (s *Service) priceItems(ctx context.Context, cartItems []checkoutservice_rest_types.CartItem, currencyCode string) ([]checkoutservice_rest_types.OrderItem, error) {
+	orderItems := make([]checkoutservice_rest_types.OrderItem, 0, len(cartItems))
+	for _, cartItem := range cartItems {
+		if cartItem.Quantity <= 0 {
+			return nil, newInvalidArgumentError("invalid quantity %d for product '%s'", cartItem.Quantity, cartItem.ProductId)
+		}
+
+		priceUSD, err := s.catalog.GetProductPrice(ctx, cartItem.ProductId)
+		if err != nil {
+			return nil, errors.Wrapf(err, "An error occurred getting the price of product '%s'", cartItem.ProductId)
+		}
+		price, err := s.currency.Convert(ctx, priceUSD, currencyCode)
+		if err != nil {
+			return nil, errors.Wrapf(err, "An error occurred converting the price of product '%s'", cartItem.ProductId)
+		}
+		cost, err := money.Multiply(money.Money(price), uint32(cartItem.Quantity))
+		if err != nil {
+			return nil, newCheckoutError(ErrInvalidArgument, fmt.Errorf("product '%s' has an invalid price: %w", cartItem.ProductId, err))
+		}
+
+		orderItems = append(orderItems, checkoutservice_rest_types.OrderItem{
+			Item: cartItem,
+			Cost: checkoutservice_rest_types.Money(cost),
+		})
+	}
+	return orderItems, nil
+}
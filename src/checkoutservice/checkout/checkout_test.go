@@ -0,0 +1,416 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	checkoutservice_rest_types "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUserID   = "2b5f8a31-3c3c-4d4e-9f0e-5d8e2f6c1a11"
+	testCurrency = "EUR"
+)
+
+type fakeCarts struct {
+	items    map[string][]checkoutservice_rest_types.CartItem
+	emptyErr error
+}
+
+func (c *fakeCarts) GetCart(ctx context.Context, userID string) ([]checkoutservice_rest_types.CartItem, error) {
+	return c.items[userID], nil
+}
+
+func (c *fakeCarts) EmptyCart(ctx context.Context, userID string) error {
+	if c.emptyErr != nil {
+		return c.emptyErr
+	}
+	delete(c.items, userID)
+	return nil
+}
+
+type fakeCatalog struct {
+	prices map[string]checkoutservice_rest_types.Money
+	// outOfStock are the products that can't be reserved, the others have plenty of stock
+	outOfStock map[string]bool
+	// commitErrs are returned by the next commits, one per commit
+	commitErrs []error
+
+	reserved  []string
+	committed []string
+	released  []string
+}
+
+func newFakeCatalog() *fakeCatalog {
+	return &fakeCatalog{
+		prices: map[string]checkoutservice_rest_types.Money{
+			"OLJCESPC7Z": {CurrencyCode: usdCurrencyCode, Units: 19, Nanos: 990000000},
+			"66VCHSJNUP": {CurrencyCode: usdCurrencyCode, Units: 2, Nanos: 500000000},
+		},
+		outOfStock: map[string]bool{},
+	}
+}
+
+func (c *fakeCatalog) GetProductPrice(ctx context.Context, productID string) (checkoutservice_rest_types.Money, error) {
+	price, found := c.prices[productID]
+	if !found {
+		return checkoutservice_rest_types.Money{}, newInvalidArgumentError("product '%s' isn't in the catalog", productID)
+	}
+	return price, nil
+}
+
+func (c *fakeCatalog) ReserveStock(ctx context.Context, reservationID string, items []checkoutservice_rest_types.CartItem) error {
+	for _, item := range items {
+		if c.outOfStock[item.ProductId] {
+			return newCheckoutError(ErrOutOfStock, fmt.Errorf("product '%s' is out of stock", item.ProductId))
+		}
+	}
+	c.reserved = append(c.reserved, reservationID)
+	return nil
+}
+
+func (c *fakeCatalog) CommitStock(ctx context.Context, reservationID string) error {
+	if len(c.commitErrs) > 0 {
+		err := c.commitErrs[0]
+		c.commitErrs = c.commitErrs[1:]
+		return err
+	}
+	c.committed = append(c.committed, reservationID)
+	return nil
+}
+
+func (c *fakeCatalog) ReleaseStock(ctx context.Context, reservationID string) error {
+	c.released = append(c.released, reservationID)
+	return nil
+}
+
+// doublingConverter converts every amount into twice as many units of the other currency
+type doublingConverter struct{}
+
+func (c doublingConverter) Convert(ctx context.Context, amount checkoutservice_rest_types.Money, toCurrencyCode string) (checkoutservice_rest_types.Money, error) {
+	return checkoutservice_rest_types.Money{
+		CurrencyCode: toCurrencyCode,
+		Units:        amount.Units*2 + int64(amount.Nanos*2/1000000000),
+		Nanos:        amount.Nanos * 2 % 1000000000,
+	}, nil
+}
+
+// fakePayments declines the "declined" card and charges the "timeout" card without answering
+type fakePayments struct {
+	charged []checkoutservice_rest_types.Money
+	// voided are the idempotency keys of the voided charges
+	voided []string
+}
+
+func (p *fakePayments) Charge(ctx context.Context, idempotencyKey string, amount checkoutservice_rest_types.Money, card checkoutservice_rest_types.CreditCard) (string, error) {
+	if card.CreditCardNumber == "declined" {
+		return "", newCheckoutError(ErrPaymentDeclined, errors.New("insufficient funds"))
+	}
+	p.charged = append(p.charged, amount)
+	if card.CreditCardNumber == "timeout" {
+		return "", newCheckoutError(ErrUnavailable, errors.New("the processor didn't answer in time"))
+	}
+	return "transaction-1", nil
+}
+
+func (p *fakePayments) Void(ctx context.Context, idempotencyKey string) error {
+	p.voided = append(p.voided, idempotencyKey)
+	return nil
+}
+
+type flatRateShipper struct{}
+
+func (s flatRateShipper) Quote(ctx context.Context, address checkoutservice_rest_types.Address, items []checkoutservice_rest_types.CartItem) (checkoutservice_rest_types.Money, error) {
+	return checkoutservice_rest_types.Money{CurrencyCode: usdCurrencyCode, Units: 8, Nanos: 990000000}, nil
+}
+
+func (s flatRateShipper) ShipOrder(ctx context.Context, address checkoutservice_rest_types.Address, items []checkoutservice_rest_types.CartItem) (string, error) {
+	return "tracking-1", nil
+}
+
+// unshippableShipper quotes like flatRateShipper but every shipment fails
+type unshippableShipper struct {
+	flatRateShipper
+}
+
+func (s unshippableShipper) ShipOrder(ctx context.Context, address checkoutservice_rest_types.Address, items []checkoutservice_rest_types.CartItem) (string, error) {
+	return "", newCheckoutError(ErrUnavailable, errors.New("connection refused"))
+}
+
+// unavailableOrderStore fails to save every order
+type unavailableOrderStore struct {
+	*MemoryOrderStore
+}
+
+func (s unavailableOrderStore) SaveOrder(ctx context.Context, order checkoutservice_rest_types.Order) error {
+	return newCheckoutError(ErrUnavailable, errors.New("connection refused"))
+}
+
+type fakeNotifier struct {
+	confirmed []string
+	err       error
+}
+
+func (n *fakeNotifier) SendOrderConfirmation(ctx context.Context, order checkoutservice_rest_types.Order) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.confirmed = append(n.confirmed, order.OrderId)
+	return nil
+}
+
+func newTestService(carts *fakeCarts, payments *fakePayments, orders *MemoryOrderStore) *Service {
+	return newTestServiceWithFollowUps(carts, payments, orders, &fakeNotifier{})
+}
+
+// newTestServiceWithFollowUps creates a service with the given dependencies of the steps that follow the placed order
+func newTestServiceWithFollowUps(carts *fakeCarts, payments *fakePayments, orders *MemoryOrderStore, notifier *fakeNotifier) *Service {
+	return NewService(carts, newFakeCatalog(), doublingConverter{}, payments, flatRateShipper{}, orders, notifier)
+}
+
+func newTestRequest(creditCardNumber string) checkoutservice_rest_types.PlaceOrderRequest {
+	return checkoutservice_rest_types.PlaceOrderRequest{
+		UserId:       testUserID,
+		UserCurrency: testCurrency,
+		Email:        "someone@example.com",
+		Address: checkoutservice_rest_types.Address{
+			StreetAddress: "1600 Amphitheatre Parkway",
+			City:          "Mountain View",
+			Country:       "United States",
+			ZipCode:       "94043",
+		},
+		CreditCard: checkoutservice_rest_types.CreditCard{
+			CreditCardNumber:          creditCardNumber,
+			CreditCardCvv:             "672",
+			CreditCardExpirationMonth: 1,
+			CreditCardExpirationYear:  2039,
+		},
+	}
+}
+
+func TestPlaceOrder(t *testing.T) {
+	carts := &fakeCarts{items: map[string][]checkoutservice_rest_types.CartItem{
+		testUserID: {
+			{ProductId: "OLJCESPC7Z", Quantity: 2},
+			{ProductId: "66VCHSJNUP", Quantity: 1},
+		},
+	}}
+	payments := &fakePayments{}
+	orders := NewMemoryOrderStore()
+	catalog := newFakeCatalog()
+	notifier := &fakeNotifier{}
+	service := NewService(carts, catalog, doublingConverter{}, payments, flatRateShipper{}, orders, notifier)
+
+	order, err := service.PlaceOrder(context.Background(), newTestRequest("4432801561520454"))
+	require.NoError(t, err)
+
+	require.Len(t, order.Items, 2)
+	require.Equal(t, checkoutservice_rest_types.Money{CurrencyCode: testCurrency, Units: 79, Nanos: 960000000}, order.Items[0].Cost)
+	require.Equal(t, checkoutservice_rest_types.Money{CurrencyCode: testCurrency, Units: 5, Nanos: 0}, order.Items[1].Cost)
+	require.Equal(t, checkoutservice_rest_types.Money{CurrencyCode: testCurrency, Units: 17, Nanos: 980000000}, order.ShippingCost)
+	require.Equal(t, checkoutservice_rest_types.Money{CurrencyCode: testCurrency, Units: 102, Nanos: 940000000}, order.TotalPaid)
+	require.Equal(t, []checkoutservice_rest_types.Money{order.TotalPaid}, payments.charged)
+	require.Equal(t, "transaction-1", order.PaymentTransactionId)
+	require.NotEmpty(t, order.OrderId)
+	require.NotEmpty(t, order.ShippingTrackingId)
+
+	savedOrder, found := orders.GetOrder(order.OrderId)
+	require.True(t, found)
+	require.Equal(t, *order, savedOrder)
+	require.Empty(t, carts.items[testUserID])
+	require.Equal(t, []string{order.OrderId}, notifier.confirmed)
+	require.Equal(t, []string{order.OrderId}, catalog.reserved)
+	require.Equal(t, []string{order.OrderId}, catalog.committed)
+	require.Empty(t, catalog.released)
+}
+
+func TestPlaceOrderRejectsAnEmptyCart(t *testing.T) {
+	payments := &fakePayments{}
+
+	_, err := newTestService(&fakeCarts{}, payments, NewMemoryOrderStore()).PlaceOrder(context.Background(), newTestRequest("4432801561520454"))
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	require.Empty(t, payments.charged)
+}
+
+func TestPlaceOrderRejectsAnUnknownProduct(t *testing.T) {
+	carts := &fakeCarts{items: map[string][]checkoutservice_rest_types.CartItem{
+		testUserID: {{ProductId: "UNKNOWN", Quantity: 1}},
+	}}
+	payments := &fakePayments{}
+
+	_, err := newTestService(carts, payments, NewMemoryOrderStore()).PlaceOrder(context.Background(), newTestRequest("4432801561520454"))
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	require.Empty(t, payments.charged)
+}
+
+func TestPlaceOrderRejectsAProductOutOfStock(t *testing.T) {
+	carts := &fakeCarts{items: map[string][]checkoutservice_rest_types.CartItem{
+		testUserID: {
+			{ProductId: "OLJCESPC7Z", Quantity: 1},
+			{ProductId: "66VCHSJNUP", Quantity: 1},
+		},
+	}}
+	payments := &fakePayments{}
+	catalog := newFakeCatalog()
+	catalog.outOfStock["66VCHSJNUP"] = true
+	service := NewService(carts, catalog, doublingConverter{}, payments, flatRateShipper{}, NewMemoryOrderStore(), &fakeNotifier{})
+
+	_, err := service.PlaceOrder(context.Background(), newTestRequest("4432801561520454"))
+	require.ErrorIs(t, err, ErrOutOfStock)
+	require.Empty(t, payments.charged)
+	require.Len(t, carts.items[testUserID], 2)
+}
+
+func TestPlaceOrderKeepsTheCartWhenThePaymentIsDeclined(t *testing.T) {
+	carts := &fakeCarts{items: map[string][]checkoutservice_rest_types.CartItem{
+		testUserID: {{ProductId: "OLJCESPC7Z", Quantity: 1}},
+	}}
+	catalog := newFakeCatalog()
+	orders := NewMemoryOrderStore()
+	payments := &fakePayments{}
+	service := NewService(carts, catalog, doublingConverter{}, payments, flatRateShipper{}, orders, &fakeNotifier{})
+
+	_, err := service.PlaceOrder(context.Background(), newTestRequest("declined"))
+	require.ErrorIs(t, err, ErrPaymentDeclined)
+	require.Len(t, carts.items[testUserID], 1)
+	require.Empty(t, orders.orders)
+	require.Empty(t, payments.voided)
+
+	// the items go back in stock
+	require.Len(t, catalog.reserved, 1)
+	require.Equal(t, catalog.reserved, catalog.released)
+	require.Empty(t, catalog.committed)
+}
+
+func TestPlaceOrderVoidsTheChargeWhenTheOrderCantBeSaved(t *testing.T) {
+	carts := &fakeCarts{items: map[string][]checkoutservice_rest_types.CartItem{
+		testUserID: {{ProductId: "OLJCESPC7Z", Quantity: 1}},
+	}}
+	catalog := newFakeCatalog()
+	payments := &fakePayments{}
+	service := NewService(carts, catalog, doublingConverter{}, payments, flatRateShipper{}, unavailableOrderStore{NewMemoryOrderStore()}, &fakeNotifier{})
+
+	_, err := service.PlaceOrder(context.Background(), newTestRequest("4432801561520454"))
+	require.ErrorIs(t, err, ErrUnavailable)
+	require.Len(t, carts.items[testUserID], 1)
+
+	// nothing records the charge, so it's voided and a retry doesn't charge the card twice
+	require.Len(t, payments.charged, 1)
+	require.Equal(t, catalog.reserved, payments.voided)
+	require.Equal(t, catalog.reserved, catalog.released)
+	require.Empty(t, catalog.committed)
+}
+
+func TestPlaceOrderVoidsTheChargeWhenItTimesOut(t *testing.T) {
+	carts := &fakeCarts{items: map[string][]checkoutservice_rest_types.CartItem{
+		testUserID: {{ProductId: "OLJCESPC7Z", Quantity: 1}},
+	}}
+	catalog := newFakeCatalog()
+	payments := &fakePayments{}
+	orders := NewMemoryOrderStore()
+	service := NewService(carts, catalog, doublingConverter{}, payments, flatRateShipper{}, orders, &fakeNotifier{})
+
+	_, err := service.PlaceOrder(context.Background(), newTestRequest("timeout"))
+	require.ErrorIs(t, err, ErrUnavailable)
+	require.Len(t, carts.items[testUserID], 1)
+	require.Empty(t, orders.orders)
+
+	// the card may have been charged, the charge is voided by the order ID it was made with
+	require.Len(t, payments.charged, 1)
+	require.Len(t, catalog.reserved, 1)
+	require.Equal(t, catalog.reserved, payments.voided)
+	require.Equal(t, catalog.reserved, catalog.released)
+	require.Empty(t, catalog.committed)
+}
+
+func TestPlaceOrderCancelsTheOrderWhenTheShipmentFails(t *testing.T) {
+	carts := &fakeCarts{items: map[string][]checkoutservice_rest_types.CartItem{
+		testUserID: {{ProductId: "OLJCESPC7Z", Quantity: 1}},
+	}}
+	catalog := newFakeCatalog()
+	payments := &fakePayments{}
+	orders := NewMemoryOrderStore()
+	service := NewService(carts, catalog, doublingConverter{}, payments, unshippableShipper{}, orders, &fakeNotifier{})
+
+	_, err := service.PlaceOrder(context.Background(), newTestRequest("4432801561520454"))
+	require.ErrorIs(t, err, ErrUnavailable)
+	require.Len(t, carts.items[testUserID], 1)
+
+	// nothing would ship the order, so it's cancelled, its charge voided and its items back in stock
+	require.Empty(t, orders.orders)
+	require.Len(t, payments.charged, 1)
+	require.Len(t, catalog.reserved, 1)
+	require.Equal(t, catalog.reserved, payments.voided)
+	require.Equal(t, catalog.reserved, catalog.released)
+	require.Empty(t, catalog.committed)
+}
+
+func TestPlaceOrderRetriesTheCommitOfTheStock(t *testing.T) {
+	carts := &fakeCarts{items: map[string][]checkoutservice_rest_types.CartItem{
+		testUserID: {{ProductId: "OLJCESPC7Z", Quantity: 1}},
+	}}
+	catalog := newFakeCatalog()
+	catalog.commitErrs = []error{
+		newCheckoutError(ErrUnavailable, errors.New("connection refused")),
+		newCheckoutError(ErrUnavailable, errors.New("connection refused")),
+	}
+	service := NewService(carts, catalog, doublingConverter{}, &fakePayments{}, flatRateShipper{}, NewMemoryOrderStore(), &fakeNotifier{})
+	service.commitStockBackoff = 0
+
+	order, err := service.PlaceOrder(context.Background(), newTestRequest("4432801561520454"))
+	require.NoError(t, err)
+	require.Equal(t, []string{order.OrderId}, catalog.committed)
+	require.Empty(t, catalog.released)
+}
+
+func TestPlaceOrderSucceedsWhenTheStockCantBeCommitted(t *testing.T) {
+	carts := &fakeCarts{items: map[string][]checkoutservice_rest_types.CartItem{
+		testUserID: {{ProductId: "OLJCESPC7Z", Quantity: 1}},
+	}}
+	catalog := newFakeCatalog()
+	// a conflict isn't retried, the reservation expired and its items were sold in the meantime
+	catalog.commitErrs = []error{
+		newCheckoutError(ErrOutOfStock, errors.New("reservation can't be committed")),
+		newCheckoutError(ErrOutOfStock, errors.New("reservation can't be committed")),
+	}
+	payments := &fakePayments{}
+	orders := NewMemoryOrderStore()
+	service := NewService(carts, catalog, doublingConverter{}, payments, flatRateShipper{}, orders, &fakeNotifier{})
+	service.commitStockBackoff = 0
+
+	order, err := service.PlaceOrder(context.Background(), newTestRequest("4432801561520454"))
+	require.NoError(t, err)
+	require.Len(t, catalog.commitErrs, 1)
+	require.Empty(t, catalog.committed)
+	require.Empty(t, catalog.released)
+	require.Empty(t, payments.voided)
+	_, found := orders.GetOrder(order.OrderId)
+	require.True(t, found)
+}
+
+func TestPlaceOrderSucceedsWhenTheCartCantBeEmptied(t *testing.T) {
+	carts := &fakeCarts{
+		items: map[string][]checkoutservice_rest_types.CartItem{
+			testUserID: {{ProductId: "OLJCESPC7Z", Quantity: 1}},
+		},
+		emptyErr: newCheckoutError(ErrUnavailable, errors.New("connection refused")),
+	}
+
+	order, err := newTestService(carts, &fakePayments{}, NewMemoryOrderStore()).PlaceOrder(context.Background(), newTestRequest("4432801561520454"))
+	require.NoError(t, err)
+	require.NotEmpty(t, order.OrderId)
+}
+
+func TestPlaceOrderSucceedsWhenTheConfirmationCantBeSent(t *testing.T) {
+	carts := &fakeCarts{items: map[string][]checkoutservice_rest_types.CartItem{
+		testUserID: {{ProductId: "OLJCESPC7Z", Quantity: 1}},
+	}}
+	notifier := &fakeNotifier{err: newCheckoutError(ErrUnavailable, errors.New("connection refused"))}
+
+	order, err := newTestServiceWithFollowUps(carts, &fakePayments{}, NewMemoryOrderStore(), notifier).PlaceOrder(context.Background(), newTestRequest("4432801561520454"))
+	require.NoError(t, err)
+	require.NotEmpty(t, order.OrderId)
+}
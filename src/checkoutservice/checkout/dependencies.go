@@ -0,0 +1,484 @@
+package checkout
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	cartservice_rest_client "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/client"
+	checkoutservice_rest_types "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/currencyexternalapi"
+	emailservice_rest_client "github.com/kurtosis-tech/new-obd/src/emailservice/api/http_rest/client"
+	emailservice_rest_types "github.com/kurtosis-tech/new-obd/src/emailservice/api/http_rest/types"
+	orderservice_rest_client "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/client"
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+	paymentservice_rest_client "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/client"
+	paymentservice_rest_types "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/types"
+	productcatalogservice_rest_client "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/client"
+	productcatalogservice_rest_types "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/types"
+	shippingservice_rest_client "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/client"
+	shippingservice_rest_types "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/types"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const usdCurrencyCode = "USD"
+
+// CartServiceClient is the CartService backed by the cart service REST API
+type CartServiceClient struct {
+	client *cartservice_rest_client.ClientWithResponses
+}
+
+func NewCartServiceClient(client *cartservice_rest_client.ClientWithResponses) *CartServiceClient {
+	return &CartServiceClient{client: client}
+}
+
+func (c *CartServiceClient) GetCart(ctx context.Context, userID string) ([]checkoutservice_rest_types.CartItem, error) {
+	response, err := c.client.GetCartUserIdWithResponse(ctx, userID)
+	if err != nil {
+		return nil, newCheckoutError(ErrUnavailable, err)
+	}
+	if err := cartservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return nil, cartServiceError(err)
+	}
+	if response.JSON200 == nil || response.JSON200.Items == nil {
+		return nil, nil
+	}
+
+	items := make([]checkoutservice_rest_types.CartItem, 0, len(*response.JSON200.Items))
+	for _, item := range *response.JSON200.Items {
+		items = append(items, checkoutservice_rest_types.CartItem{
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+		})
+	}
+	return items, nil
+}
+
+func (c *CartServiceClient) EmptyCart(ctx context.Context, userID string) error {
+	response, err := c.client.DeleteCartUserIdWithResponse(ctx, userID)
+	if err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	if err := cartservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return cartServiceError(err)
+	}
+	return nil
+}
+
+// cartServiceError keeps the invalid arguments, e.g. a malformed user ID, every other failure is on the cart service
+func cartServiceError(err error) error {
+	if errors.Is(err, cartservice_rest_client.ErrInvalidArgument) {
+		return newCheckoutError(ErrInvalidArgument, err)
+	}
+	return newCheckoutError(ErrUnavailable, err)
+}
+
+// ProductCatalogClient is the ProductCatalog backed by the product catalog service REST API
+type ProductCatalogClient struct {
+	client *productcatalogservice_rest_client.ClientWithResponses
+}
+
+func NewProductCatalogClient(client *productcatalogservice_rest_client.ClientWithResponses) *ProductCatalogClient {
+	return &ProductCatalogClient{client: client}
+}
+
+func (c *ProductCatalogClient) GetProductPrice(ctx context.Context, productID string) (checkoutservice_rest_types.Money, error) {
+	response, err := c.client.GetProductsIdWithResponse(ctx, productID)
+	if err != nil {
+		return checkoutservice_rest_types.Money{}, newCheckoutError(ErrUnavailable, err)
+	}
+	if response.StatusCode() >= http.StatusMultipleChoices {
+		return checkoutservice_rest_types.Money{}, newCheckoutError(ErrUnavailable, fmt.Errorf("product catalog service responded with status code %d", response.StatusCode()))
+	}
+
+	// the catalog answers an empty product for the unknown IDs
+	product := response.JSON200
+	if product == nil || product.Id == nil || product.PriceUsd == nil {
+		return checkoutservice_rest_types.Money{}, newInvalidArgumentError("product '%s' isn't in the catalog", productID)
+	}
+	price := product.PriceUsd
+	if price.CurrencyCode == nil || price.Units == nil || price.Nanos == nil {
+		return checkoutservice_rest_types.Money{}, newInvalidArgumentError("product '%s' has no price", productID)
+	}
+
+	return checkoutservice_rest_types.Money{
+		CurrencyCode: *price.CurrencyCode,
+		Units:        *price.Units,
+		Nanos:        *price.Nanos,
+	}, nil
+}
+
+func (c *ProductCatalogClient) ReserveStock(ctx context.Context, reservationID string, items []checkoutservice_rest_types.CartItem) error {
+	reservationItems := make([]productcatalogservice_rest_types.ReservationItem, 0, len(items))
+	for _, item := range items {
+		reservationItems = append(reservationItems, productcatalogservice_rest_types.ReservationItem{
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	// the reservation is held for the TTL of the product catalog service, enough to charge the card
+	response, err := c.client.PostReservationsWithResponse(ctx, productcatalogservice_rest_types.CreateReservationRequest{
+		ReservationId: reservationID,
+		Items:         reservationItems,
+	})
+	if err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	if err := productcatalogservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return productCatalogError(err)
+	}
+	return nil
+}
+
+func (c *ProductCatalogClient) CommitStock(ctx context.Context, reservationID string) error {
+	response, err := c.client.PostReservationsReservationIdCommitWithResponse(ctx, reservationID)
+	if err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	if err := productcatalogservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return productCatalogError(err)
+	}
+	return nil
+}
+
+func (c *ProductCatalogClient) ReleaseStock(ctx context.Context, reservationID string) error {
+	response, err := c.client.PostReservationsReservationIdReleaseWithResponse(ctx, reservationID)
+	if err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	if err := productcatalogservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return productCatalogError(err)
+	}
+	return nil
+}
+
+// productCatalogError maps a conflict to ErrOutOfStock, the only conflict a fresh reservation can run into, and the
+// unknown products to invalid arguments, every other failure is on the product catalog service
+func productCatalogError(err error) error {
+	switch {
+	case errors.Is(err, productcatalogservice_rest_client.ErrConflict):
+		return newCheckoutError(ErrOutOfStock, err)
+	case errors.Is(err, productcatalogservice_rest_client.ErrNotFound), errors.Is(err, productcatalogservice_rest_client.ErrInvalidArgument):
+		return newCheckoutError(ErrInvalidArgument, err)
+	default:
+		return newCheckoutError(ErrUnavailable, err)
+	}
+}
+
+// CurrencyAPIConverter is the CurrencyConverter backed by the external currency API, like the prices shown by the frontend
+type CurrencyAPIConverter struct {
+	api *currencyexternalapi.CurrencyAPI
+}
+
+func NewCurrencyAPIConverter(api *currencyexternalapi.CurrencyAPI) *CurrencyAPIConverter {
+	return &CurrencyAPIConverter{api: api}
+}
+
+func (c *CurrencyAPIConverter) Convert(ctx context.Context, amount checkoutservice_rest_types.Money, toCurrencyCode string) (checkoutservice_rest_types.Money, error) {
+	// no need to ask the API, and no rounding either
+	if strings.EqualFold(amount.CurrencyCode, toCurrencyCode) {
+		return amount, nil
+	}
+
+	code, units, nanos, err := c.api.Convert(ctx, amount.CurrencyCode, amount.Units, amount.Nanos, toCurrencyCode)
+	if status.Code(err) == codes.InvalidArgument {
+		return checkoutservice_rest_types.Money{}, newCheckoutError(ErrInvalidArgument, err)
+	}
+	if err != nil {
+		return checkoutservice_rest_types.Money{}, newCheckoutError(ErrUnavailable, err)
+	}
+
+	return checkoutservice_rest_types.Money{
+		CurrencyCode: code,
+		Units:        units,
+		Nanos:        nanos,
+	}, nil
+}
+
+// PaymentServiceClient is the PaymentProcessor backed by the payment service REST API
+type PaymentServiceClient struct {
+	client *paymentservice_rest_client.ClientWithResponses
+}
+
+func NewPaymentServiceClient(client *paymentservice_rest_client.ClientWithResponses) *PaymentServiceClient {
+	return &PaymentServiceClient{client: client}
+}
+
+func (c *PaymentServiceClient) Charge(ctx context.Context, idempotencyKey string, amount checkoutservice_rest_types.Money, card checkoutservice_rest_types.CreditCard) (string, error) {
+	response, err := c.client.PostChargeWithResponse(ctx, paymentservice_rest_types.ChargeRequest{
+		IdempotencyKey: idempotencyKey,
+		Amount: paymentservice_rest_types.Money{
+			CurrencyCode: amount.CurrencyCode,
+			Units:        amount.Units,
+			Nanos:        amount.Nanos,
+		},
+		CreditCard: paymentservice_rest_types.CreditCard{
+			CreditCardNumber:          card.CreditCardNumber,
+			CreditCardCvv:             card.CreditCardCvv,
+			CreditCardExpirationMonth: card.CreditCardExpirationMonth,
+			CreditCardExpirationYear:  card.CreditCardExpirationYear,
+		},
+	})
+	if err != nil {
+		return "", newCheckoutError(ErrUnavailable, err)
+	}
+	if err := paymentservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		switch {
+		case errors.Is(err, paymentservice_rest_client.ErrInvalidArgument):
+			return "", newCheckoutError(ErrInvalidArgument, err)
+		// the shopper gets the same answer when the charge is held, the review is none of their business
+		case errors.Is(err, paymentservice_rest_client.ErrPaymentDeclined), errors.Is(err, paymentservice_rest_client.ErrFraudHold):
+			return "", newCheckoutError(ErrPaymentDeclined, err)
+		default:
+			return "", newCheckoutError(ErrUnavailable, err)
+		}
+	}
+	return response.JSON200.TransactionId, nil
+}
+
+func (c *PaymentServiceClient) Void(ctx context.Context, idempotencyKey string) error {
+	response, err := c.client.PostChargeIdempotencyKeyVoidWithResponse(ctx, idempotencyKey)
+	if err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	if err := paymentservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	return nil
+}
+
+// ShippingServiceClient is the Shipper backed by the shipping service REST API
+type ShippingServiceClient struct {
+	client *shippingservice_rest_client.ClientWithResponses
+}
+
+func NewShippingServiceClient(client *shippingservice_rest_client.ClientWithResponses) *ShippingServiceClient {
+	return &ShippingServiceClient{client: client}
+}
+
+func (c *ShippingServiceClient) Quote(ctx context.Context, address checkoutservice_rest_types.Address, items []checkoutservice_rest_types.CartItem) (checkoutservice_rest_types.Money, error) {
+	shippingAddress := toShippingAddress(address)
+	response, err := c.client.PostQuoteWithResponse(ctx, shippingservice_rest_types.QuoteRequest{
+		Address: &shippingAddress,
+		Items:   toShippingItems(items),
+	})
+	if err != nil {
+		return checkoutservice_rest_types.Money{}, newCheckoutError(ErrUnavailable, err)
+	}
+	if err := shippingservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return checkoutservice_rest_types.Money{}, shippingServiceError(err)
+	}
+	cost := response.JSON200.CostUsd
+	return checkoutservice_rest_types.Money{
+		CurrencyCode: cost.CurrencyCode,
+		Units:        cost.Units,
+		Nanos:        cost.Nanos,
+	}, nil
+}
+
+func (c *ShippingServiceClient) ShipOrder(ctx context.Context, address checkoutservice_rest_types.Address, items []checkoutservice_rest_types.CartItem) (string, error) {
+	response, err := c.client.PostShipWithResponse(ctx, shippingservice_rest_types.ShipOrderRequest{
+		Address: toShippingAddress(address),
+		Items:   toShippingItems(items),
+	})
+	if err != nil {
+		return "", newCheckoutError(ErrUnavailable, err)
+	}
+	if err := shippingservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return "", shippingServiceError(err)
+	}
+	return response.JSON200.TrackingId, nil
+}
+
+func toShippingAddress(address checkoutservice_rest_types.Address) shippingservice_rest_types.Address {
+	return shippingservice_rest_types.Address{
+		StreetAddress: address.StreetAddress,
+		City:          address.City,
+		State:         address.State,
+		Country:       address.Country,
+		ZipCode:       address.ZipCode,
+	}
+}
+
+func toShippingItems(items []checkoutservice_rest_types.CartItem) []shippingservice_rest_types.CartItem {
+	shippingItems := make([]shippingservice_rest_types.CartItem, 0, len(items))
+	for _, item := range items {
+		shippingItems = append(shippingItems, shippingservice_rest_types.CartItem{
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+		})
+	}
+	return shippingItems
+}
+
+func shippingServiceError(err error) error {
+	if errors.Is(err, shippingservice_rest_client.ErrInvalidArgument) {
+		return newCheckoutError(ErrInvalidArgument, err)
+	}
+	return newCheckoutError(ErrUnavailable, err)
+}
+
+// EmailServiceClient is the Notifier backed by the email service REST API
+type EmailServiceClient struct {
+	client *emailservice_rest_client.ClientWithResponses
+}
+
+func NewEmailServiceClient(client *emailservice_rest_client.ClientWithResponses) *EmailServiceClient {
+	return &EmailServiceClient{client: client}
+}
+
+func (c *EmailServiceClient) SendOrderConfirmation(ctx context.Context, order checkoutservice_rest_types.Order) error {
+	items := make([]emailservice_rest_types.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, emailservice_rest_types.OrderItem{
+			Item: emailservice_rest_types.CartItem{
+				ProductId: item.Item.ProductId,
+				Quantity:  item.Item.Quantity,
+			},
+			Cost: toEmailMoney(item.Cost),
+		})
+	}
+
+	response, err := c.client.PostOrderConfirmationsWithResponse(ctx, emailservice_rest_types.SendOrderConfirmationRequest{
+		Email: openapi_types.Email(order.Email),
+		Order: emailservice_rest_types.Order{
+			OrderId:            order.OrderId,
+			ShippingTrackingId: order.ShippingTrackingId,
+			ShippingCost:       toEmailMoney(order.ShippingCost),
+			ShippingAddress: emailservice_rest_types.Address{
+				StreetAddress: order.ShippingAddress.StreetAddress,
+				City:          order.ShippingAddress.City,
+				State:         order.ShippingAddress.State,
+				Country:       order.ShippingAddress.Country,
+				ZipCode:       order.ShippingAddress.ZipCode,
+			},
+			Items:     items,
+			TotalPaid: toEmailMoney(order.TotalPaid),
+			PlacedAt:  order.PlacedAt,
+		},
+	})
+	if err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	if err := emailservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		if errors.Is(err, emailservice_rest_client.ErrInvalidArgument) {
+			return newCheckoutError(ErrInvalidArgument, err)
+		}
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	return nil
+}
+
+func toEmailMoney(m checkoutservice_rest_types.Money) emailservice_rest_types.Money {
+	return emailservice_rest_types.Money{
+		CurrencyCode: m.CurrencyCode,
+		Units:        m.Units,
+		Nanos:        m.Nanos,
+	}
+}
+
+// OrderServiceClient is the OrderStore backed by the order service REST API
+type OrderServiceClient struct {
+	client *orderservice_rest_client.ClientWithResponses
+}
+
+func NewOrderServiceClient(client *orderservice_rest_client.ClientWithResponses) *OrderServiceClient {
+	return &OrderServiceClient{client: client}
+}
+
+func (c *OrderServiceClient) SaveOrder(ctx context.Context, order checkoutservice_rest_types.Order) error {
+	lines := make([]orderservice_rest_types.OrderLine, 0, len(order.Items))
+	for _, item := range order.Items {
+		lines = append(lines, orderservice_rest_types.OrderLine{
+			ProductId: item.Item.ProductId,
+			Quantity:  item.Item.Quantity,
+			Cost:      toOrderMoney(item.Cost),
+		})
+	}
+
+	paymentTransactionID := order.PaymentTransactionId
+	response, err := c.client.PostOrdersWithResponse(ctx, orderservice_rest_types.CreateOrderRequest{
+		OrderId: order.OrderId,
+		UserId:  order.UserId,
+		Email:   openapi_types.Email(order.Email),
+		Status:  orderservice_rest_types.Paid,
+		ShippingAddress: orderservice_rest_types.Address{
+			StreetAddress: order.ShippingAddress.StreetAddress,
+			City:          order.ShippingAddress.City,
+			State:         order.ShippingAddress.State,
+			Country:       order.ShippingAddress.Country,
+			ZipCode:       order.ShippingAddress.ZipCode,
+		},
+		ShippingCost:         toOrderMoney(order.ShippingCost),
+		Lines:                lines,
+		TotalPaid:            toOrderMoney(order.TotalPaid),
+		PaymentTransactionId: &paymentTransactionID,
+		PlacedAt:             order.PlacedAt,
+	})
+	if err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	if err := orderservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return orderServiceError(err)
+	}
+	return nil
+}
+
+func (c *OrderServiceClient) MarkOrderShipped(ctx context.Context, orderID string, trackingID string) error {
+	response, err := c.client.PutOrdersOrderIdStatusWithResponse(ctx, orderID, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status:             orderservice_rest_types.Shipped,
+		ShippingTrackingId: &trackingID,
+	})
+	if err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	if err := orderservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return orderServiceError(err)
+	}
+	return nil
+}
+
+func (c *OrderServiceClient) MarkOrderCancelled(ctx context.Context, orderID string) error {
+	response, err := c.client.PutOrdersOrderIdStatusWithResponse(ctx, orderID, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Cancelled,
+	})
+	if err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	if err := orderservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return orderServiceError(err)
+	}
+	return nil
+}
+
+// Ping checks that the order service is ready, the checkout can't place orders without it
+func (c *OrderServiceClient) Ping(ctx context.Context) error {
+	response, err := c.client.GetHealthReadyWithResponse(ctx)
+	if err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	if err := orderservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return newCheckoutError(ErrUnavailable, err)
+	}
+	return nil
+}
+
+func toOrderMoney(m checkoutservice_rest_types.Money) orderservice_rest_types.Money {
+	return orderservice_rest_types.Money{
+		CurrencyCode: m.CurrencyCode,
+		Units:        m.Units,
+		Nanos:        m.Nanos,
+	}
+}
+
+func orderServiceError(err error) error {
+	if errors.Is(err, orderservice_rest_client.ErrInvalidArgument) {
+		return newCheckoutError(ErrInvalidArgument, err)
+	}
+	return newCheckoutError(ErrUnavailable, err)
+}
@@ -0,0 +1,37 @@
+package checkout
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of errors returned by PlaceOrder, check them with errors.Is
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrPaymentDeclined = errors.New("payment declined")
+	ErrOutOfStock      = errors.New("out of stock")
+	ErrUnavailable     = errors.New("unavailable")
+)
+
+// checkoutError tags the cause with one of the error kinds, so callers can classify it without knowing which
+// dependency failed
+type checkoutError struct {
+	kind  error
+	cause error
+}
+
+func newCheckoutError(kind error, cause error) error {
+	return &checkoutError{kind: kind, cause: cause}
+}
+
+func newInvalidArgumentError(format string, args ...interface{}) error {
+	return newCheckoutError(ErrInvalidArgument, fmt.Errorf(format, args...))
+}
+
+func (e *checkoutError) Error() string {
+	return fmt.Sprintf("%s: %s", e.kind.Error(), e.cause.Error())
+}
+
+func (e *checkoutError) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
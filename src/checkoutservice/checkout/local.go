@@ -0,0 +1,69 @@
+package checkout
+
+import (
+	"context"
+	"sync"
+
+	checkoutservice_rest_types "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/types"
+)
+
+// MemoryOrderStore keeps the orders in memory, they're lost when the service stops
+type MemoryOrderStore struct {
+	mutex  sync.RWMutex
+	orders map[string]checkoutservice_rest_types.Order
+}
+
+func NewMemoryOrderStore() *MemoryOrderStore {
+	return &MemoryOrderStore{orders: map[string]checkoutservice_rest_types.Order{}}
+}
+
+func (s *MemoryOrderStore) SaveOrder(ctx context.Context, order checkoutservice_rest_types.Order) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.orders[order.OrderId] = order
+	return nil
+}
+
+func (s *MemoryOrderStore) MarkOrderShipped(ctx context.Context, orderID string, trackingID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	order, found := s.orders[orderID]
+	if !found {
+		return newInvalidArgumentError("there's no order '%s'", orderID)
+	}
+	order.ShippingTrackingId = trackingID
+	s.orders[orderID] = order
+	return nil
+}
+
+// MarkOrderCancelled removes the order, the cancelled orders aren't kept
+func (s *MemoryOrderStore) MarkOrderCancelled(ctx context.Context, orderID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, found := s.orders[orderID]; !found {
+		return newInvalidArgumentError("there's no order '%s'", orderID)
+	}
+	delete(s.orders, orderID)
+	return nil
+}
+
+// GetOrder returns the order and true, or false if there's no order with the ID
+func (s *MemoryOrderStore) GetOrder(orderID string) (checkoutservice_rest_types.Order, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	order, found := s.orders[orderID]
+	return order, found
+}
+
+func (s *MemoryOrderStore) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
@@ -0,0 +1,344 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/configloader"
+	"github.com/kurtosis-tech/new-obd/src/libs/consts"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultHost        = "0.0.0.0"
+	defaultPort uint16 = 8060
+
+	defaultShutdownTimeout = 15 * time.Second
+	// the readiness probes of the manifests run every 10s
+	defaultDrainDelay = 10 * time.Second
+
+	defaultCartServicePort           uint16 = 8090
+	defaultProductCatalogServicePort uint16 = 8070
+	defaultPaymentServicePort        uint16 = 8050
+	defaultShippingServicePort       uint16 = 8040
+	defaultEmailServicePort          uint16 = 8030
+	defaultOrderServicePort          uint16 = 8000
+
+	headerListSeparator = ","
+)
+
+// defaultPropagatedHeaders are the headers of the order request forwarded to the services called to place it, so the Kardinal flow and trace of the checkout reach them
+var defaultPropagatedHeaders = []string{
+	consts.KardinalTraceIdHeaderKey,
+	consts.KardinalFlowIdHeaderKey,
+	consts.RequestIdHeaderKey,
+	consts.BaggageHeaderKey,
+}
+
+// Config is the configuration of the checkout service, run it with --help to list the settings
+type Config struct {
+	Host string
+	Port uint16
+	// ShutdownTimeout is the deadline to drain the in-flight requests when the service stops
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long the service keeps serving after it stops reporting ready, before it shuts down
+	DrainDelay time.Duration
+
+	CartServiceHost           string
+	CartServicePort           uint16
+	ProductCatalogServiceHost string
+	ProductCatalogServicePort uint16
+	PaymentServiceHost        string
+	PaymentServicePort        uint16
+	ShippingServiceHost       string
+	ShippingServicePort       uint16
+	EmailServiceHost          string
+	EmailServicePort          uint16
+	OrderServiceHost          string
+	OrderServicePort          uint16
+
+	JsdelivrAPIKey string
+
+	// PropagatedHeaders are the headers of the inbound requests that are set on all the outbound ones
+	PropagatedHeaders []string
+	Tracing           tracing.Config
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Host:                      defaultHost,
+		Port:                      defaultPort,
+		ShutdownTimeout:           defaultShutdownTimeout,
+		DrainDelay:                defaultDrainDelay,
+		CartServicePort:           defaultCartServicePort,
+		ProductCatalogServicePort: defaultProductCatalogServicePort,
+		PaymentServicePort:        defaultPaymentServicePort,
+		ShippingServicePort:       defaultShippingServicePort,
+		EmailServicePort:          defaultEmailServicePort,
+		OrderServicePort:          defaultOrderServicePort,
+		PropagatedHeaders:         defaultPropagatedHeaders,
+		Tracing:                   tracing.DefaultConfig(),
+	}
+}
+
+// Address is the address the REST API server listens on
+func (cfg *Config) Address() string {
+	return net.JoinHostPort(cfg.Host, strconv.Itoa(int(cfg.Port)))
+}
+
+// CartServiceURL is the base URL of the cart service REST API
+func (cfg *Config) CartServiceURL() string {
+	return serviceURL(cfg.CartServiceHost, cfg.CartServicePort)
+}
+
+// ProductCatalogServiceURL is the base URL of the product catalog service REST API
+func (cfg *Config) ProductCatalogServiceURL() string {
+	return serviceURL(cfg.ProductCatalogServiceHost, cfg.ProductCatalogServicePort)
+}
+
+// PaymentServiceURL is the base URL of the payment service REST API
+func (cfg *Config) PaymentServiceURL() string {
+	return serviceURL(cfg.PaymentServiceHost, cfg.PaymentServicePort)
+}
+
+// ShippingServiceURL is the base URL of the shipping service REST API
+func (cfg *Config) ShippingServiceURL() string {
+	return serviceURL(cfg.ShippingServiceHost, cfg.ShippingServicePort)
+}
+
+// EmailServiceURL is the base URL of the email service REST API
+func (cfg *Config) EmailServiceURL() string {
+	return serviceURL(cfg.EmailServiceHost, cfg.EmailServicePort)
+}
+
+// OrderServiceURL is the base URL of the order service REST API
+func (cfg *Config) OrderServiceURL() string {
+	return serviceURL(cfg.OrderServiceHost, cfg.OrderServicePort)
+}
+
+func serviceURL(host string, port uint16) string {
+	return fmt.Sprintf("http://%s", net.JoinHostPort(host, strconv.Itoa(int(port))))
+}
+
+var settings = append([]setting{
+	{
+		Key:         "host",
+		EnvVar:      "HOST",
+		Description: "IP the REST API server listens on",
+		Get:         func(cfg *Config) string { return cfg.Host },
+		Set:         func(cfg *Config, value string) error { cfg.Host = value; return nil },
+	},
+	{
+		Key:         "port",
+		EnvVar:      "PORT",
+		Description: "port the REST API server listens on",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.Port)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.Port = port
+			return err
+		},
+	},
+	{
+		Key:         "shutdown-timeout",
+		EnvVar:      "SHUTDOWN_TIMEOUT",
+		Description: "deadline to drain the in-flight requests when the service stops",
+		Get:         func(cfg *Config) string { return cfg.ShutdownTimeout.String() },
+		Set: func(cfg *Config, value string) error {
+			timeout, err := configloader.ParseDuration(value)
+			cfg.ShutdownTimeout = timeout
+			return err
+		},
+	},
+	{
+		Key:         "drain-delay",
+		EnvVar:      "DRAIN_DELAY",
+		Description: "time the service keeps serving after it stops reporting ready on shutdown, at least the readiness probe period",
+		Get:         func(cfg *Config) string { return cfg.DrainDelay.String() },
+		Set: func(cfg *Config, value string) error {
+			delay, err := configloader.ParseDuration(value)
+			cfg.DrainDelay = delay
+			return err
+		},
+	},
+	{
+		Key:         "cart-service-host",
+		EnvVar:      "CARTSERVICEHOST",
+		Description: "host of the cart service",
+		Get:         func(cfg *Config) string { return cfg.CartServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.CartServiceHost = value; return nil },
+	},
+	{
+		Key:         "cart-service-port",
+		EnvVar:      "CARTSERVICEPORT",
+		Description: "port of the cart service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.CartServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.CartServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "product-catalog-service-host",
+		EnvVar:      "PRODUCTCATALOGSERVICEHOST",
+		Description: "host of the product catalog service",
+		Get:         func(cfg *Config) string { return cfg.ProductCatalogServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.ProductCatalogServiceHost = value; return nil },
+	},
+	{
+		Key:         "product-catalog-service-port",
+		EnvVar:      "PRODUCTCATALOGSERVICEPORT",
+		Description: "port of the product catalog service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.ProductCatalogServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.ProductCatalogServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "payment-service-host",
+		EnvVar:      "PAYMENTSERVICEHOST",
+		Description: "host of the payment service",
+		Get:         func(cfg *Config) string { return cfg.PaymentServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.PaymentServiceHost = value; return nil },
+	},
+	{
+		Key:         "payment-service-port",
+		EnvVar:      "PAYMENTSERVICEPORT",
+		Description: "port of the payment service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.PaymentServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.PaymentServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "shipping-service-host",
+		EnvVar:      "SHIPPINGSERVICEHOST",
+		Description: "host of the shipping service",
+		Get:         func(cfg *Config) string { return cfg.ShippingServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.ShippingServiceHost = value; return nil },
+	},
+	{
+		Key:         "shipping-service-port",
+		EnvVar:      "SHIPPINGSERVICEPORT",
+		Description: "port of the shipping service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.ShippingServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.ShippingServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "email-service-host",
+		EnvVar:      "EMAILSERVICEHOST",
+		Description: "host of the email service",
+		Get:         func(cfg *Config) string { return cfg.EmailServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.EmailServiceHost = value; return nil },
+	},
+	{
+		Key:         "email-service-port",
+		EnvVar:      "EMAILSERVICEPORT",
+		Description: "port of the email service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.EmailServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.EmailServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "order-service-host",
+		EnvVar:      "ORDERSERVICEHOST",
+		Description: "host of the order service",
+		Get:         func(cfg *Config) string { return cfg.OrderServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.OrderServiceHost = value; return nil },
+	},
+	{
+		Key:         "order-service-port",
+		EnvVar:      "ORDERSERVICEPORT",
+		Description: "port of the order service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.OrderServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.OrderServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "jsdelivr-api-key",
+		EnvVar:      "JSDELIVRAPIKEY",
+		Description: "API key of the currency exchange rates API",
+		Secret:      true,
+		Get:         func(cfg *Config) string { return cfg.JsdelivrAPIKey },
+		Set:         func(cfg *Config, value string) error { cfg.JsdelivrAPIKey = value; return nil },
+	},
+	{
+		Key:         "propagated-headers",
+		EnvVar:      "PROPAGATED_HEADERS",
+		Description: "comma-separated headers of the inbound requests forwarded to the services called to place the orders",
+		Get:         func(cfg *Config) string { return strings.Join(cfg.PropagatedHeaders, headerListSeparator) },
+		Set: func(cfg *Config, value string) error {
+			headers, err := parseHeaderList(value)
+			cfg.PropagatedHeaders = headers
+			return err
+		},
+	},
+}, tracing.Settings(func(cfg *Config) *tracing.Config { return &cfg.Tracing })...)
+
+func (cfg *Config) validate() []string {
+	var violations []string
+	if net.ParseIP(cfg.Host) == nil {
+		violations = append(violations, fmt.Sprintf("'host' must be an IP address, got '%s'", cfg.Host))
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		violations = append(violations, fmt.Sprintf("'shutdown-timeout' must be positive, got '%s'", cfg.ShutdownTimeout))
+	}
+	if cfg.DrainDelay < 0 {
+		violations = append(violations, fmt.Sprintf("'drain-delay' can't be negative, got '%s'", cfg.DrainDelay))
+	}
+	if cfg.CartServiceHost == "" {
+		violations = append(violations, "'cart-service-host' is required")
+	}
+	if cfg.ProductCatalogServiceHost == "" {
+		violations = append(violations, "'product-catalog-service-host' is required")
+	}
+	if cfg.PaymentServiceHost == "" {
+		violations = append(violations, "'payment-service-host' is required")
+	}
+	if cfg.ShippingServiceHost == "" {
+		violations = append(violations, "'shipping-service-host' is required")
+	}
+	if cfg.EmailServiceHost == "" {
+		violations = append(violations, "'email-service-host' is required")
+	}
+	if cfg.OrderServiceHost == "" {
+		violations = append(violations, "'order-service-host' is required")
+	}
+	violations = append(violations, cfg.Tracing.Validate()...)
+	return violations
+}
+
+func parseHeaderList(value string) ([]string, error) {
+	headers := []string{}
+	for _, header := range strings.Split(value, headerListSeparator) {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if strings.ContainsAny(header, " \t:") {
+			return nil, errors.Errorf("'%s' is not a valid header name", header)
+		}
+		headers = append(headers, textproto.CanonicalMIMEHeaderKey(header))
+	}
+	return headers, nil
+}
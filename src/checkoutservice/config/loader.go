@@ -0,0 +1,25 @@
+package config
+
+import (
+	"io"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/configloader"
+)
+
+// setting is a config value that can be set in the YAML file and as a flag (both named after its key)
+// and in its environment variable
+type setting = configloader.Setting[Config]
+
+// CommandLine holds the command line options that are not settings
+type CommandLine = configloader.CommandLine
+
+// Load builds the config from the defaults, the optional YAML file, the environment variables and the flags,
+// each one of them overriding the previous ones. All the invalid values are reported at once in the returned error.
+func Load(programName string, args []string, lookupEnv func(key string) (string, bool)) (*Config, *CommandLine, error) {
+	return configloader.Load(programName, args, lookupEnv, defaultConfig(), settings, (*Config).validate)
+}
+
+// Print writes the config as YAML, in the format of the config file, with the secrets redacted
+func (cfg *Config) Print(w io.Writer) error {
+	return configloader.Print(w, cfg, settings)
+}
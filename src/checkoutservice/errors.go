@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kurtosis-tech/new-obd/src/checkoutservice/checkout"
+	"github.com/pkg/errors"
+)
+
+// statusCodeForError returns the status code of the errors returned by the handlers, based on the checkout error kinds
+func statusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, checkout.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, checkout.ErrPaymentDeclined):
+		return http.StatusPaymentRequired
+	case errors.Is(err, checkout.ErrOutOfStock):
+		return http.StatusConflict
+	case errors.Is(err, checkout.ErrUnavailable), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,89 @@
+module github.com/kurtosis-tech/new-obd/src/checkoutservice
+
+go 1.21
+
+replace (
+	github.com/kurtosis-tech/new-obd/src/cartservice => ../cartservice
+	github.com/kurtosis-tech/new-obd/src/currencyexternalapi => ../currencyexternalapi
+	github.com/kurtosis-tech/new-obd/src/emailservice => ../emailservice
+	github.com/kurtosis-tech/new-obd/src/libs => ../libs
+	github.com/kurtosis-tech/new-obd/src/orderservice => ../orderservice
+	github.com/kurtosis-tech/new-obd/src/paymentservice => ../paymentservice
+	github.com/kurtosis-tech/new-obd/src/productcatalogservice => ../productcatalogservice
+	github.com/kurtosis-tech/new-obd/src/shippingservice => ../shippingservice
+)
+
+require (
+	github.com/deepmap/oapi-codegen/v2 v2.2.1-0.20240604070534-2f0ff757704b
+	github.com/getkin/kin-openapi v0.124.0
+	github.com/google/uuid v1.5.0
+	github.com/kurtosis-tech/new-obd/src/cartservice v0.0.0-00010101000000-000000000000
+	github.com/kurtosis-tech/new-obd/src/currencyexternalapi v0.0.0-00010101000000-000000000000
+	github.com/kurtosis-tech/new-obd/src/emailservice v0.0.0-00010101000000-000000000000
+	github.com/kurtosis-tech/new-obd/src/libs v0.0.0-00010101000000-000000000000
+	github.com/kurtosis-tech/new-obd/src/orderservice v0.0.0-00010101000000-000000000000
+	github.com/kurtosis-tech/new-obd/src/paymentservice v0.0.0-00010101000000-000000000000
+	github.com/kurtosis-tech/new-obd/src/productcatalogservice v0.0.0-00010101000000-000000000000
+	github.com/kurtosis-tech/new-obd/src/shippingservice v0.0.0-00010101000000-000000000000
+	github.com/labstack/echo/v4 v4.12.0
+	github.com/oapi-codegen/runtime v1.1.1
+	github.com/pkg/errors v0.9.1
+	github.com/sirupsen/logrus v1.8.1
+	github.com/stretchr/testify v1.9.0
+	go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho v0.49.0
+	google.golang.org/grpc v1.61.1
+)
+
+require (
+	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cenkalti/backoff/v4 v4.2.1 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/felixge/httpsnoop v1.0.4 // indirect
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/go-openapi/jsonpointer v0.20.2 // indirect
+	github.com/go-openapi/swag v0.22.8 // indirect
+	github.com/golang-jwt/jwt v3.2.2+incompatible // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/gorilla/mux v1.8.1 // indirect
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.19.0 // indirect
+	github.com/invopop/yaml v0.2.0 // indirect
+	github.com/josharian/intern v1.0.0 // indirect
+	github.com/labstack/gommon v0.4.2 // indirect
+	github.com/mailru/easyjson v0.7.7 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
+	github.com/oapi-codegen/echo-middleware v1.0.2 // indirect
+	github.com/perimeterx/marshmallow v1.1.5 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_golang v1.19.1 // indirect
+	github.com/prometheus/client_model v0.5.0 // indirect
+	github.com/prometheus/common v0.48.0 // indirect
+	github.com/prometheus/procfs v0.12.0 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasttemplate v1.2.2 // indirect
+	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.49.0 // indirect
+	go.opentelemetry.io/otel v1.24.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.24.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp v1.24.0 // indirect
+	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.24.0 // indirect
+	go.opentelemetry.io/otel/metric v1.24.0 // indirect
+	go.opentelemetry.io/otel/sdk v1.24.0 // indirect
+	go.opentelemetry.io/otel/trace v1.24.0 // indirect
+	go.opentelemetry.io/proto/otlp v1.1.0 // indirect
+	golang.org/x/crypto v0.23.0 // indirect
+	golang.org/x/mod v0.17.0 // indirect
+	golang.org/x/net v0.25.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+	golang.org/x/text v0.15.0 // indirect
+	golang.org/x/time v0.5.0 // indirect
+	golang.org/x/tools v0.21.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20240102182953-50ed04b92917 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240102182953-50ed04b92917 // indirect
+	google.golang.org/protobuf v1.33.0 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	cartservice_rest_client "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/client"
+	checkoutservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/server"
+	"github.com/kurtosis-tech/new-obd/src/checkoutservice/checkout"
+	"github.com/kurtosis-tech/new-obd/src/checkoutservice/config"
+	"github.com/kurtosis-tech/new-obd/src/currencyexternalapi"
+	"github.com/kurtosis-tech/new-obd/src/currencyexternalapi/config/jsdelivr"
+	emailservice_rest_client "github.com/kurtosis-tech/new-obd/src/emailservice/api/http_rest/client"
+	"github.com/kurtosis-tech/new-obd/src/libs/headerpropagation"
+	"github.com/kurtosis-tech/new-obd/src/libs/metrics"
+	"github.com/kurtosis-tech/new-obd/src/libs/rest"
+	"github.com/kurtosis-tech/new-obd/src/libs/shutdown"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	orderservice_rest_client "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/client"
+	paymentservice_rest_client "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/client"
+	productcatalogservice_rest_client "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/client"
+	shippingservice_rest_client "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/client"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+)
+
+const (
+	serviceName = "checkoutservice"
+
+	// the services the requests are sent to, they label the metrics and the spans of the requests
+	cartServiceName           = "cartservice"
+	productCatalogServiceName = "productcatalogservice"
+	paymentServiceName        = "paymentservice"
+	shippingServiceName       = "shippingservice"
+	emailServiceName          = "emailservice"
+	orderServiceName          = "orderservice"
+	currencyAPIName           = "currencyapi"
+
+	// the exit code of an invalid config, it follows the ones of the shutdown package
+	exitCodeInvalidConfig = 3
+)
+
+var (
+	defaultCORSOrigins = []string{"*"}
+	defaultCORSHeaders = []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept}
+)
+
+func main() {
+	cfg, commandLine, err := config.Load(os.Args[0], os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitCodeInvalidConfig)
+	}
+
+	if commandLine.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), serviceName, cfg.Tracing)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Info("Running REST API server...")
+
+	// This is how you set up a basic Echo router
+	echoRouter := echo.New()
+	echoRouter.HTTPErrorHandler = rest.NewResponseInfoHTTPErrorHandler(statusCodeForError)
+	// first, so the duration includes the other middlewares
+	echoRouter.Use(metrics.Middleware)
+	echoRouter.Use(otelecho.Middleware(serviceName, otelecho.WithSkipper(tracing.Skipper)))
+	echoRouter.Use(middleware.Logger())
+
+	echoRouter.Use(tracing.KardinalTraceIDMiddleware)
+	echoRouter.Use(echo.WrapMiddleware(headerpropagation.NewMiddleware(cfg.PropagatedHeaders)))
+
+	// CORS configuration
+	echoRouter.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: defaultCORSOrigins,
+		AllowHeaders: defaultCORSHeaders,
+	}))
+
+	checkoutService, err := newCheckoutService(cfg)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	requestValidatorMiddleware, err := rest.NewRequestValidatorMiddleware(checkoutservice_server_rest_server.GetSwagger, "")
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	echoRouter.Use(requestValidatorMiddleware)
+
+	server := NewServer(checkoutService)
+
+	metrics.RegisterHandler(echoRouter)
+
+	strictMiddlewares := []checkoutservice_server_rest_server.StrictMiddlewareFunc{rest.NewErrorMappingMiddleware(statusCodeForError)}
+	checkoutservice_server_rest_server.RegisterHandlers(echoRouter, checkoutservice_server_rest_server.NewStrictHandler(server, strictMiddlewares))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	exitCode := shutdown.ServeUntilShutdown(ctx, func() error {
+		return echoRouter.Start(cfg.Address())
+	}, echoRouter.Shutdown, shutdown.Options{
+		OnShutdownStart: server.MarkShuttingDown,
+		DrainDelay:      cfg.DrainDelay,
+		ShutdownTimeout: cfg.ShutdownTimeout,
+	})
+	stop()
+
+	shutdown.FlushTraces(shutdownTracing, cfg.ShutdownTimeout)
+	shutdown.FlushLogs()
+	os.Exit(exitCode)
+}
+
+func newCheckoutService(cfg *config.Config) (*checkout.Service, error) {
+	cartServiceClient, err := cartservice_rest_client.NewClientWithResponses(cfg.CartServiceURL(), cartservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(cartServiceName)))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred creating the cart service client")
+	}
+
+	productCatalogServiceClient, err := productcatalogservice_rest_client.NewClientWithResponses(cfg.ProductCatalogServiceURL(), productcatalogservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(productCatalogServiceName)))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred creating the product catalog service client")
+	}
+
+	paymentServiceClient, err := paymentservice_rest_client.NewClientWithResponses(cfg.PaymentServiceURL(), paymentservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(paymentServiceName)))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred creating the payment service client")
+	}
+
+	shippingServiceClient, err := shippingservice_rest_client.NewClientWithResponses(cfg.ShippingServiceURL(), shippingservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(shippingServiceName)))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred creating the shipping service client")
+	}
+
+	emailServiceClient, err := emailservice_rest_client.NewClientWithResponses(cfg.EmailServiceURL(), emailservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(emailServiceName)))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred creating the email service client")
+	}
+
+	orderServiceClient, err := orderservice_rest_client.NewClientWithResponses(cfg.OrderServiceURL(), orderservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(orderServiceName)))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred creating the order service client")
+	}
+
+	currencyAPI := currencyexternalapi.NewCurrencyAPIWithHTTPClient(jsdelivr.GetJsdelivrAPIConfig(cfg.JsdelivrAPIKey), metrics.NewInstrumentedHTTPClient(currencyAPIName))
+
+	return checkout.NewService(
+		checkout.NewCartServiceClient(cartServiceClient),
+		checkout.NewProductCatalogClient(productCatalogServiceClient),
+		checkout.NewCurrencyAPIConverter(currencyAPI),
+		checkout.NewPaymentServiceClient(paymentServiceClient),
+		checkout.NewShippingServiceClient(shippingServiceClient),
+		checkout.NewOrderServiceClient(orderServiceClient),
+		checkout.NewEmailServiceClient(emailServiceClient),
+	), nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	checkoutservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/server"
+	checkoutservice_rest_types "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/checkoutservice/checkout"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	healthStatusOk           = "ok"
+	healthStatusError        = "error"
+	healthStatusShuttingDown = "shutting down"
+
+	orderStoreDependencyName = "order store"
+
+	readinessCheckTimeout = 2 * time.Second
+)
+
+type Server struct {
+	Checkout *checkout.Service
+	// shuttingDown is a pointer so every copy of the server (the handlers have value receivers) sees the same flag
+	shuttingDown *atomic.Bool
+}
+
+func NewServer(checkoutService *checkout.Service) Server {
+	return Server{Checkout: checkoutService, shuttingDown: &atomic.Bool{}}
+}
+
+// MarkShuttingDown makes the readiness check fail, so the service stops receiving traffic while it shuts down
+func (s Server) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s Server) GetHealth(ctx context.Context, request checkoutservice_server_rest_server.GetHealthRequestObject) (checkoutservice_server_rest_server.GetHealthResponseObject, error) {
+
+	status := healthStatusOk
+	now := time.Now()
+
+	response := checkoutservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return checkoutservice_server_rest_server.GetHealth200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthLive(ctx context.Context, request checkoutservice_server_rest_server.GetHealthLiveRequestObject) (checkoutservice_server_rest_server.GetHealthLiveResponseObject, error) {
+	status := healthStatusOk
+	now := time.Now()
+
+	response := checkoutservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return checkoutservice_server_rest_server.GetHealthLive200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthReady(ctx context.Context, request checkoutservice_server_rest_server.GetHealthReadyRequestObject) (checkoutservice_server_rest_server.GetHealthReadyResponseObject, error) {
+	now := time.Now()
+
+	if s.shuttingDown.Load() {
+		status := healthStatusShuttingDown
+		response := checkoutservice_rest_types.HealthResponse{
+			Status:    &status,
+			Timestamp: &now,
+		}
+		return checkoutservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+
+	checks := []checkoutservice_rest_types.DependencyCheck{
+		checkDependency(ctx, orderStoreDependencyName, s.Checkout.Ping),
+	}
+
+	status := healthStatusOk
+	for _, check := range checks {
+		if check.Status != healthStatusOk {
+			status = healthStatusError
+		}
+	}
+
+	response := checkoutservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+		Checks:    &checks,
+	}
+
+	if status != healthStatusOk {
+		return checkoutservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+	return checkoutservice_server_rest_server.GetHealthReady200JSONResponse(response), nil
+}
+
+func (s Server) PostOrders(ctx context.Context, request checkoutservice_server_rest_server.PostOrdersRequestObject) (checkoutservice_server_rest_server.PostOrdersResponseObject, error) {
+	logrus.Infof("Place order request - UserID: %s, Currency: %s", request.Body.UserId, request.Body.UserCurrency)
+	order, err := s.Checkout.PlaceOrder(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return checkoutservice_server_rest_server.PostOrders200JSONResponse(*order), nil
+}
+
+// checkDependency runs the check with the readiness timeout and reports its status and latency
+func checkDependency(ctx context.Context, name string, check func(ctx context.Context) error) checkoutservice_rest_types.DependencyCheck {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	dependencyCheck := checkoutservice_rest_types.DependencyCheck{
+		Name:      name,
+		Status:    healthStatusOk,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		logrus.Warnf("The readiness check of dependency '%s' failed. Error: %s", name, err)
+		errMsg := err.Error()
+		dependencyCheck.Status = healthStatusError
+		dependencyCheck.Error = &errMsg
+	}
+	return dependencyCheck
+}
@@ -2,6 +2,7 @@ package currencyexternalapi
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -13,8 +14,16 @@ type CacheItem struct {
 
 // Cache is a simple in-memory cache
 type Cache struct {
-	mu    sync.RWMutex
-	items map[string]CacheItem
+	mu     sync.RWMutex
+	items  map[string]CacheItem
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// CacheStats are the number of lookups that found a valid item (hits) and that didn't (misses)
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
 }
 
 // NewCache creates a new Cache instance
@@ -30,16 +39,27 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 	defer c.mu.RUnlock()
 	item, ok := c.items[key]
 	if !ok {
+		c.misses.Add(1)
 		return nil, false
 	}
 	if item.Expiration.Before(time.Now()) {
 		// Remove expired item from cache
 		delete(c.items, key)
+		c.misses.Add(1)
 		return nil, false
 	}
+	c.hits.Add(1)
 	return item.Body, true
 }
 
+// Stats returns the hits and misses since the cache was created
+func (c *Cache) Stats() CacheStats {
+	return CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+	}
+}
+
 // Set adds an item to the cache
 func (c *Cache) Set(key string, body []byte, duration time.Duration) {
 	c.mu.Lock()
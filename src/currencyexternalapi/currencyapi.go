@@ -25,7 +25,17 @@ type CurrencyAPI struct {
 }
 
 func NewCurrencyAPI(config *config.CurrencyAPIConfig) *CurrencyAPI {
-	return &CurrencyAPI{httpClient: http.DefaultClient, cache: NewCache(), config: config}
+	return NewCurrencyAPIWithHTTPClient(config, http.DefaultClient)
+}
+
+// NewCurrencyAPIWithHTTPClient sends the requests to the external API with the given client (e.g. an instrumented one)
+func NewCurrencyAPIWithHTTPClient(config *config.CurrencyAPIConfig, httpClient *http.Client) *CurrencyAPI {
+	return &CurrencyAPI{httpClient: httpClient, cache: NewCache(), config: config}
+}
+
+// CacheStats returns the hits and misses of the responses cache
+func (c *CurrencyAPI) CacheStats() CacheStats {
+	return c.cache.Stats()
 }
 
 func (c *CurrencyAPI) GetSupportedCurrencies(ctx context.Context) ([]string, error) {
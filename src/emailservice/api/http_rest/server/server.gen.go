@@ -0,0 +1,465 @@
+// Package emailservice_server_rest_server provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.1-0.20240604070534-2f0ff757704b DO NOT EDIT.
+package emailservice_server_rest_server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	. "github.com/kurtosis-tech/new-obd/src/emailservice/api/http_rest/types"
+	"github.com/labstack/echo/v4"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Health check endpoint
+	// (GET /health)
+	GetHealth(ctx echo.Context) error
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx echo.Context) error
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx echo.Context) error
+	// Send order confirmation
+	// (POST /order-confirmations)
+	PostOrderConfirmations(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetHealth converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealth(ctx)
+	return err
+}
+
+// GetHealthLive converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthLive(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthLive(ctx)
+	return err
+}
+
+// GetHealthReady converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthReady(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthReady(ctx)
+	return err
+}
+
+// PostOrderConfirmations converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOrderConfirmations(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostOrderConfirmations(ctx)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/health", wrapper.GetHealth)
+	router.GET(baseURL+"/health/live", wrapper.GetHealthLive)
+	router.GET(baseURL+"/health/ready", wrapper.GetHealthReady)
+	router.POST(baseURL+"/order-confirmations", wrapper.PostOrderConfirmations)
+
+}
+
+type NotOkJSONResponse ResponseInfo
+
+type GetHealthRequestObject struct {
+}
+
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
+}
+
+type GetHealth200JSONResponse HealthResponse
+
+func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthdefaultJSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthLiveRequestObject struct {
+}
+
+type GetHealthLiveResponseObject interface {
+	VisitGetHealthLiveResponse(w http.ResponseWriter) error
+}
+
+type GetHealthLive200JSONResponse HealthResponse
+
+func (response GetHealthLive200JSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthLivedefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthLivedefaultJSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthReadyRequestObject struct {
+}
+
+type GetHealthReadyResponseObject interface {
+	VisitGetHealthReadyResponse(w http.ResponseWriter) error
+}
+
+type GetHealthReady200JSONResponse HealthResponse
+
+func (response GetHealthReady200JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReady503JSONResponse HealthResponse
+
+func (response GetHealthReady503JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReadydefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthReadydefaultJSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostOrderConfirmationsRequestObject struct {
+	Body *PostOrderConfirmationsJSONRequestBody
+}
+
+type PostOrderConfirmationsResponseObject interface {
+	VisitPostOrderConfirmationsResponse(w http.ResponseWriter) error
+}
+
+type PostOrderConfirmations200JSONResponse SendOrderConfirmationResponse
+
+func (response PostOrderConfirmations200JSONResponse) VisitPostOrderConfirmationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOrderConfirmationsdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostOrderConfirmationsdefaultJSONResponse) VisitPostOrderConfirmationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Health check endpoint
+	// (GET /health)
+	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx context.Context, request GetHealthLiveRequestObject) (GetHealthLiveResponseObject, error)
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx context.Context, request GetHealthReadyRequestObject) (GetHealthReadyResponseObject, error)
+	// Send order confirmation
+	// (POST /order-confirmations)
+	PostOrderConfirmations(ctx context.Context, request PostOrderConfirmationsRequestObject) (PostOrderConfirmationsResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetHealth operation middleware
+func (sh *strictHandler) GetHealth(ctx echo.Context) error {
+	var request GetHealthRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealth(ctx.Request().Context(), request.(GetHealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealth")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthResponseObject); ok {
+		return validResponse.VisitGetHealthResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthLive operation middleware
+func (sh *strictHandler) GetHealthLive(ctx echo.Context) error {
+	var request GetHealthLiveRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthLive(ctx.Request().Context(), request.(GetHealthLiveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthLive")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthLiveResponseObject); ok {
+		return validResponse.VisitGetHealthLiveResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthReady operation middleware
+func (sh *strictHandler) GetHealthReady(ctx echo.Context) error {
+	var request GetHealthReadyRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthReady(ctx.Request().Context(), request.(GetHealthReadyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthReady")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthReadyResponseObject); ok {
+		return validResponse.VisitGetHealthReadyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostOrderConfirmations operation middleware
+func (sh *strictHandler) PostOrderConfirmations(ctx echo.Context) error {
+	var request PostOrderConfirmationsRequestObject
+
+	var body PostOrderConfirmationsJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostOrderConfirmations(ctx.Request().Context(), request.(PostOrderConfirmationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostOrderConfirmations")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostOrderConfirmationsResponseObject); ok {
+		return validResponse.VisitPostOrderConfirmationsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/8xXbW8TORD+K9YcEl82TWjLIfZbj3JcdFyLUtBJVFxl7Eliumsv9mxLDuW/n2zvprtZ",
+	"J+0JTgdCwjjz+szjmdmvIExZGY2aHORfwaKrjHYY/nNm6PzaH4TRhJr8kVdVoQQnZfT4kzPa3zmxxJL7",
+	"0yOLc8jhp/Gd1XH81Y1njempnhtYr9cZSHTCqsrbghzeafxSoSCUDK01FrxIo+xtn0hp0YVjZU2FllQM",
+	"Uyha+X9LpV+jXtAS8icZ0KpCyMGRVXoB6wyEqTXZh0g64oReLvGLRaQrfhfKPab+VtWVMBLvFV1nYPFz",
+	"rSxKyC+3HWUxybskOpY/bGyZj59QkHf7gluaEpZDsCprZC3oSslkgp9rrqnBc25syQlyUJqODmHjRmnC",
+	"BdpBzB3THUPJ8GprUYvViwaZPhGmF+fs+PDJMyYaMRbyzAC/8LIqvKl3F6eQQcWJ0HqVvy5PRu8/fD1a",
+	"P4JEEU6xQi2DwyWK6yEokW8pPApOXu+qjHJtAEdZD52fjxPoZKB5iT01kJz4R+4QdrCu7rsBcw33MSU4",
+	"2Wj3Ik5B/xvygpbtW0w8Jo9QOCnC0t33qLehXW9ccmv5alde796kECBVoiNeVn3hw8nh8WjybHT4/O1k",
+	"koe/76FTAMkJR143idUAgT+MxlUi8YZsm+e6L+0egUOltXFDJtMSmf+J1VqRY2bO/A0v/SPO2K2iZbhw",
+	"vETm1EIz7sJFEIds+AJL/kWVdQn58/ZP5ttKvBt1LodkjDaTId4uTYGpGOEBNN+iYx/G1m2LUIqR51ai",
+	"HdZjQ78H8TAYCR0vwUDjf9zV8aqCC5RXnHotbw+nMnBLVVVKL7pzYF9w7eTqqgrj6D69yNSuFlkurv1h",
+	"RzJkiBdXFVfygba3qrdBaofP7QQSWGRNwXrBdGHeyYH0wPpXQKnGxN63287G7eSDchYdpoLsLTCJOCX2",
+	"KFTvGpsZlOgcX6RXjHjxsFXqrZfdTiMYuPORwc4toWfGt1zt28glvJzNzmeQwfTs13PI4M+T2dn07FXH",
+	"xF20F6hlKN4Lo+fKZ66MnuHnGh0NMcKSq6IHUrxJGDZtV7j31Q8AaG1GE6nEd0S9ayY2UKYf3ZbzjuzQ",
+	"sxdWDXv6bXj28uLtvC7YyZspcxUKNW+WbDY3NnTklz4r5tDeKIEZU/TYsdqhZGQYr8mMFqjRckImCoWa",
+	"2MXp748d41oGJbQjpyS2qxQpCrO1ZxUyuEHrYkSTgycHk1CJCjWvFORwdDA5OIp71zIAM16GbcIfF0iJ",
+	"rJBqq+NMi6IsbgPtmGkcH0BwY0PGUwk5vEKKmwpk/W+Sw8nku32RbO1CiW+SixgfU66JP3QZiXNeFzu7",
+	"0ibecfyA8mZdXZbcf3w0CxgLexZDLSujNAWZBs1xoW7wXkjNNbtdqgIDjJU1Ap3zYfKP/s7EmjMbX6Lz",
+	"fGHSoNOPqXHt9WS7vSl0e2rw2gf0o9SBB3i+tQo+Je0h21MHi1yudhYi7LtuAOMWswPwFitjyTFt/JnL",
+	"FVNzxvWqkS3ZnKvCMWOZ6in7dN2yJlJ6waS51XtqNAvB/ihFilmSYRYFqhtkZPl8roSv29PJ0f8T1B38",
+	"6cC+iVAef7WTUWEUjURn2sSP8Wa32X7iWqKN1OqqsDDYWoIFk6G9S/RPwjpPNTJRrXZkSrQN/e668PS0",
+	"1W8G1QE70UzpG14o2baL8MzY8WQSzHO2mRHoRxEnJrjvI8SvsWuKcfZ0cjTk6BvjaDBtHcS5iY5+MXL1",
+	"3RixdyFZ96c12RrX/+GT2b9mpMhaC4HO+UWgDSrrIXzLXVtulN/MWR9fw6Muz2Jksd4O8sttfvZ2Br+x",
+	"+M88W0AOS6LK5eNxIGojAOsP638GAHWO62FmFAAA",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,106 @@
+// Package emailservice_rest_types provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.1-0.20240604070534-2f0ff757704b DO NOT EDIT.
+package emailservice_rest_types
+
+import (
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for ResponseType.
+const (
+	ERROR   ResponseType = "ERROR"
+	INFO    ResponseType = "INFO"
+	WARNING ResponseType = "WARNING"
+)
+
+// Address defines model for Address.
+type Address struct {
+	City          string  `json:"city"`
+	Country       string  `json:"country"`
+	State         *string `json:"state,omitempty"`
+	StreetAddress string  `json:"street_address"`
+	ZipCode       string  `json:"zip_code"`
+}
+
+// CartItem defines model for CartItem.
+type CartItem struct {
+	ProductId string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// CurrencyCode ISO 4217 currency code
+type CurrencyCode = string
+
+// DependencyCheck defines model for DependencyCheck.
+type DependencyCheck struct {
+	Error     *string `json:"error,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+}
+
+// HealthResponse defines model for HealthResponse.
+type HealthResponse struct {
+	Checks    *[]DependencyCheck `json:"checks,omitempty"`
+	Status    *string            `json:"status,omitempty"`
+	Timestamp *time.Time         `json:"timestamp,omitempty"`
+}
+
+// Money defines model for Money.
+type Money struct {
+	// CurrencyCode ISO 4217 currency code
+	CurrencyCode CurrencyCode `json:"currency_code"`
+
+	// Nanos the nano units of the amount, with the same sign as the units
+	Nanos int32 `json:"nanos"`
+
+	// Units the whole units of the amount
+	Units int64 `json:"units"`
+}
+
+// Order defines model for Order.
+type Order struct {
+	Items              []OrderItem `json:"items"`
+	OrderId            string      `json:"order_id"`
+	PlacedAt           time.Time   `json:"placed_at"`
+	ShippingAddress    Address     `json:"shipping_address"`
+	ShippingCost       Money       `json:"shipping_cost"`
+	ShippingTrackingId string      `json:"shipping_tracking_id"`
+	TotalPaid          Money       `json:"total_paid"`
+}
+
+// OrderItem defines model for OrderItem.
+type OrderItem struct {
+	Cost Money    `json:"cost"`
+	Item CartItem `json:"item"`
+}
+
+// ResponseInfo defines model for ResponseInfo.
+type ResponseInfo struct {
+	Code    uint32       `json:"code"`
+	Message string       `json:"message"`
+	Type    ResponseType `json:"type"`
+}
+
+// ResponseType defines model for ResponseType.
+type ResponseType string
+
+// SendOrderConfirmationRequest defines model for SendOrderConfirmationRequest.
+type SendOrderConfirmationRequest struct {
+	Email openapi_types.Email `json:"email"`
+	Order Order               `json:"order"`
+}
+
+// SendOrderConfirmationResponse defines model for SendOrderConfirmationResponse.
+type SendOrderConfirmationResponse struct {
+	MessageId string `json:"message_id"`
+}
+
+// NotOk defines model for NotOk.
+type NotOk = ResponseInfo
+
+// PostOrderConfirmationsJSONRequestBody defines body for PostOrderConfirmations for application/json ContentType.
+type PostOrderConfirmationsJSONRequestBody = SendOrderConfirmationRequest
@@ -0,0 +1,214 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"strconv"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/configloader"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+)
+
+const (
+	defaultHost        = "0.0.0.0"
+	defaultPort uint16 = 8030
+
+	defaultShutdownTimeout = 15 * time.Second
+	// the readiness probes of the manifests run every 10s
+	defaultDrainDelay = 10 * time.Second
+
+	// OutboxDelivery writes the messages as .eml files in the outbox directory, SMTPDelivery sends them to the SMTP server
+	OutboxDelivery = "outbox"
+	SMTPDelivery   = "smtp"
+
+	defaultOutboxDir           = "outbox"
+	defaultTemplatesDir        = "templates"
+	defaultFromAddress         = "Online Boutique <no-reply@example.com>"
+	defaultSMTPPort     uint16 = 587
+)
+
+// Config is the configuration of the email service, run it with --help to list the settings
+type Config struct {
+	Host string
+	Port uint16
+	// ShutdownTimeout is the deadline to drain the in-flight requests when the service stops
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long the service keeps serving after it stops reporting ready, before it shuts down
+	DrainDelay time.Duration
+
+	// Delivery selects how the messages are delivered, OutboxDelivery or SMTPDelivery
+	Delivery     string
+	OutboxDir    string
+	TemplatesDir string
+	FromAddress  string
+	SMTP         SMTPConfig
+
+	Tracing tracing.Config
+}
+
+// SMTPConfig is the SMTP server the messages are sent to with SMTPDelivery
+type SMTPConfig struct {
+	Host string
+	Port uint16
+	// Username is optional, the messages are sent without authentication if it's empty
+	Username string
+	Password string
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Host:            defaultHost,
+		Port:            defaultPort,
+		ShutdownTimeout: defaultShutdownTimeout,
+		DrainDelay:      defaultDrainDelay,
+		Delivery:        OutboxDelivery,
+		OutboxDir:       defaultOutboxDir,
+		TemplatesDir:    defaultTemplatesDir,
+		FromAddress:     defaultFromAddress,
+		SMTP: SMTPConfig{
+			Port: defaultSMTPPort,
+		},
+		Tracing: tracing.DefaultConfig(),
+	}
+}
+
+// Address is the address the REST API server listens on
+func (cfg *Config) Address() string {
+	return net.JoinHostPort(cfg.Host, strconv.Itoa(int(cfg.Port)))
+}
+
+var settings = append([]setting{
+	{
+		Key:         "host",
+		EnvVar:      "HOST",
+		Description: "IP the REST API server listens on",
+		Get:         func(cfg *Config) string { return cfg.Host },
+		Set:         func(cfg *Config, value string) error { cfg.Host = value; return nil },
+	},
+	{
+		Key:         "port",
+		EnvVar:      "PORT",
+		Description: "port the REST API server listens on",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.Port)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.Port = port
+			return err
+		},
+	},
+	{
+		Key:         "shutdown-timeout",
+		EnvVar:      "SHUTDOWN_TIMEOUT",
+		Description: "deadline to drain the in-flight requests when the service stops",
+		Get:         func(cfg *Config) string { return cfg.ShutdownTimeout.String() },
+		Set: func(cfg *Config, value string) error {
+			timeout, err := configloader.ParseDuration(value)
+			cfg.ShutdownTimeout = timeout
+			return err
+		},
+	},
+	{
+		Key:         "drain-delay",
+		EnvVar:      "DRAIN_DELAY",
+		Description: "time the service keeps serving after it stops reporting ready on shutdown, at least the readiness probe period",
+		Get:         func(cfg *Config) string { return cfg.DrainDelay.String() },
+		Set: func(cfg *Config, value string) error {
+			delay, err := configloader.ParseDuration(value)
+			cfg.DrainDelay = delay
+			return err
+		},
+	},
+	{
+		Key:         "delivery",
+		EnvVar:      "EMAIL_DELIVERY",
+		Description: fmt.Sprintf("how the messages are delivered, '%s' to write them in the outbox directory or '%s'", OutboxDelivery, SMTPDelivery),
+		Get:         func(cfg *Config) string { return cfg.Delivery },
+		Set:         func(cfg *Config, value string) error { cfg.Delivery = value; return nil },
+	},
+	{
+		Key:         "outbox-dir",
+		EnvVar:      "OUTBOX_DIR",
+		Description: fmt.Sprintf("directory of the .eml files of the messages, with the '%s' delivery", OutboxDelivery),
+		Get:         func(cfg *Config) string { return cfg.OutboxDir },
+		Set:         func(cfg *Config, value string) error { cfg.OutboxDir = value; return nil },
+	},
+	{
+		Key:         "templates-dir",
+		EnvVar:      "TEMPLATES_DIR",
+		Description: "directory of the templates of the messages",
+		Get:         func(cfg *Config) string { return cfg.TemplatesDir },
+		Set:         func(cfg *Config, value string) error { cfg.TemplatesDir = value; return nil },
+	},
+	{
+		Key:         "from-address",
+		EnvVar:      "FROM_ADDRESS",
+		Description: "sender of the messages",
+		Get:         func(cfg *Config) string { return cfg.FromAddress },
+		Set:         func(cfg *Config, value string) error { cfg.FromAddress = value; return nil },
+	},
+	{
+		Key:         "smtp-host",
+		EnvVar:      "SMTP_HOST",
+		Description: fmt.Sprintf("host of the SMTP server, with the '%s' delivery", SMTPDelivery),
+		Get:         func(cfg *Config) string { return cfg.SMTP.Host },
+		Set:         func(cfg *Config, value string) error { cfg.SMTP.Host = value; return nil },
+	},
+	{
+		Key:         "smtp-port",
+		EnvVar:      "SMTP_PORT",
+		Description: "port of the SMTP server",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.SMTP.Port)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.SMTP.Port = port
+			return err
+		},
+	},
+	{
+		Key:         "smtp-username",
+		EnvVar:      "SMTP_USERNAME",
+		Description: "username of the SMTP server, leave it empty to send without authentication",
+		Get:         func(cfg *Config) string { return cfg.SMTP.Username },
+		Set:         func(cfg *Config, value string) error { cfg.SMTP.Username = value; return nil },
+	},
+	{
+		Key:         "smtp-password",
+		EnvVar:      "SMTP_PASSWORD",
+		Description: "password of the SMTP server",
+		Secret:      true,
+		Get:         func(cfg *Config) string { return cfg.SMTP.Password },
+		Set:         func(cfg *Config, value string) error { cfg.SMTP.Password = value; return nil },
+	},
+}, tracing.Settings(func(cfg *Config) *tracing.Config { return &cfg.Tracing })...)
+
+func (cfg *Config) validate() []string {
+	var violations []string
+	if net.ParseIP(cfg.Host) == nil {
+		violations = append(violations, fmt.Sprintf("'host' must be an IP address, got '%s'", cfg.Host))
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		violations = append(violations, fmt.Sprintf("'shutdown-timeout' must be positive, got '%s'", cfg.ShutdownTimeout))
+	}
+	if cfg.DrainDelay < 0 {
+		violations = append(violations, fmt.Sprintf("'drain-delay' can't be negative, got '%s'", cfg.DrainDelay))
+	}
+	switch cfg.Delivery {
+	case OutboxDelivery:
+		if cfg.OutboxDir == "" {
+			violations = append(violations, fmt.Sprintf("'outbox-dir' is required with the '%s' delivery", OutboxDelivery))
+		}
+	case SMTPDelivery:
+		if cfg.SMTP.Host == "" {
+			violations = append(violations, fmt.Sprintf("'smtp-host' is required with the '%s' delivery", SMTPDelivery))
+		}
+	default:
+		violations = append(violations, fmt.Sprintf("'delivery' must be '%s' or '%s', got '%s'", OutboxDelivery, SMTPDelivery, cfg.Delivery))
+	}
+	if _, err := mail.ParseAddress(cfg.FromAddress); err != nil {
+		violations = append(violations, fmt.Sprintf("'from-address' must be an email address, got '%s'", cfg.FromAddress))
+	}
+	violations = append(violations, cfg.Tracing.Validate()...)
+	return violations
+}
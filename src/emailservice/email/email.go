@@ -0,0 +1,60 @@
+// Package email renders the emails sent to the customers and delivers them, to an SMTP server or to a local outbox
+package email
+
+import (
+	"context"
+	"net/mail"
+	"time"
+
+	"github.com/google/uuid"
+	emailservice_rest_types "github.com/kurtosis-tech/new-obd/src/emailservice/api/http_rest/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type Service struct {
+	renderer *Renderer
+	sender   Sender
+	// from is the sender of the emails, e.g. Online Boutique <no-reply@example.com>
+	from string
+
+	now func() time.Time
+}
+
+func NewService(renderer *Renderer, sender Sender, from string) *Service {
+	return &Service{
+		renderer: renderer,
+		sender:   sender,
+		from:     from,
+		now:      time.Now,
+	}
+}
+
+// Ping checks the sender of the emails
+func (s *Service) Ping(ctx context.Context) error {
+	return s.sender.Ping(ctx)
+}
+
+// SendOrderConfirmation renders the confirmation of the order and sends it to the customer
+func (s *Service) SendOrderConfirmation(ctx context.Context, request emailservice_rest_types.SendOrderConfirmationRequest) (*emailservice_rest_types.SendOrderConfirmationResponse, error) {
+	to := string(request.Email)
+	if _, err := mail.ParseAddress(to); err != nil {
+		return nil, newInvalidArgumentError("invalid email address '%s': %s", to, err)
+	}
+
+	message, err := s.renderer.OrderConfirmation(request.Order)
+	if err != nil {
+		return nil, err
+	}
+	message.ID = uuid.NewString()
+	message.From = s.from
+	message.To = to
+	message.Date = s.now()
+
+	if err := s.sender.Send(ctx, message); err != nil {
+		return nil, errors.Wrapf(err, "An error occurred sending the confirmation of order '%s'", request.Order.OrderId)
+	}
+
+	logrus.Infof("Sent the confirmation of order '%s', message '%s'", request.Order.OrderId, message.ID)
+	return &emailservice_rest_types.SendOrderConfirmationResponse{MessageId: message.ID}, nil
+}
@@ -0,0 +1,100 @@
+package email
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	emailservice_rest_types "github.com/kurtosis-tech/new-obd/src/emailservice/api/http_rest/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTemplatesDir = "../templates"
+	testFrom         = "Online Boutique <no-reply@example.com>"
+	testTo           = "someone@example.com"
+)
+
+var testNow = time.Date(2026, time.June, 15, 12, 30, 0, 0, time.UTC)
+
+func newTestOrder() emailservice_rest_types.Order {
+	state := "CA"
+	return emailservice_rest_types.Order{
+		OrderId:            "a1b2c3",
+		ShippingTrackingId: "OB-4K8M-X7M2Q9TZ",
+		ShippingCost:       emailservice_rest_types.Money{CurrencyCode: "EUR", Units: 8, Nanos: 990000000},
+		ShippingAddress: emailservice_rest_types.Address{
+			StreetAddress: "1600 Amphitheatre Parkway",
+			City:          "Mountain View",
+			State:         &state,
+			Country:       "United States",
+			ZipCode:       "94043",
+		},
+		Items: []emailservice_rest_types.OrderItem{
+			{
+				Item: emailservice_rest_types.CartItem{ProductId: "OLJCESPC7Z", Quantity: 2},
+				Cost: emailservice_rest_types.Money{CurrencyCode: "EUR", Units: 39, Nanos: 980000000},
+			},
+		},
+		TotalPaid: emailservice_rest_types.Money{CurrencyCode: "EUR", Units: 48, Nanos: 970000000},
+		PlacedAt:  testNow,
+	}
+}
+
+func newTestService(t *testing.T) (*Service, *Outbox) {
+	renderer, err := NewRenderer(testTemplatesDir)
+	require.NoError(t, err)
+	outbox, err := NewOutbox(t.TempDir())
+	require.NoError(t, err)
+	service := NewService(renderer, outbox, testFrom)
+	service.now = func() time.Time { return testNow }
+	return service, outbox
+}
+
+func TestSendOrderConfirmationWritesTheMessageToTheOutbox(t *testing.T) {
+	service, outbox := newTestService(t)
+
+	response, err := service.SendOrderConfirmation(context.Background(), emailservice_rest_types.SendOrderConfirmationRequest{
+		Email: testTo,
+		Order: newTestOrder(),
+	})
+	require.NoError(t, err)
+
+	message, err := outbox.Get(response.MessageId)
+	require.NoError(t, err)
+	require.Equal(t, response.MessageId, message.ID)
+	require.Equal(t, testFrom, message.From)
+	require.Equal(t, testTo, message.To)
+	require.Equal(t, "Your order a1b2c3 is confirmed", message.Subject)
+	require.True(t, testNow.Equal(message.Date))
+	for _, content := range []string{message.Text, message.HTML} {
+		require.Contains(t, content, "a1b2c3")
+		require.Contains(t, content, "OB-4K8M-X7M2Q9TZ")
+		require.Contains(t, content, "€48.97")
+		require.Contains(t, content, "€8.99")
+		require.Contains(t, content, "Mountain View, CA 94043")
+	}
+
+	messages, err := outbox.List()
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+}
+
+func TestSendOrderConfirmationRejectsAnInvalidAddress(t *testing.T) {
+	service, _ := newTestService(t)
+
+	_, err := service.SendOrderConfirmation(context.Background(), emailservice_rest_types.SendOrderConfirmationRequest{
+		Email: "not an address",
+		Order: newTestOrder(),
+	})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestOutboxGetReturnsNotFound(t *testing.T) {
+	_, outbox := newTestService(t)
+
+	for _, id := range []string{"0b9a5b8e-3f0e-4d43-8d8c-5f1bd1f6b0a1", "../config"} {
+		_, err := outbox.Get(id)
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+}
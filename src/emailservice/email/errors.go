@@ -0,0 +1,37 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of errors returned by the email service, check them with errors.Is
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrNotFound        = errors.New("not found")
+	// ErrUnavailable is a mail server that can't take the message, sending it again later may work
+	ErrUnavailable = errors.New("unavailable")
+)
+
+// emailError tags the cause with one of the error kinds, so callers can classify it without knowing which
+// check failed
+type emailError struct {
+	kind  error
+	cause error
+}
+
+func newEmailError(kind error, cause error) error {
+	return &emailError{kind: kind, cause: cause}
+}
+
+func newInvalidArgumentError(format string, args ...interface{}) error {
+	return newEmailError(ErrInvalidArgument, fmt.Errorf(format, args...))
+}
+
+func (e *emailError) Error() string {
+	return fmt.Sprintf("%s: %s", e.kind.Error(), e.cause.Error())
+}
+
+func (e *emailError) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
@@ -0,0 +1,141 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	messageIDDomain = "emailservice"
+
+	textContentType = "text/plain"
+	htmlContentType = "text/html"
+	charset         = "utf-8"
+)
+
+// Message is an email with a plain text and an HTML version of the same content
+type Message struct {
+	ID      string
+	From    string
+	To      string
+	Subject string
+	Date    time.Time
+	Text    string
+	HTML    string
+}
+
+// Bytes encodes the message in the Internet Message Format (RFC 5322), which is the content of an .eml file
+// and what's sent to the SMTP server
+func (m Message) Bytes() ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		// the last part is the preferred one, so the clients that can render the HTML show it
+		{textContentType, m.Text},
+		{htmlContentType, m.HTML},
+	} {
+		if err := writePart(parts, part.contentType, part.content); err != nil {
+			return nil, errors.Wrapf(err, "An error occurred encoding the %s part of message '%s'", part.contentType, m.ID)
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, errors.Wrapf(err, "An error occurred encoding message '%s'", m.ID)
+	}
+
+	var message bytes.Buffer
+	for _, header := range [][2]string{
+		{"Message-ID", fmt.Sprintf("<%s@%s>", m.ID, messageIDDomain)},
+		{"Date", m.Date.Format(time.RFC1123Z)},
+		{"From", m.From},
+		{"To", m.To},
+		{"Subject", mime.QEncoding.Encode(charset, m.Subject)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": parts.Boundary()})},
+	} {
+		fmt.Fprintf(&message, "%s: %s\r\n", header[0], header[1])
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
+
+func writePart(parts *multipart.Writer, contentType string, content string) error {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"charset": charset}))
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	partWriter, err := parts.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	encoder := quotedprintable.NewWriter(partWriter)
+	if _, err := io.WriteString(encoder, content); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// ParseMessage decodes a message encoded by Message.Bytes
+func ParseMessage(raw []byte) (*Message, error) {
+	parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred reading the message")
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred decoding the subject")
+	}
+	date, err := parsed.Header.Date()
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred reading the date")
+	}
+	messageID := strings.TrimSuffix(strings.Trim(parsed.Header.Get("Message-ID"), "<>"), "@"+messageIDDomain)
+	message := &Message{
+		ID:      messageID,
+		From:    parsed.Header.Get("From"),
+		To:      parsed.Header.Get("To"),
+		Subject: subject,
+		Date:    date,
+	}
+
+	_, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred reading the content type")
+	}
+	parts := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		// the quoted-printable parts are decoded by the reader
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "An error occurred reading the message parts")
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, errors.Wrap(err, "An error occurred reading a message part")
+		}
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch contentType {
+		case textContentType:
+			message.Text = string(content)
+		case htmlContentType:
+			message.HTML = string(content)
+		}
+	}
+	return message, nil
+}
@@ -0,0 +1,233 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	outboxFileExtension = ".eml"
+	outboxFileMode      = 0o644
+	outboxDirMode       = 0o755
+)
+
+// Sender delivers the messages
+type Sender interface {
+	Send(ctx context.Context, message Message) error
+	// Ping checks that the sender is able to deliver messages, it's used by the readiness check
+	Ping(ctx context.Context) error
+}
+
+// SMTPSender delivers the messages to an SMTP server, upgrading the connection with STARTTLS when the server supports it
+type SMTPSender struct {
+	host    string
+	address string
+	// auth is nil when there are no credentials
+	auth smtp.Auth
+}
+
+func NewSMTPSender(host string, port uint16, username string, password string) *SMTPSender {
+	sender := &SMTPSender{
+		host:    host,
+		address: net.JoinHostPort(host, strconv.Itoa(int(port))),
+	}
+	if username != "" {
+		sender.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return sender
+}
+
+func (s *SMTPSender) Send(ctx context.Context, message Message) error {
+	from, err := mail.ParseAddress(message.From)
+	if err != nil {
+		return newInvalidArgumentError("invalid sender '%s': %s", message.From, err)
+	}
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return newInvalidArgumentError("invalid recipient '%s': %s", message.To, err)
+	}
+	content, err := message.Bytes()
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return newEmailError(ErrUnavailable, errors.Wrapf(err, "An error occurred connecting to the SMTP server '%s'", s.address))
+	}
+	defer conn.Close()
+	if deadline, found := ctx.Deadline(); found {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return newEmailError(ErrUnavailable, err)
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return newEmailError(ErrUnavailable, errors.Wrapf(err, "An error occurred greeting the SMTP server '%s'", s.address))
+	}
+	defer client.Close()
+
+	if supported, _ := client.Extension("STARTTLS"); supported {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return newEmailError(ErrUnavailable, errors.Wrap(err, "An error occurred starting TLS"))
+		}
+	}
+	if s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			return newEmailError(ErrUnavailable, errors.Wrap(err, "An error occurred authenticating to the SMTP server"))
+		}
+	}
+	if err := client.Mail(from.Address); err != nil {
+		return smtpError(err, "An error occurred setting the sender")
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return smtpError(err, "An error occurred setting the recipient")
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return smtpError(err, "An error occurred starting the message")
+	}
+	if _, err := writer.Write(content); err != nil {
+		return newEmailError(ErrUnavailable, errors.Wrap(err, "An error occurred writing the message"))
+	}
+	if err := writer.Close(); err != nil {
+		return smtpError(err, "An error occurred sending the message")
+	}
+	return client.Quit()
+}
+
+// Ping doesn't connect to the SMTP server: each delivery checks it, and connecting on every readiness check would
+// only fill the server logs
+func (s *SMTPSender) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// smtpError makes the permanent rejections (5xx replies, e.g. an unknown mailbox) invalid arguments, since sending
+// the message again won't help
+func smtpError(err error, message string) error {
+	var replyErr *textproto.Error
+	if errors.As(err, &replyErr) && replyErr.Code >= 500 {
+		return newEmailError(ErrInvalidArgument, errors.Wrap(err, message))
+	}
+	return newEmailError(ErrUnavailable, errors.Wrap(err, message))
+}
+
+// Outbox writes each message as an .eml file in a directory instead of delivering it, so the messages can be checked
+// without a mail server
+type Outbox struct {
+	dir string
+}
+
+// NewOutbox creates the directory if it doesn't exist
+func NewOutbox(dir string) (*Outbox, error) {
+	if err := os.MkdirAll(dir, outboxDirMode); err != nil {
+		return nil, errors.Wrapf(err, "An error occurred creating the outbox directory '%s'", dir)
+	}
+	return &Outbox{dir: dir}, nil
+}
+
+func (o *Outbox) Send(ctx context.Context, message Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	content, err := message.Bytes()
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so the viewer never reads a partial message
+	file, err := os.CreateTemp(o.dir, "."+message.ID+"-*")
+	if err != nil {
+		return errors.Wrapf(err, "An error occurred creating the file of message '%s'", message.ID)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return errors.Wrapf(err, "An error occurred writing the file of message '%s'", message.ID)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(err, "An error occurred writing the file of message '%s'", message.ID)
+	}
+	if err := os.Chmod(file.Name(), outboxFileMode); err != nil {
+		return errors.Wrapf(err, "An error occurred setting the mode of the file of message '%s'", message.ID)
+	}
+	if err := os.Rename(file.Name(), o.path(message.ID)); err != nil {
+		return errors.Wrapf(err, "An error occurred moving message '%s' to the outbox", message.ID)
+	}
+	return nil
+}
+
+// Ping checks that the directory is still there, e.g. that the volume is mounted
+func (o *Outbox) Ping(ctx context.Context) error {
+	info, err := os.Stat(o.dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return errors.Errorf("the outbox '%s' is not a directory", o.dir)
+	}
+	return ctx.Err()
+}
+
+// List returns the messages in the outbox, the most recent first
+func (o *Outbox) List() ([]Message, error) {
+	entries, err := os.ReadDir(o.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "An error occurred listing the outbox '%s'", o.dir)
+	}
+
+	messages := []Message{}
+	for _, entry := range entries {
+		id, isMessage := strings.CutSuffix(entry.Name(), outboxFileExtension)
+		if !isMessage || entry.IsDir() {
+			continue
+		}
+		message, err := o.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, *message)
+	}
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Date.After(messages[j].Date)
+	})
+	return messages, nil
+}
+
+// Get returns the message, or an ErrNotFound if there's no message with the ID in the outbox
+func (o *Outbox) Get(id string) (*Message, error) {
+	// the IDs come from the URLs, only the ones that can't be a path are looked up
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, newEmailError(ErrNotFound, errors.Errorf("there's no message '%s' in the outbox", id))
+	}
+	content, err := os.ReadFile(o.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, newEmailError(ErrNotFound, errors.Errorf("there's no message '%s' in the outbox", id))
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "An error occurred reading message '%s'", id)
+	}
+	message, err := ParseMessage(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "An error occurred parsing message '%s'", id)
+	}
+	return message, nil
+}
+
+func (o *Outbox) path(id string) string {
+	return filepath.Join(o.dir, id+outboxFileExtension)
+}
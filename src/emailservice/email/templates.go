@@ -0,0 +1,91 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"path/filepath"
+	texttemplate "text/template"
+	"time"
+
+	emailservice_rest_types "github.com/kurtosis-tech/new-obd/src/emailservice/api/http_rest/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	orderConfirmationTemplateName = "order_confirmation"
+	orderConfirmationSubject      = "Your order %s is confirmed"
+
+	placedAtLayout = "January 2, 2006 at 15:04 MST"
+	centsPerNano   = 10000000
+)
+
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"CAD": "$",
+	"JPY": "¥",
+	"EUR": "€",
+	"TRY": "₺",
+	"GBP": "£",
+}
+
+// templateFuncs are the functions available in the templates
+var templateFuncs = map[string]interface{}{
+	"renderMoney":    renderMoney,
+	"renderPlacedAt": renderPlacedAt,
+}
+
+// Renderer renders the emails from the Go templates in a directory, each email has an .html and a .txt template
+type Renderer struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// NewRenderer parses the templates, so a broken template stops the service at startup instead of failing the orders
+func NewRenderer(templatesDir string) (*Renderer, error) {
+	htmlPath := filepath.Join(templatesDir, orderConfirmationTemplateName+".html")
+	html, err := htmltemplate.New(filepath.Base(htmlPath)).Funcs(templateFuncs).ParseFiles(htmlPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "An error occurred parsing the HTML template '%s'", htmlPath)
+	}
+
+	textPath := filepath.Join(templatesDir, orderConfirmationTemplateName+".txt")
+	text, err := texttemplate.New(filepath.Base(textPath)).Funcs(templateFuncs).ParseFiles(textPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "An error occurred parsing the text template '%s'", textPath)
+	}
+
+	return &Renderer{html: html, text: text}, nil
+}
+
+// OrderConfirmation returns the subject and the content of the confirmation of the order, the other fields of the
+// message are left to the caller
+func (r *Renderer) OrderConfirmation(order emailservice_rest_types.Order) (Message, error) {
+	var html bytes.Buffer
+	if err := r.html.Execute(&html, order); err != nil {
+		return Message{}, errors.Wrapf(err, "An error occurred rendering the HTML confirmation of order '%s'", order.OrderId)
+	}
+	var text bytes.Buffer
+	if err := r.text.Execute(&text, order); err != nil {
+		return Message{}, errors.Wrapf(err, "An error occurred rendering the text confirmation of order '%s'", order.OrderId)
+	}
+
+	return Message{
+		Subject: fmt.Sprintf(orderConfirmationSubject, order.OrderId),
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
+
+// renderMoney renders the amount like the frontend does, e.g. $48.97
+func renderMoney(money emailservice_rest_types.Money) string {
+	symbol, found := currencySymbols[money.CurrencyCode]
+	if !found {
+		symbol = currencySymbols["USD"]
+	}
+	return fmt.Sprintf("%s%d.%02d", symbol, money.Units, money.Nanos/centsPerNano)
+}
+
+func renderPlacedAt(placedAt time.Time) string {
+	return placedAt.UTC().Format(placedAtLayout)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/kurtosis-tech/new-obd/src/emailservice/email"
+	"github.com/pkg/errors"
+)
+
+// statusCodeForError returns the status code of the errors returned by the handlers, based on the email error kinds
+func statusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, email.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, email.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, email.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
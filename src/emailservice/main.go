@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	emailservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/emailservice/api/http_rest/server"
+	"github.com/kurtosis-tech/new-obd/src/emailservice/config"
+	"github.com/kurtosis-tech/new-obd/src/emailservice/email"
+	"github.com/kurtosis-tech/new-obd/src/libs/metrics"
+	"github.com/kurtosis-tech/new-obd/src/libs/rest"
+	"github.com/kurtosis-tech/new-obd/src/libs/shutdown"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const (
+	serviceName = "emailservice"
+
+	// the exit code of an invalid config, it follows the ones of the shutdown package
+	exitCodeInvalidConfig = 3
+)
+
+var (
+	defaultCORSOrigins = []string{"*"}
+	defaultCORSHeaders = []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept}
+)
+
+func main() {
+	cfg, commandLine, err := config.Load(os.Args[0], os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitCodeInvalidConfig)
+	}
+
+	if commandLine.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), serviceName, cfg.Tracing)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Info("Running REST API server...")
+
+	// This is how you set up a basic Echo router
+	echoRouter := echo.New()
+	echoRouter.HTTPErrorHandler = rest.NewResponseInfoHTTPErrorHandler(statusCodeForError)
+	// first, so the duration includes the other middlewares
+	echoRouter.Use(metrics.Middleware)
+	echoRouter.Use(otelecho.Middleware(serviceName, otelecho.WithSkipper(tracing.Skipper)))
+	echoRouter.Use(middleware.Logger())
+
+	echoRouter.Use(tracing.KardinalTraceIDMiddleware)
+
+	// CORS configuration
+	echoRouter.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: defaultCORSOrigins,
+		AllowHeaders: defaultCORSHeaders,
+	}))
+
+	requestValidatorMiddleware, err := rest.NewRequestValidatorMiddleware(emailservice_server_rest_server.GetSwagger, "")
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	echoRouter.Use(requestValidatorMiddleware)
+
+	emailService, outbox, err := newEmailService(cfg)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	server := NewServer(emailService)
+
+	metrics.RegisterHandler(echoRouter)
+	if outbox != nil {
+		registerOutboxViewer(echoRouter, outbox)
+	}
+
+	strictMiddlewares := []emailservice_server_rest_server.StrictMiddlewareFunc{rest.NewErrorMappingMiddleware(statusCodeForError)}
+	emailservice_server_rest_server.RegisterHandlers(echoRouter, emailservice_server_rest_server.NewStrictHandler(server, strictMiddlewares))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	exitCode := shutdown.ServeUntilShutdown(ctx, func() error {
+		return echoRouter.Start(cfg.Address())
+	}, echoRouter.Shutdown, shutdown.Options{
+		OnShutdownStart: server.MarkShuttingDown,
+		DrainDelay:      cfg.DrainDelay,
+		ShutdownTimeout: cfg.ShutdownTimeout,
+	})
+	stop()
+
+	shutdown.FlushTraces(shutdownTracing, cfg.ShutdownTimeout)
+	shutdown.FlushLogs()
+	os.Exit(exitCode)
+}
+
+// newEmailService returns the service and, with the outbox delivery, the outbox the messages are written to
+func newEmailService(cfg *config.Config) (*email.Service, *email.Outbox, error) {
+	renderer, err := email.NewRenderer(cfg.TemplatesDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.Delivery == config.SMTPDelivery {
+		logrus.Infof("Sending the emails to the SMTP server '%s'", cfg.SMTP.Host)
+		sender := email.NewSMTPSender(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password)
+		return email.NewService(renderer, sender, cfg.FromAddress), nil, nil
+	}
+
+	outbox, err := email.NewOutbox(cfg.OutboxDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	logrus.Infof("Writing the emails to the outbox '%s' instead of sending them, browse them at %s", cfg.OutboxDir, outboxPath)
+	return email.NewService(renderer, outbox, cfg.FromAddress), outbox, nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+
+	"github.com/kurtosis-tech/new-obd/src/emailservice/email"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	outboxPath            = "/outbox"
+	outboxMessagePath     = "/outbox/:id"
+	outboxMessageTextPath = "/outbox/:id/text"
+
+	outboxDateLayout = "2006-01-02 15:04:05 MST"
+)
+
+var outboxListTemplate = template.Must(template.New("outbox").Funcs(template.FuncMap{
+	"formatDate": func(message email.Message) string { return message.Date.UTC().Format(outboxDateLayout) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <title>Outbox</title>
+    <style>
+        body { font-family: Arial, Helvetica, sans-serif; margin: 24px; }
+        table { border-collapse: collapse; width: 100%; }
+        th, td { border-bottom: 1px solid #e0e0e0; padding: 8px; text-align: left; }
+    </style>
+</head>
+<body>
+    <h1>Outbox</h1>
+    {{ if . }}
+    <table>
+        <tr><th>Date</th><th>To</th><th>Subject</th><th></th></tr>
+        {{ range . }}
+        <tr>
+            <td>{{ formatDate . }}</td>
+            <td>{{ .To }}</td>
+            <td><a href="/outbox/{{ .ID }}">{{ .Subject }}</a></td>
+            <td><a href="/outbox/{{ .ID }}/text">text</a></td>
+        </tr>
+        {{ end }}
+    </table>
+    {{ else }}
+    <p>No messages were sent yet.</p>
+    {{ end }}
+</body>
+</html>
+`))
+
+// registerOutboxViewer serves the list of the messages in the outbox and their content, so the emails sent by a flow
+// can be checked in a browser without a mail server
+func registerOutboxViewer(echoRouter *echo.Echo, outbox *email.Outbox) {
+	echoRouter.GET(outboxPath, func(c echo.Context) error {
+		messages, err := outbox.List()
+		if err != nil {
+			return err
+		}
+		var page bytes.Buffer
+		if err := outboxListTemplate.Execute(&page, messages); err != nil {
+			return errors.Wrap(err, "An error occurred rendering the outbox")
+		}
+		return c.HTMLBlob(http.StatusOK, page.Bytes())
+	})
+
+	echoRouter.GET(outboxMessagePath, func(c echo.Context) error {
+		message, err := outbox.Get(c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.HTML(http.StatusOK, message.HTML)
+	})
+
+	echoRouter.GET(outboxMessageTextPath, func(c echo.Context) error {
+		message, err := outbox.Get(c.Param("id"))
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, message.Text)
+	})
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	emailservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/emailservice/api/http_rest/server"
+	emailservice_rest_types "github.com/kurtosis-tech/new-obd/src/emailservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/emailservice/email"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	healthStatusOk           = "ok"
+	healthStatusError        = "error"
+	healthStatusShuttingDown = "shutting down"
+
+	senderDependencyName = "email sender"
+
+	readinessCheckTimeout = 2 * time.Second
+)
+
+type Server struct {
+	Email *email.Service
+	// shuttingDown is a pointer so every copy of the server (the handlers have value receivers) sees the same flag
+	shuttingDown *atomic.Bool
+}
+
+func NewServer(emailService *email.Service) Server {
+	return Server{Email: emailService, shuttingDown: &atomic.Bool{}}
+}
+
+// MarkShuttingDown makes the readiness check fail, so the service stops receiving traffic while it shuts down
+func (s Server) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s Server) GetHealth(ctx context.Context, request emailservice_server_rest_server.GetHealthRequestObject) (emailservice_server_rest_server.GetHealthResponseObject, error) {
+
+	status := healthStatusOk
+	now := time.Now()
+
+	response := emailservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return emailservice_server_rest_server.GetHealth200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthLive(ctx context.Context, request emailservice_server_rest_server.GetHealthLiveRequestObject) (emailservice_server_rest_server.GetHealthLiveResponseObject, error) {
+	status := healthStatusOk
+	now := time.Now()
+
+	response := emailservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return emailservice_server_rest_server.GetHealthLive200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthReady(ctx context.Context, request emailservice_server_rest_server.GetHealthReadyRequestObject) (emailservice_server_rest_server.GetHealthReadyResponseObject, error) {
+	now := time.Now()
+
+	if s.shuttingDown.Load() {
+		status := healthStatusShuttingDown
+		response := emailservice_rest_types.HealthResponse{
+			Status:    &status,
+			Timestamp: &now,
+		}
+		return emailservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+
+	checks := []emailservice_rest_types.DependencyCheck{
+		checkDependency(ctx, senderDependencyName, s.Email.Ping),
+	}
+
+	status := healthStatusOk
+	for _, check := range checks {
+		if check.Status != healthStatusOk {
+			status = healthStatusError
+		}
+	}
+
+	response := emailservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+		Checks:    &checks,
+	}
+
+	if status != healthStatusOk {
+		return emailservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+	return emailservice_server_rest_server.GetHealthReady200JSONResponse(response), nil
+}
+
+func (s Server) PostOrderConfirmations(ctx context.Context, request emailservice_server_rest_server.PostOrderConfirmationsRequestObject) (emailservice_server_rest_server.PostOrderConfirmationsResponseObject, error) {
+	logrus.Infof("Order confirmation request - OrderID: %s", request.Body.Order.OrderId)
+	response, err := s.Email.SendOrderConfirmation(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return emailservice_server_rest_server.PostOrderConfirmations200JSONResponse(*response), nil
+}
+
+// checkDependency runs the check with the readiness timeout and reports its status and latency
+func checkDependency(ctx context.Context, name string, check func(ctx context.Context) error) emailservice_rest_types.DependencyCheck {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	dependencyCheck := emailservice_rest_types.DependencyCheck{
+		Name:      name,
+		Status:    healthStatusOk,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		logrus.Warnf("The readiness check of dependency '%s' failed. Error: %s", name, err)
+		errMsg := err.Error()
+		dependencyCheck.Status = healthStatusError
+		dependencyCheck.Error = &errMsg
+	}
+	return dependencyCheck
+}
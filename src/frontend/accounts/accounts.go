@@ -0,0 +1,137 @@
+// Package accounts registers the shoppers, checks their passwords and keeps their login sessions on the server side,
+// the browser only gets an opaque session token
+package accounts
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// The bounds of the password length, bcrypt ignores the bytes after the 72nd so longer passwords would be silently truncated
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+const sessionTokenBytes = 32
+
+// dummyPasswordHash is the bcrypt hash, with the default cost, of a random password nobody knows. The login of an
+// unknown email is checked against it, so it takes as long as the one of a registered email and the response time
+// doesn't tell which emails have an account.
+var dummyPasswordHash = []byte("$2a$10$duoOS8joi9XCjbnkPS9/EOMSCZEqM65v3atabAKuO5EeGCXVQbPWy")
+
+var (
+	ErrInvalidEmail       = errors.New("invalid email")
+	ErrInvalidPassword    = errors.New("invalid password")
+	ErrEmailTaken         = errors.New("email already registered")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrSessionNotFound    = errors.New("session not found")
+)
+
+// Account is a registered shopper, its ID owns the shopper's cart
+type Account struct {
+	ID        string
+	Email     string
+	CreatedAt time.Time
+
+	passwordHash []byte
+}
+
+// Session is a login session, the token is the only thing the browser gets
+type Session struct {
+	Token     string
+	AccountID string
+	ExpiresAt time.Time
+}
+
+// Store keeps the accounts and their login sessions, every implementation has to honor this contract:
+//   - the emails are unique, registering an email that already has an account is an ErrEmailTaken
+//   - a failed login is an ErrInvalidCredentials whether the email or the password is wrong
+//   - an expired session is an ErrSessionNotFound, like an unknown one
+//   - the methods are safe for concurrent use
+type Store interface {
+	// Register creates the account and logs it in
+	Register(ctx context.Context, email, password string) (*Account, *Session, error)
+	// Login checks the password and starts a new session
+	Login(ctx context.Context, email, password string) (*Account, *Session, error)
+	// Logout ends the session, it's a no-op if the session doesn't exist
+	Logout(ctx context.Context, token string) error
+	// AccountForSession returns the account logged in with the session
+	AccountForSession(ctx context.Context, token string) (*Account, error)
+	// SweepExpiredSessions deletes the expired sessions and returns how many were deleted
+	SweepExpiredSessions(ctx context.Context) (int64, error)
+}
+
+// newAccount validates the credentials and hashes the password, it's slow on purpose
+func newAccount(email, password string, now time.Time) (*Account, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return nil, errors.Wrapf(ErrInvalidPassword, "the password must have between %d and %d characters", MinPasswordLength, MaxPasswordLength)
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred hashing the password")
+	}
+
+	return &Account{
+		ID:           uuid.NewString(),
+		Email:        email,
+		CreatedAt:    now,
+		passwordHash: passwordHash,
+	}, nil
+}
+
+// checkPassword returns ErrInvalidCredentials unless the password is the one of the account, a nil account is an
+// unknown email, whose password is checked against the dummy hash anyway
+func checkPassword(account *Account, password string) error {
+	passwordHash := dummyPasswordHash
+	if account != nil {
+		passwordHash = account.passwordHash
+	}
+	if err := bcrypt.CompareHashAndPassword(passwordHash, []byte(password)); err != nil || account == nil {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+func newSession(accountID string, expiresAt time.Time) (*Session, error) {
+	tokenBytes := make([]byte, sessionTokenBytes)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, errors.Wrap(err, "An error occurred generating the session token")
+	}
+
+	return &Session{
+		Token:     base64.RawURLEncoding.EncodeToString(tokenBytes),
+		AccountID: accountID,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// hashSessionToken is what the stores keep instead of the token, so reading them doesn't give access to the sessions
+func hashSessionToken(token string) string {
+	tokenHash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(tokenHash[:])
+}
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return "", errors.Wrapf(ErrInvalidEmail, "'%s' is not a valid email address", email)
+	}
+	return email, nil
+}
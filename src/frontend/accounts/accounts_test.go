@@ -0,0 +1,157 @@
+package accounts
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	testEmail    = "shopper@example.com"
+	testPassword = "correct horse battery staple"
+
+	testSessionTTL = time.Hour
+
+	// the Postgres tests only run when this environment variable contains the DSN of a database they can write to
+	testPostgresDSNEnvVarKey = "FRONTEND_TEST_POSTGRES_DSN"
+)
+
+// forEachStore runs the test against every Store implementation, setNow moves the clock of the store
+func forEachStore(t *testing.T, test func(t *testing.T, store Store, setNow func(time.Time))) {
+	t.Run("memory", func(t *testing.T) {
+		store := NewMemory(testSessionTTL)
+		test(t, store, func(now time.Time) { store.now = func() time.Time { return now } })
+	})
+	t.Run("postgres", func(t *testing.T) {
+		store := newTestDb(t)
+		test(t, store, func(now time.Time) { store.now = func() time.Time { return now } })
+	})
+}
+
+func newTestDb(t *testing.T) *Db {
+	dsn := os.Getenv(testPostgresDSNEnvVarKey)
+	if dsn == "" {
+		t.Skipf("Skipping Postgres account store test because the '%s' environment variable is not set", testPostgresDSNEnvVarKey)
+	}
+
+	db, err := NewDb(dsn, "", "", "", "", "", testSessionTTL)
+	require.NoError(t, err)
+	require.NoError(t, db.MigrateUp(context.Background()))
+	// the sessions are deleted with their accounts, by the foreign key
+	require.NoError(t, db.db.Exec("DELETE FROM accounts.accounts").Error)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	return db
+}
+
+func TestRegisterLoginAndLogout(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store, _ func(time.Time)) {
+		ctx := context.Background()
+
+		account, registerSession, err := store.Register(ctx, " Shopper@Example.com ", testPassword)
+		require.NoError(t, err)
+		require.Equal(t, testEmail, account.Email)
+		require.NotEqual(t, testPassword, string(account.passwordHash))
+
+		loggedInAccount, loginSession, err := store.Login(ctx, testEmail, testPassword)
+		require.NoError(t, err)
+		require.Equal(t, account.ID, loggedInAccount.ID)
+		require.NotEqual(t, registerSession.Token, loginSession.Token)
+
+		sessionAccount, err := store.AccountForSession(ctx, loginSession.Token)
+		require.NoError(t, err)
+		require.Equal(t, account.ID, sessionAccount.ID)
+
+		require.NoError(t, store.Logout(ctx, loginSession.Token))
+		_, err = store.AccountForSession(ctx, loginSession.Token)
+		require.ErrorIs(t, err, ErrSessionNotFound)
+
+		// the other sessions of the account are still valid
+		_, err = store.AccountForSession(ctx, registerSession.Token)
+		require.NoError(t, err)
+	})
+}
+
+func TestRegisterRejectsInvalidAndDuplicatedAccounts(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store, _ func(time.Time)) {
+		ctx := context.Background()
+
+		_, _, err := store.Register(ctx, "not-an-email", testPassword)
+		require.ErrorIs(t, err, ErrInvalidEmail)
+		_, _, err = store.Register(ctx, testEmail, "short")
+		require.ErrorIs(t, err, ErrInvalidPassword)
+
+		_, _, err = store.Register(ctx, testEmail, testPassword)
+		require.NoError(t, err)
+		_, _, err = store.Register(ctx, "SHOPPER@example.com", testPassword)
+		require.ErrorIs(t, err, ErrEmailTaken)
+	})
+}
+
+func TestLoginDoesNotTellWhichCredentialIsWrong(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store, _ func(time.Time)) {
+		ctx := context.Background()
+
+		_, _, err := store.Register(ctx, testEmail, testPassword)
+		require.NoError(t, err)
+
+		_, _, err = store.Login(ctx, testEmail, "wrong password")
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+		_, _, err = store.Login(ctx, "unknown@example.com", testPassword)
+		require.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+}
+
+func TestUnknownEmailsAreCheckedAgainstTheDummyHash(t *testing.T) {
+	// the hash has to be a valid one with the default cost, or the login of an unknown email would return faster
+	require.ErrorIs(t, checkPassword(nil, testPassword), ErrInvalidCredentials)
+	cost, err := bcrypt.Cost(dummyPasswordHash)
+	require.NoError(t, err)
+	require.Equal(t, bcrypt.DefaultCost, cost)
+}
+
+func TestSessionsExpire(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store, setNow func(time.Time)) {
+		ctx := context.Background()
+		now := time.Now()
+		setNow(now)
+
+		_, session, err := store.Register(ctx, testEmail, testPassword)
+		require.NoError(t, err)
+
+		setNow(now.Add(testSessionTTL))
+		_, err = store.AccountForSession(ctx, session.Token)
+		require.ErrorIs(t, err, ErrSessionNotFound)
+	})
+}
+
+func TestSweepExpiredSessions(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store, setNow func(time.Time)) {
+		ctx := context.Background()
+		now := time.Now()
+		setNow(now)
+
+		_, expiredSession, err := store.Register(ctx, testEmail, testPassword)
+		require.NoError(t, err)
+		setNow(now.Add(testSessionTTL / 2))
+		_, validSession, err := store.Login(ctx, testEmail, testPassword)
+		require.NoError(t, err)
+
+		setNow(now.Add(testSessionTTL))
+		swept, err := store.SweepExpiredSessions(ctx)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), swept)
+
+		setNow(now)
+		_, err = store.AccountForSession(ctx, expiredSession.Token)
+		require.ErrorIs(t, err, ErrSessionNotFound)
+		_, err = store.AccountForSession(ctx, validSession.Token)
+		require.NoError(t, err)
+	})
+}
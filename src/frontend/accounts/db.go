@@ -0,0 +1,187 @@
+package accounts
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// uniqueViolationPgErrCode is the Postgres error code of a duplicated key, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const uniqueViolationPgErrCode = "23505"
+
+type accountRow struct {
+	ID           string `gorm:"primaryKey"`
+	Email        string
+	PasswordHash []byte
+	CreatedAt    time.Time
+}
+
+func (accountRow) TableName() string {
+	return "accounts.accounts"
+}
+
+type sessionRow struct {
+	TokenHash string `gorm:"primaryKey"`
+	AccountID string
+	ExpiresAt time.Time
+}
+
+func (sessionRow) TableName() string {
+	return "accounts.sessions"
+}
+
+// Db keeps the accounts and the sessions in Postgres, so they're shared by all the replicas of the frontend
+type Db struct {
+	db         *gorm.DB
+	sessionTTL time.Duration
+	now        func() time.Time
+	// Migrator applies the migrations embedded in the binary
+	*database.Migrator
+}
+
+func NewDb(
+	uri string,
+	host string,
+	username string,
+	password string,
+	name string,
+	port string,
+	sessionTTL time.Duration,
+) (*Db, error) {
+	db, err := database.Connect(database.DSN(uri, host, username, password, name, port))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Db{
+		db:         db,
+		sessionTTL: sessionTTL,
+		now:        time.Now,
+		Migrator:   database.NewMigrator(db, migrationsConfig),
+	}, nil
+}
+
+func (db *Db) Close() error {
+	return database.Close(db.db)
+}
+
+func (db *Db) Register(ctx context.Context, email, password string) (*Account, *Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	account, err := newAccount(email, password, db.now())
+	if err != nil {
+		return nil, nil, err
+	}
+	session, err := newSession(account.ID, db.now().Add(db.sessionTTL))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// in a transaction, so there's never an account that was registered but not logged in
+	err = db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(toAccountRow(account)).Error; err != nil {
+			return err
+		}
+		return tx.Create(toSessionRow(session)).Error
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationPgErrCode {
+		return nil, nil, errors.Wrapf(ErrEmailTaken, "there's already an account for '%s'", account.Email)
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "An error occurred storing the new account")
+	}
+	return account, session, nil
+}
+
+func (db *Db) Login(ctx context.Context, email, password string) (*Account, *Session, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	var rows []accountRow
+	if err := db.db.WithContext(ctx).Where("email = ?", email).Limit(1).Find(&rows).Error; err != nil {
+		return nil, nil, errors.Wrap(err, "An error occurred getting the account")
+	}
+	var account *Account
+	if len(rows) > 0 {
+		account = toAccount(rows[0])
+	}
+
+	if err := checkPassword(account, password); err != nil {
+		return nil, nil, err
+	}
+
+	session, err := newSession(account.ID, db.now().Add(db.sessionTTL))
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := db.db.WithContext(ctx).Create(toSessionRow(session)).Error; err != nil {
+		return nil, nil, errors.Wrap(err, "An error occurred storing the session")
+	}
+	return account, session, nil
+}
+
+func (db *Db) Logout(ctx context.Context, token string) error {
+	if err := db.db.WithContext(ctx).Where("token_hash = ?", hashSessionToken(token)).Delete(&sessionRow{}).Error; err != nil {
+		return errors.Wrap(err, "An error occurred deleting the session")
+	}
+	return nil
+}
+
+func (db *Db) AccountForSession(ctx context.Context, token string) (*Account, error) {
+	var rows []accountRow
+	err := db.db.WithContext(ctx).
+		Joins("JOIN accounts.sessions ON accounts.sessions.account_id = accounts.accounts.id").
+		Where("accounts.sessions.token_hash = ? AND accounts.sessions.expires_at > ?", hashSessionToken(token), db.now()).
+		Limit(1).
+		Find(&rows).Error
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred getting the account of the session")
+	}
+	if len(rows) == 0 {
+		return nil, ErrSessionNotFound
+	}
+	return toAccount(rows[0]), nil
+}
+
+func (db *Db) SweepExpiredSessions(ctx context.Context) (int64, error) {
+	result := db.db.WithContext(ctx).Where("expires_at <= ?", db.now()).Delete(&sessionRow{})
+	if result.Error != nil {
+		return 0, errors.Wrap(result.Error, "An error occurred deleting the expired sessions")
+	}
+	return result.RowsAffected, nil
+}
+
+func toAccountRow(account *Account) *accountRow {
+	return &accountRow{
+		ID:           account.ID,
+		Email:        account.Email,
+		PasswordHash: account.passwordHash,
+		CreatedAt:    account.CreatedAt,
+	}
+}
+
+func toAccount(row accountRow) *Account {
+	return &Account{
+		ID:           row.ID,
+		Email:        row.Email,
+		CreatedAt:    row.CreatedAt,
+		passwordHash: row.PasswordHash,
+	}
+}
+
+func toSessionRow(session *Session) *sessionRow {
+	return &sessionRow{
+		TokenHash: hashSessionToken(session.Token),
+		AccountID: session.AccountID,
+		ExpiresAt: session.ExpiresAt,
+	}
+}
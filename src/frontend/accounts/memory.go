@@ -0,0 +1,137 @@
+package accounts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Memory keeps the accounts and the sessions in memory, so they don't survive a restart and they aren't shared between
+// the replicas of the frontend
+type Memory struct {
+	sessionTTL time.Duration
+	now        func() time.Time
+
+	mu               sync.RWMutex
+	accountsByID     map[string]*Account
+	accountIDByEmail map[string]string
+	// sessions are indexed by the hash of their token
+	sessions map[string]*Session
+}
+
+func NewMemory(sessionTTL time.Duration) *Memory {
+	return &Memory{
+		sessionTTL:       sessionTTL,
+		now:              time.Now,
+		accountsByID:     map[string]*Account{},
+		accountIDByEmail: map[string]string{},
+		sessions:         map[string]*Session{},
+	}
+}
+
+func (s *Memory) Register(ctx context.Context, email, password string) (*Account, *Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	account, err := newAccount(email, password, s.now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.accountIDByEmail[account.Email]; found {
+		return nil, nil, errors.Wrapf(ErrEmailTaken, "there's already an account for '%s'", account.Email)
+	}
+	s.accountsByID[account.ID] = account
+	s.accountIDByEmail[account.Email] = account.ID
+
+	session, err := s.newSessionLocked(account.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return account, session, nil
+}
+
+func (s *Memory) Login(ctx context.Context, email, password string) (*Account, *Session, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, nil, ErrInvalidCredentials
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	s.mu.RLock()
+	account := s.accountsByID[s.accountIDByEmail[email]]
+	s.mu.RUnlock()
+
+	if err := checkPassword(account, password); err != nil {
+		return nil, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, err := s.newSessionLocked(account.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return account, session, nil
+}
+
+func (s *Memory) Logout(_ context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, hashSessionToken(token))
+	return nil
+}
+
+func (s *Memory) AccountForSession(ctx context.Context, token string) (*Account, error) {
+	s.mu.RLock()
+	session, found := s.sessions[hashSessionToken(token)]
+	var account *Account
+	if found {
+		account = s.accountsByID[session.AccountID]
+	}
+	s.mu.RUnlock()
+
+	if !found || account == nil {
+		return nil, ErrSessionNotFound
+	}
+	if !s.now().Before(session.ExpiresAt) {
+		if err := s.Logout(ctx, token); err != nil {
+			return nil, err
+		}
+		return nil, ErrSessionNotFound
+	}
+	return account, nil
+}
+
+func (s *Memory) SweepExpiredSessions(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	var swept int64
+	for tokenHash, session := range s.sessions {
+		if !now.Before(session.ExpiresAt) {
+			delete(s.sessions, tokenHash)
+			swept++
+		}
+	}
+	return swept, nil
+}
+
+func (s *Memory) newSessionLocked(accountID string) (*Session, error) {
+	session, err := newSession(accountID, s.now().Add(s.sessionTTL))
+	if err != nil {
+		return nil, err
+	}
+	s.sessions[hashSessionToken(session.Token)] = session
+	return session, nil
+}
@@ -0,0 +1,24 @@
+package accounts
+
+import (
+	"embed"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+)
+
+// random key shared by every frontend replica, so only one of them migrates the database at a time
+const migrationsAdvisoryLockKey int64 = 5_602_381_947_118_264_039
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+var migrationsConfig = database.MigrationsConfig{
+	FS:              migrationsFS,
+	Schema:          "accounts",
+	AdvisoryLockKey: migrationsAdvisoryLockKey,
+}
+
+// Migrations returns the migrations embedded in the binary sorted by version
+func Migrations() ([]database.Migration, error) {
+	return database.Migrations(migrationsFS)
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS accounts.sessions;
+DROP TABLE IF EXISTS accounts.accounts;
@@ -0,0 +1,31 @@
+package accounts
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RunSessionSweeper deletes the expired sessions of the store every interval until the context is done, the
+// sessions of the shoppers who never log out would pile up otherwise
+func RunSessionSweeper(ctx context.Context, store Store, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			swept, err := store.SweepExpiredSessions(ctx)
+			if err != nil {
+				logrus.Warnf("An error occurred sweeping the expired sessions. Error: %s", err)
+				continue
+			}
+			if swept > 0 {
+				logrus.Debugf("Swept %d expired sessions", swept)
+			}
+		}
+	}
+}
@@ -0,0 +1,522 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/configloader"
+	"github.com/kurtosis-tech/new-obd/src/libs/consts"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	"github.com/pkg/errors"
+)
+
+const (
+	PostgresAccountStore = "postgres"
+	MemoryAccountStore   = "memory"
+
+	defaultAddress = ":8070"
+
+	defaultShutdownTimeout = 15 * time.Second
+
+	defaultAccountSessionTTL           = 24 * time.Hour
+	defaultAccountSessionSweepInterval = 10 * time.Minute
+
+	defaultDatabasePort = "5432"
+
+	defaultCartServicePort           uint16 = 8090
+	defaultProductCatalogServicePort uint16 = 8070
+	defaultCheckoutServicePort       uint16 = 8060
+	defaultShippingServicePort       uint16 = 8040
+	defaultRecommendationServicePort uint16 = 8020
+	defaultAdServicePort             uint16 = 8010
+	defaultOrderServicePort          uint16 = 8000
+
+	headerListSeparator = ","
+)
+
+// defaultPropagatedHeaders are the headers forwarded to the backend services, the W3C trace context isn't
+// in the list because the tracing creates a new span for each outbound call
+var defaultPropagatedHeaders = []string{
+	consts.KardinalTraceIdHeaderKey,
+	consts.KardinalFlowIdHeaderKey,
+	consts.RequestIdHeaderKey,
+	consts.BaggageHeaderKey,
+}
+
+// Config is the configuration of the frontend, run it with --help to list the settings
+type Config struct {
+	// Address is the address the HTTP server listens on, e.g. ":8080"
+	Address string
+	// ShutdownTimeout is the deadline to drain the in-flight requests when the service stops
+	ShutdownTimeout time.Duration
+
+	CartServiceHost           string
+	CartServicePort           uint16
+	ProductCatalogServiceHost string
+	ProductCatalogServicePort uint16
+	CheckoutServiceHost       string
+	CheckoutServicePort       uint16
+	ShippingServiceHost       string
+	ShippingServicePort       uint16
+	RecommendationServiceHost string
+	RecommendationServicePort uint16
+	AdServiceHost             string
+	AdServicePort             uint16
+	OrderServiceHost          string
+	OrderServicePort          uint16
+
+	JsdelivrAPIKey string
+
+	CymbalBranding bool
+	// BannerColor illustrates canary deployments, it's the color of the banner in the home page
+	BannerColor string
+
+	// PropagatedHeaders are the headers of the inbound requests that are set on all the outbound ones
+	PropagatedHeaders []string
+
+	// DemoShopperID makes all the anonymous sessions share the cart and the order history of this shopper, e.g. the
+	// one of the Kardinal seed data. It's an opt-in for single-user demos, when it's empty every session has its own cart.
+	DemoShopperID string
+
+	// AccountStore selects the accounts.Store implementation, PostgresAccountStore or MemoryAccountStore
+	AccountStore     string
+	MigrateOnStartup bool
+	Database         DatabaseConfig
+	// AccountSessionTTL is how long the shoppers stay logged in
+	AccountSessionTTL time.Duration
+	// AccountSessionSweepInterval is how often the expired sessions are deleted
+	AccountSessionSweepInterval time.Duration
+
+	Tracing tracing.Config
+}
+
+// DatabaseConfig is the Postgres connection of the account store, the URI takes precedence over the other fields
+type DatabaseConfig struct {
+	URI      string
+	Host     string
+	Port     string
+	Username string
+	Password string
+	Name     string
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Address:                   defaultAddress,
+		ShutdownTimeout:           defaultShutdownTimeout,
+		CartServicePort:           defaultCartServicePort,
+		ProductCatalogServicePort: defaultProductCatalogServicePort,
+		CheckoutServicePort:       defaultCheckoutServicePort,
+		ShippingServicePort:       defaultShippingServicePort,
+		RecommendationServicePort: defaultRecommendationServicePort,
+		AdServicePort:             defaultAdServicePort,
+		OrderServicePort:          defaultOrderServicePort,
+		PropagatedHeaders:         defaultPropagatedHeaders,
+		AccountStore:              PostgresAccountStore,
+		MigrateOnStartup:          true,
+		Database: DatabaseConfig{
+			Port: defaultDatabasePort,
+		},
+		AccountSessionTTL:           defaultAccountSessionTTL,
+		AccountSessionSweepInterval: defaultAccountSessionSweepInterval,
+		Tracing:                     tracing.DefaultConfig(),
+	}
+}
+
+// CartServiceURL is the base URL of the cart service REST API
+func (cfg *Config) CartServiceURL() string {
+	return serviceURL(cfg.CartServiceHost, cfg.CartServicePort)
+}
+
+// ProductCatalogServiceURL is the base URL of the product catalog service REST API
+func (cfg *Config) ProductCatalogServiceURL() string {
+	return serviceURL(cfg.ProductCatalogServiceHost, cfg.ProductCatalogServicePort)
+}
+
+// CheckoutServiceURL is the base URL of the checkout service REST API
+func (cfg *Config) CheckoutServiceURL() string {
+	return serviceURL(cfg.CheckoutServiceHost, cfg.CheckoutServicePort)
+}
+
+// ShippingServiceURL is the base URL of the shipping service REST API
+func (cfg *Config) ShippingServiceURL() string {
+	return serviceURL(cfg.ShippingServiceHost, cfg.ShippingServicePort)
+}
+
+// RecommendationServiceURL is the base URL of the recommendation service REST API
+func (cfg *Config) RecommendationServiceURL() string {
+	return serviceURL(cfg.RecommendationServiceHost, cfg.RecommendationServicePort)
+}
+
+// AdServiceURL is the base URL of the ad service REST API
+func (cfg *Config) AdServiceURL() string {
+	return serviceURL(cfg.AdServiceHost, cfg.AdServicePort)
+}
+
+// OrderServiceURL is the base URL of the order service REST API
+func (cfg *Config) OrderServiceURL() string {
+	return serviceURL(cfg.OrderServiceHost, cfg.OrderServicePort)
+}
+
+func serviceURL(host string, port uint16) string {
+	return fmt.Sprintf("http://%s", net.JoinHostPort(host, strconv.Itoa(int(port))))
+}
+
+var settings = append([]setting{
+	{
+		Key:         "address",
+		EnvVar:      "ADDRESS",
+		Description: "address the HTTP server listens on",
+		Get:         func(cfg *Config) string { return cfg.Address },
+		Set:         func(cfg *Config, value string) error { cfg.Address = value; return nil },
+	},
+	{
+		Key:         "shutdown-timeout",
+		EnvVar:      "SHUTDOWN_TIMEOUT",
+		Description: "deadline to drain the in-flight requests when the service stops",
+		Get:         func(cfg *Config) string { return cfg.ShutdownTimeout.String() },
+		Set: func(cfg *Config, value string) error {
+			timeout, err := configloader.ParseDuration(value)
+			cfg.ShutdownTimeout = timeout
+			return err
+		},
+	},
+	{
+		Key:         "cart-service-host",
+		EnvVar:      "CARTSERVICEHOST",
+		Description: "host of the cart service",
+		Get:         func(cfg *Config) string { return cfg.CartServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.CartServiceHost = value; return nil },
+	},
+	{
+		Key:         "cart-service-port",
+		EnvVar:      "CARTSERVICEPORT",
+		Description: "port of the cart service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.CartServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.CartServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "product-catalog-service-host",
+		EnvVar:      "PRODUCTCATALOGSERVICEHOST",
+		Description: "host of the product catalog service",
+		Get:         func(cfg *Config) string { return cfg.ProductCatalogServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.ProductCatalogServiceHost = value; return nil },
+	},
+	{
+		Key:         "product-catalog-service-port",
+		EnvVar:      "PRODUCTCATALOGSERVICEPORT",
+		Description: "port of the product catalog service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.ProductCatalogServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.ProductCatalogServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "checkout-service-host",
+		EnvVar:      "CHECKOUTSERVICEHOST",
+		Description: "host of the checkout service",
+		Get:         func(cfg *Config) string { return cfg.CheckoutServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.CheckoutServiceHost = value; return nil },
+	},
+	{
+		Key:         "checkout-service-port",
+		EnvVar:      "CHECKOUTSERVICEPORT",
+		Description: "port of the checkout service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.CheckoutServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.CheckoutServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "shipping-service-host",
+		EnvVar:      "SHIPPINGSERVICEHOST",
+		Description: "host of the shipping service",
+		Get:         func(cfg *Config) string { return cfg.ShippingServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.ShippingServiceHost = value; return nil },
+	},
+	{
+		Key:         "shipping-service-port",
+		EnvVar:      "SHIPPINGSERVICEPORT",
+		Description: "port of the shipping service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.ShippingServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.ShippingServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "recommendation-service-host",
+		EnvVar:      "RECOMMENDATIONSERVICEHOST",
+		Description: "host of the recommendation service",
+		Get:         func(cfg *Config) string { return cfg.RecommendationServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.RecommendationServiceHost = value; return nil },
+	},
+	{
+		Key:         "recommendation-service-port",
+		EnvVar:      "RECOMMENDATIONSERVICEPORT",
+		Description: "port of the recommendation service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.RecommendationServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.RecommendationServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "ad-service-host",
+		EnvVar:      "ADSERVICEHOST",
+		Description: "host of the ad service",
+		Get:         func(cfg *Config) string { return cfg.AdServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.AdServiceHost = value; return nil },
+	},
+	{
+		Key:         "ad-service-port",
+		EnvVar:      "ADSERVICEPORT",
+		Description: "port of the ad service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.AdServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.AdServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "order-service-host",
+		EnvVar:      "ORDERSERVICEHOST",
+		Description: "host of the order service",
+		Get:         func(cfg *Config) string { return cfg.OrderServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.OrderServiceHost = value; return nil },
+	},
+	{
+		Key:         "order-service-port",
+		EnvVar:      "ORDERSERVICEPORT",
+		Description: "port of the order service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.OrderServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.OrderServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "jsdelivr-api-key",
+		EnvVar:      "JSDELIVRAPIKEY",
+		Description: "API key of the currency exchange rates API",
+		Secret:      true,
+		Get:         func(cfg *Config) string { return cfg.JsdelivrAPIKey },
+		Set:         func(cfg *Config, value string) error { cfg.JsdelivrAPIKey = value; return nil },
+	},
+	{
+		Key:         "cymbal-branding",
+		EnvVar:      "CYMBAL_BRANDING",
+		Description: "use the Cymbal Shops branding",
+		Get:         func(cfg *Config) string { return strconv.FormatBool(cfg.CymbalBranding) },
+		Set: func(cfg *Config, value string) error {
+			cymbalBranding, err := configloader.ParseBool(value)
+			cfg.CymbalBranding = cymbalBranding
+			return err
+		},
+	},
+	{
+		Key:         "banner-color",
+		EnvVar:      "BANNER_COLOR",
+		Description: "color of the banner in the home page, it illustrates canary deployments",
+		Get:         func(cfg *Config) string { return cfg.BannerColor },
+		Set:         func(cfg *Config, value string) error { cfg.BannerColor = value; return nil },
+	},
+	{
+		Key:         "propagated-headers",
+		EnvVar:      "PROPAGATED_HEADERS",
+		Description: "comma-separated headers of the inbound requests forwarded to the backend services",
+		Get:         func(cfg *Config) string { return strings.Join(cfg.PropagatedHeaders, headerListSeparator) },
+		Set: func(cfg *Config, value string) error {
+			headers, err := parseHeaderList(value)
+			cfg.PropagatedHeaders = headers
+			return err
+		},
+	},
+	{
+		Key:         "demo-shopper-id",
+		EnvVar:      "DEMO_SHOPPER_ID",
+		Description: "shopper whose cart and orders are shared by all the anonymous sessions, only for single-user demos (e.g. to show the seed data), empty to give each session its own cart",
+		Get:         func(cfg *Config) string { return cfg.DemoShopperID },
+		Set:         func(cfg *Config, value string) error { cfg.DemoShopperID = strings.TrimSpace(value); return nil },
+	},
+	{
+		Key:         "account-session-ttl",
+		EnvVar:      "ACCOUNT_SESSION_TTL",
+		Description: "how long the shoppers stay logged in",
+		Get:         func(cfg *Config) string { return cfg.AccountSessionTTL.String() },
+		Set: func(cfg *Config, value string) error {
+			ttl, err := configloader.ParseDuration(value)
+			cfg.AccountSessionTTL = ttl
+			return err
+		},
+	},
+	{
+		Key:         "account-session-sweep-interval",
+		EnvVar:      "ACCOUNT_SESSION_SWEEP_INTERVAL",
+		Description: "how often the expired login sessions are deleted",
+		Get:         func(cfg *Config) string { return cfg.AccountSessionSweepInterval.String() },
+		Set: func(cfg *Config, value string) error {
+			interval, err := configloader.ParseDuration(value)
+			cfg.AccountSessionSweepInterval = interval
+			return err
+		},
+	},
+	{
+		Key:         "account-store",
+		EnvVar:      "ACCOUNT_STORE",
+		Description: fmt.Sprintf("where the accounts and the login sessions are stored, '%s' or '%s'", PostgresAccountStore, MemoryAccountStore),
+		Get:         func(cfg *Config) string { return cfg.AccountStore },
+		Set:         func(cfg *Config, value string) error { cfg.AccountStore = value; return nil },
+	},
+	{
+		Key:         "db-migrate-on-startup",
+		EnvVar:      "DB_MIGRATE_ON_STARTUP",
+		Description: "apply the pending database migrations when the frontend starts",
+		Get:         func(cfg *Config) string { return strconv.FormatBool(cfg.MigrateOnStartup) },
+		Set: func(cfg *Config, value string) error {
+			migrateOnStartup, err := configloader.ParseBool(value)
+			cfg.MigrateOnStartup = migrateOnStartup
+			return err
+		},
+	},
+	{
+		Key:         "db-uri",
+		EnvVar:      "POSTGRES",
+		Description: "Postgres connection URI, it takes precedence over the other database settings",
+		Secret:      true,
+		Get:         func(cfg *Config) string { return cfg.Database.URI },
+		Set:         func(cfg *Config, value string) error { cfg.Database.URI = value; return nil },
+	},
+	{
+		Key:         "db-host",
+		EnvVar:      "DB_HOST",
+		Description: "Postgres host",
+		Get:         func(cfg *Config) string { return cfg.Database.Host },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Host = value; return nil },
+	},
+	{
+		Key:         "db-port",
+		EnvVar:      "DB_PORT",
+		Description: "Postgres port",
+		Get:         func(cfg *Config) string { return cfg.Database.Port },
+		Set: func(cfg *Config, value string) error {
+			if _, err := configloader.ParsePort(value); err != nil {
+				return err
+			}
+			cfg.Database.Port = value
+			return nil
+		},
+	},
+	{
+		Key:         "db-username",
+		EnvVar:      "DB_USERNAME",
+		Description: "Postgres user",
+		Get:         func(cfg *Config) string { return cfg.Database.Username },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Username = value; return nil },
+	},
+	{
+		Key:         "db-password",
+		EnvVar:      "DB_PASSWORD",
+		Description: "Postgres password",
+		Secret:      true,
+		Get:         func(cfg *Config) string { return cfg.Database.Password },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Password = value; return nil },
+	},
+	{
+		Key:         "db-name",
+		EnvVar:      "DB_NAME",
+		Description: "Postgres database",
+		Get:         func(cfg *Config) string { return cfg.Database.Name },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Name = value; return nil },
+	},
+}, tracing.Settings(func(cfg *Config) *tracing.Config { return &cfg.Tracing })...)
+
+func (cfg *Config) validate() []string {
+	var violations []string
+	if _, port, err := net.SplitHostPort(cfg.Address); err != nil {
+		violations = append(violations, fmt.Sprintf("'address' must be a host:port address, got '%s'", cfg.Address))
+	} else if _, err := configloader.ParsePort(port); err != nil {
+		violations = append(violations, fmt.Sprintf("'address' has an invalid port: %s", err))
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		violations = append(violations, fmt.Sprintf("'shutdown-timeout' must be positive, got '%s'", cfg.ShutdownTimeout))
+	}
+	if cfg.AccountSessionTTL <= 0 {
+		violations = append(violations, fmt.Sprintf("'account-session-ttl' must be positive, got '%s'", cfg.AccountSessionTTL))
+	}
+	if cfg.AccountSessionSweepInterval <= 0 {
+		violations = append(violations, fmt.Sprintf("'account-session-sweep-interval' must be positive, got '%s'", cfg.AccountSessionSweepInterval))
+	}
+	switch cfg.AccountStore {
+	case MemoryAccountStore:
+	case PostgresAccountStore:
+		if cfg.Database.URI == "" {
+			requiredSettings := []struct{ key, value string }{
+				{"db-host", cfg.Database.Host},
+				{"db-username", cfg.Database.Username},
+				{"db-name", cfg.Database.Name},
+			}
+			for _, required := range requiredSettings {
+				if required.value == "" {
+					violations = append(violations, fmt.Sprintf("'%s' is required when 'db-uri' isn't set", required.key))
+				}
+			}
+		}
+	default:
+		violations = append(violations, fmt.Sprintf("'account-store' must be '%s' or '%s', got '%s'", PostgresAccountStore, MemoryAccountStore, cfg.AccountStore))
+	}
+	if cfg.CartServiceHost == "" {
+		violations = append(violations, "'cart-service-host' is required")
+	}
+	if cfg.ProductCatalogServiceHost == "" {
+		violations = append(violations, "'product-catalog-service-host' is required")
+	}
+	if cfg.CheckoutServiceHost == "" {
+		violations = append(violations, "'checkout-service-host' is required")
+	}
+	if cfg.ShippingServiceHost == "" {
+		violations = append(violations, "'shipping-service-host' is required")
+	}
+	if cfg.RecommendationServiceHost == "" {
+		violations = append(violations, "'recommendation-service-host' is required")
+	}
+	if cfg.AdServiceHost == "" {
+		violations = append(violations, "'ad-service-host' is required")
+	}
+	if cfg.OrderServiceHost == "" {
+		violations = append(violations, "'order-service-host' is required")
+	}
+	violations = append(violations, cfg.Tracing.Validate()...)
+	return violations
+}
+
+func parseHeaderList(value string) ([]string, error) {
+	headers := []string{}
+	for _, header := range strings.Split(value, headerListSeparator) {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if strings.ContainsAny(header, " \t:") {
+			return nil, errors.Errorf("'%s' is not a valid header name", header)
+		}
+		headers = append(headers, textproto.CanonicalMIMEHeaderKey(header))
+	}
+	return headers, nil
+}
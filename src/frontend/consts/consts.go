@@ -1,5 +0,0 @@
-package consts
-
-const (
-	KardinalTraceIdHeaderKey = "X-Kardinal-Trace-Id"
-)
@@ -14,6 +14,11 @@ func NewService(primaryApi *currencyexternalapi.CurrencyAPI) *CurrencyExternalSe
 	return &CurrencyExternalService{primaryApi: primaryApi}
 }
 
+// CacheStats returns the hits and misses of the currency API responses cache
+func (s *CurrencyExternalService) CacheStats() currencyexternalapi.CacheStats {
+	return s.primaryApi.CacheStats()
+}
+
 func (s *CurrencyExternalService) GetSupportedCurrencies(ctx context.Context) ([]string, error) {
 
 	var (
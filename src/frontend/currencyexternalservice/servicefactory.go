@@ -1,12 +1,14 @@
 package currencyexternalservice
 
 import (
+	"net/http"
+
 	"github.com/kurtosis-tech/new-obd/src/currencyexternalapi"
 	"github.com/kurtosis-tech/new-obd/src/currencyexternalapi/config/jsdelivr"
 )
 
-func CreateService(apiKey string) *CurrencyExternalService {
-	primaryApi := currencyexternalapi.NewCurrencyAPI(jsdelivr.GetJsdelivrAPIConfig(apiKey))
+func CreateService(apiKey string, httpClient *http.Client) *CurrencyExternalService {
+	primaryApi := currencyexternalapi.NewCurrencyAPIWithHTTPClient(jsdelivr.GetJsdelivrAPIConfig(apiKey), httpClient)
 	service := NewService(primaryApi)
 	return service
 }
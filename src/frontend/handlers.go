@@ -15,33 +15,47 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"html/template"
 	"log"
 	"net/http"
-	"os"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
+	adservice_rest_client "github.com/kurtosis-tech/new-obd/src/adservice/api/http_rest/client"
+	adservice_rest_types "github.com/kurtosis-tech/new-obd/src/adservice/api/http_rest/types"
+	cartservice_rest_client "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/client"
 	cartservice_rest_types "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/types"
-	"github.com/kurtosis-tech/new-obd/src/frontend/consts"
+	checkoutservice_rest_client "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/client"
+	checkoutservice_rest_types "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/frontend/accounts"
 	"github.com/kurtosis-tech/new-obd/src/frontend/money"
+	orderservice_rest_client "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/client"
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+	productcatalogservice_rest_client "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/client"
 	productcatalogservice_rest_types "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/types"
+	recommendationservice_rest_client "github.com/kurtosis-tech/new-obd/src/recommendationservice/api/http_rest/client"
+	recommendationservice_rest_types "github.com/kurtosis-tech/new-obd/src/recommendationservice/api/http_rest/types"
+	shippingservice_rest_client "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/client"
+	shippingservice_rest_types "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/types"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
+// maxAddToCartQuantity is the largest quantity of the product page's dropdown
+const maxAddToCartQuantity = 10
+
 type platformDetails struct {
 	css      string
 	provider string
 }
 
 var (
-	isCymbalBrand = strings.ToLower(os.Getenv("CYMBAL_BRANDING")) == "true"
-	templates     = template.Must(template.New("").
+	templates = template.Must(template.New("").
 			Funcs(template.FuncMap{
 			"renderMoney":        renderMoney,
 			"renderCurrencyLogo": renderCurrencyLogo,
@@ -49,10 +63,6 @@ var (
 	plat platformDetails
 )
 
-const (
-	userID = "0494c5e0-dde0-48fa-a6d8-f7962f5476bf"
-)
-
 func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
 	currencies, err := fe.currencyService.GetSupportedCurrencies(r.Context())
 	if err != nil {
@@ -60,18 +70,19 @@ func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	setKardinalReqEditorFcn := getSetTraceIdHeaderRequestEditorFcn(r)
-
-	productResponse, err := fe.productCatalogService.GetProductsWithResponse(r.Context(), setKardinalReqEditorFcn)
+	productResponse, err := fe.productCatalogService.GetProductsWithResponse(r.Context())
 	if err != nil {
 		renderHTTPError(r, w, errors.Wrapf(err, "could not retrieve products"), http.StatusInternalServerError)
 		return
 	}
 	productsList := productResponse.JSON200
 
-	cartResponse, err := fe.cartService.GetCartUserIdWithResponse(r.Context(), userID, setKardinalReqEditorFcn)
+	cartResponse, err := fe.cartService.GetCartUserIdWithResponse(r.Context(), fe.shopperID(r))
+	if err == nil {
+		err = cartservice_rest_client.CheckResponse(cartResponse, cartResponse.JSONDefault)
+	}
 	if err != nil {
-		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve cart"), cartServiceErrorStatusCode(err))
 		return
 	}
 
@@ -100,16 +111,19 @@ func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
 
 	if err := templates.ExecuteTemplate(w, "home", map[string]interface{}{
 		"session_id":      sessionID(r),
+		"account_email":   accountEmail(r),
 		"request_id":      r.Context().Value(ctxKeyRequestID{}),
 		"user_currency":   currentCurrency(r),
 		"show_currency":   true,
 		"currencies":      currencies,
 		"products":        ps,
+		"recommendations": fe.getRecommendations(r, cartProductIDs(*cart.Items)),
+		"ad":              fe.getAd(r, cartCategories(*cart.Items, products)),
 		"cart_size":       cartSize(*cart.Items),
-		"banner_color":    os.Getenv("BANNER_COLOR"), // illustrates canary deployments
+		"banner_color":    fe.bannerColor, // illustrates canary deployments
 		"platform_css":    plat.css,
 		"platform_name":   plat.provider,
-		"is_cymbal_brand": isCymbalBrand,
+		"is_cymbal_brand": fe.isCymbalBrand,
 	}); err != nil {
 		logrus.Error(err)
 	}
@@ -122,10 +136,8 @@ func (fe *frontendServer) productHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	setKardinalReqEditorFcn := getSetTraceIdHeaderRequestEditorFcn(r)
-
 	fmt.Printf("product: %p\n", r.Context())
-	productResponse, err := fe.productCatalogService.GetProductsIdWithResponse(r.Context(), id, setKardinalReqEditorFcn)
+	productResponse, err := fe.productCatalogService.GetProductsIdWithResponse(r.Context(), id)
 	if err != nil {
 		renderHTTPError(r, w, errors.Wrapf(err, "could not retrieve product #%s", id), http.StatusInternalServerError)
 		return
@@ -138,9 +150,12 @@ func (fe *frontendServer) productHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	cartResponse, err := fe.cartService.GetCartUserIdWithResponse(r.Context(), userID, setKardinalReqEditorFcn)
+	cartResponse, err := fe.cartService.GetCartUserIdWithResponse(r.Context(), fe.shopperID(r))
+	if err == nil {
+		err = cartservice_rest_client.CheckResponse(cartResponse, cartResponse.JSONDefault)
+	}
 	if err != nil {
-		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve cart"), cartServiceErrorStatusCode(err))
 		return
 	}
 
@@ -168,15 +183,19 @@ func (fe *frontendServer) productHandler(w http.ResponseWriter, r *http.Request)
 
 	if err := templates.ExecuteTemplate(w, "product", map[string]interface{}{
 		"session_id":         sessionID(r),
+		"account_email":      accountEmail(r),
 		"request_id":         r.Context().Value(ctxKeyRequestID{}),
 		"user_currency":      currentCurrency(r),
 		"show_currency":      true,
 		"currencies":         currencies,
 		"product":            productInView,
+		"recommendations":    fe.getRecommendations(r, []string{id}),
+		"ad":                 fe.getAd(r, valueOrEmpty(productFromCatalog.Categories)),
+		"out_of_stock":       fe.isOutOfStock(r, id),
 		"cart_size":          cartSize(*cart.Items),
 		"platform_css":       plat.css,
 		"platform_name":      plat.provider,
-		"is_cymbal_brand":    isCymbalBrand,
+		"is_cymbal_brand":    fe.isCymbalBrand,
 		"is_present_feature": false,
 		"is_product":         isProduct,
 		"id":                 id,
@@ -188,34 +207,56 @@ func (fe *frontendServer) productHandler(w http.ResponseWriter, r *http.Request)
 func (fe *frontendServer) addToCartHandler(w http.ResponseWriter, r *http.Request) {
 	quantity, _ := strconv.ParseUint(r.FormValue("quantity"), 10, 32)
 	productID := r.FormValue("product_id")
-	if productID == "" || quantity == 0 {
+	if productID == "" || quantity == 0 || quantity > maxAddToCartQuantity {
 		renderHTTPError(r, w, errors.New("invalid form input"), http.StatusBadRequest)
 		return
 	}
 
-	setKardinalReqEditorFcn := getSetTraceIdHeaderRequestEditorFcn(r)
-
-	productResponse, err := fe.productCatalogService.GetProductsIdWithResponse(r.Context(), productID, setKardinalReqEditorFcn)
+	// the form is checked against the stock too, the page may be stale or the request not come from it. The unknown
+	// products are rejected by the product catalog service.
+	stockResponse, err := fe.productCatalogService.GetProductsIdStockWithResponse(r.Context(), productID)
+	if err == nil {
+		err = productcatalogservice_rest_client.CheckResponse(stockResponse, stockResponse.JSONDefault)
+	}
 	if err != nil {
-		renderHTTPError(r, w, errors.Wrapf(err, "could not retrieve product #%s", productID), http.StatusInternalServerError)
+		renderHTTPError(r, w, errors.Wrapf(err, "could not retrieve the stock of product #%s", productID), productCatalogServiceErrorStatusCode(err))
+		return
+	}
+	cartResponse, err := fe.cartService.GetCartUserIdWithResponse(r.Context(), fe.shopperID(r))
+	if err == nil {
+		err = cartservice_rest_client.CheckResponse(cartResponse, cartResponse.JSONDefault)
+	}
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve cart"), cartServiceErrorStatusCode(err))
+		return
+	}
+	// the cart merges the quantities of the same product, so the ones already in it count too
+	inCart := int64(0)
+	if cartResponse.JSON200.Items != nil {
+		for _, item := range *cartResponse.JSON200.Items {
+			if item.ProductId == productID {
+				inCart += int64(item.Quantity)
+			}
+		}
+	}
+	if available := int64(stockResponse.JSON200.Available); inCart+int64(quantity) > available {
+		renderHTTPError(r, w, errors.Errorf("only %d of product #%s are available and %d are already in the cart", available, productID, inCart), http.StatusConflict)
 		return
 	}
-	p := productResponse.JSON200
-
-	quantityInt32 := int32(quantity)
-	userId := userID
 
 	body := cartservice_rest_types.AddItemRequest{
-		Item: &cartservice_rest_types.CartItem{
-			ProductId: p.Id,
-			Quantity:  &quantityInt32,
+		Item: cartservice_rest_types.CartItem{
+			ProductId: productID,
+			Quantity:  int32(quantity),
 		},
-		UserId: &userId,
+		UserId: fe.shopperID(r),
 	}
-	postCartResponse, err := fe.cartService.PostCartWithResponse(r.Context(), body, setKardinalReqEditorFcn)
-	logrus.Infof("Post cart response status code: %d", postCartResponse.StatusCode())
-	if postCartResponse.StatusCode() != 200 || err != nil {
-		renderHTTPError(r, w, errors.Wrapf(err, "could not retrieve execute post cart request for product #%s", productID), http.StatusInternalServerError)
+	postCartResponse, err := fe.cartService.PostCartWithResponse(r.Context(), body)
+	if err == nil {
+		err = cartservice_rest_client.CheckResponse(postCartResponse, postCartResponse.JSONDefault)
+	}
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrapf(err, "could not add product #%s to the cart", productID), cartServiceErrorStatusCode(err))
 		return
 	}
 
@@ -224,29 +265,75 @@ func (fe *frontendServer) addToCartHandler(w http.ResponseWriter, r *http.Reques
 }
 
 func (fe *frontendServer) emptyCartHandler(w http.ResponseWriter, r *http.Request) {
-	setKardinalReqEditorFcn := getSetTraceIdHeaderRequestEditorFcn(r)
-
-	userId := userID
-	if _, err := fe.cartService.DeleteCartUserId(r.Context(), userId, setKardinalReqEditorFcn); err != nil {
-		renderHTTPError(r, w, errors.Wrap(err, "failed to empty cart"), http.StatusInternalServerError)
+	deleteCartResponse, err := fe.cartService.DeleteCartUserIdWithResponse(r.Context(), fe.shopperID(r))
+	if err == nil {
+		err = cartservice_rest_client.CheckResponse(deleteCartResponse, deleteCartResponse.JSONDefault)
+	}
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "failed to empty cart"), cartServiceErrorStatusCode(err))
 		return
 	}
 	w.Header().Set("location", "/")
 	w.WriteHeader(http.StatusFound)
 }
 
-func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request) {
-	setKardinalReqEditorFcn := getSetTraceIdHeaderRequestEditorFcn(r)
+func (fe *frontendServer) updateCartItemHandler(w http.ResponseWriter, r *http.Request) {
+	quantity, err := strconv.ParseUint(r.FormValue("quantity"), 10, 31)
+	productID := r.FormValue("product_id")
+	if productID == "" || err != nil {
+		renderHTTPError(r, w, errors.New("invalid form input"), http.StatusBadRequest)
+		return
+	}
+
+	body := cartservice_rest_types.UpdateItemRequest{
+		Quantity: int32(quantity),
+	}
+	putCartItemResponse, err := fe.cartService.PutCartUserIdItemsProductIdWithResponse(r.Context(), fe.shopperID(r), productID, body)
+	if err == nil {
+		err = cartservice_rest_client.CheckResponse(putCartItemResponse, putCartItemResponse.JSONDefault)
+	}
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrapf(err, "could not update the quantity of product #%s", productID), cartServiceErrorStatusCode(err))
+		return
+	}
+
+	w.Header().Set("location", "/cart")
+	w.WriteHeader(http.StatusFound)
+}
+
+func (fe *frontendServer) removeCartItemHandler(w http.ResponseWriter, r *http.Request) {
+	productID := r.FormValue("product_id")
+	if productID == "" {
+		renderHTTPError(r, w, errors.New("invalid form input"), http.StatusBadRequest)
+		return
+	}
+
+	deleteCartItemResponse, err := fe.cartService.DeleteCartUserIdItemsProductIdWithResponse(r.Context(), fe.shopperID(r), productID)
+	if err == nil {
+		err = cartservice_rest_client.CheckResponse(deleteCartItemResponse, deleteCartItemResponse.JSONDefault)
+	}
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrapf(err, "could not remove product #%s from the cart", productID), cartServiceErrorStatusCode(err))
+		return
+	}
+
+	w.Header().Set("location", "/cart")
+	w.WriteHeader(http.StatusFound)
+}
 
+func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request) {
 	currencies, err := fe.currencyService.GetSupportedCurrencies(r.Context())
 	if err != nil {
 		renderHTTPError(r, w, errors.Wrapf(err, "error retrieving currencies"), http.StatusInternalServerError)
 		return
 	}
 
-	cartResponse, err := fe.cartService.GetCartUserIdWithResponse(r.Context(), userID, setKardinalReqEditorFcn)
+	cartResponse, err := fe.cartService.GetCartUserIdWithResponse(r.Context(), fe.shopperID(r))
+	if err == nil {
+		err = cartservice_rest_client.CheckResponse(cartResponse, cartResponse.JSONDefault)
+	}
 	if err != nil {
-		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve cart"), cartServiceErrorStatusCode(err))
 		return
 	}
 
@@ -271,24 +358,24 @@ func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request
 	cartItems := *cart.Items
 
 	for i, item := range cartItems {
-		productResponse, err := fe.productCatalogService.GetProductsIdWithResponse(r.Context(), *item.ProductId, setKardinalReqEditorFcn)
+		productResponse, err := fe.productCatalogService.GetProductsIdWithResponse(r.Context(), item.ProductId)
 		if err != nil {
-			renderHTTPError(r, w, errors.Wrapf(err, "could not retrieve product #%s", *item.ProductId), http.StatusInternalServerError)
+			renderHTTPError(r, w, errors.Wrapf(err, "could not retrieve product #%s", item.ProductId), http.StatusInternalServerError)
 			return
 		}
 		p := productResponse.JSON200
 		price, err := fe.currencyService.Convert(r.Context(), *p.PriceUsd.CurrencyCode, *p.PriceUsd.Units, *p.PriceUsd.Nanos, currentCurrency(r))
 		if err != nil {
-			renderHTTPError(r, w, errors.Wrapf(err, "could not convert currency for product #%s", *item.ProductId), http.StatusInternalServerError)
+			renderHTTPError(r, w, errors.Wrapf(err, "could not convert currency for product #%s", item.ProductId), http.StatusInternalServerError)
 			return
 		}
 
 		logrus.Debugf("Price is %+v", price)
 
-		multPrice := money.MultiplySlow(price, uint32(*item.Quantity))
+		multPrice := money.MultiplySlow(price, uint32(item.Quantity))
 
 		prod := *p
-		quan := *item.Quantity
+		quan := item.Quantity
 
 		items[i] = cartItemView{
 			Item:     prod,
@@ -299,25 +386,485 @@ func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request
 		totalPrice = money.Must(money.Sum(totalPrice, multPrice))
 	}
 
+	// the address isn't known yet, so the quote doesn't include the international surcharge
+	quoteResponse, err := fe.shippingService.PostQuoteWithResponse(r.Context(), shippingservice_rest_types.QuoteRequest{
+		Items: toShippingItems(cartItems),
+	})
+	if err == nil {
+		err = shippingservice_rest_client.CheckResponse(quoteResponse, quoteResponse.JSONDefault)
+	}
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "failed to get shipping quote"), shippingServiceErrorStatusCode(err))
+		return
+	}
+	quote := quoteResponse.JSON200.CostUsd
+	shippingCost, err := fe.currencyService.Convert(r.Context(), quote.CurrencyCode, quote.Units, quote.Nanos, currentCurrency(r))
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "failed to convert currency for shipping cost"), http.StatusInternalServerError)
+		return
+	}
+	totalPrice = money.Must(money.Sum(totalPrice, shippingCost))
+
 	year := time.Now().Year()
 	if err := templates.ExecuteTemplate(w, "cart", map[string]interface{}{
 		"session_id":       sessionID(r),
+		"account_email":    accountEmail(r),
 		"request_id":       r.Context().Value(ctxKeyRequestID{}),
 		"user_currency":    currentCurrency(r),
 		"currencies":       currencies,
 		"cart_size":        cartSize(*cart.Items),
 		"show_currency":    true,
+		"shipping_cost":    shippingCost,
 		"total_cost":       totalPrice,
 		"items":            items,
+		"recommendations":  fe.getRecommendations(r, cartProductIDs(cartItems)),
 		"expiration_years": []int{year, year + 1, year + 2, year + 3, year + 4},
 		"platform_css":     plat.css,
 		"platform_name":    plat.provider,
-		"is_cymbal_brand":  isCymbalBrand,
+		"is_cymbal_brand":  fe.isCymbalBrand,
+	}); err != nil {
+		log.Println(err)
+	}
+}
+
+func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Request) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	log.Debug("placing order")
+
+	ccMonth, monthErr := strconv.ParseInt(r.FormValue("credit_card_expiration_month"), 10, 32)
+	ccYear, yearErr := strconv.ParseInt(r.FormValue("credit_card_expiration_year"), 10, 32)
+	if monthErr != nil || yearErr != nil {
+		renderHTTPError(r, w, errors.New("invalid credit card expiration date"), http.StatusBadRequest)
+		return
+	}
+
+	var state *string
+	if value := r.FormValue("state"); value != "" {
+		state = &value
+	}
+	body := checkoutservice_rest_types.PlaceOrderRequest{
+		UserId:       fe.shopperID(r),
+		UserCurrency: currentCurrency(r),
+		Email:        openapi_types.Email(r.FormValue("email")),
+		Address: checkoutservice_rest_types.Address{
+			StreetAddress: r.FormValue("street_address"),
+			City:          r.FormValue("city"),
+			State:         state,
+			Country:       r.FormValue("country"),
+			ZipCode:       r.FormValue("zip_code"),
+		},
+		CreditCard: checkoutservice_rest_types.CreditCard{
+			// the card number is often typed in groups
+			CreditCardNumber:          strings.NewReplacer(" ", "", "-", "").Replace(r.FormValue("credit_card_number")),
+			CreditCardCvv:             r.FormValue("credit_card_cvv"),
+			CreditCardExpirationMonth: int32(ccMonth),
+			CreditCardExpirationYear:  int32(ccYear),
+		},
+	}
+	orderResponse, err := fe.checkoutService.PostOrdersWithResponse(r.Context(), body)
+	if err == nil {
+		err = checkoutservice_rest_client.CheckResponse(orderResponse, orderResponse.JSONDefault)
+	}
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "failed to complete the order"), checkoutServiceErrorStatusCode(err))
+		return
+	}
+	order := orderResponse.JSON200
+	log.WithField("order", order.OrderId).Info("order placed")
+
+	orderProductIDs := make([]string, 0, len(order.Items))
+	for _, item := range order.Items {
+		orderProductIDs = append(orderProductIDs, item.Item.ProductId)
+	}
+
+	// the order is placed already, so the page doesn't depend on anything else, e.g. the currencies aren't listed
+	if err := templates.ExecuteTemplate(w, "order", map[string]interface{}{
+		"session_id":      sessionID(r),
+		"account_email":   accountEmail(r),
+		"request_id":      r.Context().Value(ctxKeyRequestID{}),
+		"user_currency":   currentCurrency(r),
+		"show_currency":   false,
+		"order":           order,
+		"total_paid":      toProductCatalogMoney(order.TotalPaid),
+		"recommendations": fe.getRecommendations(r, orderProductIDs),
+		"platform_css":    plat.css,
+		"platform_name":   plat.provider,
+		"is_cymbal_brand": fe.isCymbalBrand,
+	}); err != nil {
+		log.Println(err)
+	}
+}
+
+// orderSummaryView is an order of the order history, its total converted into the user's currency
+type orderSummaryView struct {
+	Order     orderservice_rest_types.Order
+	ItemCount int
+	TotalPaid *productcatalogservice_rest_types.Money
+}
+
+// orderLineView is a line of an order, with the cost it was bought at converted into the user's currency
+type orderLineView struct {
+	ProductID string
+	Name      string
+	Picture   string
+	Quantity  int32
+	Cost      *productcatalogservice_rest_types.Money
+}
+
+func (fe *frontendServer) ordersHandler(w http.ResponseWriter, r *http.Request) {
+	currencies, err := fe.currencyService.GetSupportedCurrencies(r.Context())
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrapf(err, "error retrieving currencies"), http.StatusInternalServerError)
+		return
+	}
+
+	ordersResponse, err := fe.orderService.GetOrdersWithResponse(r.Context(), &orderservice_rest_types.GetOrdersParams{UserId: fe.shopperID(r)})
+	if err == nil {
+		err = orderservice_rest_client.CheckResponse(ordersResponse, ordersResponse.JSONDefault)
+	}
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve orders"), orderServiceErrorStatusCode(err))
+		return
+	}
+
+	orders := make([]orderSummaryView, 0, len(ordersResponse.JSON200.Orders))
+	for _, order := range ordersResponse.JSON200.Orders {
+		totalPaid, err := fe.convertOrderMoney(r, order.TotalPaid)
+		if err != nil {
+			renderHTTPError(r, w, errors.Wrapf(err, "could not convert currency for order #%s", order.OrderId), http.StatusInternalServerError)
+			return
+		}
+		orders = append(orders, orderSummaryView{
+			Order:     order,
+			ItemCount: orderItemCount(order),
+			TotalPaid: totalPaid,
+		})
+	}
+
+	if err := templates.ExecuteTemplate(w, "orders", map[string]interface{}{
+		"session_id":      sessionID(r),
+		"account_email":   accountEmail(r),
+		"request_id":      r.Context().Value(ctxKeyRequestID{}),
+		"user_currency":   currentCurrency(r),
+		"currencies":      currencies,
+		"show_currency":   true,
+		"orders":          orders,
+		"platform_css":    plat.css,
+		"platform_name":   plat.provider,
+		"is_cymbal_brand": fe.isCymbalBrand,
+	}); err != nil {
+		log.Println(err)
+	}
+}
+
+func (fe *frontendServer) orderHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if id == "" {
+		renderHTTPError(r, w, errors.New("order id not specified"), http.StatusBadRequest)
+		return
+	}
+
+	currencies, err := fe.currencyService.GetSupportedCurrencies(r.Context())
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrapf(err, "error retrieving currencies"), http.StatusInternalServerError)
+		return
+	}
+
+	orderResponse, err := fe.orderService.GetOrdersOrderIdWithResponse(r.Context(), id)
+	if err == nil {
+		err = orderservice_rest_client.CheckResponse(orderResponse, orderResponse.JSONDefault)
+	}
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrapf(err, "could not retrieve order #%s", id), orderServiceErrorStatusCode(err))
+		return
+	}
+	order := orderResponse.JSON200
+	// the orders of the other shoppers don't exist as far as this one is concerned
+	if order.UserId != fe.shopperID(r) {
+		renderHTTPError(r, w, errors.Errorf("could not retrieve order #%s", id), http.StatusNotFound)
+		return
+	}
+
+	lines := make([]orderLineView, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		cost, err := fe.convertOrderMoney(r, line.Cost)
+		if err != nil {
+			renderHTTPError(r, w, errors.Wrapf(err, "could not convert currency for product #%s", line.ProductId), http.StatusInternalServerError)
+			return
+		}
+		lineView := orderLineView{
+			ProductID: line.ProductId,
+			Name:      line.ProductId,
+			Quantity:  line.Quantity,
+			Cost:      cost,
+		}
+		// the order was placed with the product, the history is still shown if it's no longer in the catalog
+		if product := fe.getOrderedProduct(r, line.ProductId); product != nil {
+			lineView.Name = valueOrEmpty(product.Name)
+			lineView.Picture = valueOrEmpty(product.Picture)
+		}
+		lines = append(lines, lineView)
+	}
+	shippingCost, err := fe.convertOrderMoney(r, order.ShippingCost)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "failed to convert currency for shipping cost"), http.StatusInternalServerError)
+		return
+	}
+	totalPaid, err := fe.convertOrderMoney(r, order.TotalPaid)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "failed to convert currency for total paid"), http.StatusInternalServerError)
+		return
+	}
+
+	if err := templates.ExecuteTemplate(w, "order_details", map[string]interface{}{
+		"session_id":      sessionID(r),
+		"account_email":   accountEmail(r),
+		"request_id":      r.Context().Value(ctxKeyRequestID{}),
+		"user_currency":   currentCurrency(r),
+		"currencies":      currencies,
+		"show_currency":   true,
+		"order":           order,
+		"lines":           lines,
+		"shipping_cost":   shippingCost,
+		"total_paid":      totalPaid,
+		"platform_css":    plat.css,
+		"platform_name":   plat.provider,
+		"is_cymbal_brand": fe.isCymbalBrand,
 	}); err != nil {
 		log.Println(err)
 	}
 }
 
+// convertOrderMoney converts an amount of an order, in the currency it was paid in, into the user's currency
+func (fe *frontendServer) convertOrderMoney(r *http.Request, m orderservice_rest_types.Money) (*productcatalogservice_rest_types.Money, error) {
+	return fe.currencyService.Convert(r.Context(), m.CurrencyCode, m.Units, m.Nanos, currentCurrency(r))
+}
+
+// getOrderedProduct returns the product of an order line, nil if the product catalog doesn't have it
+func (fe *frontendServer) getOrderedProduct(r *http.Request, productID string) *productcatalogservice_rest_types.Product {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+
+	productResponse, err := fe.productCatalogService.GetProductsIdWithResponse(r.Context(), productID)
+	if err != nil {
+		log.WithError(err).Warnf("could not retrieve ordered product #%s", productID)
+		return nil
+	}
+	product := productResponse.JSON200
+	if product == nil || product.Id == nil {
+		return nil
+	}
+	return product
+}
+
+// getRecommendations returns the products recommended with the given ones, the page is rendered without
+// recommendations if the recommendation service fails
+func (fe *frontendServer) getRecommendations(r *http.Request, productIDs []string) []recommendationservice_rest_types.Product {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+
+	response, err := fe.recommendationService.PostRecommendationsWithResponse(r.Context(), recommendationservice_rest_types.ListRecommendationsRequest{
+		ProductIds: productIDs,
+	})
+	if err == nil {
+		err = recommendationservice_rest_client.CheckResponse(response, response.JSONDefault)
+	}
+	if err != nil {
+		log.WithError(err).Warn("could not retrieve recommendations")
+		return nil
+	}
+	return response.JSON200.Products
+}
+
+// adView is the ad rendered by the text_ad template, its link goes through the frontend so the click is counted
+type adView struct {
+	Text        string
+	RedirectUrl string
+}
+
+// getAd returns an ad for the given categories, the page is rendered without an ad if the ad service fails
+func (fe *frontendServer) getAd(r *http.Request, categories []string) *adView {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+
+	response, err := fe.adService.GetAdsWithResponse(r.Context(), &adservice_rest_types.GetAdsParams{
+		Categories: &categories,
+	})
+	if err == nil {
+		err = adservice_rest_client.CheckResponse(response, response.JSONDefault)
+	}
+	if err != nil {
+		log.WithError(err).Warn("could not retrieve ads")
+		return nil
+	}
+	if len(response.JSON200.Ads) == 0 {
+		return nil
+	}
+	ad := response.JSON200.Ads[0]
+	return &adView{
+		Text:        ad.Text,
+		RedirectUrl: "/ad/" + url.PathEscape(ad.Id),
+	}
+}
+
+// isOutOfStock tells whether the product can't be added to the cart, it's shown in stock if the product catalog service
+// fails since the checkout rejects the products that aren't
+func (fe *frontendServer) isOutOfStock(r *http.Request, productID string) bool {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+
+	response, err := fe.productCatalogService.GetProductsIdStockWithResponse(r.Context(), productID)
+	if err == nil {
+		err = productcatalogservice_rest_client.CheckResponse(response, response.JSONDefault)
+	}
+	if err != nil {
+		log.WithError(err).Warn("could not retrieve the product stock")
+		return false
+	}
+	return !response.JSON200.InStock
+}
+
+// adClickHandler counts the click on the ad and redirects to the page it advertises
+func (fe *frontendServer) adClickHandler(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	response, err := fe.adService.GetAdsIdClickWithResponse(r.Context(), id)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrapf(err, "could not track the click on ad #%s", id), http.StatusInternalServerError)
+		return
+	}
+	if response.StatusCode() != http.StatusFound {
+		err = adservice_rest_client.CheckResponse(response, response.JSONDefault)
+		if err == nil {
+			err = errors.Errorf("ad service responded with status code %d instead of a redirect", response.StatusCode())
+		}
+		renderHTTPError(r, w, errors.Wrapf(err, "could not track the click on ad #%s", id), adServiceErrorStatusCode(err))
+		return
+	}
+	// the ads only advertise the pages of the shop, any other location would make the frontend an open redirect
+	location := response.HTTPResponse.Header.Get("Location")
+	if !isRelativePath(location) {
+		renderHTTPError(r, w, errors.Errorf("ad #%s redirects to '%s', which is not a page of the shop", id, location), http.StatusBadGateway)
+		return
+	}
+	http.Redirect(w, r, location, http.StatusFound)
+}
+
+// isRelativePath returns true if the location is a path of this host, without a scheme or a host. The browsers read the
+// locations starting with '//' or '/\' as URLs of another host
+func isRelativePath(location string) bool {
+	if !strings.HasPrefix(location, "/") || strings.HasPrefix(location, "//") || strings.HasPrefix(location, "/\\") {
+		return false
+	}
+	parsed, err := url.Parse(location)
+	if err != nil {
+		return false
+	}
+	return parsed.Scheme == "" && parsed.Host == "" && parsed.User == nil
+}
+
+func (fe *frontendServer) loginPageHandler(w http.ResponseWriter, r *http.Request) {
+	fe.renderLogin(w, r, http.StatusOK, "", "")
+}
+
+func (fe *frontendServer) loginHandler(w http.ResponseWriter, r *http.Request) {
+	email := r.FormValue("email")
+	account, session, err := fe.accounts.Login(r.Context(), email, r.FormValue("password"))
+	if errors.Is(err, accounts.ErrInvalidCredentials) {
+		fe.renderLogin(w, r, http.StatusUnauthorized, email, "The e-mail address or the password is wrong.")
+		return
+	}
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not log in"), http.StatusInternalServerError)
+		return
+	}
+
+	fe.startAccountSession(w, r, account, session)
+}
+
+func (fe *frontendServer) registerHandler(w http.ResponseWriter, r *http.Request) {
+	account, session, err := fe.accounts.Register(r.Context(), r.FormValue("email"), r.FormValue("password"))
+	switch {
+	case errors.Is(err, accounts.ErrInvalidEmail), errors.Is(err, accounts.ErrInvalidPassword):
+		fe.renderLogin(w, r, http.StatusBadRequest, "", err.Error())
+		return
+	case errors.Is(err, accounts.ErrEmailTaken):
+		// the same answer as the other rejected registrations, so the form doesn't tell which emails have an account
+		fe.renderLogin(w, r, http.StatusBadRequest, "", "The account could not be created, if you already have one sign in instead.")
+		return
+	case err != nil:
+		renderHTTPError(r, w, errors.Wrap(err, "could not create the account"), http.StatusInternalServerError)
+		return
+	}
+
+	fe.startAccountSession(w, r, account, session)
+}
+
+// startAccountSession sets the session cookie and moves the cart the shopper filled while anonymous into the account's cart
+func (fe *frontendServer) startAccountSession(w http.ResponseWriter, r *http.Request, account *accounts.Account, session *accounts.Session) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieAccountSession,
+		Value:    session.Token,
+		Path:     "/",
+		Expires:  session.ExpiresAt,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	// the demo shopper's cart is shared by all the anonymous sessions, it's not this session's to take
+	if fe.demoShopperID == "" {
+		mergeResponse, err := fe.cartService.PostCartUserIdMergeWithResponse(r.Context(), account.ID, cartservice_rest_types.MergeCartRequest{
+			SourceUserId: sessionID(r),
+		})
+		if err == nil {
+			err = cartservice_rest_client.CheckResponse(mergeResponse, mergeResponse.JSONDefault)
+		}
+		if err != nil {
+			// the shopper is logged in anyway, the items stay in the anonymous cart of the session
+			log.WithError(err).Warn("could not merge the anonymous cart into the account's cart")
+		}
+	}
+
+	w.Header().Set("location", "/")
+	w.WriteHeader(http.StatusFound)
+}
+
+func (fe *frontendServer) logoutHandler(w http.ResponseWriter, r *http.Request) {
+	if c, err := r.Cookie(cookieAccountSession); err == nil {
+		if err := fe.accounts.Logout(r.Context(), c.Value); err != nil {
+			renderHTTPError(r, w, errors.Wrap(err, "could not log out"), http.StatusInternalServerError)
+			return
+		}
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieAccountSession,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	w.Header().Set("location", "/")
+	w.WriteHeader(http.StatusFound)
+}
+
+// renderLogin renders the sign in and registration forms, with the error of the previous attempt if there's one
+func (fe *frontendServer) renderLogin(w http.ResponseWriter, r *http.Request, code int, email string, loginError string) {
+	w.WriteHeader(code)
+	if err := templates.ExecuteTemplate(w, "login", map[string]interface{}{
+		"session_id":          sessionID(r),
+		"account_email":       accountEmail(r),
+		"request_id":          r.Context().Value(ctxKeyRequestID{}),
+		"email":               email,
+		"login_error":         loginError,
+		"min_password_length": accounts.MinPasswordLength,
+		"max_password_length": accounts.MaxPasswordLength,
+		"platform_css":        plat.css,
+		"platform_name":       plat.provider,
+		"is_cymbal_brand":     fe.isCymbalBrand,
+	}); err != nil {
+		logrus.Error(err)
+	}
+}
+
 func (fe *frontendServer) setCurrencyHandler(w http.ResponseWriter, r *http.Request) {
 	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
 	cur := r.FormValue("currency_code")
@@ -376,6 +923,31 @@ func sessionID(r *http.Request) string {
 	return ""
 }
 
+// shopperID is the owner of the cart of the request: the logged in account, or the session unless all the
+// anonymous sessions share the demo shopper
+func (fe *frontendServer) shopperID(r *http.Request) string {
+	if account := currentAccount(r); account != nil {
+		return account.ID
+	}
+	if fe.demoShopperID != "" {
+		return fe.demoShopperID
+	}
+	return sessionID(r)
+}
+
+// currentAccount returns the account logged in with the request, nil if it's anonymous
+func currentAccount(r *http.Request) *accounts.Account {
+	account, _ := r.Context().Value(ctxKeyAccount{}).(*accounts.Account)
+	return account
+}
+
+func accountEmail(r *http.Request) string {
+	if account := currentAccount(r); account != nil {
+		return account.Email
+	}
+	return ""
+}
+
 func currentCurrency(r *http.Request) string {
 	c, _ := r.Cookie(cookieCurrency)
 	if c != nil {
@@ -408,31 +980,172 @@ func renderHTTPError(r *http.Request, w http.ResponseWriter, err error, code int
 	w.WriteHeader(code)
 
 	if templateErr := templates.ExecuteTemplate(w, "error", map[string]interface{}{
-		"session_id":  sessionID(r),
-		"request_id":  r.Context().Value(ctxKeyRequestID{}),
-		"error":       errMsg,
-		"status_code": code,
-		"status":      http.StatusText(code),
+		"session_id":    sessionID(r),
+		"account_email": accountEmail(r),
+		"request_id":    r.Context().Value(ctxKeyRequestID{}),
+		"error":         errMsg,
+		"status_code":   code,
+		"status":        http.StatusText(code),
 	}); templateErr != nil {
 		log.Println(templateErr)
 	}
 }
 
-func cartSize(c []cartservice_rest_types.CartItem) int {
-	cartSize := 0
+// cartServiceErrorStatusCode returns the status code to render for an error returned by the cart service
+func cartServiceErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, cartservice_rest_client.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, cartservice_rest_client.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, cartservice_rest_client.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// productCatalogServiceErrorStatusCode returns the status code to render for an error returned by the product catalog
+// service
+func productCatalogServiceErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, productcatalogservice_rest_client.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, productcatalogservice_rest_client.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, productcatalogservice_rest_client.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// adServiceErrorStatusCode returns the status code to render for an error returned by the ad service
+func adServiceErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, adservice_rest_client.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, adservice_rest_client.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// checkoutServiceErrorStatusCode returns the status code to render for an error returned by the checkout service
+func checkoutServiceErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, checkoutservice_rest_client.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, checkoutservice_rest_client.ErrPaymentDeclined):
+		return http.StatusPaymentRequired
+	case errors.Is(err, checkoutservice_rest_client.ErrOutOfStock):
+		return http.StatusConflict
+	case errors.Is(err, checkoutservice_rest_client.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// orderServiceErrorStatusCode returns the status code to render for an error returned by the order service
+func orderServiceErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, orderservice_rest_client.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, orderservice_rest_client.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, orderservice_rest_client.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// shippingServiceErrorStatusCode returns the status code to render for an error returned by the shipping service
+func shippingServiceErrorStatusCode(err error) int {
+	switch {
+	case errors.Is(err, shippingservice_rest_client.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, shippingservice_rest_client.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// toShippingItems converts the cart items into the items quoted by the shipping service
+func toShippingItems(cartItems []cartservice_rest_types.CartItem) []shippingservice_rest_types.CartItem {
+	shippingItems := make([]shippingservice_rest_types.CartItem, 0, len(cartItems))
+	for _, item := range cartItems {
+		shippingItems = append(shippingItems, shippingservice_rest_types.CartItem{
+			ProductId: item.ProductId,
+			Quantity:  item.Quantity,
+		})
+	}
+	return shippingItems
+}
+
+// toProductCatalogMoney converts the amounts returned by the checkout service into the type renderMoney takes
+func toProductCatalogMoney(m checkoutservice_rest_types.Money) productcatalogservice_rest_types.Money {
+	return productcatalogservice_rest_types.Money{
+		CurrencyCode: &m.CurrencyCode,
+		Units:        &m.Units,
+		Nanos:        &m.Nanos,
+	}
+}
+
+func cartProductIDs(c []cartservice_rest_types.CartItem) []string {
+	productIDs := make([]string, 0, len(c))
 	for _, item := range c {
-		cartSize += int(*item.Quantity)
+		productIDs = append(productIDs, item.ProductId)
 	}
-	return cartSize
+	return productIDs
 }
 
-func getSetTraceIdHeaderRequestEditorFcn(upsTreamRequest *http.Request) func(ctx context.Context, req *http.Request) error {
-	traceID := upsTreamRequest.Header.Get(consts.KardinalTraceIdHeaderKey)
+// cartCategories returns the categories of the products in the cart, without duplicates
+func cartCategories(c []cartservice_rest_types.CartItem, products []productcatalogservice_rest_types.Product) []string {
+	inCart := make(map[string]struct{}, len(c))
+	for _, item := range c {
+		inCart[item.ProductId] = struct{}{}
+	}
+	seen := map[string]struct{}{}
+	categories := []string{}
+	for _, p := range products {
+		if _, found := inCart[*p.Id]; !found {
+			continue
+		}
+		for _, category := range valueOrEmpty(p.Categories) {
+			if _, found := seen[category]; !found {
+				seen[category] = struct{}{}
+				categories = append(categories, category)
+			}
+		}
+	}
+	return categories
+}
 
-	setKardinalReqEditorFcn := func(ctx context.Context, req *http.Request) error {
-		req.Header.Set(consts.KardinalTraceIdHeaderKey, traceID)
-		return nil
+func valueOrEmpty[T any](value *T) T {
+	var empty T
+	if value == nil {
+		return empty
 	}
+	return *value
+}
 
-	return setKardinalReqEditorFcn
+// orderItemCount returns the number of items bought in the order, like cartSize for a cart
+func orderItemCount(order orderservice_rest_types.Order) int {
+	count := 0
+	for _, line := range order.Lines {
+		count += int(line.Quantity)
+	}
+	return count
+}
+
+func cartSize(c []cartservice_rest_types.CartItem) int {
+	cartSize := 0
+	for _, item := range c {
+		cartSize += int(item.Quantity)
+	}
+	return cartSize
 }
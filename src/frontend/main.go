@@ -1,39 +1,108 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	adservice_rest_client "github.com/kurtosis-tech/new-obd/src/adservice/api/http_rest/client"
 	cartservice_rest_client "github.com/kurtosis-tech/new-obd/src/cartservice/api/http_rest/client"
+	checkoutservice_rest_client "github.com/kurtosis-tech/new-obd/src/checkoutservice/api/http_rest/client"
+	"github.com/kurtosis-tech/new-obd/src/frontend/accounts"
+	"github.com/kurtosis-tech/new-obd/src/frontend/config"
 	"github.com/kurtosis-tech/new-obd/src/frontend/currencyexternalservice"
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+	"github.com/kurtosis-tech/new-obd/src/libs/headerpropagation"
+	"github.com/kurtosis-tech/new-obd/src/libs/metrics"
+	"github.com/kurtosis-tech/new-obd/src/libs/shutdown"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	orderservice_rest_client "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/client"
 	productcatalogservice_rest_client "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/client"
+	recommendationservice_rest_client "github.com/kurtosis-tech/new-obd/src/recommendationservice/api/http_rest/client"
+	shippingservice_rest_client "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/client"
 
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 )
 
 const (
 	name = "frontend"
 
+	// the exit code of an invalid config, it follows the ones of the shutdown package
+	exitCodeInvalidConfig = 3
+
 	defaultCurrency = "USD"
 	cookieMaxAge    = 60 * 60 * 48
 
 	cookiePrefix    = "shop_"
 	cookieSessionID = cookiePrefix + "session-id"
 	cookieCurrency  = cookiePrefix + "currency"
+	// cookieAccountSession holds the token of the login session, the session itself is kept on the server
+	cookieAccountSession = cookiePrefix + "account-session"
 )
 
 type ctxKeySessionID struct{}
+type ctxKeyAccount struct{}
 
 type frontendServer struct {
 	cartService           *cartservice_rest_client.ClientWithResponses
 	productCatalogService *productcatalogservice_rest_client.ClientWithResponses
+	checkoutService       *checkoutservice_rest_client.ClientWithResponses
+	shippingService       *shippingservice_rest_client.ClientWithResponses
+	recommendationService *recommendationservice_rest_client.ClientWithResponses
+	adService             *adservice_rest_client.ClientWithResponses
+	orderService          *orderservice_rest_client.ClientWithResponses
 	currencyService       *currencyexternalservice.CurrencyExternalService
+	accounts              accounts.Store
+
+	isCymbalBrand bool
+	bannerColor   string
+
+	// demoShopperID is the owner of all the carts when it's set, see config.Config.DemoShopperID
+	demoShopperID string
 }
 
 func main() {
+	cfg, commandLine, err := config.Load(os.Args[0], os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitCodeInvalidConfig)
+	}
+
+	if commandLine.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+
+	if len(commandLine.Args) > 0 {
+		if commandLine.Args[0] != database.MigrateCommandName {
+			fmt.Fprintf(os.Stderr, "unknown command '%s', the only command is '%s'\n", commandLine.Args[0], database.MigrateCommandName)
+			os.Exit(exitCodeInvalidConfig)
+		}
+		if err := runMigrateCommand(cfg, commandLine.Args[1:]); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), name, cfg.Tracing)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
 	log := logrus.New()
 	log.Level = logrus.DebugLevel
 	log.Formatter = &logrus.JSONFormatter{
@@ -46,48 +115,153 @@ func main() {
 	}
 	log.Out = os.Stdout
 
-	cartServiceHost := os.Getenv("CARTSERVICEHOST")
-	productCatalogServiceHost := os.Getenv("PRODUCTCATALOGSERVICEHOST")
-
-	cartServiceServer := fmt.Sprintf("http://%s:8090", cartServiceHost)
-	productCatalogServiceServer := fmt.Sprintf("http://%s:8070", productCatalogServiceHost)
-
-	cartServiceClient, err := cartservice_rest_client.NewClientWithResponses(cartServiceServer, cartservice_rest_client.WithHTTPClient(&http.Client{}))
+	cartServiceClient, err := cartservice_rest_client.NewClientWithResponses(cfg.CartServiceURL(), cartservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(cartServiceName)))
 	if err != nil {
 		logrus.Fatal("An error occurred creating cart service client!\nError was: %s", err)
 	}
 
-	productCatalogServiceClient, err := productcatalogservice_rest_client.NewClientWithResponses(productCatalogServiceServer, productcatalogservice_rest_client.WithHTTPClient(&http.Client{}))
+	productCatalogServiceClient, err := productcatalogservice_rest_client.NewClientWithResponses(cfg.ProductCatalogServiceURL(), productcatalogservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(productCatalogServiceName)))
 	if err != nil {
 		logrus.Fatal("An error occurred creating cart service client!\nError was: %s", err)
 	}
 
-	apiKey := os.Getenv("JSDELIVRAPIKEY")
+	checkoutServiceClient, err := checkoutservice_rest_client.NewClientWithResponses(cfg.CheckoutServiceURL(), checkoutservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(checkoutServiceName)))
+	if err != nil {
+		logrus.Fatalf("An error occurred creating checkout service client!\nError was: %s", err)
+	}
+
+	shippingServiceClient, err := shippingservice_rest_client.NewClientWithResponses(cfg.ShippingServiceURL(), shippingservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(shippingServiceName)))
+	if err != nil {
+		logrus.Fatalf("An error occurred creating shipping service client!\nError was: %s", err)
+	}
+
+	recommendationServiceClient, err := recommendationservice_rest_client.NewClientWithResponses(cfg.RecommendationServiceURL(), recommendationservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(recommendationServiceName)))
+	if err != nil {
+		logrus.Fatalf("An error occurred creating recommendation service client!\nError was: %s", err)
+	}
+
+	// the click endpoint of the ad service redirects to the advertised page, it's the browser that follows it
+	adServiceHTTPClient := metrics.NewInstrumentedHTTPClient(adServiceName)
+	adServiceHTTPClient.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	adServiceClient, err := adservice_rest_client.NewClientWithResponses(cfg.AdServiceURL(), adservice_rest_client.WithHTTPClient(adServiceHTTPClient))
+	if err != nil {
+		logrus.Fatalf("An error occurred creating ad service client!\nError was: %s", err)
+	}
+
+	orderServiceClient, err := orderservice_rest_client.NewClientWithResponses(cfg.OrderServiceURL(), orderservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(orderServiceName)))
+	if err != nil {
+		logrus.Fatalf("An error occurred creating order service client!\nError was: %s", err)
+	}
+
+	currencyService := currencyexternalservice.CreateService(cfg.JsdelivrAPIKey, metrics.NewInstrumentedHTTPClient(currencyAPIName))
+	registerCurrencyCacheMetrics(currencyService)
+
+	accountStore, err := newAccountStore(cfg)
+	if err != nil {
+		logrus.Fatal(err)
+	}
 
 	svc := &frontendServer{
 		cartService:           cartServiceClient,
 		productCatalogService: productCatalogServiceClient,
-		currencyService:       currencyexternalservice.CreateService(apiKey),
+		checkoutService:       checkoutServiceClient,
+		shippingService:       shippingServiceClient,
+		recommendationService: recommendationServiceClient,
+		adService:             adServiceClient,
+		orderService:          orderServiceClient,
+		currencyService:       currencyService,
+		accounts:              accountStore,
+		isCymbalBrand:         cfg.CymbalBranding,
+		bannerColor:           cfg.BannerColor,
+		demoShopperID:         cfg.DemoShopperID,
+	}
+	if cfg.DemoShopperID != "" {
+		logrus.Warnf("All the anonymous sessions share the cart and the orders of the demo shopper '%s', unset it unless this is a single-user demo", cfg.DemoShopperID)
 	}
 
 	r := mux.NewRouter()
 	r.HandleFunc("/", svc.homeHandler).Methods(http.MethodGet, http.MethodHead)
-	r.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir("./static/"))))
+	r.PathPrefix(staticPathPrefix).Handler(http.StripPrefix(staticPathPrefix, http.FileServer(http.Dir("./static/"))))
 	r.HandleFunc("/product/{id}", svc.productHandler).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/ad/{id}", svc.adClickHandler).Methods(http.MethodGet)
 	r.HandleFunc("/cart", svc.addToCartHandler).Methods(http.MethodPost)
 	r.HandleFunc("/cart", svc.viewCartHandler).Methods(http.MethodGet, http.MethodHead)
 	r.HandleFunc("/cart/empty", svc.emptyCartHandler).Methods(http.MethodPost)
+	r.HandleFunc("/cart/update", svc.updateCartItemHandler).Methods(http.MethodPost)
+	r.HandleFunc("/cart/remove", svc.removeCartItemHandler).Methods(http.MethodPost)
+	r.HandleFunc("/cart/checkout", svc.placeOrderHandler).Methods(http.MethodPost)
+	r.HandleFunc("/orders", svc.ordersHandler).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/orders/{id}", svc.orderHandler).Methods(http.MethodGet, http.MethodHead)
 	r.HandleFunc("/setCurrency", svc.setCurrencyHandler).Methods(http.MethodPost)
+	r.HandleFunc("/login", svc.loginPageHandler).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/login", svc.loginHandler).Methods(http.MethodPost)
+	r.HandleFunc("/register", svc.registerHandler).Methods(http.MethodPost)
+	r.HandleFunc("/logout", svc.logoutHandler).Methods(http.MethodPost)
 	r.HandleFunc("/robots.txt", func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, "User-agent: *\nDisallow: /") })
-	r.HandleFunc("/_healthz", func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, "ok") })
+	r.HandleFunc(healthzPath, func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, "ok") })
+	registerMetricsRoute(r)
 
 	var handler http.Handler = r
+	// inside the log handler, which sets the request ID header when the request doesn't have one
+	handler = headerpropagation.NewMiddleware(cfg.PropagatedHeaders)(handler)
+	handler = loadAccount(svc.accounts, handler)
 	handler = &logHandler{log: log, next: handler}
 	handler = ensureSessionID(handler)
+	handler = metricsHandler(r, handler)
+	r.Use(otelmux.Middleware(name, otelmux.WithFilter(isTraced)))
 	r.Use(KardinalTracingContextWrapper)
 
 	// Start the server
 	http.Handle("/", r)
-	fmt.Println("Server starting on port 8070...")
-	http.ListenAndServe(":8070", handler)
+	httpServer := &http.Server{
+		Addr:    cfg.Address,
+		Handler: handler,
+	}
+	fmt.Printf("Server starting on %s...\n", cfg.Address)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go accounts.RunSessionSweeper(ctx, accountStore, cfg.AccountSessionSweepInterval)
+	exitCode := shutdown.ServeUntilShutdown(ctx, httpServer.ListenAndServe, httpServer.Shutdown, shutdown.Options{ShutdownTimeout: cfg.ShutdownTimeout})
+	stop()
+
+	// the in-memory store has nothing to release, the database one closes its connection pool
+	if closer, ok := accountStore.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logrus.Errorf("An error occurred closing the account store. Error: %s", err)
+			if exitCode == shutdown.ExitCodeOk {
+				exitCode = shutdown.ExitCodeServerError
+			}
+		}
+	}
+
+	shutdown.FlushTraces(shutdownTracing, cfg.ShutdownTimeout)
+	shutdown.FlushLogs()
+	os.Exit(exitCode)
+}
+
+func newAccountStore(cfg *config.Config) (accounts.Store, error) {
+	switch cfg.AccountStore {
+	case config.MemoryAccountStore:
+		logrus.Info("Using the in-memory account store, the accounts will be lost when the frontend stops and each replica has its own")
+		return accounts.NewMemory(cfg.AccountSessionTTL), nil
+	case config.PostgresAccountStore:
+		db, err := newAccountDb(cfg)
+		if err != nil {
+			return nil, err
+		}
+		// the migrations hold a lock, so it's safe to run them when several replicas start at once
+		if cfg.MigrateOnStartup {
+			if err := db.MigrateUp(context.Background()); err != nil {
+				return nil, err
+			}
+		}
+		return db, nil
+	default:
+		return nil, fmt.Errorf("unknown account store '%s', valid values are '%s' and '%s'", cfg.AccountStore, config.PostgresAccountStore, config.MemoryAccountStore)
+	}
+}
+
+func newAccountDb(cfg *config.Config) (*accounts.Db, error) {
+	dbConfig := cfg.Database
+	return accounts.NewDb(dbConfig.URI, dbConfig.Host, dbConfig.Username, dbConfig.Password, dbConfig.Name, dbConfig.Port, cfg.AccountSessionTTL)
 }
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kurtosis-tech/new-obd/src/frontend/currencyexternalservice"
+	"github.com/kurtosis-tech/new-obd/src/libs/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// the services the requests are sent to, they label the metrics and the spans of the requests
+const (
+	cartServiceName           = "cartservice"
+	productCatalogServiceName = "productcatalogservice"
+	checkoutServiceName       = "checkoutservice"
+	shippingServiceName       = "shippingservice"
+	recommendationServiceName = "recommendationservice"
+	adServiceName             = "adservice"
+	orderServiceName          = "orderservice"
+	currencyAPIName           = "currencyapi"
+)
+
+// metricsHandler records the rate, errors and duration of the requests per route and status code.
+// It wraps the router instead of being a router middleware so the unmatched requests are recorded too.
+func metricsHandler(router *mux.Router, next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rr := &responseRecorder{w: w}
+
+		next.ServeHTTP(rr, r)
+
+		route := metrics.UnmatchedRoute
+		var match mux.RouteMatch
+		if router.Match(r, &match) && match.Route != nil {
+			if template, err := match.Route.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+		status := rr.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		metrics.ObserveRequest(r.Method, route, status, start)
+	}
+}
+
+// registerCurrencyCacheMetrics exposes the hits and misses of the currency API responses cache
+func registerCurrencyCacheMetrics(currencyService *currencyexternalservice.CurrencyExternalService) {
+	promauto.NewCounterFunc(prometheus.CounterOpts{
+		Name: "currency_api_cache_hits_total",
+		Help: "Number of currency API responses served from the cache.",
+	}, func() float64 {
+		return float64(currencyService.CacheStats().Hits)
+	})
+	promauto.NewCounterFunc(prometheus.CounterOpts{
+		Name: "currency_api_cache_misses_total",
+		Help: "Number of currency API requests that weren't in the cache.",
+	}, func() float64 {
+		return float64(currencyService.CacheStats().Misses)
+	})
+}
+
+// registerMetricsRoute exposes the metrics in the Prometheus format
+func registerMetricsRoute(router *mux.Router) {
+	router.Handle(metrics.Path, promhttp.Handler()).Methods(http.MethodGet)
+}
@@ -20,6 +20,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kurtosis-tech/new-obd/src/frontend/accounts"
+	"github.com/kurtosis-tech/new-obd/src/libs/consts"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -55,15 +58,21 @@ func (r *responseRecorder) WriteHeader(statusCode int) {
 
 func (lh *logHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	requestID, _ := uuid.NewRandom()
-	ctx = context.WithValue(ctx, ctxKeyRequestID{}, requestID.String())
+	// keep the ID of the request that called us, if any, so the logs of all the services can be correlated
+	requestID := r.Header.Get(consts.RequestIdHeaderKey)
+	if requestID == "" {
+		requestUUID, _ := uuid.NewRandom()
+		requestID = requestUUID.String()
+		r.Header.Set(consts.RequestIdHeaderKey, requestID)
+	}
+	ctx = context.WithValue(ctx, ctxKeyRequestID{}, requestID)
 
 	start := time.Now()
 	rr := &responseRecorder{w: w}
 	log := lh.log.WithFields(logrus.Fields{
 		"http.req.path":   r.URL.Path,
 		"http.req.method": r.Method,
-		"http.req.id":     requestID.String(),
+		"http.req.id":     requestID,
 	})
 	if v, ok := r.Context().Value(ctxKeySessionID{}).(string); ok {
 		log = log.WithField("session", v)
@@ -103,3 +112,22 @@ func ensureSessionID(next http.Handler) http.HandlerFunc {
 		next.ServeHTTP(w, r)
 	}
 }
+
+// loadAccount adds the account logged in with the session cookie to the request context, the requests without
+// a valid session are anonymous
+func loadAccount(accountStore accounts.Store, next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if c, err := r.Cookie(cookieAccountSession); err == nil {
+			account, err := accountStore.AccountForSession(r.Context(), c.Value)
+			switch {
+			case err == nil:
+				r = r.WithContext(context.WithValue(r.Context(), ctxKeyAccount{}, account))
+			case !errors.Is(err, accounts.ErrSessionNotFound):
+				// the shopper browses anonymously until the account store is back
+				log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+				log.WithError(err).Warn("could not load the account of the session")
+			}
+		}
+		next.ServeHTTP(w, r)
+	}
+}
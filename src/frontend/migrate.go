@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+
+	"github.com/kurtosis-tech/new-obd/src/frontend/config"
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+	"github.com/sirupsen/logrus"
+)
+
+// runMigrateCommand runs the 'frontend migrate' subcommands against the database of the account store
+func runMigrateCommand(cfg *config.Config, args []string) error {
+	db, err := newAccountDb(cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logrus.Warnf("An error occurred closing the database connection. Error: %s", err.Error())
+		}
+	}()
+
+	return database.RunMigrateCommand(context.Background(), name, db.Migrator, args)
+}
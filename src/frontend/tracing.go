@@ -1,17 +1,42 @@
 package main
 
 import (
-	"github.com/kurtosis-tech/new-obd/src/frontend/consts"
-	"github.com/sirupsen/logrus"
 	"net/http"
+	"strings"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/consts"
+	"github.com/kurtosis-tech/new-obd/src/libs/metrics"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	healthzPath      = "/_healthz"
+	staticPathPrefix = "/static/"
 )
 
+// isTraced leaves the metrics scrapes, the health probes and the static files out of the traces, they would drown the pages
+func isTraced(r *http.Request) bool {
+	path := r.URL.Path
+	return path != metrics.Path && path != healthzPath && !strings.HasPrefix(path, staticPathPrefix)
+}
+
+// KardinalTracingContextWrapper logs the trace ID from the request headers, attaches it to the server span and
+// adds it to the baggage, so it's propagated to the backend services along with the W3C trace context
 func KardinalTracingContextWrapper(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
 		traceID := r.Header.Get(consts.KardinalTraceIdHeaderKey)
 		logrus.Infof("[KARDINAL-DEBUG] Trace ID: %s", traceID)
 
+		if traceID != "" {
+			ctx := r.Context()
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String(consts.KardinalTraceIdBaggageKey, traceID))
+			r = r.WithContext(tracing.ContextWithKardinalTraceID(ctx, traceID))
+		}
+
 		if next != nil && r != nil {
 			next.ServeHTTP(w, r)
 		}
@@ -0,0 +1,217 @@
+// Package configloader builds the config of a service from its defaults, an optional YAML file, the environment
+// variables and the flags, the services only declare their settings
+package configloader
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configFileFlagName  = "config"
+	configFileEnvVarKey = "CONFIG_FILE"
+	printConfigFlagName = "print-config"
+	// flagsTerminator ends the flags, the arguments after it are positional even if they start with a dash
+	flagsTerminator = "--"
+
+	// RedactedValue replaces the secret settings when the config is printed
+	RedactedValue = "REDACTED"
+)
+
+// Setting is a value of the config C that can be set in the YAML file and as a flag (both named after its key)
+// and in its environment variable
+type Setting[C any] struct {
+	Key         string
+	EnvVar      string
+	Description string
+	// Secret settings are redacted when the config is printed
+	Secret bool
+	Get    func(cfg *C) string
+	Set    func(cfg *C, value string) error
+}
+
+// CommandLine holds the command line options that are not settings
+type CommandLine struct {
+	ConfigFilePath string
+	PrintConfig    bool
+	// Args are the positional arguments left after the flags (e.g. a subcommand)
+	Args []string
+}
+
+// Load builds the config from the defaults, the optional YAML file, the environment variables and the flags,
+// each one of them overriding the previous ones. Empty environment variables are ignored.
+// All the invalid values, and the violations returned by validate, are reported at once in the returned error.
+func Load[C any](
+	programName string,
+	args []string,
+	lookupEnv func(key string) (string, bool),
+	cfg *C,
+	settings []Setting[C],
+	validate func(cfg *C) []string,
+) (*C, *CommandLine, error) {
+	commandLine := &CommandLine{}
+	flagSet := flag.NewFlagSet(programName, flag.ContinueOnError)
+	flagSet.StringVar(&commandLine.ConfigFilePath, configFileFlagName, "", fmt.Sprintf("path of the YAML config file (env %s)", configFileEnvVarKey))
+	flagSet.BoolVar(&commandLine.PrintConfig, printConfigFlagName, false, "print the effective config, with the secrets redacted, and exit")
+	flagValues := map[string]*string{}
+	for _, s := range settings {
+		flagValues[s.Key] = flagSet.String(s.Key, s.Get(cfg), fmt.Sprintf("%s (env %s)", s.Description, s.EnvVar))
+	}
+	args, err := parseInterspersed(flagSet, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	commandLine.Args = args
+
+	var violations []string
+
+	if commandLine.ConfigFilePath == "" {
+		commandLine.ConfigFilePath, _ = lookupEnv(configFileEnvVarKey)
+	}
+	if commandLine.ConfigFilePath != "" {
+		fileViolations, err := loadFile(cfg, settings, commandLine.ConfigFilePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		violations = append(violations, fileViolations...)
+	}
+
+	for _, s := range settings {
+		if value, found := lookupEnv(s.EnvVar); found && value != "" {
+			if err := s.Set(cfg, value); err != nil {
+				violations = append(violations, fmt.Sprintf("'%s' set in the %s environment variable: %s", s.Key, s.EnvVar, err))
+			}
+		}
+	}
+
+	flagSet.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if s.Key == f.Name {
+				if err := s.Set(cfg, *flagValues[s.Key]); err != nil {
+					violations = append(violations, fmt.Sprintf("'%s' set in the --%s flag: %s", s.Key, s.Key, err))
+				}
+			}
+		}
+	})
+
+	violations = append(violations, validate(cfg)...)
+	if len(violations) > 0 {
+		return nil, nil, errors.Errorf("invalid configuration:\n  - %s", strings.Join(violations, "\n  - "))
+	}
+
+	return cfg, commandLine, nil
+}
+
+// parseInterspersed parses the flags wherever they are among the positional arguments (e.g. after a subcommand,
+// like 'migrate up --db-host localhost') and returns the positional ones. Everything after '--' is positional.
+func parseInterspersed(flagSet *flag.FlagSet, args []string) ([]string, error) {
+	var positionalArgs []string
+	for {
+		if err := flagSet.Parse(args); err != nil {
+			return nil, err
+		}
+		remainingArgs := flagSet.Args()
+		if len(remainingArgs) == 0 {
+			return positionalArgs, nil
+		}
+		if parsedArgs := args[:len(args)-len(remainingArgs)]; len(parsedArgs) > 0 && parsedArgs[len(parsedArgs)-1] == flagsTerminator {
+			return append(positionalArgs, remainingArgs...), nil
+		}
+		positionalArgs = append(positionalArgs, remainingArgs[0])
+		args = remainingArgs[1:]
+	}
+}
+
+// Print writes the config as YAML, in the format of the config file, with the secrets redacted
+func Print[C any](w io.Writer, cfg *C, settings []Setting[C]) error {
+	document := &yaml.Node{Kind: yaml.MappingNode}
+	for _, s := range settings {
+		value := s.Get(cfg)
+		if s.Secret && value != "" {
+			value = RedactedValue
+		}
+		document.Content = append(document.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: s.Key},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: value},
+		)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	if err := encoder.Encode(document); err != nil {
+		return errors.Wrap(err, "An error occurred printing the config")
+	}
+	return encoder.Close()
+}
+
+// loadFile applies the settings in the YAML file, which is a flat map of setting keys to values
+func loadFile[C any](cfg *C, settings []Setting[C], path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "An error occurred reading the config file '%s'", path)
+	}
+
+	values := map[string]yaml.Node{}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, errors.Wrapf(err, "An error occurred parsing the config file '%s'", path)
+	}
+
+	var violations []string
+	for _, s := range settings {
+		node, found := values[s.Key]
+		if !found {
+			continue
+		}
+		delete(values, s.Key)
+		if node.Kind != yaml.ScalarNode {
+			violations = append(violations, fmt.Sprintf("'%s' set in the config file: it must be a single value", s.Key))
+			continue
+		}
+		if err := s.Set(cfg, node.Value); err != nil {
+			violations = append(violations, fmt.Sprintf("'%s' set in the config file: %s", s.Key, err))
+		}
+	}
+
+	unknownKeys := []string{}
+	for key := range values {
+		unknownKeys = append(unknownKeys, key)
+	}
+	sort.Strings(unknownKeys)
+	for _, key := range unknownKeys {
+		violations = append(violations, fmt.Sprintf("unknown setting '%s' in the config file", key))
+	}
+
+	return violations, nil
+}
+
+func ParsePort(value string) (uint16, error) {
+	port, err := strconv.ParseUint(value, 10, 16)
+	if err != nil || port == 0 {
+		return 0, errors.Errorf("'%s' is not a valid port number", value)
+	}
+	return uint16(port), nil
+}
+
+func ParseBool(value string) (bool, error) {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.Errorf("'%s' is not a valid boolean, use true or false", value)
+	}
+	return parsed, nil
+}
+
+func ParseDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Errorf("'%s' is not a valid duration, use a number with a unit (e.g. 30s)", value)
+	}
+	return duration, nil
+}
@@ -0,0 +1,161 @@
+package configloader
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testProgramName = "testservice"
+
+type testConfig struct {
+	Host     string
+	Port     uint16
+	Timeout  time.Duration
+	Password string
+}
+
+func newTestConfig() *testConfig {
+	return &testConfig{
+		Host:    "0.0.0.0",
+		Port:    8080,
+		Timeout: time.Second,
+	}
+}
+
+var testSettings = []Setting[testConfig]{
+	{
+		Key:         "host",
+		EnvVar:      "HOST",
+		Description: "IP the server listens on",
+		Get:         func(cfg *testConfig) string { return cfg.Host },
+		Set:         func(cfg *testConfig, value string) error { cfg.Host = value; return nil },
+	},
+	{
+		Key:         "port",
+		EnvVar:      "PORT",
+		Description: "port the server listens on",
+		Get:         func(cfg *testConfig) string { return strconv.Itoa(int(cfg.Port)) },
+		Set: func(cfg *testConfig, value string) error {
+			port, err := ParsePort(value)
+			cfg.Port = port
+			return err
+		},
+	},
+	{
+		Key:         "timeout",
+		EnvVar:      "TIMEOUT",
+		Description: "timeout of the requests",
+		Get:         func(cfg *testConfig) string { return cfg.Timeout.String() },
+		Set: func(cfg *testConfig, value string) error {
+			timeout, err := ParseDuration(value)
+			cfg.Timeout = timeout
+			return err
+		},
+	},
+	{
+		Key:         "password",
+		EnvVar:      "PASSWORD",
+		Description: "password of the database",
+		Secret:      true,
+		Get:         func(cfg *testConfig) string { return cfg.Password },
+		Set:         func(cfg *testConfig, value string) error { cfg.Password = value; return nil },
+	},
+}
+
+func validateTestConfig(cfg *testConfig) []string {
+	if cfg.Host == "" {
+		return []string{"'host' is required"}
+	}
+	return nil
+}
+
+func loadTestConfig(args []string, env map[string]string) (*testConfig, *CommandLine, error) {
+	lookupEnv := func(key string) (string, bool) {
+		value, found := env[key]
+		return value, found
+	}
+	return Load(testProgramName, args, lookupEnv, newTestConfig(), testSettings, validateTestConfig)
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadAppliesTheSourcesInOrderOfPrecedence(t *testing.T) {
+	path := writeConfigFile(t, `
+port: 9000
+timeout: 5s
+host: file-host
+`)
+	env := map[string]string{
+		"HOST":    "env-host",
+		"TIMEOUT": "10s",
+		// empty environment variables are ignored
+		"PORT": "",
+	}
+
+	cfg, commandLine, err := loadTestConfig([]string{"--config", path, "--host", "flag-host", "migrate", "up"}, env)
+	require.NoError(t, err)
+
+	require.Equal(t, "flag-host", cfg.Host)
+	require.Equal(t, 10*time.Second, cfg.Timeout)
+	require.Equal(t, uint16(9000), cfg.Port)
+	require.Equal(t, "", cfg.Password)
+	require.Equal(t, path, commandLine.ConfigFilePath)
+	require.Equal(t, []string{"migrate", "up"}, commandLine.Args)
+}
+
+func TestLoadParsesTheFlagsAfterASubcommand(t *testing.T) {
+	cfg, commandLine, err := loadTestConfig([]string{"migrate", "down", "--host", "flag-host", "2", "--port=9000", "--", "--not-a-flag"}, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, "flag-host", cfg.Host)
+	require.Equal(t, uint16(9000), cfg.Port)
+	require.Equal(t, []string{"migrate", "down", "2", "--not-a-flag"}, commandLine.Args)
+
+	_, _, err = loadTestConfig([]string{"migrate", "up", "--unknown-flag"}, nil)
+	require.Error(t, err)
+}
+
+func TestLoadReportsEveryViolation(t *testing.T) {
+	path := writeConfigFile(t, `
+port: not-a-port
+unknown: value
+timeout: [1s, 2s]
+`)
+	env := map[string]string{
+		"TIMEOUT": "forever",
+	}
+
+	_, _, err := loadTestConfig([]string{"--config", path, "--host", ""}, env)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'port' set in the config file")
+	require.Contains(t, err.Error(), "'timeout' set in the config file: it must be a single value")
+	require.Contains(t, err.Error(), "unknown setting 'unknown'")
+	require.Contains(t, err.Error(), "'timeout' set in the TIMEOUT environment variable")
+	require.Contains(t, err.Error(), "'host' is required")
+}
+
+func TestPrintRedactsTheSecrets(t *testing.T) {
+	cfg, commandLine, err := loadTestConfig([]string{"--print-config"}, map[string]string{"PASSWORD": "secret"})
+	require.NoError(t, err)
+	require.True(t, commandLine.PrintConfig)
+
+	output := &bytes.Buffer{}
+	require.NoError(t, Print(output, cfg, testSettings))
+	require.NotContains(t, output.String(), "secret")
+	require.Contains(t, output.String(), "password: "+RedactedValue)
+
+	// the printed config is a valid config file
+	path := writeConfigFile(t, output.String())
+	_, _, err = loadTestConfig([]string{"--config", path}, nil)
+	require.NoError(t, err)
+}
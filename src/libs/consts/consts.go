@@ -0,0 +1,16 @@
+package consts
+
+const (
+	KardinalTraceIdHeaderKey = "X-Kardinal-Trace-Id"
+	// KardinalTraceIdBaggageKey is the baggage member and the span attribute carrying the Kardinal trace ID
+	KardinalTraceIdBaggageKey = "kardinal.trace_id"
+
+	// KardinalFlowIdHeaderKey is the header identifying the dev flow a request belongs to
+	KardinalFlowIdHeaderKey = "X-Kardinal-Flow-Id"
+	// KardinalFlowIdAttributeKey is the span attribute carrying the flow of the request
+	KardinalFlowIdAttributeKey = "kardinal.flow_id"
+
+	RequestIdHeaderKey = "X-Request-Id"
+	// BaggageHeaderKey is the W3C baggage header
+	BaggageHeaderKey = "Baggage"
+)
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+const unknownTable = "unknown"
+
+// registerOperationCallbacks registers a callback to run before and one to run after each kind of gorm operation
+// (create, query, update, delete, row and raw), e.g. to time them
+func registerOperationCallbacks(db *gorm.DB, namePrefix string, before func(tx *gorm.DB, operation string), after func(tx *gorm.DB, operation string)) error {
+	callback := db.Callback()
+	processors := []struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", callback.Create().Before("gorm:create").Register, callback.Create().After("gorm:create").Register},
+		{"query", callback.Query().Before("gorm:query").Register, callback.Query().After("gorm:query").Register},
+		{"update", callback.Update().Before("gorm:update").Register, callback.Update().After("gorm:update").Register},
+		{"delete", callback.Delete().Before("gorm:delete").Register, callback.Delete().After("gorm:delete").Register},
+		{"row", callback.Row().Before("gorm:row").Register, callback.Row().After("gorm:row").Register},
+		{"raw", callback.Raw().Before("gorm:raw").Register, callback.Raw().After("gorm:raw").Register},
+	}
+
+	for _, processor := range processors {
+		operation := processor.operation
+		if err := processor.before(namePrefix+"before_"+operation, func(tx *gorm.DB) {
+			before(tx, operation)
+		}); err != nil {
+			return errors.Wrapf(err, "An error occurred registering the '%s' callback before the '%s' operations", namePrefix, operation)
+		}
+		if err := processor.after(namePrefix+"after_"+operation, func(tx *gorm.DB) {
+			after(tx, operation)
+		}); err != nil {
+			return errors.Wrapf(err, "An error occurred registering the '%s' callback after the '%s' operations", namePrefix, operation)
+		}
+	}
+	return nil
+}
+
+// tableName is the table of the statement, for the metrics labels and the span attributes
+func tableName(tx *gorm.DB) string {
+	if tx.Statement != nil && tx.Statement.Table != "" {
+		return tx.Statement.Table
+	}
+	return unknownTable
+}
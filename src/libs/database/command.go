@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// MigrateCommandName is the subcommand of the services that migrates their database
+const MigrateCommandName = "migrate"
+
+const (
+	migrateUpCommandName     = "up"
+	migrateDownCommandName   = "down"
+	migrateStatusCommandName = "status"
+
+	defaultMigrateDownSteps = 1
+)
+
+// RunMigrateCommand runs the '<service> migrate' subcommands, args are the ones after 'migrate'
+func RunMigrateCommand(ctx context.Context, serviceName string, migrator *Migrator, args []string) error {
+	usage := fmt.Sprintf("usage: %s %s up | down [steps] | status", serviceName, MigrateCommandName)
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	switch args[0] {
+	case migrateUpCommandName:
+		if len(args) != 1 {
+			return errors.New(usage)
+		}
+		if err := migrator.MigrateUp(ctx); err != nil {
+			return err
+		}
+		logrus.Info("The database is up to date")
+	case migrateDownCommandName:
+		steps := defaultMigrateDownSteps
+		if len(args) > 2 {
+			return errors.New(usage)
+		}
+		if len(args) == 2 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil {
+				return errors.Wrapf(err, "Invalid number of migrations to revert '%s'", args[1])
+			}
+		}
+		if err := migrator.MigrateDown(ctx, steps); err != nil {
+			return err
+		}
+	case migrateStatusCommandName:
+		if len(args) != 1 {
+			return errors.New(usage)
+		}
+		statuses, err := migrator.MigrationsStatus(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			fmt.Println(status)
+		}
+	default:
+		return errors.Errorf("unknown migrate command '%s', %s", args[0], usage)
+	}
+
+	return nil
+}
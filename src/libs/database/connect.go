@@ -0,0 +1,93 @@
+// Package database connects the services to Postgres and applies their schema migrations
+package database
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	maxConnectRetries        = 5
+	initialConnectBackoff    = 1 * time.Second
+	connectBackoffMultiplier = 2.0
+
+	resolverDialTimeout = 10 * time.Second
+)
+
+// DSN is the connection string of the database, the URI takes precedence over the other fields
+func DSN(uri string, host string, username string, password string, name string, port string) string {
+	if uri != "" {
+		return uri
+	}
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s", host, username, password, name, port)
+}
+
+// Connect opens the connection pool to the database, retrying with an exponential backoff because the database
+// usually starts along with the services
+func Connect(dsn string) (*gorm.DB, error) {
+	db, err := retryConnect(dsn, maxConnectRetries, initialConnectBackoff, connectBackoffMultiplier)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("An error occurred opening the connection to the database with dsn %s", dsn))
+	}
+
+	logrus.Info("connected to database")
+	return db, nil
+}
+
+func retryConnect(dsn string, maxRetries int, initialBackoff time.Duration, backoffMultiplier float64) (*gorm.DB, error) {
+	var (
+		err error
+		db  *gorm.DB
+	)
+	backoff := initialBackoff
+
+	for i := 0; i < maxRetries; i++ {
+		// Need to change the resolver to resolve all addresses to use ipv4 instead of ipv6
+		net.DefaultResolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				d := net.Dialer{
+					Timeout: resolverDialTimeout,
+				}
+				return d.DialContext(ctx, "tcp4", address)
+			},
+		}
+		logrus.Infof("Attempting to connecting to datbase with dsn: %v\n", dsn)
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		if err != nil {
+			logrus.Debugf("An error occurred opening the connection to the database with dsn %s", dsn)
+		} else {
+			return db, nil
+		}
+
+		// Log the error and wait before retrying
+		logrus.Debugf("Attempt %d failed: %v\n", i+1, err)
+		time.Sleep(backoff)
+
+		// Increase backoff duration
+		backoff = time.Duration(float64(backoff) * backoffMultiplier)
+	}
+
+	return nil, fmt.Errorf("connection to db failed after %d retries: %w", maxRetries, err)
+}
+
+// Close closes the connection pool of the database
+func Close(db *gorm.DB) error {
+	sqlDb, err := db.DB()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred closing the database connection")
+	}
+
+	if err = sqlDb.Close(); err != nil {
+		return errors.Wrap(err, "An error occurred closing the database connection")
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	uniqueViolationPgErrCode            = "23505"
+	notNullViolationPgErrCode           = "23502"
+	checkViolationPgErrCode             = "23514"
+	dataExceptionPgErrCodeClass         = "22"
+	connectionExceptionPgErrCodeClass   = "08"
+	insufficientResourcesPgErrCodeClass = "53"
+	operatorInterventionPgErrCodeClass  = "57"
+)
+
+// ErrorKind is what a database error means to the caller, the stores map it to their own error kinds
+type ErrorKind int
+
+const (
+	// OtherError is an error the caller can't do anything about
+	OtherError ErrorKind = iota
+	// ConflictError is a violated unique constraint
+	ConflictError
+	// InvalidArgumentError is a value the database rejected
+	InvalidArgumentError
+	// UnavailableError is a database that can't be reached or can't serve the query right now, it's worth retrying
+	UnavailableError
+)
+
+// ClassifyError returns the kind of an error returned by gorm or the Postgres driver
+func ClassifyError(err error) ErrorKind {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == uniqueViolationPgErrCode:
+			return ConflictError
+		case strings.HasPrefix(pgErr.Code, dataExceptionPgErrCodeClass),
+			pgErr.Code == notNullViolationPgErrCode,
+			pgErr.Code == checkViolationPgErrCode:
+			return InvalidArgumentError
+		case strings.HasPrefix(pgErr.Code, connectionExceptionPgErrCodeClass),
+			strings.HasPrefix(pgErr.Code, insufficientResourcesPgErrCodeClass),
+			strings.HasPrefix(pgErr.Code, operatorInterventionPgErrCodeClass):
+			return UnavailableError
+		}
+		return OtherError
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.As(err, &netErr) ||
+		pgconn.Timeout(err) {
+		return UnavailableError
+	}
+
+	return OtherError
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		kind ErrorKind
+	}{
+		{"unique violation", &pgconn.PgError{Code: "23505"}, ConflictError},
+		{"check violation", &pgconn.PgError{Code: "23514"}, InvalidArgumentError},
+		{"numeric out of range", &pgconn.PgError{Code: "22003"}, InvalidArgumentError},
+		{"too many connections", &pgconn.PgError{Code: "53300"}, UnavailableError},
+		{"wrapped admin shutdown", errors.Wrap(&pgconn.PgError{Code: "57P01"}, "wrapped"), UnavailableError},
+		{"deadline exceeded", context.DeadlineExceeded, UnavailableError},
+		{"syntax error", &pgconn.PgError{Code: "42601"}, OtherError},
+		{"other error", errors.New("other"), OtherError},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.kind, ClassifyError(testCase.err))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"gorm.io/gorm"
+)
+
+const (
+	queryStartTimeInstanceKey = "metrics:query_start_time"
+	metricsCallbackNamePrefix = "metrics:"
+)
+
+var dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "db_query_duration_seconds",
+	Help:    "Duration of the database queries, by operation and table.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"operation", "table"})
+
+// RegisterQueryMetricsCallbacks times every gorm operation, from before the statement is built to after it's run
+func RegisterQueryMetricsCallbacks(db *gorm.DB) error {
+	return registerOperationCallbacks(db, metricsCallbackNamePrefix, startQueryTimer, observeQueryDuration)
+}
+
+func startQueryTimer(tx *gorm.DB, _ string) {
+	tx.InstanceSet(queryStartTimeInstanceKey, time.Now())
+}
+
+func observeQueryDuration(tx *gorm.DB, operation string) {
+	value, found := tx.InstanceGet(queryStartTimeInstanceKey)
+	if !found {
+		return
+	}
+	start, ok := value.(time.Time)
+	if !ok {
+		return
+	}
+
+	dbQueryDuration.WithLabelValues(operation, tableName(tx)).Observe(time.Since(start).Seconds())
+}
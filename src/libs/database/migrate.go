@@ -0,0 +1,291 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// MigrationsDirName is the directory of the migration files in the file system of a Migrator
+	MigrationsDirName = "migrations"
+
+	upMigrationDirection   = "up"
+	downMigrationDirection = "down"
+
+	schemaMigrationsTableName = "schema_migrations"
+)
+
+var migrationFileNameRegex = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change, applied in order of version and recorded in the schema_migrations table
+type Migration struct {
+	Version  int64
+	Name     string
+	UpSQL    string
+	DownSQL  string
+	Checksum string
+}
+
+// MigrationStatus reports whether a migration has been applied to the database and if the applied SQL
+// still matches the one embedded in the binary
+type MigrationStatus struct {
+	Migration
+	AppliedAt       *time.Time
+	ChecksumMatches bool
+}
+
+// MigrationsConfig describes the migrations of a service
+type MigrationsConfig struct {
+	// FS holds the migration files in MigrationsDirName, named '<version>_<name>.<up|down>.sql'
+	FS fs.FS
+	// Schema holds the schema_migrations table and is created if it's missing, the public schema is used when
+	// it's empty. A service with its own schema doesn't mix its migrations with the ones of the other services
+	// sharing the database.
+	Schema string
+	// AdvisoryLockKey is a random key shared by every replica of the service, so only one of them migrates the
+	// database at a time
+	AdvisoryLockKey int64
+}
+
+type schemaMigration struct {
+	Version   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Migrator applies and reverts the migrations of a service
+type Migrator struct {
+	db     *gorm.DB
+	config MigrationsConfig
+}
+
+func NewMigrator(db *gorm.DB, config MigrationsConfig) *Migrator {
+	return &Migrator{
+		db:     db,
+		config: config,
+	}
+}
+
+// Migrations returns the migrations of the file system sorted by version
+func Migrations(fsys fs.FS) ([]Migration, error) {
+	fileNames, err := fs.Glob(fsys, path.Join(MigrationsDirName, "*.sql"))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred listing the migration files")
+	}
+
+	migrationsByVersion := map[int64]*Migration{}
+	for _, fileName := range fileNames {
+		matches := migrationFileNameRegex.FindStringSubmatch(path.Base(fileName))
+		if matches == nil {
+			return nil, errors.Errorf("Migration file '%s' doesn't follow the '<version>_<name>.<up|down>.sql' naming convention", fileName)
+		}
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "An error occurred parsing the version of migration file '%s'", fileName)
+		}
+		name := matches[2]
+		direction := matches[3]
+
+		content, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "An error occurred reading migration file '%s'", fileName)
+		}
+
+		migration, found := migrationsByVersion[version]
+		if !found {
+			migration = &Migration{Version: version, Name: name}
+			migrationsByVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, errors.Errorf("Migration version %d is used by both '%s' and '%s'", version, migration.Name, name)
+		}
+
+		switch direction {
+		case upMigrationDirection:
+			migration.UpSQL = string(content)
+			checksum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(checksum[:])
+		case downMigrationDirection:
+			migration.DownSQL = string(content)
+		}
+	}
+
+	migrations := []Migration{}
+	for _, migration := range migrationsByVersion {
+		if migration.UpSQL == "" || migration.DownSQL == "" {
+			return nil, errors.Errorf("Migration %d_%s must have both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// MigrateUp applies every pending migration, it fails without applying anything if a migration that was already
+// applied has been modified since
+func (migrator *Migrator) MigrateUp(ctx context.Context) error {
+	migrations, err := Migrations(migrator.config.FS)
+	if err != nil {
+		return err
+	}
+
+	return migrator.withMigrationsLock(ctx, func(tx *gorm.DB, applied map[int64]schemaMigration) error {
+		for _, migration := range migrations {
+			appliedMigration, isApplied := applied[migration.Version]
+			if isApplied {
+				if appliedMigration.Checksum != migration.Checksum {
+					return errors.Errorf("Migration %d_%s has been modified after being applied, add a new migration instead of editing it", migration.Version, migration.Name)
+				}
+				continue
+			}
+
+			logrus.Infof("Applying migration %d_%s", migration.Version, migration.Name)
+			if err := tx.Exec(migration.UpSQL).Error; err != nil {
+				return errors.Wrapf(err, "An error occurred applying migration %d_%s", migration.Version, migration.Name)
+			}
+			record := &schemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Checksum:  migration.Checksum,
+				AppliedAt: time.Now(),
+			}
+			if err := tx.Table(migrator.schemaMigrationsTable()).Create(record).Error; err != nil {
+				return errors.Wrapf(err, "An error occurred recording migration %d_%s as applied", migration.Version, migration.Name)
+			}
+		}
+		return nil
+	})
+}
+
+// MigrateDown reverts the last 'steps' applied migrations, newest first
+func (migrator *Migrator) MigrateDown(ctx context.Context, steps int) error {
+	if steps < 1 {
+		return errors.Errorf("Invalid number of migrations to revert %d, it must be greater than zero", steps)
+	}
+
+	migrations, err := Migrations(migrator.config.FS)
+	if err != nil {
+		return err
+	}
+
+	return migrator.withMigrationsLock(ctx, func(tx *gorm.DB, applied map[int64]schemaMigration) error {
+		for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+			migration := migrations[i]
+			if _, isApplied := applied[migration.Version]; !isApplied {
+				continue
+			}
+
+			logrus.Infof("Reverting migration %d_%s", migration.Version, migration.Name)
+			if err := tx.Exec(migration.DownSQL).Error; err != nil {
+				return errors.Wrapf(err, "An error occurred reverting migration %d_%s", migration.Version, migration.Name)
+			}
+			if err := tx.Table(migrator.schemaMigrationsTable()).Delete(&schemaMigration{Version: migration.Version}).Error; err != nil {
+				return errors.Wrapf(err, "An error occurred removing migration %d_%s from the applied migrations", migration.Version, migration.Name)
+			}
+			steps--
+		}
+		return nil
+	})
+}
+
+// MigrationsStatus returns the status of every migration
+func (migrator *Migrator) MigrationsStatus(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := Migrations(migrator.config.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := []MigrationStatus{}
+	err = migrator.withMigrationsLock(ctx, func(tx *gorm.DB, applied map[int64]schemaMigration) error {
+		for _, migration := range migrations {
+			status := MigrationStatus{
+				Migration:       migration,
+				AppliedAt:       nil,
+				ChecksumMatches: true,
+			}
+			if appliedMigration, isApplied := applied[migration.Version]; isApplied {
+				appliedAt := appliedMigration.AppliedAt
+				status.AppliedAt = &appliedAt
+				status.ChecksumMatches = appliedMigration.Checksum == migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return statuses, nil
+}
+
+// withMigrationsLock runs the function in a transaction holding the migrations advisory lock, which is released
+// when the transaction ends, so the migrations of concurrent replicas don't interleave
+func (migrator *Migrator) withMigrationsLock(ctx context.Context, fn func(tx *gorm.DB, applied map[int64]schemaMigration) error) error {
+	return migrator.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrator.config.AdvisoryLockKey).Error; err != nil {
+			return errors.Wrap(err, "An error occurred acquiring the migrations lock")
+		}
+
+		if migrator.config.Schema != "" {
+			if err := tx.Exec(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", migrator.config.Schema)).Error; err != nil {
+				return errors.Wrapf(err, "An error occurred creating the %s schema", migrator.config.Schema)
+			}
+		}
+
+		if err := tx.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s(
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL
+		)`, migrator.schemaMigrationsTable())).Error; err != nil {
+			return errors.Wrap(err, "An error occurred creating the schema migrations table")
+		}
+
+		var appliedMigrations []schemaMigration
+		if err := tx.Table(migrator.schemaMigrationsTable()).Order("version").Find(&appliedMigrations).Error; err != nil {
+			return errors.Wrap(err, "An error occurred getting the applied migrations")
+		}
+		applied := map[int64]schemaMigration{}
+		for _, appliedMigration := range appliedMigrations {
+			applied[appliedMigration.Version] = appliedMigration
+		}
+
+		return fn(tx, applied)
+	})
+}
+
+func (migrator *Migrator) schemaMigrationsTable() string {
+	schema := migrator.config.Schema
+	if schema == "" {
+		schema = "public"
+	}
+	return schema + "." + schemaMigrationsTableName
+}
+
+func (status MigrationStatus) String() string {
+	state := "pending"
+	if status.AppliedAt != nil {
+		state = fmt.Sprintf("applied at %s", status.AppliedAt.Format(time.RFC3339))
+	}
+	if !status.ChecksumMatches {
+		state += " (modified after being applied)"
+	}
+	return fmt.Sprintf("%04d_%s: %s", status.Version, status.Name, state)
+}
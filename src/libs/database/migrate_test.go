@@ -0,0 +1,49 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationsAreSortedByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0002_add_index.up.sql":      {Data: []byte("CREATE INDEX idx ON things (name);")},
+		"migrations/0002_add_index.down.sql":    {Data: []byte("DROP INDEX idx;")},
+		"migrations/0001_create_table.up.sql":   {Data: []byte("CREATE TABLE things (name TEXT);")},
+		"migrations/0001_create_table.down.sql": {Data: []byte("DROP TABLE things;")},
+	}
+
+	migrations, err := Migrations(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	require.Equal(t, int64(1), migrations[0].Version)
+	require.Equal(t, "create_table", migrations[0].Name)
+	require.Equal(t, "DROP TABLE things;", migrations[0].DownSQL)
+	require.Equal(t, int64(2), migrations[1].Version)
+	require.NotEqual(t, migrations[0].Checksum, migrations[1].Checksum)
+}
+
+func TestMigrationsRejectsInvalidFiles(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"invalid name": {
+			"migrations/create_table.up.sql": {Data: []byte("CREATE TABLE things (name TEXT);")},
+		},
+		"missing down file": {
+			"migrations/0001_create_table.up.sql": {Data: []byte("CREATE TABLE things (name TEXT);")},
+		},
+		"version used twice": {
+			"migrations/0001_create_table.up.sql":   {Data: []byte("CREATE TABLE things (name TEXT);")},
+			"migrations/0001_create_table.down.sql": {Data: []byte("DROP TABLE things;")},
+			"migrations/0001_add_index.up.sql":      {Data: []byte("CREATE INDEX idx ON things (name);")},
+			"migrations/0001_add_index.down.sql":    {Data: []byte("DROP INDEX idx;")},
+		},
+	}
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Migrations(fsys)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	tracerName                = "github.com/kurtosis-tech/new-obd/src/libs/database"
+	querySpanInstanceKey      = "tracing:query_span"
+	tracingCallbackNamePrefix = "tracing:"
+)
+
+// RegisterQueryTracingCallbacks creates a span for every gorm operation, child of the span in the operation context
+func RegisterQueryTracingCallbacks(db *gorm.DB) error {
+	return registerOperationCallbacks(db, tracingCallbackNamePrefix, startQuerySpan, endQuerySpan)
+}
+
+func startQuerySpan(tx *gorm.DB, operation string) {
+	if tx.Statement == nil || tx.Statement.Context == nil {
+		return
+	}
+	ctx, span := otel.Tracer(tracerName).Start(tx.Statement.Context, "db."+operation, trace.WithSpanKind(trace.SpanKindClient))
+	tx.Statement.Context = ctx
+	tx.InstanceSet(querySpanInstanceKey, span)
+}
+
+func endQuerySpan(tx *gorm.DB, _ string) {
+	value, found := tx.InstanceGet(querySpanInstanceKey)
+	if !found {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		semconv.DBSystemPostgreSQL,
+		semconv.DBSQLTable(tableName(tx)),
+		semconv.DBStatement(tx.Statement.SQL.String()),
+	)
+	// not finding a row is an expected result, not a failure of the query
+	if tx.Error != nil && !errors.Is(tx.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(tx.Error)
+		span.SetStatus(codes.Error, tx.Error.Error())
+	}
+}
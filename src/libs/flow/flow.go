@@ -0,0 +1,66 @@
+// Package flow scopes the data of the services to the Kardinal dev flow of the requests, so the data written by a dev
+// flow is isolated from the baseline and from the other flows even if they share the same store
+package flow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/consts"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// BaselineID is the flow of the requests that don't belong to any Kardinal dev flow
+	BaselineID = ""
+
+	// MaxIDLength matches the FlowId schema of the admin endpoints, so every flow that writes data can be purged
+	MaxIDLength = 128
+)
+
+type ctxKeyFlowID struct{}
+
+// ContextWithID scopes the operations run with the returned context to the flow
+func ContextWithID(ctx context.Context, flowID string) context.Context {
+	return context.WithValue(ctx, ctxKeyFlowID{}, flowID)
+}
+
+// IDFromContext returns the flow the operations are scoped to, BaselineID if the context has none
+func IDFromContext(ctx context.Context) string {
+	flowID, found := ctx.Value(ctxKeyFlowID{}).(string)
+	if !found {
+		return BaselineID
+	}
+	return flowID
+}
+
+// NewMiddleware scopes the request to its Kardinal flow, which is the value of the first of the headers that is set.
+// The requests without any of them belong to the baseline.
+func NewMiddleware(headerKeys []string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			flowID := BaselineID
+			for _, headerKey := range headerKeys {
+				if value := strings.TrimSpace(c.Request().Header.Get(headerKey)); value != "" {
+					flowID = value
+					break
+				}
+			}
+			if len(flowID) > MaxIDLength {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid request: the flow ID can't be longer than %d characters", MaxIDLength))
+			}
+
+			ctx := c.Request().Context()
+			if flowID != BaselineID {
+				trace.SpanFromContext(ctx).SetAttributes(attribute.String(consts.KardinalFlowIdAttributeKey, flowID))
+			}
+			c.SetRequest(c.Request().WithContext(ContextWithID(ctx, flowID)))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package flow
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	firstHeaderKey  = "X-First-Flow-Id"
+	secondHeaderKey = "X-Second-Flow-Id"
+)
+
+// serveFlowID returns the status code and the flow the request was scoped to
+func serveFlowID(t *testing.T, headers map[string]string) (int, string) {
+	echoRouter := echo.New()
+	echoRouter.Use(NewMiddleware([]string{firstHeaderKey, secondHeaderKey}))
+
+	var flowID string
+	echoRouter.GET("/", func(c echo.Context) error {
+		flowID = IDFromContext(c.Request().Context())
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	recorder := httptest.NewRecorder()
+	echoRouter.ServeHTTP(recorder, request)
+	return recorder.Code, flowID
+}
+
+func TestIDFromContextDefaultsToTheBaseline(t *testing.T) {
+	require.Equal(t, BaselineID, IDFromContext(context.Background()))
+	require.Equal(t, "flow-1", IDFromContext(ContextWithID(context.Background(), "flow-1")))
+}
+
+func TestMiddlewareUsesTheFirstHeaderThatIsSet(t *testing.T) {
+	code, flowID := serveFlowID(t, nil)
+	require.Equal(t, http.StatusNoContent, code)
+	require.Equal(t, BaselineID, flowID)
+
+	_, flowID = serveFlowID(t, map[string]string{firstHeaderKey: " ", secondHeaderKey: "flow-2"})
+	require.Equal(t, "flow-2", flowID)
+
+	_, flowID = serveFlowID(t, map[string]string{firstHeaderKey: "flow-1", secondHeaderKey: "flow-2"})
+	require.Equal(t, "flow-1", flowID)
+}
+
+func TestMiddlewareRejectsTooLongFlowIDs(t *testing.T) {
+	code, _ := serveFlowID(t, map[string]string{firstHeaderKey: strings.Repeat("f", MaxIDLength+1)})
+	require.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,69 @@
+// Package headerpropagation forwards a set of headers of the inbound requests (e.g. the Kardinal trace ID and the
+// request ID) to all the requests sent while handling them, so the handlers don't have to pass them along
+package headerpropagation
+
+import (
+	"context"
+	"net/http"
+	"net/textproto"
+)
+
+type ctxKeyHeaders struct{}
+
+// NewMiddleware captures the given headers of the inbound requests into their context, the ones that aren't
+// set are skipped
+func NewMiddleware(headerKeys []string) func(next http.Handler) http.Handler {
+	canonicalHeaderKeys := make([]string, len(headerKeys))
+	for i, headerKey := range headerKeys {
+		canonicalHeaderKeys[i] = textproto.CanonicalMIMEHeaderKey(headerKey)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers := http.Header{}
+			for _, headerKey := range canonicalHeaderKeys {
+				if values := r.Header.Values(headerKey); len(values) > 0 {
+					headers[headerKey] = append([]string(nil), values...)
+				}
+			}
+			next.ServeHTTP(w, r.WithContext(ContextWithHeaders(r.Context(), headers)))
+		})
+	}
+}
+
+// ContextWithHeaders returns a copy of the context carrying the headers to propagate
+func ContextWithHeaders(ctx context.Context, headers http.Header) context.Context {
+	return context.WithValue(ctx, ctxKeyHeaders{}, headers)
+}
+
+// HeadersFromContext returns the headers to propagate, nil if there are none
+func HeadersFromContext(ctx context.Context) http.Header {
+	headers, _ := ctx.Value(ctxKeyHeaders{}).(http.Header)
+	return headers
+}
+
+// Transport sets the headers of the request context on the outbound requests, without overriding the ones
+// already set (e.g. the baggage injected by OpenTelemetry)
+type Transport struct {
+	next http.RoundTripper
+}
+
+func NewTransport(next http.RoundTripper) *Transport {
+	return &Transport{next: next}
+}
+
+func (t *Transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	headers := HeadersFromContext(request.Context())
+	if len(headers) == 0 {
+		return t.next.RoundTrip(request)
+	}
+
+	// a RoundTripper must not modify the request it's given
+	propagatedRequest := request.Clone(request.Context())
+	for headerKey, values := range headers {
+		if propagatedRequest.Header.Get(headerKey) == "" {
+			propagatedRequest.Header[headerKey] = append([]string(nil), values...)
+		}
+	}
+	return t.next.RoundTrip(propagatedRequest)
+}
@@ -0,0 +1,47 @@
+package headerpropagation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	kardinalTraceIDHeaderKey = "X-Kardinal-Trace-Id"
+	requestIDHeaderKey       = "X-Request-Id"
+	baggageHeaderKey         = "Baggage"
+)
+
+func TestOutboundRequestsGetTheInboundHeaders(t *testing.T) {
+	var outboundHeaders http.Header
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outboundHeaders = r.Header.Clone()
+	}))
+	t.Cleanup(backend.Close)
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+	handler := NewMiddleware([]string{"x-kardinal-trace-id", requestIDHeaderKey, baggageHeaderKey})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outboundRequest, err := http.NewRequestWithContext(r.Context(), http.MethodGet, backend.URL, nil)
+		require.NoError(t, err)
+		// the headers already set, e.g. by the tracing, are kept
+		outboundRequest.Header.Set(baggageHeaderKey, "kardinal.trace_id=from-otel")
+
+		response, err := client.Do(outboundRequest)
+		require.NoError(t, err)
+		require.NoError(t, response.Body.Close())
+	}))
+
+	inboundRequest := httptest.NewRequest(http.MethodGet, "/cart", nil)
+	inboundRequest.Header.Set(kardinalTraceIDHeaderKey, "kardinal-trace-1")
+	inboundRequest.Header.Set(baggageHeaderKey, "kardinal.trace_id=inbound")
+	inboundRequest.Header.Set("Cookie", "shop_session-id=secret")
+	handler.ServeHTTP(httptest.NewRecorder(), inboundRequest)
+
+	require.Equal(t, "kardinal-trace-1", outboundHeaders.Get(kardinalTraceIDHeaderKey))
+	require.Equal(t, "kardinal.trace_id=from-otel", outboundHeaders.Get(baggageHeaderKey))
+	// only the allowed headers are propagated, and the missing ones are skipped
+	require.Empty(t, outboundHeaders.Get("Cookie"))
+	require.NotContains(t, outboundHeaders, requestIDHeaderKey)
+}
@@ -0,0 +1,106 @@
+// Package metrics records the rate, errors and duration of the HTTP requests served by the services and sent to the
+// other services, and exposes them in the Prometheus format
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/headerpropagation"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	// Path is where the metrics are exposed
+	Path = "/metrics"
+
+	// UnmatchedRoute is the route label of the requests that don't match any route, so unknown paths don't create new series
+	UnmatchedRoute = "unmatched"
+	// clientErrorStatus is the status label of the outbound requests that didn't get a response
+	clientErrorStatus = "error"
+)
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duration of the HTTP requests served, by method, route and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	httpClientRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_client_request_duration_seconds",
+		Help:    "Duration of the HTTP requests sent to the other services, by service, method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"service", "method", "status"})
+)
+
+// ObserveRequest records a request served since start, for the servers that don't use Middleware
+func ObserveRequest(method string, route string, status int, start time.Time) {
+	httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(time.Since(start).Seconds())
+}
+
+// Middleware records the rate, errors and duration of the requests per route and status code
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+
+		err := next(c)
+		if err != nil {
+			// render the error now to know the status code, the error handler skips the committed responses
+			c.Error(err)
+		}
+
+		route := c.Path()
+		if route == "" {
+			route = UnmatchedRoute
+		}
+		ObserveRequest(c.Request().Method, route, c.Response().Status, start)
+
+		return err
+	}
+}
+
+// RegisterHandler exposes the metrics in the Prometheus format
+func RegisterHandler(echoRouter *echo.Echo) {
+	echoRouter.GET(Path, echo.WrapHandler(promhttp.Handler()))
+}
+
+// instrumentedTransport records the duration of the requests sent to a service
+type instrumentedTransport struct {
+	service string
+	next    http.RoundTripper
+}
+
+// NewInstrumentedHTTPClient creates a client that records the metrics and the client spans of the requests sent to a
+// service, and propagates the trace context and the allowed headers of the inbound request to it
+func NewInstrumentedHTTPClient(service string) *http.Client {
+	return &http.Client{
+		Transport: otelhttp.NewTransport(
+			headerpropagation.NewTransport(&instrumentedTransport{
+				service: service,
+				next:    http.DefaultTransport,
+			}),
+			otelhttp.WithSpanNameFormatter(func(_ string, request *http.Request) string {
+				return service + " " + request.Method
+			}),
+		),
+	}
+}
+
+func (t *instrumentedTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := t.next.RoundTrip(request)
+
+	status := clientErrorStatus
+	if err == nil {
+		status = strconv.Itoa(response.StatusCode)
+	}
+	httpClientRequestDuration.WithLabelValues(t.service, request.Method, status).Observe(time.Since(start).Seconds())
+
+	return response, err
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, echoRouter *echo.Echo) string {
+	recorder := httptest.NewRecorder()
+	echoRouter.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, Path, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	return recorder.Body.String()
+}
+
+func TestMiddlewareRecordsTheUnmatchedRequestsUnderASingleRoute(t *testing.T) {
+	echoRouter := echo.New()
+	echoRouter.Use(Middleware)
+	RegisterHandler(echoRouter)
+
+	for _, path := range []string{"/unknown-1", "/unknown-2"} {
+		recorder := httptest.NewRecorder()
+		echoRouter.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusNotFound, recorder.Code)
+	}
+
+	require.Contains(t, scrape(t, echoRouter), `http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 2`)
+}
+
+func TestInstrumentedHTTPClientRecordsTheRequestsByService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	t.Cleanup(server.Close)
+
+	response, err := NewInstrumentedHTTPClient("teaservice").Get(server.URL)
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+
+	// nothing listens on the closed server, so the request doesn't get a response
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedServer.Close()
+	_, err = NewInstrumentedHTTPClient("closedservice").Get(closedServer.URL)
+	require.Error(t, err)
+
+	echoRouter := echo.New()
+	RegisterHandler(echoRouter)
+	metrics := scrape(t, echoRouter)
+	require.Contains(t, metrics, `http_client_request_duration_seconds_count{method="GET",service="teaservice",status="418"} 1`)
+	require.Contains(t, metrics, `http_client_request_duration_seconds_count{method="GET",service="closedservice",status="error"} 1`)
+}
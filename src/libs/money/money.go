@@ -0,0 +1,84 @@
+// Package money does the arithmetic of the amounts, in whole units and nano units like the Money schema of the
+// services. The generated Money types have the same fields, so they convert to and from this one.
+package money
+
+import (
+	"errors"
+)
+
+const (
+	nanosMin = -999999999
+	nanosMax = +999999999
+	nanosMod = 1000000000
+)
+
+var (
+	ErrInvalidValue        = errors.New("one of the specified money values is invalid")
+	ErrMismatchingCurrency = errors.New("mismatching currency codes")
+)
+
+// Money is an amount in a currency, its fields are in the order of the generated Money types
+type Money struct {
+	CurrencyCode string
+	Nanos        int32
+	Units        int64
+}
+
+// Zero returns a zero amount in the currency
+func Zero(currencyCode string) Money {
+	return Money{CurrencyCode: currencyCode}
+}
+
+// IsValid checks that the value has a currency and that its units and nanos have matching signs and valid ranges
+func IsValid(m Money) bool {
+	signMatches := m.Nanos == 0 || m.Units == 0 || (m.Nanos < 0) == (m.Units < 0)
+	return m.CurrencyCode != "" && signMatches && nanosMin <= m.Nanos && m.Nanos <= nanosMax
+}
+
+// IsPositive returns true if the value is valid and greater than zero
+func IsPositive(m Money) bool {
+	return IsValid(m) && (m.Units > 0 || (m.Units == 0 && m.Nanos > 0))
+}
+
+// Sum adds two values of the same currency
+func Sum(l, r Money) (Money, error) {
+	if !IsValid(l) || !IsValid(r) {
+		return Money{}, ErrInvalidValue
+	}
+	if l.CurrencyCode != r.CurrencyCode {
+		return Money{}, ErrMismatchingCurrency
+	}
+
+	units := l.Units + r.Units
+	nanos := l.Nanos + r.Nanos
+	// carry the nanos that overflow into the units, then give both the same sign
+	units += int64(nanos / nanosMod)
+	nanos = nanos % nanosMod
+	if units > 0 && nanos < 0 {
+		units--
+		nanos += nanosMod
+	} else if units < 0 && nanos > 0 {
+		units++
+		nanos -= nanosMod
+	}
+
+	return Money{
+		CurrencyCode: l.CurrencyCode,
+		Units:        units,
+		Nanos:        nanos,
+	}, nil
+}
+
+// Multiply returns the value times n, e.g. the cost of a cart line
+func Multiply(m Money, n uint32) (Money, error) {
+	if !IsValid(m) {
+		return Money{}, ErrInvalidValue
+	}
+
+	nanos := int64(m.Nanos) * int64(n)
+	return Money{
+		CurrencyCode: m.CurrencyCode,
+		Units:        m.Units*int64(n) + nanos/nanosMod,
+		Nanos:        int32(nanos % nanosMod),
+	}, nil
+}
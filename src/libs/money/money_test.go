@@ -0,0 +1,64 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func usd(units int64, nanos int32) Money {
+	return Money{CurrencyCode: "USD", Units: units, Nanos: nanos}
+}
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		l, r     Money
+		expected Money
+	}{
+		{"zero", usd(0, 0), usd(0, 0), usd(0, 0)},
+		{"no carry", usd(2, 200000000), usd(2, 200000000), usd(4, 400000000)},
+		{"carry", usd(2, 200000000), usd(2, 900000000), usd(5, 100000000)},
+		{"negative carry", usd(-2, -200000000), usd(-2, -900000000), usd(-5, -100000000)},
+		{"borrow", usd(11, 100000000), usd(-2, -900000000), usd(8, 200000000)},
+		{"negative borrow", usd(-11, -100000000), usd(2, 900000000), usd(-8, -200000000)},
+		{"nanos only", usd(0, -500000000), usd(1, 0), usd(0, 500000000)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sum, err := Sum(test.l, test.r)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, sum)
+		})
+	}
+}
+
+func TestSumRejectsInvalidValues(t *testing.T) {
+	_, err := Sum(usd(1, -1), usd(0, 0))
+	require.ErrorIs(t, err, ErrInvalidValue)
+	_, err = Sum(usd(0, nanosMod), usd(0, 0))
+	require.ErrorIs(t, err, ErrInvalidValue)
+	_, err = Sum(usd(1, 0), Zero(""))
+	require.ErrorIs(t, err, ErrInvalidValue)
+	_, err = Sum(usd(1, 0), Zero("EUR"))
+	require.ErrorIs(t, err, ErrMismatchingCurrency)
+}
+
+func TestMultiply(t *testing.T) {
+	product, err := Multiply(usd(19, 990000000), 3)
+	require.NoError(t, err)
+	require.Equal(t, usd(59, 970000000), product)
+
+	product, err = Multiply(usd(19, 990000000), 0)
+	require.NoError(t, err)
+	require.Equal(t, usd(0, 0), product)
+
+	_, err = Multiply(usd(1, -1), 2)
+	require.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestIsPositive(t *testing.T) {
+	require.True(t, IsPositive(usd(0, 1)))
+	require.False(t, IsPositive(usd(0, 0)))
+	require.False(t, IsPositive(usd(-1, 0)))
+}
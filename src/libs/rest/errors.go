@@ -0,0 +1,86 @@
+// Package rest holds the middlewares shared by the REST APIs of the services: the validation of the requests against
+// the API spec, and the mapping of the errors to status codes and their rendering as a ResponseInfo
+package rest
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// errorResponseType is the type of the ResponseInfo of the errors
+const errorResponseType = "ERROR"
+
+// ResponseInfo is the body of the error responses, it matches the ResponseInfo schema of the API specs
+type ResponseInfo struct {
+	Code    uint32 `json:"code"`
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// StatusCodeForError returns the status code of an error returned by the handlers, based on the error kinds of the
+// service
+type StatusCodeForError func(err error) int
+
+// NewErrorMappingMiddleware turns the errors returned by the strict handlers into HTTP errors with the matching status
+// code, which are rendered by the handler returned by NewResponseInfoHTTPErrorHandler
+func NewErrorMappingMiddleware(statusCodeForError StatusCodeForError) strictecho.StrictEchoMiddlewareFunc {
+	return func(next strictecho.StrictEchoHandlerFunc, operationID string) strictecho.StrictEchoHandlerFunc {
+		return func(ctx echo.Context, request interface{}) (interface{}, error) {
+			response, err := next(ctx, request)
+			if err == nil {
+				return response, nil
+			}
+
+			statusCode := statusCodeForError(err)
+			if statusCode >= http.StatusInternalServerError {
+				logrus.Errorf("Operation '%s' failed. Error: %+v", operationID, err)
+			} else {
+				logrus.Infof("Operation '%s' was rejected. Error: %s", operationID, err)
+			}
+
+			return nil, echo.NewHTTPError(statusCode, err.Error()).SetInternal(err)
+		}
+	}
+}
+
+// NewResponseInfoHTTPErrorHandler renders every error (the ones returned by the handlers and the ones returned by echo,
+// like unknown routes or malformed bodies) as a ResponseInfo, as defined in the API specs
+func NewResponseInfoHTTPErrorHandler(statusCodeForError StatusCodeForError) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		statusCode := http.StatusInternalServerError
+		message := err.Error()
+
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			statusCode = httpErr.Code
+			if msg, ok := httpErr.Message.(string); ok {
+				message = msg
+			}
+		} else {
+			statusCode = statusCodeForError(err)
+		}
+
+		response := ResponseInfo{
+			Type:    errorResponseType,
+			Message: message,
+			Code:    uint32(statusCode),
+		}
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(statusCode)
+		} else {
+			err = c.JSON(statusCode, response)
+		}
+		if err != nil {
+			logrus.Errorf("An error occurred writing the error response. Error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAdminToken = "admin-token"
+
+	testSpec = `
+openapi: 3.0.0
+info:
+  title: Test
+  version: 1.0.0
+paths:
+  /items:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                  minLength: 1
+                quantity:
+                  type: integer
+                  minimum: 1
+              required:
+                - name
+                - quantity
+      responses:
+        "204":
+          description: Created
+  /admin/items:
+    delete:
+      security:
+        - adminToken: []
+      responses:
+        "204":
+          description: Deleted
+components:
+  securitySchemes:
+    adminToken:
+      type: http
+      scheme: bearer
+`
+)
+
+var errTestNotFound = errors.New("not found")
+
+func testStatusCodeForError(err error) int {
+	if errors.Is(err, errTestNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func newTestRouter(t *testing.T, adminToken string) *echo.Echo {
+	getSwagger := func() (*openapi3.T, error) {
+		return openapi3.NewLoader().LoadFromData([]byte(testSpec))
+	}
+	requestValidatorMiddleware, err := NewRequestValidatorMiddleware(getSwagger, adminToken)
+	require.NoError(t, err)
+
+	echoRouter := echo.New()
+	echoRouter.HTTPErrorHandler = NewResponseInfoHTTPErrorHandler(testStatusCodeForError)
+	echoRouter.Use(requestValidatorMiddleware)
+	echoRouter.POST("/items", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
+	echoRouter.DELETE("/admin/items", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) })
+	echoRouter.GET("/missing", func(c echo.Context) error { return errTestNotFound })
+	return echoRouter
+}
+
+func serve(echoRouter *echo.Echo, request *http.Request) (int, ResponseInfo) {
+	recorder := httptest.NewRecorder()
+	echoRouter.ServeHTTP(recorder, request)
+	var response ResponseInfo
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	return recorder.Code, response
+}
+
+func TestRequestValidatorListsEveryViolation(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"name": "", "quantity": 0}`))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	code, response := serve(newTestRouter(t, testAdminToken), request)
+	require.Equal(t, http.StatusBadRequest, code)
+	require.Equal(t, errorResponseType, response.Type)
+	require.Equal(t, uint32(http.StatusBadRequest), response.Code)
+	require.Contains(t, response.Message, "field 'name'")
+	require.Contains(t, response.Message, "field 'quantity'")
+}
+
+func TestRequestValidatorChecksTheAdminToken(t *testing.T) {
+	newRequest := func(token string) *http.Request {
+		request := httptest.NewRequest(http.MethodDelete, "/admin/items", nil)
+		if token != "" {
+			request.Header.Set(echo.HeaderAuthorization, bearerPrefix+token)
+		}
+		return request
+	}
+
+	code, _ := serve(newTestRouter(t, testAdminToken), newRequest(testAdminToken))
+	require.Equal(t, http.StatusNoContent, code)
+
+	code, _ = serve(newTestRouter(t, testAdminToken), newRequest("wrong-token"))
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	code, _ = serve(newTestRouter(t, testAdminToken), newRequest(""))
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	// the admin endpoints are disabled without a token
+	code, _ = serve(newTestRouter(t, ""), newRequest(""))
+	require.Equal(t, http.StatusForbidden, code)
+}
+
+func TestResponseInfoHTTPErrorHandlerMapsTheErrors(t *testing.T) {
+	echoRouter := newTestRouter(t, testAdminToken)
+
+	code, response := serve(echoRouter, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	require.Equal(t, http.StatusNotFound, code)
+	require.Equal(t, errTestNotFound.Error(), response.Message)
+
+	// the routes that aren't in the spec are left to echo
+	code, response = serve(echoRouter, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	require.Equal(t, http.StatusNotFound, code)
+	require.Equal(t, uint32(http.StatusNotFound), response.Code)
+}
@@ -0,0 +1,136 @@
+package rest
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/oapi-codegen/echo-middleware"
+	"github.com/pkg/errors"
+)
+
+const (
+	invalidRequestMessagePrefix = "invalid request: "
+
+	// adminTokenSecurityScheme is the security scheme of the admin endpoints in the API spec
+	adminTokenSecurityScheme = "adminToken"
+	bearerPrefix             = "Bearer "
+)
+
+// NewRequestValidatorMiddleware validates the requests against the API spec of the service before they reach the strict
+// handlers, the violations are returned as a 400 error that the handler returned by NewResponseInfoHTTPErrorHandler
+// renders as a ResponseInfo. The requests to routes that are not in the spec (e.g. unknown routes) are left to echo.
+// The admin endpoints of the spec, if any, require the admin token as a bearer token, they are rejected if it's empty.
+func NewRequestValidatorMiddleware(getSwagger func() (*openapi3.T, error), adminToken string) (echo.MiddlewareFunc, error) {
+	swagger, err := getSwagger()
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred loading the embedded API spec")
+	}
+	// the servers in the spec are the in-cluster hostnames, don't match the requests against them
+	swagger.Servers = nil
+
+	router, err := gorillamux.NewRouter(swagger)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred creating the router for the API spec")
+	}
+
+	return echomiddleware.OapiRequestValidatorWithOptions(swagger, &echomiddleware.Options{
+		Options: openapi3filter.Options{
+			MultiError:         true,
+			AuthenticationFunc: newAdminTokenAuthenticator(adminToken),
+		},
+		Skipper: func(c echo.Context) bool {
+			_, _, err := router.FindRoute(c.Request())
+			return err != nil
+		},
+		ErrorHandler: func(c echo.Context, err *echo.HTTPError) error {
+			if err.Code != http.StatusBadRequest {
+				return err
+			}
+			// the validator reports the rejected admin token among the other violations
+			if authenticationErr := adminTokenError(err.Internal); authenticationErr != nil {
+				return authenticationErr
+			}
+			message := err.Message
+			if err.Internal != nil {
+				message = invalidRequestMessagePrefix + strings.Join(validationViolations(err.Internal), "; ")
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, message).SetInternal(err.Internal)
+		},
+	}), nil
+}
+
+// newAdminTokenAuthenticator checks the bearer token of the requests to the admin endpoints, in constant time
+func newAdminTokenAuthenticator(adminToken string) openapi3filter.AuthenticationFunc {
+	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+		if input.SecuritySchemeName != adminTokenSecurityScheme {
+			return errors.Errorf("unknown security scheme '%s'", input.SecuritySchemeName)
+		}
+		if adminToken == "" {
+			return echo.NewHTTPError(http.StatusForbidden, "the admin endpoints are disabled, set the admin token of the service to enable them")
+		}
+		token, found := strings.CutPrefix(input.RequestValidationInput.Request.Header.Get(echo.HeaderAuthorization), bearerPrefix)
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			return echo.NewHTTPError(http.StatusUnauthorized, "a valid admin token is required")
+		}
+		return nil
+	}
+}
+
+// adminTokenError returns the rejection of the admin token found in the validation errors, if any
+func adminTokenError(err error) *echo.HTTPError {
+	var securityErr *openapi3filter.SecurityRequirementsError
+	if !errors.As(err, &securityErr) {
+		return nil
+	}
+	for _, authenticationErr := range securityErr.Errors {
+		var httpErr *echo.HTTPError
+		if errors.As(authenticationErr, &httpErr) {
+			return httpErr
+		}
+	}
+	return nil
+}
+
+// validationViolations flattens the errors returned by the validator into one message per violation
+func validationViolations(err error) []string {
+	switch typedErr := err.(type) {
+	case openapi3.MultiError:
+		violations := []string{}
+		for _, violationErr := range typedErr {
+			violations = append(violations, validationViolations(violationErr)...)
+		}
+		return violations
+	case *openapi3filter.RequestError:
+		location := "request body"
+		if typedErr.Parameter != nil {
+			location = fmt.Sprintf("%s parameter '%s'", typedErr.Parameter.In, typedErr.Parameter.Name)
+		}
+		if typedErr.Err == nil {
+			return []string{fmt.Sprintf("%s: %s", location, typedErr.Reason)}
+		}
+		violations := []string{}
+		for _, violation := range validationViolations(typedErr.Err) {
+			violations = append(violations, fmt.Sprintf("%s: %s", location, violation))
+		}
+		return violations
+	case *openapi3.SchemaError:
+		field := strings.Join(typedErr.JSONPointer(), ".")
+		if field == "" {
+			return []string{typedErr.Reason}
+		}
+		return []string{fmt.Sprintf("field '%s' %s", field, typedErr.Reason)}
+	case *routers.RouteError:
+		return []string{typedErr.Reason}
+	default:
+		// the validator errors can span several lines, the first one describes the violation
+		return []string{strings.Split(err.Error(), "\n")[0]}
+	}
+}
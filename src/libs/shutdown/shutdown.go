@@ -0,0 +1,99 @@
+// Package shutdown stops the servers of the services gracefully, so the in-flight requests are not cut off when
+// the service is stopped or rolled out
+package shutdown
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// the exit codes of the services, so the orchestrator can tell a clean stop from a failure
+const (
+	ExitCodeOk              = 0
+	ExitCodeServerError     = 1
+	ExitCodeShutdownTimeout = 2
+)
+
+// Options are the steps of the shutdown of a server
+type Options struct {
+	// OnShutdownStart is called as soon as the shutdown is requested, e.g. to make the readiness check fail
+	OnShutdownStart func()
+	// DrainDelay is how long the server keeps accepting requests after OnShutdownStart, so the orchestrator sees it's
+	// not ready and stops routing traffic to it first. It must be at least the period of the readiness probe.
+	DrainDelay time.Duration
+	// ShutdownTimeout is the deadline to finish the in-flight requests once the server stops accepting new ones
+	ShutdownTimeout time.Duration
+}
+
+// ServeUntilShutdown runs the server until it fails or the context is done (e.g. on SIGTERM), in which case it
+// stops reporting ready, keeps serving during the drain delay and then finishes the in-flight requests within the
+// shutdown timeout. It returns the exit code of the process.
+func ServeUntilShutdown(ctx context.Context, serve func() error, shutdown func(ctx context.Context) error, options Options) int {
+	serveErrs := make(chan error, 1)
+	go func() {
+		serveErrs <- serve()
+	}()
+
+	select {
+	case err := <-serveErrs:
+		return exitCodeOfServeError(err)
+	case <-ctx.Done():
+	}
+
+	if options.OnShutdownStart != nil {
+		options.OnShutdownStart()
+	}
+	if options.DrainDelay > 0 {
+		logrus.Infof("Stopped reporting ready, still serving for %s so the traffic is routed to the other replicas...", options.DrainDelay)
+		drainTimer := time.NewTimer(options.DrainDelay)
+		defer drainTimer.Stop()
+		select {
+		case err := <-serveErrs:
+			return exitCodeOfServeError(err)
+		case <-drainTimer.C:
+		}
+	}
+
+	logrus.Infof("Shutting down the server, waiting up to %s for the in-flight requests to finish...", options.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), options.ShutdownTimeout)
+	defer cancel()
+
+	if err := shutdown(shutdownCtx); err != nil {
+		logrus.Errorf("The server didn't shut down gracefully. Error: %s", err)
+		return ExitCodeShutdownTimeout
+	}
+
+	logrus.Info("The server has been shut down")
+	return ExitCodeOk
+}
+
+// exitCodeOfServeError tells a server that failed from one that was closed
+func exitCodeOfServeError(err error) int {
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logrus.Errorf("The server failed. Error: %s", err)
+		return ExitCodeServerError
+	}
+	return ExitCodeOk
+}
+
+// FlushLogs makes sure the last log lines are written before the process exits
+func FlushLogs() {
+	// syncing fails on pipes and terminals, which are not buffered anyway
+	_ = os.Stderr.Sync()
+	_ = os.Stdout.Sync()
+}
+
+// FlushTraces exports the spans that are still buffered, it's the last thing to do before exiting
+func FlushTraces(shutdownTracing func(ctx context.Context) error, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := shutdownTracing(ctx); err != nil {
+		logrus.Errorf("An error occurred exporting the last spans. Error: %s", err)
+	}
+}
@@ -0,0 +1,111 @@
+package shutdown
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testShutdownTimeout = 5 * time.Second
+	slowRequestDuration = 200 * time.Millisecond
+	testDrainDelay      = 300 * time.Millisecond
+)
+
+func TestServeUntilShutdownDrainsInFlightRequests(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	requestStarted := make(chan struct{})
+	echoRouter := echo.New()
+	echoRouter.Listener = listener
+	echoRouter.GET("/slow", func(c echo.Context) error {
+		close(requestStarted)
+		time.Sleep(slowRequestDuration)
+		return c.String(http.StatusOK, "done")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	exitCodes := make(chan int, 1)
+	go func() {
+		exitCodes <- ServeUntilShutdown(ctx, func() error {
+			return echoRouter.Start("")
+		}, echoRouter.Shutdown, Options{ShutdownTimeout: testShutdownTimeout})
+	}()
+
+	type result struct {
+		body string
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/slow")
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		results <- result{body: string(body), err: err}
+	}()
+
+	<-requestStarted
+	cancel()
+
+	requestResult := <-results
+	require.NoError(t, requestResult.err)
+	require.Equal(t, "done", requestResult.body)
+	require.Equal(t, ExitCodeOk, <-exitCodes)
+}
+
+func TestServeUntilShutdownReportsServerErrors(t *testing.T) {
+	exitCode := ServeUntilShutdown(context.Background(), func() error {
+		return &net.OpError{Op: "listen", Err: io.ErrUnexpectedEOF}
+	}, func(ctx context.Context) error {
+		return nil
+	}, Options{ShutdownTimeout: testShutdownTimeout})
+	require.Equal(t, ExitCodeServerError, exitCode)
+}
+
+func TestServeUntilShutdownKeepsServingWhileItDrains(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	echoRouter := echo.New()
+	echoRouter.Listener = listener
+	echoRouter.GET("/ping", func(c echo.Context) error {
+		return c.String(http.StatusOK, "pong")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	shutdownStarted := make(chan struct{})
+	exitCodes := make(chan int, 1)
+	go func() {
+		exitCodes <- ServeUntilShutdown(ctx, func() error {
+			return echoRouter.Start("")
+		}, echoRouter.Shutdown, Options{
+			OnShutdownStart: func() { close(shutdownStarted) },
+			DrainDelay:      testDrainDelay,
+			ShutdownTimeout: testShutdownTimeout,
+		})
+	}()
+
+	cancel()
+	<-shutdownStarted
+
+	// the requests routed before the orchestrator noticed the service is not ready are still served
+	resp, err := http.Get("http://" + listener.Addr().String() + "/ping")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, err)
+	require.Equal(t, "pong", string(body))
+
+	require.Equal(t, ExitCodeOk, <-exitCodes)
+}
@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/configloader"
+)
+
+const (
+	NoExporter   = "none"
+	OTLPExporter = "otlp"
+	FileExporter = "file"
+
+	defaultOTLPEndpoint = "http://localhost:4318"
+)
+
+// Config is where the OpenTelemetry spans are exported to
+type Config struct {
+	// Exporter is NoExporter, OTLPExporter or FileExporter
+	Exporter string
+	// OTLPEndpoint is the URL of the OTLP/HTTP collector, e.g. a local OpenTelemetry collector
+	OTLPEndpoint string
+	// FilePath is the file the spans are written to, one JSON document per span
+	FilePath string
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Exporter:     NoExporter,
+		OTLPEndpoint: defaultOTLPEndpoint,
+	}
+}
+
+// Settings are the tracing settings of a service config C, tracingConfig returns where they are stored in it
+func Settings[C any](tracingConfig func(cfg *C) *Config) []configloader.Setting[C] {
+	return []configloader.Setting[C]{
+		{
+			Key:         "tracing-exporter",
+			EnvVar:      "TRACING_EXPORTER",
+			Description: fmt.Sprintf("where the spans are exported, '%s', '%s' or '%s'", NoExporter, OTLPExporter, FileExporter),
+			Get:         func(cfg *C) string { return tracingConfig(cfg).Exporter },
+			Set:         func(cfg *C, value string) error { tracingConfig(cfg).Exporter = value; return nil },
+		},
+		{
+			Key:         "tracing-otlp-endpoint",
+			EnvVar:      "OTEL_EXPORTER_OTLP_ENDPOINT",
+			Description: "URL of the OTLP/HTTP collector the spans are sent to",
+			Get:         func(cfg *C) string { return tracingConfig(cfg).OTLPEndpoint },
+			Set:         func(cfg *C, value string) error { tracingConfig(cfg).OTLPEndpoint = value; return nil },
+		},
+		{
+			Key:         "tracing-file",
+			EnvVar:      "TRACING_FILE",
+			Description: "file the spans are written to",
+			Get:         func(cfg *C) string { return tracingConfig(cfg).FilePath },
+			Set:         func(cfg *C, value string) error { tracingConfig(cfg).FilePath = value; return nil },
+		},
+	}
+}
+
+// Validate returns the violations of the tracing settings
+func (cfg Config) Validate() []string {
+	var violations []string
+	switch cfg.Exporter {
+	case NoExporter:
+	case OTLPExporter:
+		if endpoint, err := url.Parse(cfg.OTLPEndpoint); err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+			violations = append(violations, fmt.Sprintf("'tracing-otlp-endpoint' must be a URL like '%s', got '%s'", defaultOTLPEndpoint, cfg.OTLPEndpoint))
+		}
+	case FileExporter:
+		if cfg.FilePath == "" {
+			violations = append(violations, fmt.Sprintf("'tracing-file' is required when 'tracing-exporter' is '%s'", FileExporter))
+		}
+	default:
+		violations = append(violations, fmt.Sprintf("'tracing-exporter' must be '%s', '%s' or '%s', got '%s'", NoExporter, OTLPExporter, FileExporter, cfg.Exporter))
+	}
+	return violations
+}
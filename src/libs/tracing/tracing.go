@@ -0,0 +1,127 @@
+// Package tracing exports the OpenTelemetry spans of a service and carries the Kardinal trace ID of its requests
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/consts"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracesFilePerm = 0o644
+
+	// MetricsPath and HealthPathPrefix are the paths of the scrapes and probes the echo services serve
+	MetricsPath      = "/metrics"
+	HealthPathPrefix = "/health"
+)
+
+// Init sets up the W3C trace context and baggage propagation and the exporter of the spans.
+// The returned function flushes the pending spans, it must be called before the process exits.
+func Init(ctx context.Context, serviceName string, cfg Config) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+		// closeOutput releases the file the spans are written to, once the last ones are flushed
+		closeOutput = func() error { return nil }
+	)
+	switch cfg.Exporter {
+	case NoExporter:
+		return func(ctx context.Context) error { return nil }, nil
+	case OTLPExporter:
+		exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		if err != nil {
+			return nil, errors.Wrapf(err, "An error occurred creating the OTLP exporter for '%s'", cfg.OTLPEndpoint)
+		}
+	case FileExporter:
+		file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, tracesFilePerm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "An error occurred opening the traces file '%s'", cfg.FilePath)
+		}
+		closeOutput = file.Close
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+			return nil, errors.Wrap(err, "An error occurred creating the file exporter")
+		}
+	default:
+		return nil, errors.Errorf("unknown tracing exporter '%s'", cfg.Exporter)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	logrus.Infof("Exporting the spans with the '%s' exporter", cfg.Exporter)
+
+	return func(ctx context.Context) error {
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			return errors.Wrap(err, "An error occurred flushing the spans")
+		}
+		return closeOutput()
+	}, nil
+}
+
+// Skipper leaves the metrics scrapes and the health probes out of the traces, they would drown the requests
+func Skipper(c echo.Context) bool {
+	path := c.Request().URL.Path
+	return path == MetricsPath || strings.HasPrefix(path, HealthPathPrefix)
+}
+
+// KardinalTraceIDMiddleware logs the trace ID from the request headers, attaches it to the server span and
+// adds it to the baggage, so it's propagated to the calls made while handling the request
+func KardinalTraceIDMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		// Get the trace ID from the request header, or from the baggage if the caller only propagated the W3C headers
+		traceID := c.Request().Header.Get(consts.KardinalTraceIdHeaderKey)
+		if traceID == "" {
+			traceID = baggage.FromContext(ctx).Member(consts.KardinalTraceIdBaggageKey).Value()
+		}
+
+		// Log the trace ID
+		if traceID != "" {
+			logrus.Infof("[KARDINAL-DEBUG] Trace ID: %s", traceID)
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String(consts.KardinalTraceIdBaggageKey, traceID))
+			c.SetRequest(c.Request().WithContext(ContextWithKardinalTraceID(ctx, traceID)))
+		} else {
+			logrus.Info("[KARDINAL-DEBUG] Trace ID: not provided")
+		}
+
+		// Call the next handler
+		return next(c)
+	}
+}
+
+// ContextWithKardinalTraceID adds the trace ID to the baggage of the context, an invalid ID is only logged
+func ContextWithKardinalTraceID(ctx context.Context, traceID string) context.Context {
+	member, err := baggage.NewMember(consts.KardinalTraceIdBaggageKey, traceID)
+	if err != nil {
+		logrus.Debugf("The Kardinal trace ID '%s' can't be added to the baggage. Error: %s", traceID, err)
+		return ctx
+	}
+	bag, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		logrus.Debugf("The Kardinal trace ID '%s' can't be added to the baggage. Error: %s", traceID, err)
+		return ctx
+	}
+	return baggage.ContextWithBaggage(ctx, bag)
+}
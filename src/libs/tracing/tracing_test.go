@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/consts"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	testTraceParent     = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testParentTraceID   = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testKardinalTraceID = "kardinal-trace-1"
+
+	testServiceName = "testservice"
+)
+
+func TestServerSpanContinuesTheTraceAndCarriesTheKardinalTraceID(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+
+	var kardinalTraceIDInBaggage string
+	echoRouter := echo.New()
+	echoRouter.Use(otelecho.Middleware(testServiceName,
+		otelecho.WithTracerProvider(tracerProvider),
+		otelecho.WithPropagators(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})),
+		otelecho.WithSkipper(Skipper),
+	))
+	echoRouter.Use(KardinalTraceIDMiddleware)
+	echoRouter.GET("/items/:item_id", func(c echo.Context) error {
+		kardinalTraceIDInBaggage = baggage.FromContext(c.Request().Context()).Member(consts.KardinalTraceIdBaggageKey).Value()
+		return c.NoContent(http.StatusOK)
+	})
+	echoRouter.GET(HealthPathPrefix, func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	request.Header.Set("traceparent", testTraceParent)
+	request.Header.Set(consts.KardinalTraceIdHeaderKey, testKardinalTraceID)
+	echoRouter.ServeHTTP(httptest.NewRecorder(), request)
+
+	// the probes are not traced
+	echoRouter.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, HealthPathPrefix, nil))
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, testParentTraceID, spans[0].SpanContext().TraceID().String())
+	require.Contains(t, spans[0].Attributes(), attribute.String(consts.KardinalTraceIdBaggageKey, testKardinalTraceID))
+	require.Equal(t, testKardinalTraceID, kardinalTraceIDInBaggage)
+}
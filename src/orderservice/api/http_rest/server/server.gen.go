@@ -0,0 +1,880 @@
+// Package orderservice_server_rest_server provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.1-0.20240604070534-2f0ff757704b DO NOT EDIT.
+package orderservice_server_rest_server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	. "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Purge flow
+	// (DELETE /admin/flows/{flow_id})
+	DeleteAdminFlowsFlowId(ctx echo.Context, flowId FlowId) error
+	// Health check endpoint
+	// (GET /health)
+	GetHealth(ctx echo.Context) error
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx echo.Context) error
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx echo.Context) error
+	// List orders
+	// (GET /orders)
+	GetOrders(ctx echo.Context, params GetOrdersParams) error
+	// Create order
+	// (POST /orders)
+	PostOrders(ctx echo.Context) error
+	// Get order
+	// (GET /orders/{order_id})
+	GetOrdersOrderId(ctx echo.Context, orderId OrderId) error
+	// Update order status
+	// (PUT /orders/{order_id}/status)
+	PutOrdersOrderIdStatus(ctx echo.Context, orderId OrderId) error
+	// List recent purchases
+	// (GET /purchases)
+	GetPurchases(ctx echo.Context, params GetPurchasesParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// DeleteAdminFlowsFlowId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteAdminFlowsFlowId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "flow_id" -------------
+	var flowId FlowId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "flow_id", ctx.Param("flow_id"), &flowId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter flow_id: %s", err))
+	}
+
+	ctx.Set(AdminTokenScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteAdminFlowsFlowId(ctx, flowId)
+	return err
+}
+
+// GetHealth converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealth(ctx)
+	return err
+}
+
+// GetHealthLive converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthLive(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthLive(ctx)
+	return err
+}
+
+// GetHealthReady converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthReady(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthReady(ctx)
+	return err
+}
+
+// GetOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOrders(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOrdersParams
+	// ------------- Required query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrders(ctx, params)
+	return err
+}
+
+// PostOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) PostOrders(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostOrders(ctx)
+	return err
+}
+
+// GetOrdersOrderId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOrdersOrderId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "order_id" -------------
+	var orderId OrderId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", ctx.Param("order_id"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrdersOrderId(ctx, orderId)
+	return err
+}
+
+// PutOrdersOrderIdStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) PutOrdersOrderIdStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "order_id" -------------
+	var orderId OrderId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "order_id", ctx.Param("order_id"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter order_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutOrdersOrderIdStatus(ctx, orderId)
+	return err
+}
+
+// GetPurchases converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPurchases(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPurchasesParams
+	// ------------- Required query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPurchases(ctx, params)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.DELETE(baseURL+"/admin/flows/:flow_id", wrapper.DeleteAdminFlowsFlowId)
+	router.GET(baseURL+"/health", wrapper.GetHealth)
+	router.GET(baseURL+"/health/live", wrapper.GetHealthLive)
+	router.GET(baseURL+"/health/ready", wrapper.GetHealthReady)
+	router.GET(baseURL+"/orders", wrapper.GetOrders)
+	router.POST(baseURL+"/orders", wrapper.PostOrders)
+	router.GET(baseURL+"/orders/:order_id", wrapper.GetOrdersOrderId)
+	router.PUT(baseURL+"/orders/:order_id/status", wrapper.PutOrdersOrderIdStatus)
+	router.GET(baseURL+"/purchases", wrapper.GetPurchases)
+
+}
+
+type NotOkJSONResponse ResponseInfo
+
+type DeleteAdminFlowsFlowIdRequestObject struct {
+	FlowId FlowId `json:"flow_id"`
+}
+
+type DeleteAdminFlowsFlowIdResponseObject interface {
+	VisitDeleteAdminFlowsFlowIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteAdminFlowsFlowId200JSONResponse PurgeFlowResponse
+
+func (response DeleteAdminFlowsFlowId200JSONResponse) VisitDeleteAdminFlowsFlowIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteAdminFlowsFlowIddefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response DeleteAdminFlowsFlowIddefaultJSONResponse) VisitDeleteAdminFlowsFlowIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthRequestObject struct {
+}
+
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
+}
+
+type GetHealth200JSONResponse HealthResponse
+
+func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthdefaultJSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthLiveRequestObject struct {
+}
+
+type GetHealthLiveResponseObject interface {
+	VisitGetHealthLiveResponse(w http.ResponseWriter) error
+}
+
+type GetHealthLive200JSONResponse HealthResponse
+
+func (response GetHealthLive200JSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthLivedefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthLivedefaultJSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthReadyRequestObject struct {
+}
+
+type GetHealthReadyResponseObject interface {
+	VisitGetHealthReadyResponse(w http.ResponseWriter) error
+}
+
+type GetHealthReady200JSONResponse HealthResponse
+
+func (response GetHealthReady200JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReady503JSONResponse HealthResponse
+
+func (response GetHealthReady503JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReadydefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthReadydefaultJSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOrdersRequestObject struct {
+	Params GetOrdersParams
+}
+
+type GetOrdersResponseObject interface {
+	VisitGetOrdersResponse(w http.ResponseWriter) error
+}
+
+type GetOrders200JSONResponse ListOrdersResponse
+
+func (response GetOrders200JSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrdersdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetOrdersdefaultJSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostOrdersRequestObject struct {
+	Body *PostOrdersJSONRequestBody
+}
+
+type PostOrdersResponseObject interface {
+	VisitPostOrdersResponse(w http.ResponseWriter) error
+}
+
+type PostOrders200JSONResponse Order
+
+func (response PostOrders200JSONResponse) VisitPostOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostOrdersdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostOrdersdefaultJSONResponse) VisitPostOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOrdersOrderIdRequestObject struct {
+	OrderId OrderId `json:"order_id"`
+}
+
+type GetOrdersOrderIdResponseObject interface {
+	VisitGetOrdersOrderIdResponse(w http.ResponseWriter) error
+}
+
+type GetOrdersOrderId200JSONResponse Order
+
+func (response GetOrdersOrderId200JSONResponse) VisitGetOrdersOrderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrdersOrderIddefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetOrdersOrderIddefaultJSONResponse) VisitGetOrdersOrderIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutOrdersOrderIdStatusRequestObject struct {
+	OrderId OrderId `json:"order_id"`
+	Body    *PutOrdersOrderIdStatusJSONRequestBody
+}
+
+type PutOrdersOrderIdStatusResponseObject interface {
+	VisitPutOrdersOrderIdStatusResponse(w http.ResponseWriter) error
+}
+
+type PutOrdersOrderIdStatus200JSONResponse Order
+
+func (response PutOrdersOrderIdStatus200JSONResponse) VisitPutOrdersOrderIdStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutOrdersOrderIdStatusdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PutOrdersOrderIdStatusdefaultJSONResponse) VisitPutOrdersOrderIdStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetPurchasesRequestObject struct {
+	Params GetPurchasesParams
+}
+
+type GetPurchasesResponseObject interface {
+	VisitGetPurchasesResponse(w http.ResponseWriter) error
+}
+
+type GetPurchases200JSONResponse ListPurchasesResponse
+
+func (response GetPurchases200JSONResponse) VisitGetPurchasesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPurchasesdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetPurchasesdefaultJSONResponse) VisitGetPurchasesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Purge flow
+	// (DELETE /admin/flows/{flow_id})
+	DeleteAdminFlowsFlowId(ctx context.Context, request DeleteAdminFlowsFlowIdRequestObject) (DeleteAdminFlowsFlowIdResponseObject, error)
+	// Health check endpoint
+	// (GET /health)
+	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx context.Context, request GetHealthLiveRequestObject) (GetHealthLiveResponseObject, error)
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx context.Context, request GetHealthReadyRequestObject) (GetHealthReadyResponseObject, error)
+	// List orders
+	// (GET /orders)
+	GetOrders(ctx context.Context, request GetOrdersRequestObject) (GetOrdersResponseObject, error)
+	// Create order
+	// (POST /orders)
+	PostOrders(ctx context.Context, request PostOrdersRequestObject) (PostOrdersResponseObject, error)
+	// Get order
+	// (GET /orders/{order_id})
+	GetOrdersOrderId(ctx context.Context, request GetOrdersOrderIdRequestObject) (GetOrdersOrderIdResponseObject, error)
+	// Update order status
+	// (PUT /orders/{order_id}/status)
+	PutOrdersOrderIdStatus(ctx context.Context, request PutOrdersOrderIdStatusRequestObject) (PutOrdersOrderIdStatusResponseObject, error)
+	// List recent purchases
+	// (GET /purchases)
+	GetPurchases(ctx context.Context, request GetPurchasesRequestObject) (GetPurchasesResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// DeleteAdminFlowsFlowId operation middleware
+func (sh *strictHandler) DeleteAdminFlowsFlowId(ctx echo.Context, flowId FlowId) error {
+	var request DeleteAdminFlowsFlowIdRequestObject
+
+	request.FlowId = flowId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAdminFlowsFlowId(ctx.Request().Context(), request.(DeleteAdminFlowsFlowIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAdminFlowsFlowId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteAdminFlowsFlowIdResponseObject); ok {
+		return validResponse.VisitDeleteAdminFlowsFlowIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealth operation middleware
+func (sh *strictHandler) GetHealth(ctx echo.Context) error {
+	var request GetHealthRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealth(ctx.Request().Context(), request.(GetHealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealth")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthResponseObject); ok {
+		return validResponse.VisitGetHealthResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthLive operation middleware
+func (sh *strictHandler) GetHealthLive(ctx echo.Context) error {
+	var request GetHealthLiveRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthLive(ctx.Request().Context(), request.(GetHealthLiveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthLive")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthLiveResponseObject); ok {
+		return validResponse.VisitGetHealthLiveResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthReady operation middleware
+func (sh *strictHandler) GetHealthReady(ctx echo.Context) error {
+	var request GetHealthReadyRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthReady(ctx.Request().Context(), request.(GetHealthReadyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthReady")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthReadyResponseObject); ok {
+		return validResponse.VisitGetHealthReadyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetOrders operation middleware
+func (sh *strictHandler) GetOrders(ctx echo.Context, params GetOrdersParams) error {
+	var request GetOrdersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrders(ctx.Request().Context(), request.(GetOrdersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrders")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetOrdersResponseObject); ok {
+		return validResponse.VisitGetOrdersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostOrders operation middleware
+func (sh *strictHandler) PostOrders(ctx echo.Context) error {
+	var request PostOrdersRequestObject
+
+	var body PostOrdersJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostOrders(ctx.Request().Context(), request.(PostOrdersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostOrders")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostOrdersResponseObject); ok {
+		return validResponse.VisitPostOrdersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetOrdersOrderId operation middleware
+func (sh *strictHandler) GetOrdersOrderId(ctx echo.Context, orderId OrderId) error {
+	var request GetOrdersOrderIdRequestObject
+
+	request.OrderId = orderId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrdersOrderId(ctx.Request().Context(), request.(GetOrdersOrderIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrdersOrderId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetOrdersOrderIdResponseObject); ok {
+		return validResponse.VisitGetOrdersOrderIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PutOrdersOrderIdStatus operation middleware
+func (sh *strictHandler) PutOrdersOrderIdStatus(ctx echo.Context, orderId OrderId) error {
+	var request PutOrdersOrderIdStatusRequestObject
+
+	request.OrderId = orderId
+
+	var body PutOrdersOrderIdStatusJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutOrdersOrderIdStatus(ctx.Request().Context(), request.(PutOrdersOrderIdStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutOrdersOrderIdStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutOrdersOrderIdStatusResponseObject); ok {
+		return validResponse.VisitPutOrdersOrderIdStatusResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetPurchases operation middleware
+func (sh *strictHandler) GetPurchases(ctx echo.Context, params GetPurchasesParams) error {
+	var request GetPurchasesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPurchases(ctx.Request().Context(), request.(GetPurchasesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPurchases")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetPurchasesResponseObject); ok {
+		return validResponse.VisitGetPurchasesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/9Ra3W4ctxV+FYINkJuRdiO5SaM7NU5cIY4lSDYKxFAFinN2h9EMOSbPaL0V9rYP0Efs",
+	"kxSHnN8d7o8iKXWDAJ6dOSQ/fueXh3rg0hSl0aDR8ZMHXgorCkCw/tcsN4sbldJjCk5aVaIymp/wn4VN",
+	"lRY5IwmmUp5wRe9LgRlPuBYF8JN2eMItfKqUhZSfoK0g4U5mUAia9ysLM37C/zTpcEzCVzf5KTeLs5Sv",
+	"VgnPVaFwjKMQn1VRFUxXxS1YZmbM2BSsY2iYBaysbpB9qsAuO2hhvm3AZsYWAvkJVxqPj3jSrMVPvplO",
+	"pwkvlK5/JhyXJQRJmIP1gD2OKHf+y0bO2nG/l7RzmqBmrXIbMKiUuMIMGImwRWZYmQsJqX8XONzAXOWe",
+	"hu+Dq+GtaAZXGu3AG9s7g+d39CCNRtBe26IscyUFoZ785gj6w57LXNZTn+mZCYsNGfig4XMJEiFlYK0J",
+	"SqsH09ynaWrB+cfSmhIsqgBTKlzSv4XSb0HPMetbgEOr9JyvEi5NpdHuI+lQIJBc5IsFwBvRQdkx1T9V",
+	"eSNNCjtFV33tfVxfKAmb7DbRm/m6ncvc/gYSadkfLAgEb3iX8KkCh2PaoBAqH/hVeBPZRa50GKMQCreX",
+	"vb9VGmhoofRZGNTtWVgrlnzNJffyoISXYlmAxhu0QjshyXai/kReIzNh59D4lV8sYQ3LbJGBZgq/dkx6",
+	"tlKmtBcshUoZ2UDlYmQEt7wROOAuFQgHqAqIDXGZKkul533D2bbfxtT7Q6VxuGvcL0bDsrHgaj9FXQXR",
+	"VcLRoMhvaPd7r9MLZ3tFmKGR9yJrF8MaI2wVMCJvnZTGPgc76Osp6iGVtaDl8ofaOYfWc3Z1zl4dffMd",
+	"k7UY866WcPgsijKnqT5cvaZFBCJYGvKPj6cHv14/HK++ilnAayhBp37BDORdxBt9yIvFnFwgjbsJjtcC",
+	"OE4GCfHbV3yc9ZoU0RtGhipuhYvbaWs2nby547uClV+kp7Ee4hj1dRFBEVF8biPi0V+SXcH0byByzJo8",
+	"EkkERO3+cWpdJ6txgIoR8uEiRh25vkNRlEPho+nRq4PpdwdH37+fTk/8/7/yZK+wsYpQ91Y59F7rNrNQ",
+	"1wqPitbjvcdcNa5OwnRRWZkJB1tglY3I3siaSXeC66aO4QvBamwstWe36XkblEG08G6ljYsnHfrEKq3Q",
+	"NYlHFJS0E7ZQmPkXThTAnJprJpx/4cV5sqXC/b75r1fmHvRejj0/zBmFuMhMDjGMfI+Yssb9kMZm2Yah",
+	"mD6CwW0uR56z+njRguP/szJoRqEV8o4eNmzmDyshSiKoYW1orL5E8x7jV2C5cEhVnZ5DumcU/cJrlLhx",
+	"bVBT38IGxG10s1Ga/fbVzizbOdA4Zj7G0kpr0kriHtRfBMngb58qobE+0Y3j4fYj/iApdMv3Jk3CHjYS",
+	"dtUlfE3rfKwp97rqNONfpJCre7D+WQotIc8h5dcRSrsNPlobbRKM5/lHxrLHB6iOxg3JpBZwa8csEDJj",
+	"RgMzWtLE+2X8viHsrkdqn+gh3FX1X1R2DlR/bq5TUsiB3KoroyI5fr25VQ/ie9XkvR7enr22/s67Ft4a",
+	"0th+Bz2XiDuHyqfFXDV+NgZdgHNiHu+KhBf7dX/ek+z6nvwE3RoJ39jYGEzT89IfLy/PL3nCz979dM4T",
+	"/vfTy3dn795EnfGDj5s9b9/YIXm+XgMaVph7YArp0TcYKp2Dc/RmIRxzgE1Dwv+uWxJbS4a1DD7G1Aiw",
+	"s9cNMBpMe4pga3HTmy7KPUdhMGpu+dcx/dZZ+LFnQ4IFsrIKl1cEIOhPpIXS780d6DE/76nope8MSaDl",
+	"B+y9kpB4hShShwtiB0HMAaLS80PWjQedlkZpErTAUuXEbT5oL2mDNO6Q1+1MAn4LwoLt6M0Qy9AUVbWr",
+	"DuFe/nj1flbl7PTijLkSpJrVTVg2M9Yj94R3+P3KlQv6FRWagzlosAKByVyBRnb1+uevHRM69YPAHjiV",
+	"QtPnQIX+/DqYlSf8HqwLiKaH3xxOfU1dghal4if8+HB6eByaIplXwMQzNKGQ5SYPdeRadVF2vM/X/r1j",
+	"Is87g3RsYRUiaHa79G9t8Ng259DMic807U+mHJsprVwGadAWNT2oCGNS6K+R3QIrKRukpBfyek/nWdqC",
+	"OCXsFIRdHYmTwV3Mx7j9dyKTer98db3WVz+aTp+tqz7OaJHW+lUlJThHBmRbMRKaiSrfWNG1mCfhIqDv",
+	"ZX7/ff/6eE3bdFVRCLvkJyHTek34cZPM925oqTlEKv1LfzUUTsNBtKn5h445VtYbwNAX4i/I8lrnKUZx",
+	"wEdmF/Avfy/DLYVhUea7Wm2U6bM5ofpzJ6Xmji0ylQfXKK2RPu04RmHKh3ry/talKHKw1IAjHwlL07i0",
+	"6ZUpcFt08JYAfSl6EJ6ep2qBtqSJsi16sCDS5UZF+O6iG9E4SjkKmYXSWAwpw0/K1IwJvaxlCzYTKnfM",
+	"WKYGg2m7Lqt8bmKpWegtOrr0YL8UJYVd+sthCereFy2zmZKktz9Pj/83oDr648CeZFDEv9poUd2xY2ec",
+	"DKL9a+PEPxXGoUetkc2UdRg1hvPmSvlxSa1pgrxoUou0uV82q/W83WFNLM1V1g2PdSVIY1PHBNOwaGr9",
+	"5vIwXNo3yYs+zJqi3tf9og6rpsIkcuM41tWFcZ2y6jD9V5Mun43tyGXxarVa/2uC1Qvqu76D2EvFwcab",
+	"K1vT3F48SeuBgWayzg0nD02fYdXzyA2u1PRZHutRzRIv61KPofjJfL4B3ErmpDtDllXEwX4x9+CGB1L6",
+	"oeEz9spCOpvlagZyKXNIGs/7z7/+HfyJHupjrH9u23UJE7qeWApNB4G2e8cqjSoPh6dW/pCdUgbGjJBY",
+	"oZ3yBy+qMJg0epYrGQmxF9XQLq6aXvGTrOP5vX9jO+SLjwF1A/yZYkDgobYL1zUuJoOLy51JuW2H3ppq",
+	"nmET4fs5ObjDhlwdrglNhf5zZ5hh0CE7I9NcZALrg7A0RQE69YyHDsRtpXJkM2uKhDnTL+cR8jz+l2XR",
+	"CqG91H200Ya/5XvxEmF86/xHVgm11jrz8IuHXkpgaQhk0EqhRg5PeGXzuvnjTiZ1nAwCfHW9+u8AmOU6",
+	"VQsqAAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
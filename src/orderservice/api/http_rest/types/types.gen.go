@@ -0,0 +1,195 @@
+// Package orderservice_rest_types provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.1-0.20240604070534-2f0ff757704b DO NOT EDIT.
+package orderservice_rest_types
+
+import (
+	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	AdminTokenScopes = "adminToken.Scopes"
+)
+
+// Defines values for OrderStatus.
+const (
+	Cancelled OrderStatus = "cancelled"
+	Delivered OrderStatus = "delivered"
+	Paid      OrderStatus = "paid"
+	Placed    OrderStatus = "placed"
+	Shipped   OrderStatus = "shipped"
+)
+
+// Defines values for ResponseType.
+const (
+	ERROR   ResponseType = "ERROR"
+	INFO    ResponseType = "INFO"
+	WARNING ResponseType = "WARNING"
+)
+
+// Address defines model for Address.
+type Address struct {
+	City          string  `json:"city"`
+	Country       string  `json:"country"`
+	State         *string `json:"state,omitempty"`
+	StreetAddress string  `json:"street_address"`
+	ZipCode       string  `json:"zip_code"`
+}
+
+// CreateOrderRequest defines model for CreateOrderRequest.
+type CreateOrderRequest struct {
+	Email   openapi_types.Email `json:"email"`
+	Lines   []OrderLine         `json:"lines"`
+	OrderId OrderId             `json:"order_id"`
+
+	// PaymentTransactionId the charge of the order, required when it's created in the paid status
+	PaymentTransactionId *string     `json:"payment_transaction_id,omitempty"`
+	PlacedAt             time.Time   `json:"placed_at"`
+	ShippingAddress      Address     `json:"shipping_address"`
+	ShippingCost         Money       `json:"shipping_cost"`
+	Status               OrderStatus `json:"status"`
+	TotalPaid            Money       `json:"total_paid"`
+	UserId               UserId      `json:"user_id"`
+}
+
+// CurrencyCode ISO 4217 currency code
+type CurrencyCode = string
+
+// DependencyCheck defines model for DependencyCheck.
+type DependencyCheck struct {
+	Error     *string `json:"error,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+}
+
+// FlowId defines model for FlowId.
+type FlowId = string
+
+// HealthResponse defines model for HealthResponse.
+type HealthResponse struct {
+	Checks    *[]DependencyCheck `json:"checks,omitempty"`
+	Status    *string            `json:"status,omitempty"`
+	Timestamp *time.Time         `json:"timestamp,omitempty"`
+}
+
+// ListOrdersResponse defines model for ListOrdersResponse.
+type ListOrdersResponse struct {
+	Orders []Order `json:"orders"`
+}
+
+// ListPurchasesResponse defines model for ListPurchasesResponse.
+type ListPurchasesResponse struct {
+	Purchases []Purchase `json:"purchases"`
+}
+
+// Money defines model for Money.
+type Money struct {
+	// CurrencyCode ISO 4217 currency code
+	CurrencyCode CurrencyCode `json:"currency_code"`
+
+	// Nanos the nano units of the amount, with the same sign as the units
+	Nanos int32 `json:"nanos"`
+
+	// Units the whole units of the amount
+	Units int64 `json:"units"`
+}
+
+// Order defines model for Order.
+type Order struct {
+	Email                string      `json:"email"`
+	Lines                []OrderLine `json:"lines"`
+	OrderId              OrderId     `json:"order_id"`
+	PaymentTransactionId string      `json:"payment_transaction_id"`
+	PlacedAt             time.Time   `json:"placed_at"`
+	ShippingAddress      Address     `json:"shipping_address"`
+	ShippingCost         Money       `json:"shipping_cost"`
+	ShippingTrackingId   string      `json:"shipping_tracking_id"`
+	Status               OrderStatus `json:"status"`
+	TotalPaid            Money       `json:"total_paid"`
+
+	// UpdatedAt when the status last changed
+	UpdatedAt time.Time `json:"updated_at"`
+	UserId    UserId    `json:"user_id"`
+}
+
+// OrderId defines model for OrderId.
+type OrderId = string
+
+// OrderLine defines model for OrderLine.
+type OrderLine struct {
+	Cost      Money     `json:"cost"`
+	ProductId ProductId `json:"product_id"`
+	Quantity  int32     `json:"quantity"`
+}
+
+// OrderStatus defines model for OrderStatus.
+type OrderStatus string
+
+// ProductId defines model for ProductId.
+type ProductId = string
+
+// Purchase defines model for Purchase.
+type Purchase struct {
+	OrderId  OrderId   `json:"order_id"`
+	PlacedAt time.Time `json:"placed_at"`
+
+	// ProductIds the products of the order, each one once
+	ProductIds []ProductId `json:"product_ids"`
+}
+
+// PurgeFlowResponse defines model for PurgeFlowResponse.
+type PurgeFlowResponse struct {
+	// DeletedOrders the number of orders deleted
+	DeletedOrders int64  `json:"deleted_orders"`
+	FlowId        FlowId `json:"flow_id"`
+}
+
+// ResponseInfo defines model for ResponseInfo.
+type ResponseInfo struct {
+	Code    uint32       `json:"code"`
+	Message string       `json:"message"`
+	Type    ResponseType `json:"type"`
+}
+
+// ResponseType defines model for ResponseType.
+type ResponseType string
+
+// UpdateOrderStatusRequest defines model for UpdateOrderStatusRequest.
+type UpdateOrderStatusRequest struct {
+	// PaymentTransactionId the charge of the order, required to move it to paid unless it was set when it was created
+	PaymentTransactionId *string `json:"payment_transaction_id,omitempty"`
+
+	// ShippingTrackingId the tracking ID of the shipment, required to move the order to shipped
+	ShippingTrackingId *string     `json:"shipping_tracking_id,omitempty"`
+	Status             OrderStatus `json:"status"`
+}
+
+// UserId defines model for UserId.
+type UserId = string
+
+// Limit defines model for limit.
+type Limit = int32
+
+// NotOk defines model for NotOk.
+type NotOk = ResponseInfo
+
+// GetOrdersParams defines parameters for GetOrders.
+type GetOrdersParams struct {
+	// UserId id of the user who placed the orders
+	UserId UserId `form:"user_id" json:"user_id"`
+}
+
+// GetPurchasesParams defines parameters for GetPurchases.
+type GetPurchasesParams struct {
+	// Limit maximum number of orders to return
+	Limit Limit `form:"limit" json:"limit"`
+}
+
+// PostOrdersJSONRequestBody defines body for PostOrders for application/json ContentType.
+type PostOrdersJSONRequestBody = CreateOrderRequest
+
+// PutOrdersOrderIdStatusJSONRequestBody defines body for PutOrdersOrderIdStatus for application/json ContentType.
+type PutOrdersOrderIdStatusJSONRequestBody = UpdateOrderStatusRequest
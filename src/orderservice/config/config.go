@@ -0,0 +1,262 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/configloader"
+	"github.com/kurtosis-tech/new-obd/src/libs/consts"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	"github.com/pkg/errors"
+)
+
+const (
+	PostgresOrderStore = "postgres"
+	MemoryOrderStore   = "memory"
+
+	defaultHost        = "0.0.0.0"
+	defaultPort uint16 = 8000
+
+	defaultShutdownTimeout = 15 * time.Second
+	// the readiness probes of the manifests run every 10s
+	defaultDrainDelay = 10 * time.Second
+
+	defaultDatabasePort = "5432"
+
+	headerListSeparator = ","
+)
+
+// defaultFlowIDHeaders are the headers the flow of a request is read from, the dedicated one takes precedence
+var defaultFlowIDHeaders = []string{consts.KardinalFlowIdHeaderKey, consts.KardinalTraceIdHeaderKey}
+
+// Config is the configuration of the order service, run it with --help to list the settings
+type Config struct {
+	Host string
+	Port uint16
+	// ShutdownTimeout is the deadline to drain the in-flight requests when the service stops
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long the service keeps serving after it stops reporting ready, before it shuts down
+	DrainDelay time.Duration
+	// OrderStore selects the OrderStore implementation, PostgresOrderStore or MemoryOrderStore
+	OrderStore       string
+	MigrateOnStartup bool
+	Database         DatabaseConfig
+	// FlowIDHeaders are the headers the Kardinal flow of a request is read from, the first one set wins
+	FlowIDHeaders []string
+	// AdminToken is the bearer token of the admin endpoints, they are disabled when it's empty
+	AdminToken string
+	Tracing    tracing.Config
+}
+
+// DatabaseConfig is the Postgres connection, the URI takes precedence over the other fields
+type DatabaseConfig struct {
+	URI      string
+	Host     string
+	Port     string
+	Username string
+	Password string
+	Name     string
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Host:             defaultHost,
+		Port:             defaultPort,
+		ShutdownTimeout:  defaultShutdownTimeout,
+		DrainDelay:       defaultDrainDelay,
+		OrderStore:       PostgresOrderStore,
+		MigrateOnStartup: true,
+		Database: DatabaseConfig{
+			Port: defaultDatabasePort,
+		},
+		FlowIDHeaders: defaultFlowIDHeaders,
+		Tracing:       tracing.DefaultConfig(),
+	}
+}
+
+// Address is the address the REST API server listens on
+func (cfg *Config) Address() string {
+	return net.JoinHostPort(cfg.Host, strconv.Itoa(int(cfg.Port)))
+}
+
+var settings = append([]setting{
+	{
+		Key:         "host",
+		EnvVar:      "HOST",
+		Description: "IP the REST API server listens on",
+		Get:         func(cfg *Config) string { return cfg.Host },
+		Set:         func(cfg *Config, value string) error { cfg.Host = value; return nil },
+	},
+	{
+		Key:         "port",
+		EnvVar:      "PORT",
+		Description: "port the REST API server listens on",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.Port)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.Port = port
+			return err
+		},
+	},
+	{
+		Key:         "shutdown-timeout",
+		EnvVar:      "SHUTDOWN_TIMEOUT",
+		Description: "deadline to drain the in-flight requests when the service stops",
+		Get:         func(cfg *Config) string { return cfg.ShutdownTimeout.String() },
+		Set: func(cfg *Config, value string) error {
+			timeout, err := configloader.ParseDuration(value)
+			cfg.ShutdownTimeout = timeout
+			return err
+		},
+	},
+	{
+		Key:         "drain-delay",
+		EnvVar:      "DRAIN_DELAY",
+		Description: "time the service keeps serving after it stops reporting ready on shutdown, at least the readiness probe period",
+		Get:         func(cfg *Config) string { return cfg.DrainDelay.String() },
+		Set: func(cfg *Config, value string) error {
+			delay, err := configloader.ParseDuration(value)
+			cfg.DrainDelay = delay
+			return err
+		},
+	},
+	{
+		Key:         "order-store",
+		EnvVar:      "ORDER_STORE",
+		Description: fmt.Sprintf("where the orders are stored, '%s' or '%s'", PostgresOrderStore, MemoryOrderStore),
+		Get:         func(cfg *Config) string { return cfg.OrderStore },
+		Set:         func(cfg *Config, value string) error { cfg.OrderStore = value; return nil },
+	},
+	{
+		Key:         "db-migrate-on-startup",
+		EnvVar:      "DB_MIGRATE_ON_STARTUP",
+		Description: "apply the pending database migrations when the service starts",
+		Get:         func(cfg *Config) string { return strconv.FormatBool(cfg.MigrateOnStartup) },
+		Set: func(cfg *Config, value string) error {
+			migrateOnStartup, err := configloader.ParseBool(value)
+			cfg.MigrateOnStartup = migrateOnStartup
+			return err
+		},
+	},
+	{
+		Key:         "db-uri",
+		EnvVar:      "POSTGRES",
+		Description: "Postgres connection URI, it takes precedence over the other database settings",
+		Secret:      true,
+		Get:         func(cfg *Config) string { return cfg.Database.URI },
+		Set:         func(cfg *Config, value string) error { cfg.Database.URI = value; return nil },
+	},
+	{
+		Key:         "db-host",
+		EnvVar:      "DB_HOST",
+		Description: "Postgres host",
+		Get:         func(cfg *Config) string { return cfg.Database.Host },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Host = value; return nil },
+	},
+	{
+		Key:         "db-port",
+		EnvVar:      "DB_PORT",
+		Description: "Postgres port",
+		Get:         func(cfg *Config) string { return cfg.Database.Port },
+		Set: func(cfg *Config, value string) error {
+			if _, err := configloader.ParsePort(value); err != nil {
+				return err
+			}
+			cfg.Database.Port = value
+			return nil
+		},
+	},
+	{
+		Key:         "db-username",
+		EnvVar:      "DB_USERNAME",
+		Description: "Postgres user",
+		Get:         func(cfg *Config) string { return cfg.Database.Username },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Username = value; return nil },
+	},
+	{
+		Key:         "db-password",
+		EnvVar:      "DB_PASSWORD",
+		Description: "Postgres password",
+		Secret:      true,
+		Get:         func(cfg *Config) string { return cfg.Database.Password },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Password = value; return nil },
+	},
+	{
+		Key:         "db-name",
+		EnvVar:      "DB_NAME",
+		Description: "Postgres database",
+		Get:         func(cfg *Config) string { return cfg.Database.Name },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Name = value; return nil },
+	},
+	{
+		Key:         "flow-id-headers",
+		EnvVar:      "FLOW_ID_HEADERS",
+		Description: "comma-separated headers the Kardinal flow of a request is read from, the orders are isolated per flow",
+		Get:         func(cfg *Config) string { return strings.Join(cfg.FlowIDHeaders, headerListSeparator) },
+		Set: func(cfg *Config, value string) error {
+			headers, err := parseHeaderList(value)
+			cfg.FlowIDHeaders = headers
+			return err
+		},
+	},
+	{
+		Key:         "admin-token",
+		EnvVar:      "ADMIN_TOKEN",
+		Description: "bearer token of the admin endpoints (e.g. the purge of a flow), they are disabled when it's empty",
+		Secret:      true,
+		Get:         func(cfg *Config) string { return cfg.AdminToken },
+		Set:         func(cfg *Config, value string) error { cfg.AdminToken = value; return nil },
+	},
+}, tracing.Settings(func(cfg *Config) *tracing.Config { return &cfg.Tracing })...)
+
+func (cfg *Config) validate() []string {
+	var violations []string
+	if net.ParseIP(cfg.Host) == nil {
+		violations = append(violations, fmt.Sprintf("'host' must be an IP address, got '%s'", cfg.Host))
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		violations = append(violations, fmt.Sprintf("'shutdown-timeout' must be positive, got '%s'", cfg.ShutdownTimeout))
+	}
+	if cfg.DrainDelay < 0 {
+		violations = append(violations, fmt.Sprintf("'drain-delay' can't be negative, got '%s'", cfg.DrainDelay))
+	}
+	switch cfg.OrderStore {
+	case MemoryOrderStore:
+	case PostgresOrderStore:
+		if cfg.Database.URI == "" {
+			requiredSettings := []struct{ key, value string }{
+				{"db-host", cfg.Database.Host},
+				{"db-username", cfg.Database.Username},
+				{"db-name", cfg.Database.Name},
+			}
+			for _, required := range requiredSettings {
+				if required.value == "" {
+					violations = append(violations, fmt.Sprintf("'%s' is required when 'db-uri' isn't set", required.key))
+				}
+			}
+		}
+	default:
+		violations = append(violations, fmt.Sprintf("'order-store' must be '%s' or '%s', got '%s'", PostgresOrderStore, MemoryOrderStore, cfg.OrderStore))
+	}
+	violations = append(violations, cfg.Tracing.Validate()...)
+	return violations
+}
+
+func parseHeaderList(value string) ([]string, error) {
+	headers := []string{}
+	for _, header := range strings.Split(value, headerListSeparator) {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if strings.ContainsAny(header, " \t:") {
+			return nil, errors.Errorf("'%s' is not a valid header name", header)
+		}
+		headers = append(headers, textproto.CanonicalMIMEHeaderKey(header))
+	}
+	return headers, nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
+	"github.com/kurtosis-tech/new-obd/src/libs/metrics"
+	"github.com/kurtosis-tech/new-obd/src/libs/rest"
+	"github.com/kurtosis-tech/new-obd/src/libs/shutdown"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	orderservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/server"
+	"github.com/kurtosis-tech/new-obd/src/orderservice/config"
+	"github.com/kurtosis-tech/new-obd/src/orderservice/orderstore"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+)
+
+const (
+	serviceName = "orderservice"
+
+	// the exit code of an invalid config, it follows the ones of the shutdown package
+	exitCodeInvalidConfig = 3
+)
+
+var (
+	defaultCORSOrigins = []string{"*"}
+	defaultCORSHeaders = []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept}
+)
+
+func main() {
+	cfg, commandLine, err := config.Load(os.Args[0], os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitCodeInvalidConfig)
+	}
+
+	if commandLine.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+
+	if len(commandLine.Args) > 0 {
+		if commandLine.Args[0] != database.MigrateCommandName {
+			fmt.Fprintf(os.Stderr, "unknown command '%s', the only command is '%s'\n", commandLine.Args[0], database.MigrateCommandName)
+			os.Exit(exitCodeInvalidConfig)
+		}
+		if err := runMigrateCommand(cfg, commandLine.Args[1:]); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), serviceName, cfg.Tracing)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Info("Running REST API server...")
+
+	// This is how you set up a basic Echo router
+	echoRouter := echo.New()
+	echoRouter.HTTPErrorHandler = rest.NewResponseInfoHTTPErrorHandler(statusCodeForError)
+	// first, so the duration includes the other middlewares
+	echoRouter.Use(metrics.Middleware)
+	echoRouter.Use(otelecho.Middleware(serviceName, otelecho.WithSkipper(tracing.Skipper)))
+	echoRouter.Use(middleware.Logger())
+
+	echoRouter.Use(tracing.KardinalTraceIDMiddleware)
+	echoRouter.Use(flow.NewMiddleware(cfg.FlowIDHeaders))
+
+	// CORS configuration
+	echoRouter.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: defaultCORSOrigins,
+		AllowHeaders: defaultCORSHeaders,
+	}))
+
+	store, err := newOrderStore(cfg)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	requestValidatorMiddleware, err := rest.NewRequestValidatorMiddleware(orderservice_server_rest_server.GetSwagger, cfg.AdminToken)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	echoRouter.Use(requestValidatorMiddleware)
+	if cfg.AdminToken == "" {
+		logrus.Info("The admin endpoints are disabled, set the admin token to purge the orders of the finished flows")
+	}
+
+	server := NewServer(store)
+
+	metrics.RegisterHandler(echoRouter)
+
+	strictMiddlewares := []orderservice_server_rest_server.StrictMiddlewareFunc{rest.NewErrorMappingMiddleware(statusCodeForError)}
+	orderservice_server_rest_server.RegisterHandlers(echoRouter, orderservice_server_rest_server.NewStrictHandler(server, strictMiddlewares))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	exitCode := shutdown.ServeUntilShutdown(ctx, func() error {
+		return echoRouter.Start(cfg.Address())
+	}, echoRouter.Shutdown, shutdown.Options{
+		OnShutdownStart: server.MarkShuttingDown,
+		DrainDelay:      cfg.DrainDelay,
+		ShutdownTimeout: cfg.ShutdownTimeout,
+	})
+	stop()
+
+	// the in-memory store has nothing to release, the database one closes its connection pool
+	if closer, ok := store.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logrus.Errorf("An error occurred closing the order store. Error: %s", err)
+			if exitCode == shutdown.ExitCodeOk {
+				exitCode = shutdown.ExitCodeServerError
+			}
+		}
+	}
+
+	shutdown.FlushTraces(shutdownTracing, cfg.ShutdownTimeout)
+	shutdown.FlushLogs()
+	os.Exit(exitCode)
+}
+
+func newOrderStore(cfg *config.Config) (orderstore.OrderStore, error) {
+	switch cfg.OrderStore {
+	case config.MemoryOrderStore:
+		logrus.Info("Using the in-memory order store, orders will be lost when the service stops")
+		return orderstore.NewMemory(), nil
+	case config.PostgresOrderStore:
+		db, err := newDb(cfg.Database)
+		if err != nil {
+			return nil, err
+		}
+		// the migrations hold a lock, so it's safe to run them when several replicas start at once
+		if cfg.MigrateOnStartup {
+			if err := db.MigrateUp(context.Background()); err != nil {
+				return nil, err
+			}
+		}
+		return db, nil
+	default:
+		return nil, fmt.Errorf("unknown order store '%s', valid values are '%s' and '%s'", cfg.OrderStore, config.PostgresOrderStore, config.MemoryOrderStore)
+	}
+}
+
+func newDb(dbConfig config.DatabaseConfig) (*orderstore.Db, error) {
+	return orderstore.NewDb(dbConfig.URI, dbConfig.Host, dbConfig.Username, dbConfig.Password, dbConfig.Name, dbConfig.Port)
+}
@@ -0,0 +1,267 @@
+package orderstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Db struct {
+	db *gorm.DB
+	// Migrator applies the migrations embedded in the binary
+	*database.Migrator
+}
+
+func NewDb(
+	uri string,
+	host string,
+	username string,
+	password string,
+	name string,
+	port string,
+) (*Db, error) {
+	db, err := database.Connect(database.DSN(uri, host, username, password, name, port))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := database.RegisterQueryMetricsCallbacks(db); err != nil {
+		return nil, err
+	}
+	if err := database.RegisterQueryTracingCallbacks(db); err != nil {
+		return nil, err
+	}
+
+	return &Db{
+		db:       db,
+		Migrator: database.NewMigrator(db, migrationsConfig),
+	}, nil
+}
+
+func (db *Db) Close() error {
+	return database.Close(db.db)
+}
+
+func (db *Db) Ping(ctx context.Context) error {
+	sqlDb, err := db.db.DB()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred getting the database connection")
+	}
+
+	if err = sqlDb.PingContext(ctx); err != nil {
+		return errors.Wrap(classifyDbError(err), "An error occurred pinging the database")
+	}
+
+	return nil
+}
+
+func (db *Db) CreateOrder(ctx context.Context, request orderservice_rest_types.CreateOrderRequest) (*orderservice_rest_types.Order, error) {
+	if err := validateCreateOrder(request); err != nil {
+		return nil, err
+	}
+
+	order := newOrder(request, time.Now())
+	row, lines := newOrderRows(flow.IDFromContext(ctx), order)
+
+	// in a transaction, so there's never an order without its lines
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(row).Error; err != nil {
+			return err
+		}
+		return tx.Create(&lines).Error
+	})
+	if err != nil {
+		return nil, errors.Wrap(classifyDbError(err), fmt.Sprintf("An internal error has occurred creating order '%s'", request.OrderId))
+	}
+	logrus.Debugf("Success! Stored order '%s' with %d lines in database", order.OrderId, len(lines))
+	return &order, nil
+}
+
+func (db *Db) GetOrder(ctx context.Context, orderID string) (*orderservice_rest_types.Order, error) {
+	if err := validateOrderID(orderID); err != nil {
+		return nil, err
+	}
+
+	var order *orderservice_rest_types.Order
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		order, err = getOrder(tx, flow.IDFromContext(ctx), orderID, false)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(classifyDbError(err), fmt.Sprintf("An internal error has occurred getting order '%s'", orderID))
+	}
+	return order, nil
+}
+
+func (db *Db) ListOrders(ctx context.Context, userID string) ([]orderservice_rest_types.Order, error) {
+	if err := validateUserID(userID); err != nil {
+		return nil, err
+	}
+	flowID := flow.IDFromContext(ctx)
+
+	orders := []orderservice_rest_types.Order{}
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []Order
+		if err := tx.Where("flow_id = ? AND user_id = ?", flowID, userID).Order("placed_at DESC, order_id").Find(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		orderIDs := make([]string, 0, len(rows))
+		for _, row := range rows {
+			orderIDs = append(orderIDs, row.OrderID)
+		}
+		var lines []OrderLine
+		if err := tx.Where("flow_id = ? AND order_id IN ?", flowID, orderIDs).Order("order_id, line_number").Find(&lines).Error; err != nil {
+			return err
+		}
+		linesByOrderID := map[string][]OrderLine{}
+		for _, line := range lines {
+			linesByOrderID[line.OrderID] = append(linesByOrderID[line.OrderID], line)
+		}
+
+		for _, row := range rows {
+			orders = append(orders, toRestOrder(row, linesByOrderID[row.OrderID]))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(classifyDbError(err), fmt.Sprintf("An internal error has occurred listing the orders of user '%s'", userID))
+	}
+	return orders, nil
+}
+
+func (db *Db) ListRecentPurchases(ctx context.Context, limit int) ([]orderservice_rest_types.Purchase, error) {
+	if err := validatePurchasesLimit(limit); err != nil {
+		return nil, err
+	}
+	flowID := flow.IDFromContext(ctx)
+
+	purchases := []orderservice_rest_types.Purchase{}
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []Order
+		err := tx.Select("order_id", "placed_at").
+			Where("flow_id = ? AND status <> ?", flowID, string(orderservice_rest_types.Cancelled)).
+			Order("placed_at DESC, order_id").
+			Limit(limit).
+			Find(&rows).Error
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		orderIDs := make([]string, 0, len(rows))
+		for _, row := range rows {
+			orderIDs = append(orderIDs, row.OrderID)
+		}
+		var lines []OrderLine
+		if err := tx.Select("order_id", "product_id").Where("flow_id = ? AND order_id IN ?", flowID, orderIDs).Order("order_id, line_number").Find(&lines).Error; err != nil {
+			return err
+		}
+		productIDsByOrderID := map[string][]string{}
+		for _, line := range lines {
+			productIDsByOrderID[line.OrderID] = append(productIDsByOrderID[line.OrderID], line.ProductID)
+		}
+
+		for _, row := range rows {
+			purchases = append(purchases, toPurchase(row.OrderID, row.PlacedAt, productIDsByOrderID[row.OrderID]))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(classifyDbError(err), fmt.Sprintf("An internal error has occurred listing the last %d purchases", limit))
+	}
+	return purchases, nil
+}
+
+func (db *Db) UpdateOrderStatus(ctx context.Context, orderID string, request orderservice_rest_types.UpdateOrderStatusRequest) (*orderservice_rest_types.Order, error) {
+	if err := validateOrderID(orderID); err != nil {
+		return nil, err
+	}
+	flowID := flow.IDFromContext(ctx)
+
+	var order *orderservice_rest_types.Order
+	// the row is locked until the transaction ends, so two concurrent updates can't both leave the same status
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		order, err = getOrder(tx, flowID, orderID, true)
+		if err != nil {
+			return err
+		}
+		if err := applyStatusUpdate(order, request, time.Now()); err != nil {
+			return err
+		}
+		return tx.Model(&Order{}).Where("flow_id = ? AND order_id = ?", flowID, orderID).Updates(map[string]interface{}{
+			"status":                 string(order.Status),
+			"payment_transaction_id": order.PaymentTransactionId,
+			"shipping_tracking_id":   order.ShippingTrackingId,
+			"updated_at":             order.UpdatedAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, errors.Wrap(classifyDbError(err), fmt.Sprintf("An internal error has occurred updating the status of order '%s'", orderID))
+	}
+	return order, nil
+}
+
+func (db *Db) PurgeFlow(ctx context.Context, flowID string) (int64, error) {
+	if flowID == flow.BaselineID {
+		return 0, newInvalidArgumentError("the baseline flow can't be purged")
+	}
+
+	// the lines are deleted with their orders, by the foreign key
+	result := db.db.WithContext(ctx).Where("flow_id = ?", flowID).Delete(&Order{})
+	if result.Error != nil {
+		return 0, errors.Wrap(classifyDbError(result.Error), fmt.Sprintf("An internal error has occurred while purging the orders of flow '%s'", flowID))
+	}
+	logrus.Infof("Purged %d orders of flow '%s'", result.RowsAffected, flowID)
+	return result.RowsAffected, nil
+}
+
+// getOrder reads the order and its lines in the transaction, forUpdate locks the order row until the transaction ends
+func getOrder(tx *gorm.DB, flowID string, orderID string, forUpdate bool) (*orderservice_rest_types.Order, error) {
+	query := tx.Where("flow_id = ? AND order_id = ?", flowID, orderID)
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var rows []Order
+	if err := query.Limit(1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, newNotFoundError(orderID)
+	}
+
+	var lines []OrderLine
+	if err := tx.Where("flow_id = ? AND order_id = ?", flowID, orderID).Order("line_number").Find(&lines).Error; err != nil {
+		return nil, err
+	}
+	order := toRestOrder(rows[0], lines)
+	return &order, nil
+}
+
+// classifyDbError tags the database errors with the matching error kind, the rest are returned untouched
+func classifyDbError(err error) error {
+	switch database.ClassifyError(err) {
+	case database.ConflictError:
+		return newStoreError(ErrConflict, err)
+	case database.InvalidArgumentError:
+		return newStoreError(ErrInvalidArgument, err)
+	case database.UnavailableError:
+		return newStoreError(ErrUnavailable, err)
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+package orderstore_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/orderservice/orderstore"
+	"github.com/kurtosis-tech/new-obd/src/orderservice/orderstore/orderstoretest"
+	"github.com/stretchr/testify/require"
+)
+
+// the Postgres tests only run when this environment variable contains the DSN of a database they can write to
+const testPostgresDSNEnvVarKey = "ORDERSERVICE_TEST_POSTGRES_DSN"
+
+func newTestDb(t *testing.T) *orderstore.Db {
+	dsn := os.Getenv(testPostgresDSNEnvVarKey)
+	if dsn == "" {
+		t.Skipf("Skipping Postgres order store test because the '%s' environment variable is not set", testPostgresDSNEnvVarKey)
+	}
+
+	db, err := orderstore.NewDb(dsn, "", "", "", "", "")
+	require.NoError(t, err)
+	require.NoError(t, db.MigrateUp(context.Background()))
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	return db
+}
+
+func TestDbOrderWithoutStateHasNoState(t *testing.T) {
+	db := newTestDb(t)
+	ctx := context.Background()
+
+	request := orderservice_rest_types.CreateOrderRequest{
+		OrderId: "order-without-state-" + time.Now().Format(time.RFC3339Nano),
+		UserId:  "user-without-state",
+		Email:   "someone@example.com",
+		Status:  orderservice_rest_types.Placed,
+		ShippingAddress: orderservice_rest_types.Address{
+			StreetAddress: "10 Downing Street",
+			City:          "London",
+			Country:       "United Kingdom",
+			ZipCode:       "SW1A 2AA",
+		},
+		ShippingCost: orderservice_rest_types.Money{CurrencyCode: "GBP", Units: 5},
+		Lines: []orderservice_rest_types.OrderLine{
+			{ProductId: "OLJCESPC7Z", Quantity: 1, Cost: orderservice_rest_types.Money{CurrencyCode: "GBP", Units: 15, Nanos: 500_000_000}},
+		},
+		TotalPaid: orderservice_rest_types.Money{CurrencyCode: "GBP", Units: 20, Nanos: 500_000_000},
+		PlacedAt:  time.Now(),
+	}
+	_, err := db.CreateOrder(ctx, request)
+	require.NoError(t, err)
+
+	order, err := db.GetOrder(ctx, request.OrderId)
+	require.NoError(t, err)
+	require.Nil(t, order.ShippingAddress.State)
+	require.Empty(t, order.PaymentTransactionId)
+}
+
+func TestDbConformance(t *testing.T) {
+	orderstoretest.RunOrderStoreConformance(t, func(t *testing.T) orderstore.OrderStore {
+		return newTestDb(t)
+	})
+}
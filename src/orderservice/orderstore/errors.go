@@ -0,0 +1,103 @@
+package orderstore
+
+import (
+	"errors"
+	"fmt"
+
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+)
+
+// The kinds of errors returned by the OrderStore implementations, check them with errors.Is
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrConflict        = errors.New("conflict")
+	ErrUnavailable     = errors.New("unavailable")
+)
+
+// storeError tags the cause with one of the error kinds, so callers can classify it without knowing the implementation details
+type storeError struct {
+	kind  error
+	cause error
+}
+
+func newStoreError(kind error, cause error) error {
+	return &storeError{kind: kind, cause: cause}
+}
+
+func newInvalidArgumentError(format string, args ...interface{}) error {
+	return newStoreError(ErrInvalidArgument, fmt.Errorf(format, args...))
+}
+
+func newNotFoundError(orderID string) error {
+	return newStoreError(ErrNotFound, fmt.Errorf("there's no order '%s'", orderID))
+}
+
+func (e *storeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.kind.Error(), e.cause.Error())
+}
+
+func (e *storeError) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
+
+// validateCreateOrder checks what the API spec can't, the request validator middleware checks the rest
+func validateCreateOrder(request orderservice_rest_types.CreateOrderRequest) error {
+	if request.OrderId == "" {
+		return newInvalidArgumentError("the order ID can't be empty")
+	}
+	if request.UserId == "" {
+		return newInvalidArgumentError("the user ID can't be empty")
+	}
+	if len(request.Lines) == 0 {
+		return newInvalidArgumentError("order '%s' has no lines", request.OrderId)
+	}
+	for _, line := range request.Lines {
+		if line.ProductId == "" {
+			return newInvalidArgumentError("order '%s' has a line without product ID", request.OrderId)
+		}
+		if line.Quantity <= 0 {
+			return newInvalidArgumentError("invalid quantity %d for product '%s' in order '%s', it must be greater than zero", line.Quantity, line.ProductId, request.OrderId)
+		}
+	}
+
+	switch request.Status {
+	case orderservice_rest_types.Placed:
+	case orderservice_rest_types.Paid:
+		if valueOrEmpty(request.PaymentTransactionId) == "" {
+			return newInvalidArgumentError("order '%s' is created paid but it has no payment transaction ID", request.OrderId)
+		}
+	default:
+		return newInvalidArgumentError("an order is created '%s' or '%s', not '%s'", orderservice_rest_types.Placed, orderservice_rest_types.Paid, request.Status)
+	}
+	return nil
+}
+
+func validateOrderID(orderID string) error {
+	if orderID == "" {
+		return newInvalidArgumentError("the order ID can't be empty")
+	}
+	return nil
+}
+
+func validatePurchasesLimit(limit int) error {
+	if limit < 1 || limit > MaxPurchasesLimit {
+		return newInvalidArgumentError("the limit must be between 1 and %d, got %d", MaxPurchasesLimit, limit)
+	}
+	return nil
+}
+
+func validateUserID(userID string) error {
+	if userID == "" {
+		return newInvalidArgumentError("the user ID can't be empty")
+	}
+	return nil
+}
+
+func valueOrEmpty[T any](value *T) T {
+	var empty T
+	if value == nil {
+		return empty
+	}
+	return *value
+}
@@ -0,0 +1,37 @@
+package orderstore
+
+import (
+	"context"
+
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+)
+
+// OrderStore persists the users' orders, every implementation has to honor this contract
+// (which is enforced by orderstoretest.RunOrderStoreConformance):
+//   - orders are isolated per flow, an operation only reads and modifies the orders of the flow in its context
+//     (see flow.ContextWithID)
+//   - an order ID is unique in a flow, creating an order with the ID of an existing one is an ErrConflict
+//   - the lines and the amounts are snapshots, they're returned as they were created whatever the catalog prices
+//     are now
+//   - the orders of a user are listed the most recently placed first, a user without orders has an empty list
+//   - the recent purchases are the most recently placed orders of the flow, whoever placed them, without the
+//     cancelled ones
+//   - the status follows the lifecycle in status.go, a transition it doesn't allow is an ErrConflict and leaves
+//     the order untouched
+//   - the methods are safe for concurrent use and none of the writes are lost
+//   - the methods fail without side effects if the context is already cancelled
+//   - the errors are tagged with the error kinds in errors.go (e.g. ErrNotFound for an unknown order)
+type OrderStore interface {
+	CreateOrder(ctx context.Context, request orderservice_rest_types.CreateOrderRequest) (*orderservice_rest_types.Order, error)
+	GetOrder(ctx context.Context, orderID string) (*orderservice_rest_types.Order, error)
+	ListOrders(ctx context.Context, userID string) ([]orderservice_rest_types.Order, error)
+	// ListRecentPurchases returns the products of the last orders placed in the flow, at most limit of them and
+	// the most recent first. The limit must be between 1 and MaxPurchasesLimit.
+	ListRecentPurchases(ctx context.Context, limit int) ([]orderservice_rest_types.Purchase, error)
+	UpdateOrderStatus(ctx context.Context, orderID string, request orderservice_rest_types.UpdateOrderStatusRequest) (*orderservice_rest_types.Order, error)
+	// PurgeFlow deletes every order of the flow, whatever the flow in the context is, and returns how many orders
+	// were deleted. Purging the baseline is rejected with ErrInvalidArgument.
+	PurgeFlow(ctx context.Context, flowID string) (int64, error)
+	// Ping checks that the store is able to serve requests, it's used by the readiness check
+	Ping(ctx context.Context) error
+}
@@ -0,0 +1,186 @@
+package orderstore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+)
+
+// Memory is an OrderStore implementation that keeps every order in a process-local map,
+// it's useful to run the order service without a database (e.g. locally or in unit tests)
+type Memory struct {
+	mu     sync.RWMutex
+	orders map[orderKey]orderservice_rest_types.Order
+
+	now func() time.Time
+}
+
+// orderKey identifies an order in a flow
+type orderKey struct {
+	flowID  string
+	orderID string
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		orders: map[orderKey]orderservice_rest_types.Order{},
+		now:    time.Now,
+	}
+}
+
+func newOrderKey(ctx context.Context, orderID string) orderKey {
+	return orderKey{flowID: flow.IDFromContext(ctx), orderID: orderID}
+}
+
+// Ping never fails unless the context is done, the orders are always available
+func (m *Memory) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (m *Memory) CreateOrder(ctx context.Context, request orderservice_rest_types.CreateOrderRequest) (*orderservice_rest_types.Order, error) {
+	if err := validateCreateOrder(request); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key := newOrderKey(ctx, request.OrderId)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, found := m.orders[key]; found {
+		return nil, newStoreError(ErrConflict, fmt.Errorf("there's already an order '%s'", request.OrderId))
+	}
+	order := newOrder(request, m.now())
+	m.orders[key] = order
+	return copyOrder(order), nil
+}
+
+func (m *Memory) GetOrder(ctx context.Context, orderID string) (*orderservice_rest_types.Order, error) {
+	if err := validateOrderID(orderID); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	order, found := m.orders[newOrderKey(ctx, orderID)]
+	if !found {
+		return nil, newNotFoundError(orderID)
+	}
+	return copyOrder(order), nil
+}
+
+func (m *Memory) ListOrders(ctx context.Context, userID string) ([]orderservice_rest_types.Order, error) {
+	if err := validateUserID(userID); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	flowID := flow.IDFromContext(ctx)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	orders := []orderservice_rest_types.Order{}
+	for key, order := range m.orders {
+		if key.flowID == flowID && order.UserId == userID {
+			orders = append(orders, *copyOrder(order))
+		}
+	}
+	sortMostRecentFirst(orders)
+	return orders, nil
+}
+
+func (m *Memory) ListRecentPurchases(ctx context.Context, limit int) ([]orderservice_rest_types.Purchase, error) {
+	if err := validatePurchasesLimit(limit); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	flowID := flow.IDFromContext(ctx)
+	m.mu.RLock()
+	orders := []orderservice_rest_types.Order{}
+	for key, order := range m.orders {
+		if key.flowID == flowID && order.Status != orderservice_rest_types.Cancelled {
+			orders = append(orders, order)
+		}
+	}
+	m.mu.RUnlock()
+
+	sortMostRecentFirst(orders)
+	if len(orders) > limit {
+		orders = orders[:limit]
+	}
+	purchases := make([]orderservice_rest_types.Purchase, 0, len(orders))
+	for _, order := range orders {
+		productIDs := make([]string, 0, len(order.Lines))
+		for _, line := range order.Lines {
+			productIDs = append(productIDs, line.ProductId)
+		}
+		purchases = append(purchases, toPurchase(order.OrderId, order.PlacedAt, productIDs))
+	}
+	return purchases, nil
+}
+
+func (m *Memory) UpdateOrderStatus(ctx context.Context, orderID string, request orderservice_rest_types.UpdateOrderStatusRequest) (*orderservice_rest_types.Order, error) {
+	if err := validateOrderID(orderID); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	key := newOrderKey(ctx, orderID)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	order, found := m.orders[key]
+	if !found {
+		return nil, newNotFoundError(orderID)
+	}
+	// the stored order is only replaced once the update is valid
+	if err := applyStatusUpdate(&order, request, m.now()); err != nil {
+		return nil, err
+	}
+	m.orders[key] = order
+	return copyOrder(order), nil
+}
+
+func (m *Memory) PurgeFlow(ctx context.Context, flowID string) (int64, error) {
+	if flowID == flow.BaselineID {
+		return 0, newInvalidArgumentError("the baseline flow can't be purged")
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deletedOrders := int64(0)
+	for key := range m.orders {
+		if key.flowID == flowID {
+			deletedOrders++
+			delete(m.orders, key)
+		}
+	}
+	return deletedOrders, nil
+}
+
+// copyOrder returns a copy that doesn't share its lines with the stored order
+func copyOrder(order orderservice_rest_types.Order) *orderservice_rest_types.Order {
+	order.Lines = append([]orderservice_rest_types.OrderLine{}, order.Lines...)
+	return &order
+}
@@ -0,0 +1,14 @@
+package orderstore_test
+
+import (
+	"testing"
+
+	"github.com/kurtosis-tech/new-obd/src/orderservice/orderstore"
+	"github.com/kurtosis-tech/new-obd/src/orderservice/orderstore/orderstoretest"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	orderstoretest.RunOrderStoreConformance(t, func(t *testing.T) orderstore.OrderStore {
+		return orderstore.NewMemory()
+	})
+}
@@ -0,0 +1,24 @@
+package orderstore
+
+import (
+	"embed"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+)
+
+// random key shared by every orderservice replica, so only one of them migrates the database at a time
+const migrationsAdvisoryLockKey int64 = 2_918_663_402_551_870_147
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+var migrationsConfig = database.MigrationsConfig{
+	FS:              migrationsFS,
+	Schema:          "orders",
+	AdvisoryLockKey: migrationsAdvisoryLockKey,
+}
+
+// Migrations returns the migrations embedded in the binary sorted by version
+func Migrations() ([]database.Migration, error) {
+	return database.Migrations(migrationsFS)
+}
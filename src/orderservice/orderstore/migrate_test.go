@@ -0,0 +1,45 @@
+package orderstore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kurtosis-tech/new-obd/src/orderservice/orderstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationsAreSequential(t *testing.T) {
+	migrations, err := orderstore.Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		require.Equal(t, int64(i+1), migration.Version, "migration %s is out of sequence", migration.Name)
+		require.NotEmpty(t, migration.Checksum)
+	}
+}
+
+func TestDbMigrateDownAndUp(t *testing.T) {
+	db := newTestDb(t)
+	ctx := context.Background()
+
+	migrations, err := orderstore.Migrations()
+	require.NoError(t, err)
+
+	require.NoError(t, db.MigrateDown(ctx, len(migrations)))
+	statuses, err := db.MigrationsStatus(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		require.Nil(t, status.AppliedAt, "migration %d_%s should be pending", status.Version, status.Name)
+	}
+
+	require.NoError(t, db.MigrateUp(ctx))
+	// applying the migrations again is a no-op
+	require.NoError(t, db.MigrateUp(ctx))
+	statuses, err = db.MigrationsStatus(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		require.NotNil(t, status.AppliedAt, "migration %d_%s should be applied", status.Version, status.Name)
+		require.True(t, status.ChecksumMatches)
+	}
+}
@@ -0,0 +1,39 @@
+CREATE SCHEMA IF NOT EXISTS orders;
+
+CREATE TABLE IF NOT EXISTS orders.orders(
+    flow_id TEXT NOT NULL DEFAULT '',
+    order_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    email TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('placed', 'paid', 'shipped', 'delivered', 'cancelled')),
+    shipping_street_address TEXT NOT NULL,
+    shipping_city TEXT NOT NULL,
+    shipping_state TEXT NOT NULL DEFAULT '',
+    shipping_country TEXT NOT NULL,
+    shipping_zip_code TEXT NOT NULL,
+    shipping_cost_currency_code TEXT NOT NULL,
+    shipping_cost_units BIGINT NOT NULL,
+    shipping_cost_nanos INTEGER NOT NULL,
+    total_paid_currency_code TEXT NOT NULL,
+    total_paid_units BIGINT NOT NULL,
+    total_paid_nanos INTEGER NOT NULL,
+    payment_transaction_id TEXT NOT NULL DEFAULT '',
+    shipping_tracking_id TEXT NOT NULL DEFAULT '',
+    placed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (flow_id, order_id)
+);
+CREATE INDEX IF NOT EXISTS idx_orders_flow_id_user_id_placed_at ON orders.orders (flow_id, user_id, placed_at DESC);
+
+CREATE TABLE IF NOT EXISTS orders.order_lines(
+    flow_id TEXT NOT NULL,
+    order_id TEXT NOT NULL,
+    line_number INTEGER NOT NULL,
+    product_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    cost_currency_code TEXT NOT NULL,
+    cost_units BIGINT NOT NULL,
+    cost_nanos INTEGER NOT NULL,
+    PRIMARY KEY (flow_id, order_id, line_number),
+    FOREIGN KEY (flow_id, order_id) REFERENCES orders.orders (flow_id, order_id) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS orders.idx_orders_flow_id_placed_at;
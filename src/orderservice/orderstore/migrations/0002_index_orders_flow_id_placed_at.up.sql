@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_orders_flow_id_placed_at ON orders.orders (flow_id, placed_at DESC);
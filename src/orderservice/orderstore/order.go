@@ -0,0 +1,190 @@
+package orderstore
+
+import (
+	"sort"
+	"time"
+
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+)
+
+// MaxPurchasesLimit is the maximum number of orders returned by ListRecentPurchases, as in the API spec
+const MaxPurchasesLimit = 1000
+
+// Money is an amount of the orders and order_lines tables, stored in the three columns of the API Money
+type Money struct {
+	CurrencyCode string
+	Units        int64
+	Nanos        int32
+}
+
+// Order is a row of the orders table, the schema is defined by the SQL files in the migrations directory
+type Order struct {
+	// FlowID is the Kardinal flow that wrote the order, flow.BaselineID for the baseline
+	FlowID                string `gorm:"primaryKey"`
+	OrderID               string `gorm:"primaryKey"`
+	UserID                string
+	Email                 string
+	Status                string
+	ShippingStreetAddress string
+	ShippingCity          string
+	ShippingState         string
+	ShippingCountry       string
+	ShippingZipCode       string
+	ShippingCost          Money `gorm:"embedded;embeddedPrefix:shipping_cost_"`
+	TotalPaid             Money `gorm:"embedded;embeddedPrefix:total_paid_"`
+	PaymentTransactionID  string
+	ShippingTrackingID    string
+	PlacedAt              time.Time
+	// UpdatedAt is when the status last changed, it's set by the store and not by gorm
+	UpdatedAt time.Time `gorm:"autoUpdateTime:false"`
+}
+
+func (Order) TableName() string {
+	return "orders.orders"
+}
+
+// OrderLine is a row of the order_lines table, the cost is a snapshot of the price paid for the line
+type OrderLine struct {
+	FlowID     string `gorm:"primaryKey"`
+	OrderID    string `gorm:"primaryKey"`
+	LineNumber int32  `gorm:"primaryKey;autoIncrement:false"`
+	ProductID  string
+	Quantity   int32
+	Cost       Money `gorm:"embedded;embeddedPrefix:cost_"`
+}
+
+func (OrderLine) TableName() string {
+	return "orders.order_lines"
+}
+
+// newOrder is the order created by the request, before it's stored
+func newOrder(request orderservice_rest_types.CreateOrderRequest, now time.Time) orderservice_rest_types.Order {
+	address := request.ShippingAddress
+	if valueOrEmpty(address.State) == "" {
+		address.State = nil
+	}
+	return orderservice_rest_types.Order{
+		OrderId:              request.OrderId,
+		UserId:               request.UserId,
+		Email:                string(request.Email),
+		Status:               request.Status,
+		ShippingAddress:      address,
+		ShippingCost:         request.ShippingCost,
+		Lines:                append([]orderservice_rest_types.OrderLine{}, request.Lines...),
+		TotalPaid:            request.TotalPaid,
+		PaymentTransactionId: valueOrEmpty(request.PaymentTransactionId),
+		PlacedAt:             storedTime(request.PlacedAt),
+		UpdatedAt:            storedTime(now),
+	}
+}
+
+// storedTime drops the precision Postgres doesn't keep, so every store returns the same times
+func storedTime(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
+
+// sortMostRecentFirst sorts the orders by placement time, the order IDs break the ties so the order is stable
+func sortMostRecentFirst(orders []orderservice_rest_types.Order) {
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].PlacedAt.Equal(orders[j].PlacedAt) {
+			return orders[i].PlacedAt.After(orders[j].PlacedAt)
+		}
+		return orders[i].OrderId < orders[j].OrderId
+	})
+}
+
+func newOrderRows(flowID string, order orderservice_rest_types.Order) (*Order, []OrderLine) {
+	row := &Order{
+		FlowID:                flowID,
+		OrderID:               order.OrderId,
+		UserID:                order.UserId,
+		Email:                 order.Email,
+		Status:                string(order.Status),
+		ShippingStreetAddress: order.ShippingAddress.StreetAddress,
+		ShippingCity:          order.ShippingAddress.City,
+		ShippingState:         valueOrEmpty(order.ShippingAddress.State),
+		ShippingCountry:       order.ShippingAddress.Country,
+		ShippingZipCode:       order.ShippingAddress.ZipCode,
+		ShippingCost:          fromRestMoney(order.ShippingCost),
+		TotalPaid:             fromRestMoney(order.TotalPaid),
+		PaymentTransactionID:  order.PaymentTransactionId,
+		ShippingTrackingID:    order.ShippingTrackingId,
+		PlacedAt:              order.PlacedAt,
+		UpdatedAt:             order.UpdatedAt,
+	}
+	lines := make([]OrderLine, 0, len(order.Lines))
+	for i, line := range order.Lines {
+		lines = append(lines, OrderLine{
+			FlowID:     flowID,
+			OrderID:    order.OrderId,
+			LineNumber: int32(i + 1),
+			ProductID:  line.ProductId,
+			Quantity:   line.Quantity,
+			Cost:       fromRestMoney(line.Cost),
+		})
+	}
+	return row, lines
+}
+
+// toRestOrder assembles the order from its row and its lines, sorted by line number
+func toRestOrder(row Order, lines []OrderLine) orderservice_rest_types.Order {
+	var state *string
+	if row.ShippingState != "" {
+		shippingState := row.ShippingState
+		state = &shippingState
+	}
+	orderLines := make([]orderservice_rest_types.OrderLine, 0, len(lines))
+	for _, line := range lines {
+		orderLines = append(orderLines, orderservice_rest_types.OrderLine{
+			ProductId: line.ProductID,
+			Quantity:  line.Quantity,
+			Cost:      toRestMoney(line.Cost),
+		})
+	}
+	return orderservice_rest_types.Order{
+		OrderId: row.OrderID,
+		UserId:  row.UserID,
+		Email:   row.Email,
+		Status:  orderservice_rest_types.OrderStatus(row.Status),
+		ShippingAddress: orderservice_rest_types.Address{
+			StreetAddress: row.ShippingStreetAddress,
+			City:          row.ShippingCity,
+			State:         state,
+			Country:       row.ShippingCountry,
+			ZipCode:       row.ShippingZipCode,
+		},
+		ShippingCost:         toRestMoney(row.ShippingCost),
+		Lines:                orderLines,
+		TotalPaid:            toRestMoney(row.TotalPaid),
+		PaymentTransactionId: row.PaymentTransactionID,
+		ShippingTrackingId:   row.ShippingTrackingID,
+		PlacedAt:             storedTime(row.PlacedAt),
+		UpdatedAt:            storedTime(row.UpdatedAt),
+	}
+}
+
+// toPurchase returns the products of the order, in the order of its lines and each one once
+func toPurchase(orderID string, placedAt time.Time, productIDs []string) orderservice_rest_types.Purchase {
+	purchase := orderservice_rest_types.Purchase{
+		OrderId:    orderID,
+		ProductIds: make([]string, 0, len(productIDs)),
+		PlacedAt:   storedTime(placedAt),
+	}
+	seen := make(map[string]struct{}, len(productIDs))
+	for _, productID := range productIDs {
+		if _, found := seen[productID]; found {
+			continue
+		}
+		seen[productID] = struct{}{}
+		purchase.ProductIds = append(purchase.ProductIds, productID)
+	}
+	return purchase
+}
+
+func fromRestMoney(m orderservice_rest_types.Money) Money {
+	return Money{CurrencyCode: m.CurrencyCode, Units: m.Units, Nanos: m.Nanos}
+}
+
+func toRestMoney(m Money) orderservice_rest_types.Money {
+	return orderservice_rest_types.Money{CurrencyCode: m.CurrencyCode, Units: m.Units, Nanos: m.Nanos}
+}
@@ -0,0 +1,631 @@
+// Package orderstoretest contains the conformance suite that every orderstore.OrderStore implementation is tested against
+package orderstoretest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/orderservice/orderstore"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sunglassesProductID = "OLJCESPC7Z"
+	tankTopProductID    = "66VCHSJNUP"
+	watchProductID      = "1YMWWN1N4O"
+
+	paymentTransactionID = "c2d4a4b6-7d4d-4bb9-9b5f-1f0a1b3c5d7e"
+	shippingTrackingID   = "AB-1234-5678901"
+
+	concurrentUpdaters = 10
+)
+
+// Factory returns the OrderStore under test, it's called once per test case so implementations
+// can either return a fresh store or a shared one (every test case uses its own user and order IDs)
+type Factory func(t *testing.T) orderstore.OrderStore
+
+var idsCounter uint64
+
+// RunOrderStoreConformance runs the whole OrderStore contract against the stores returned by the factory
+func RunOrderStoreConformance(t *testing.T, factory Factory) {
+	t.Run("CreateOrder", func(t *testing.T) {
+		testCreateOrder(t, factory(t))
+	})
+	t.Run("CreateOrderWithExistingIDConflicts", func(t *testing.T) {
+		testCreateOrderWithExistingIDConflicts(t, factory(t))
+	})
+	t.Run("CreateOrderRejectsInvalidArguments", func(t *testing.T) {
+		testCreateOrderRejectsInvalidArguments(t, factory(t))
+	})
+	t.Run("GetUnknownOrderIsNotFound", func(t *testing.T) {
+		testGetUnknownOrderIsNotFound(t, factory(t))
+	})
+	t.Run("ListOrdersOfUnknownUserIsEmpty", func(t *testing.T) {
+		testListOrdersOfUnknownUserIsEmpty(t, factory(t))
+	})
+	t.Run("ListOrdersMostRecentFirst", func(t *testing.T) {
+		testListOrdersMostRecentFirst(t, factory(t))
+	})
+	t.Run("ListRecentPurchases", func(t *testing.T) {
+		testListRecentPurchases(t, factory(t))
+	})
+	t.Run("StatusLifecycle", func(t *testing.T) {
+		testStatusLifecycle(t, factory(t))
+	})
+	t.Run("CancelOrder", func(t *testing.T) {
+		testCancelOrder(t, factory(t))
+	})
+	t.Run("StatusUpdateRejectsInvalidTransitions", func(t *testing.T) {
+		testStatusUpdateRejectsInvalidTransitions(t, factory(t))
+	})
+	t.Run("StatusUpdateRequiresTheStatusIDs", func(t *testing.T) {
+		testStatusUpdateRequiresTheStatusIDs(t, factory(t))
+	})
+	t.Run("StatusUpdateOfUnknownOrderIsNotFound", func(t *testing.T) {
+		testStatusUpdateOfUnknownOrderIsNotFound(t, factory(t))
+	})
+	t.Run("ConcurrentStatusUpdates", func(t *testing.T) {
+		testConcurrentStatusUpdates(t, factory(t))
+	})
+	t.Run("CancelledContext", func(t *testing.T) {
+		testCancelledContext(t, factory(t))
+	})
+	t.Run("Ping", func(t *testing.T) {
+		testPing(t, factory(t))
+	})
+	t.Run("FlowIsolation", func(t *testing.T) {
+		testFlowIsolation(t, factory(t))
+	})
+	t.Run("PurgeFlow", func(t *testing.T) {
+		testPurgeFlow(t, factory(t))
+	})
+}
+
+func testCreateOrder(t *testing.T, store orderstore.OrderStore) {
+	ctx := context.Background()
+	request := newCreateOrderRequest(t, newUserID(t), time.Now())
+
+	created, err := store.CreateOrder(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, request.OrderId, created.OrderId)
+	require.Equal(t, request.UserId, created.UserId)
+	require.Equal(t, string(request.Email), created.Email)
+	require.Equal(t, orderservice_rest_types.Paid, created.Status)
+	require.Equal(t, request.ShippingAddress, created.ShippingAddress)
+	require.Equal(t, request.ShippingCost, created.ShippingCost)
+	require.Equal(t, request.Lines, created.Lines)
+	require.Equal(t, request.TotalPaid, created.TotalPaid)
+	require.Equal(t, paymentTransactionID, created.PaymentTransactionId)
+	require.Empty(t, created.ShippingTrackingId)
+	require.True(t, request.PlacedAt.Truncate(time.Microsecond).Equal(created.PlacedAt))
+	require.False(t, created.UpdatedAt.IsZero())
+
+	// the lines keep their order and their costs are the snapshots of the request
+	order, err := store.GetOrder(ctx, request.OrderId)
+	require.NoError(t, err)
+	require.Equal(t, created, order)
+}
+
+func testCreateOrderWithExistingIDConflicts(t *testing.T, store orderstore.OrderStore) {
+	ctx := context.Background()
+	request := newCreateOrderRequest(t, newUserID(t), time.Now())
+
+	_, err := store.CreateOrder(ctx, request)
+	require.NoError(t, err)
+
+	otherRequest := newCreateOrderRequest(t, newUserID(t), time.Now())
+	otherRequest.OrderId = request.OrderId
+	_, err = store.CreateOrder(ctx, otherRequest)
+	require.ErrorIs(t, err, orderstore.ErrConflict)
+
+	order, err := store.GetOrder(ctx, request.OrderId)
+	require.NoError(t, err)
+	require.Equal(t, request.UserId, order.UserId)
+}
+
+func testCreateOrderRejectsInvalidArguments(t *testing.T, store orderstore.OrderStore) {
+	ctx := context.Background()
+	userID := newUserID(t)
+
+	invalidRequests := map[string]func(request *orderservice_rest_types.CreateOrderRequest){
+		"EmptyOrderID": func(request *orderservice_rest_types.CreateOrderRequest) {
+			request.OrderId = ""
+		},
+		"EmptyUserID": func(request *orderservice_rest_types.CreateOrderRequest) {
+			request.UserId = ""
+		},
+		"NoLines": func(request *orderservice_rest_types.CreateOrderRequest) {
+			request.Lines = nil
+		},
+		"LineWithoutProductID": func(request *orderservice_rest_types.CreateOrderRequest) {
+			request.Lines[0].ProductId = ""
+		},
+		"LineWithoutQuantity": func(request *orderservice_rest_types.CreateOrderRequest) {
+			request.Lines[1].Quantity = 0
+		},
+		"PaidWithoutPaymentTransactionID": func(request *orderservice_rest_types.CreateOrderRequest) {
+			request.PaymentTransactionId = nil
+		},
+		"CreatedShipped": func(request *orderservice_rest_types.CreateOrderRequest) {
+			request.Status = orderservice_rest_types.Shipped
+		},
+		"UnknownStatus": func(request *orderservice_rest_types.CreateOrderRequest) {
+			request.Status = "lost"
+		},
+	}
+	for name, invalidate := range invalidRequests {
+		request := newCreateOrderRequest(t, userID, time.Now())
+		invalidate(&request)
+		_, err := store.CreateOrder(ctx, request)
+		require.ErrorIs(t, err, orderstore.ErrInvalidArgument, name)
+	}
+
+	orders, err := store.ListOrders(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, orders)
+}
+
+func testGetUnknownOrderIsNotFound(t *testing.T, store orderstore.OrderStore) {
+	_, err := store.GetOrder(context.Background(), newOrderID(t))
+	require.ErrorIs(t, err, orderstore.ErrNotFound)
+
+	_, err = store.GetOrder(context.Background(), "")
+	require.ErrorIs(t, err, orderstore.ErrInvalidArgument)
+}
+
+func testListOrdersOfUnknownUserIsEmpty(t *testing.T, store orderstore.OrderStore) {
+	orders, err := store.ListOrders(context.Background(), newUserID(t))
+	require.NoError(t, err)
+	require.NotNil(t, orders)
+	require.Empty(t, orders)
+
+	_, err = store.ListOrders(context.Background(), "")
+	require.ErrorIs(t, err, orderstore.ErrInvalidArgument)
+}
+
+func testListOrdersMostRecentFirst(t *testing.T, store orderstore.OrderStore) {
+	ctx := context.Background()
+	userID := newUserID(t)
+	now := time.Now()
+
+	oldest := newCreateOrderRequest(t, userID, now.Add(-2*time.Hour))
+	newest := newCreateOrderRequest(t, userID, now)
+	middle := newCreateOrderRequest(t, userID, now.Add(-time.Hour))
+	otherUserOrder := newCreateOrderRequest(t, newUserID(t), now.Add(-30*time.Minute))
+	for _, request := range []orderservice_rest_types.CreateOrderRequest{oldest, newest, middle, otherUserOrder} {
+		_, err := store.CreateOrder(ctx, request)
+		require.NoError(t, err)
+	}
+
+	orders, err := store.ListOrders(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, []string{newest.OrderId, middle.OrderId, oldest.OrderId}, orderIDs(orders))
+	for _, order := range orders {
+		require.Len(t, order.Lines, 2)
+	}
+}
+
+func testListRecentPurchases(t *testing.T, store orderstore.OrderStore) {
+	// a flow of its own, so the orders of the other test cases don't show up
+	ctx := flow.ContextWithID(context.Background(), newFlowID(t))
+	now := time.Now()
+
+	oldest := newCreateOrderRequest(t, newUserID(t), now.Add(-3*time.Hour))
+	middle := newCreateOrderRequest(t, newUserID(t), now.Add(-2*time.Hour))
+	// a product bought in two lines is listed once
+	middle.Lines = append(middle.Lines, orderservice_rest_types.OrderLine{
+		ProductId: watchProductID,
+		Quantity:  1,
+		Cost:      orderservice_rest_types.Money{CurrencyCode: "USD", Units: 109, Nanos: 990_000_000},
+	})
+	cancelled := newCreateOrderRequest(t, newUserID(t), now.Add(-time.Hour))
+	newest := newCreateOrderRequest(t, newUserID(t), now)
+	newest.Lines = newest.Lines[:1]
+	for _, request := range []orderservice_rest_types.CreateOrderRequest{oldest, middle, cancelled, newest} {
+		_, err := store.CreateOrder(ctx, request)
+		require.NoError(t, err)
+	}
+	// the orders of the other flows aren't listed
+	_, err := store.CreateOrder(context.Background(), newCreateOrderRequest(t, newUserID(t), now))
+	require.NoError(t, err)
+	requireStatusUpdate(t, store, ctx, cancelled.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Cancelled,
+	})
+
+	purchases, err := store.ListRecentPurchases(ctx, orderstore.MaxPurchasesLimit)
+	require.NoError(t, err)
+	require.Equal(t, []orderservice_rest_types.Purchase{
+		{OrderId: newest.OrderId, ProductIds: []string{watchProductID}, PlacedAt: newest.PlacedAt.UTC().Truncate(time.Microsecond)},
+		{OrderId: middle.OrderId, ProductIds: []string{watchProductID, sunglassesProductID}, PlacedAt: middle.PlacedAt.UTC().Truncate(time.Microsecond)},
+		{OrderId: oldest.OrderId, ProductIds: []string{watchProductID, sunglassesProductID}, PlacedAt: oldest.PlacedAt.UTC().Truncate(time.Microsecond)},
+	}, purchases)
+
+	purchases, err = store.ListRecentPurchases(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, purchases, 2)
+	require.Equal(t, newest.OrderId, purchases[0].OrderId)
+	require.Equal(t, middle.OrderId, purchases[1].OrderId)
+
+	// a flow without orders has no purchases
+	purchases, err = store.ListRecentPurchases(flow.ContextWithID(context.Background(), newFlowID(t)), 1)
+	require.NoError(t, err)
+	require.NotNil(t, purchases)
+	require.Empty(t, purchases)
+
+	for _, limit := range []int{0, -1, orderstore.MaxPurchasesLimit + 1} {
+		_, err = store.ListRecentPurchases(ctx, limit)
+		require.ErrorIs(t, err, orderstore.ErrInvalidArgument, "limit %d", limit)
+	}
+}
+
+func testStatusLifecycle(t *testing.T, store orderstore.OrderStore) {
+	ctx := context.Background()
+	request := newCreateOrderRequest(t, newUserID(t), time.Now())
+	request.Status = orderservice_rest_types.Placed
+	request.PaymentTransactionId = nil
+
+	created, err := store.CreateOrder(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, orderservice_rest_types.Placed, created.Status)
+
+	order := requireStatusUpdate(t, store, ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status:               orderservice_rest_types.Paid,
+		PaymentTransactionId: stringPtr(paymentTransactionID),
+	})
+	require.Equal(t, paymentTransactionID, order.PaymentTransactionId)
+
+	order = requireStatusUpdate(t, store, ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status:             orderservice_rest_types.Shipped,
+		ShippingTrackingId: stringPtr(shippingTrackingID),
+	})
+	require.Equal(t, paymentTransactionID, order.PaymentTransactionId)
+	require.Equal(t, shippingTrackingID, order.ShippingTrackingId)
+
+	order = requireStatusUpdate(t, store, ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Delivered,
+	})
+	require.Equal(t, shippingTrackingID, order.ShippingTrackingId)
+	// the status doesn't change the snapshots
+	require.Equal(t, created.Lines, order.Lines)
+	require.Equal(t, created.TotalPaid, order.TotalPaid)
+
+	// delivered is final
+	_, err = store.UpdateOrderStatus(ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Cancelled,
+	})
+	require.ErrorIs(t, err, orderstore.ErrConflict)
+	requireStatus(t, store, ctx, request.OrderId, orderservice_rest_types.Delivered)
+}
+
+func testCancelOrder(t *testing.T, store orderstore.OrderStore) {
+	ctx := context.Background()
+	request := newCreateOrderRequest(t, newUserID(t), time.Now())
+
+	_, err := store.CreateOrder(ctx, request)
+	require.NoError(t, err)
+
+	requireStatusUpdate(t, store, ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Cancelled,
+	})
+
+	// cancelled is final
+	for _, status := range []orderservice_rest_types.OrderStatus{orderservice_rest_types.Paid, orderservice_rest_types.Shipped, orderservice_rest_types.Cancelled} {
+		_, err = store.UpdateOrderStatus(ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+			Status:               status,
+			PaymentTransactionId: stringPtr(paymentTransactionID),
+			ShippingTrackingId:   stringPtr(shippingTrackingID),
+		})
+		require.ErrorIs(t, err, orderstore.ErrConflict, "an order can't go from cancelled to %s", status)
+	}
+	requireStatus(t, store, ctx, request.OrderId, orderservice_rest_types.Cancelled)
+}
+
+func testStatusUpdateRejectsInvalidTransitions(t *testing.T, store orderstore.OrderStore) {
+	ctx := context.Background()
+	request := newCreateOrderRequest(t, newUserID(t), time.Now())
+
+	created, err := store.CreateOrder(ctx, request)
+	require.NoError(t, err)
+
+	// an order can't skip a status, go back to a previous one or stay in the same one
+	for _, status := range []orderservice_rest_types.OrderStatus{orderservice_rest_types.Delivered, orderservice_rest_types.Placed, orderservice_rest_types.Paid} {
+		_, err = store.UpdateOrderStatus(ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+			Status:             status,
+			ShippingTrackingId: stringPtr(shippingTrackingID),
+		})
+		require.ErrorIs(t, err, orderstore.ErrConflict, "an order can't go from paid to %s", status)
+	}
+
+	_, err = store.UpdateOrderStatus(ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: "lost",
+	})
+	require.ErrorIs(t, err, orderstore.ErrInvalidArgument)
+
+	order, err := store.GetOrder(ctx, request.OrderId)
+	require.NoError(t, err)
+	require.Equal(t, created, order)
+}
+
+func testStatusUpdateRequiresTheStatusIDs(t *testing.T, store orderstore.OrderStore) {
+	ctx := context.Background()
+	request := newCreateOrderRequest(t, newUserID(t), time.Now())
+	request.Status = orderservice_rest_types.Placed
+	request.PaymentTransactionId = nil
+
+	_, err := store.CreateOrder(ctx, request)
+	require.NoError(t, err)
+
+	_, err = store.UpdateOrderStatus(ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Paid,
+	})
+	require.ErrorIs(t, err, orderstore.ErrInvalidArgument)
+	requireStatus(t, store, ctx, request.OrderId, orderservice_rest_types.Placed)
+
+	requireStatusUpdate(t, store, ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status:               orderservice_rest_types.Paid,
+		PaymentTransactionId: stringPtr(paymentTransactionID),
+	})
+
+	_, err = store.UpdateOrderStatus(ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Shipped,
+	})
+	require.ErrorIs(t, err, orderstore.ErrInvalidArgument)
+	requireStatus(t, store, ctx, request.OrderId, orderservice_rest_types.Paid)
+}
+
+func testStatusUpdateOfUnknownOrderIsNotFound(t *testing.T, store orderstore.OrderStore) {
+	_, err := store.UpdateOrderStatus(context.Background(), newOrderID(t), orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Cancelled,
+	})
+	require.ErrorIs(t, err, orderstore.ErrNotFound)
+}
+
+func testConcurrentStatusUpdates(t *testing.T, store orderstore.OrderStore) {
+	ctx := context.Background()
+	request := newCreateOrderRequest(t, newUserID(t), time.Now())
+
+	_, err := store.CreateOrder(ctx, request)
+	require.NoError(t, err)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, concurrentUpdaters)
+	)
+	for i := 0; i < concurrentUpdaters; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := store.UpdateOrderStatus(ctx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+				Status:             orderservice_rest_types.Shipped,
+				ShippingTrackingId: stringPtr(fmt.Sprintf("%s-%d", shippingTrackingID, i)),
+			})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	// only one of the updaters ships the order, the others find it already shipped
+	shipped := 0
+	for err := range errs {
+		if err == nil {
+			shipped++
+			continue
+		}
+		require.ErrorIs(t, err, orderstore.ErrConflict)
+	}
+	require.Equal(t, 1, shipped)
+	requireStatus(t, store, ctx, request.OrderId, orderservice_rest_types.Shipped)
+}
+
+func testCancelledContext(t *testing.T, store orderstore.OrderStore) {
+	userID := newUserID(t)
+	request := newCreateOrderRequest(t, userID, time.Now())
+
+	_, err := store.CreateOrder(context.Background(), request)
+	require.NoError(t, err)
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = store.CreateOrder(cancelledCtx, newCreateOrderRequest(t, userID, time.Now()))
+	require.Error(t, err)
+	_, err = store.UpdateOrderStatus(cancelledCtx, request.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Cancelled,
+	})
+	require.Error(t, err)
+	_, err = store.GetOrder(cancelledCtx, request.OrderId)
+	require.Error(t, err)
+	_, err = store.ListOrders(cancelledCtx, userID)
+	require.Error(t, err)
+	_, err = store.ListRecentPurchases(cancelledCtx, 1)
+	require.Error(t, err)
+
+	// none of the calls with the cancelled context changed the orders
+	orders, err := store.ListOrders(context.Background(), userID)
+	require.NoError(t, err)
+	require.Equal(t, []string{request.OrderId}, orderIDs(orders))
+	require.Equal(t, orderservice_rest_types.Paid, orders[0].Status)
+}
+
+func testPing(t *testing.T, store orderstore.OrderStore) {
+	require.NoError(t, store.Ping(context.Background()))
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.Error(t, store.Ping(cancelledCtx))
+}
+
+func testFlowIsolation(t *testing.T, store orderstore.OrderStore) {
+	baselineCtx := context.Background()
+	flowCtx := flow.ContextWithID(context.Background(), newFlowID(t))
+	userID := newUserID(t)
+	now := time.Now()
+
+	baselineOrder := newCreateOrderRequest(t, userID, now)
+	flowOrder := newCreateOrderRequest(t, userID, now)
+	// the same order ID can be used in different flows
+	sharedIDOrder := newCreateOrderRequest(t, userID, now.Add(-time.Hour))
+	for _, ctx := range []context.Context{baselineCtx, flowCtx} {
+		_, err := store.CreateOrder(ctx, sharedIDOrder)
+		require.NoError(t, err)
+	}
+	_, err := store.CreateOrder(baselineCtx, baselineOrder)
+	require.NoError(t, err)
+	_, err = store.CreateOrder(flowCtx, flowOrder)
+	require.NoError(t, err)
+
+	requireStatusUpdate(t, store, flowCtx, sharedIDOrder.OrderId, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Cancelled,
+	})
+
+	orders, err := store.ListOrders(baselineCtx, userID)
+	require.NoError(t, err)
+	require.Equal(t, []string{baselineOrder.OrderId, sharedIDOrder.OrderId}, orderIDs(orders))
+	requireStatus(t, store, baselineCtx, sharedIDOrder.OrderId, orderservice_rest_types.Paid)
+	_, err = store.GetOrder(baselineCtx, flowOrder.OrderId)
+	require.ErrorIs(t, err, orderstore.ErrNotFound)
+
+	orders, err = store.ListOrders(flowCtx, userID)
+	require.NoError(t, err)
+	require.Equal(t, []string{flowOrder.OrderId, sharedIDOrder.OrderId}, orderIDs(orders))
+	requireStatus(t, store, flowCtx, sharedIDOrder.OrderId, orderservice_rest_types.Cancelled)
+}
+
+func testPurgeFlow(t *testing.T, store orderstore.OrderStore) {
+	flowID := newFlowID(t)
+	flowCtx := flow.ContextWithID(context.Background(), flowID)
+	otherFlowCtx := flow.ContextWithID(context.Background(), newFlowID(t))
+	userID := newUserID(t)
+	otherUserID := newUserID(t)
+	now := time.Now()
+
+	baselineOrder := newCreateOrderRequest(t, userID, now)
+	otherFlowOrder := newCreateOrderRequest(t, userID, now)
+	requests := map[context.Context][]orderservice_rest_types.CreateOrderRequest{
+		context.Background(): {baselineOrder},
+		flowCtx: {
+			newCreateOrderRequest(t, userID, now),
+			newCreateOrderRequest(t, userID, now),
+			newCreateOrderRequest(t, otherUserID, now),
+		},
+		otherFlowCtx: {otherFlowOrder},
+	}
+	for ctx, ctxRequests := range requests {
+		for _, request := range ctxRequests {
+			_, err := store.CreateOrder(ctx, request)
+			require.NoError(t, err)
+		}
+	}
+
+	// the flow to purge is the one in the argument, not the one in the context
+	deletedOrders, err := store.PurgeFlow(otherFlowCtx, flowID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), deletedOrders)
+
+	for _, purgedUserID := range []string{userID, otherUserID} {
+		orders, err := store.ListOrders(flowCtx, purgedUserID)
+		require.NoError(t, err)
+		require.Empty(t, orders)
+	}
+	orders, err := store.ListOrders(context.Background(), userID)
+	require.NoError(t, err)
+	require.Equal(t, []string{baselineOrder.OrderId}, orderIDs(orders))
+	orders, err = store.ListOrders(otherFlowCtx, userID)
+	require.NoError(t, err)
+	require.Equal(t, []string{otherFlowOrder.OrderId}, orderIDs(orders))
+
+	// purging a flow without orders is a no-op and the baseline can't be purged
+	deletedOrders, err = store.PurgeFlow(context.Background(), flowID)
+	require.NoError(t, err)
+	require.Zero(t, deletedOrders)
+	_, err = store.PurgeFlow(context.Background(), flow.BaselineID)
+	require.ErrorIs(t, err, orderstore.ErrInvalidArgument)
+}
+
+// newCreateOrderRequest returns a paid order of two lines for the user, with a unique order ID
+func newCreateOrderRequest(t *testing.T, userID string, placedAt time.Time) orderservice_rest_types.CreateOrderRequest {
+	return orderservice_rest_types.CreateOrderRequest{
+		OrderId: newOrderID(t),
+		UserId:  userID,
+		Email:   "someone@example.com",
+		Status:  orderservice_rest_types.Paid,
+		ShippingAddress: orderservice_rest_types.Address{
+			StreetAddress: "1600 Amphitheatre Parkway",
+			City:          "Mountain View",
+			State:         stringPtr("CA"),
+			Country:       "United States",
+			ZipCode:       "94043",
+		},
+		ShippingCost: orderservice_rest_types.Money{CurrencyCode: "USD", Units: 8, Nanos: 990_000_000},
+		Lines: []orderservice_rest_types.OrderLine{
+			{
+				ProductId: watchProductID,
+				Quantity:  1,
+				Cost:      orderservice_rest_types.Money{CurrencyCode: "USD", Units: 109, Nanos: 990_000_000},
+			},
+			{
+				ProductId: sunglassesProductID,
+				Quantity:  2,
+				Cost:      orderservice_rest_types.Money{CurrencyCode: "USD", Units: 19, Nanos: 990_000_000},
+			},
+		},
+		TotalPaid:            orderservice_rest_types.Money{CurrencyCode: "USD", Units: 158, Nanos: 950_000_000},
+		PaymentTransactionId: stringPtr(paymentTransactionID),
+		PlacedAt:             placedAt,
+	}
+}
+
+// newUserID returns a user ID that is unique across the test run, so stores shared between test cases don't collide
+func newUserID(t *testing.T) string {
+	return fmt.Sprintf("%s-%d", t.Name(), atomic.AddUint64(&idsCounter, 1))
+}
+
+// newOrderID returns an order ID that is unique across the test run, like newUserID
+func newOrderID(t *testing.T) string {
+	return fmt.Sprintf("order-%s-%d", t.Name(), atomic.AddUint64(&idsCounter, 1))
+}
+
+// newFlowID returns a flow ID that is unique across the test run, like newUserID
+func newFlowID(t *testing.T) string {
+	return fmt.Sprintf("flow-%s-%d", t.Name(), atomic.AddUint64(&idsCounter, 1))
+}
+
+// requireStatusUpdate applies the status update, which has to succeed, and checks that the order was moved to the status
+func requireStatusUpdate(t *testing.T, store orderstore.OrderStore, ctx context.Context, orderID string, request orderservice_rest_types.UpdateOrderStatusRequest) *orderservice_rest_types.Order {
+	t.Helper()
+
+	order, err := store.UpdateOrderStatus(ctx, orderID, request)
+	require.NoError(t, err)
+	require.Equal(t, request.Status, order.Status)
+	requireStatus(t, store, ctx, orderID, request.Status)
+	return order
+}
+
+// requireStatus checks the status of the order in the flow of the context
+func requireStatus(t *testing.T, store orderstore.OrderStore, ctx context.Context, orderID string, expectedStatus orderservice_rest_types.OrderStatus) {
+	t.Helper()
+
+	order, err := store.GetOrder(ctx, orderID)
+	require.NoError(t, err)
+	require.Equal(t, expectedStatus, order.Status, "unexpected status of order '%s'", orderID)
+}
+
+func orderIDs(orders []orderservice_rest_types.Order) []string {
+	ids := []string{}
+	for _, order := range orders {
+		ids = append(ids, order.OrderId)
+	}
+	return ids
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,76 @@
+package orderstore
+
+import (
+	"fmt"
+	"time"
+
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+)
+
+// transitions are the statuses an order can move to from each status: it's paid, shipped and then delivered, and it
+// can be cancelled until it's delivered. Delivered and cancelled are final.
+var transitions = map[orderservice_rest_types.OrderStatus][]orderservice_rest_types.OrderStatus{
+	orderservice_rest_types.Placed:  {orderservice_rest_types.Paid, orderservice_rest_types.Cancelled},
+	orderservice_rest_types.Paid:    {orderservice_rest_types.Shipped, orderservice_rest_types.Cancelled},
+	orderservice_rest_types.Shipped: {orderservice_rest_types.Delivered, orderservice_rest_types.Cancelled},
+}
+
+func isValidStatus(status orderservice_rest_types.OrderStatus) bool {
+	switch status {
+	case orderservice_rest_types.Placed,
+		orderservice_rest_types.Paid,
+		orderservice_rest_types.Shipped,
+		orderservice_rest_types.Delivered,
+		orderservice_rest_types.Cancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+func canTransition(from, to orderservice_rest_types.OrderStatus) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// applyStatusUpdate moves the order to the requested status, with the payment transaction or the tracking ID the new
+// status requires. Moving an order to a status it can't reach from its current one is an ErrConflict, including the
+// status it already has.
+func applyStatusUpdate(order *orderservice_rest_types.Order, request orderservice_rest_types.UpdateOrderStatusRequest, now time.Time) error {
+	if !isValidStatus(request.Status) {
+		return newInvalidArgumentError("unknown order status '%s'", request.Status)
+	}
+	if !canTransition(order.Status, request.Status) {
+		return newStoreError(ErrConflict, fmt.Errorf("order '%s' can't go from '%s' to '%s'", order.OrderId, order.Status, request.Status))
+	}
+
+	paymentTransactionID := order.PaymentTransactionId
+	if id := valueOrEmpty(request.PaymentTransactionId); id != "" {
+		paymentTransactionID = id
+	}
+	shippingTrackingID := order.ShippingTrackingId
+	if id := valueOrEmpty(request.ShippingTrackingId); id != "" {
+		shippingTrackingID = id
+	}
+
+	switch request.Status {
+	case orderservice_rest_types.Paid:
+		if paymentTransactionID == "" {
+			return newInvalidArgumentError("order '%s' can't be paid without a payment transaction ID", order.OrderId)
+		}
+	case orderservice_rest_types.Shipped:
+		if shippingTrackingID == "" {
+			return newInvalidArgumentError("order '%s' can't be shipped without a tracking ID", order.OrderId)
+		}
+	}
+
+	order.Status = request.Status
+	order.PaymentTransactionId = paymentTransactionID
+	order.ShippingTrackingId = shippingTrackingID
+	order.UpdatedAt = storedTime(now)
+	return nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	orderservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/server"
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/orderservice/orderstore"
+	"github.com/sirupsen/logrus"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	healthStatusOk           = "ok"
+	healthStatusError        = "error"
+	healthStatusShuttingDown = "shutting down"
+
+	databaseDependencyName = "database"
+
+	readinessCheckTimeout = 2 * time.Second
+)
+
+type Server struct {
+	Store orderstore.OrderStore
+	// shuttingDown is a pointer so every copy of the server (the handlers have value receivers) sees the same flag
+	shuttingDown *atomic.Bool
+}
+
+func NewServer(store orderstore.OrderStore) Server {
+	return Server{Store: store, shuttingDown: &atomic.Bool{}}
+}
+
+// MarkShuttingDown makes the readiness check fail, so the service stops receiving traffic while it shuts down
+func (s Server) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s Server) GetHealth(ctx context.Context, request orderservice_server_rest_server.GetHealthRequestObject) (orderservice_server_rest_server.GetHealthResponseObject, error) {
+
+	status := healthStatusOk
+	now := time.Now()
+
+	response := orderservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return orderservice_server_rest_server.GetHealth200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthLive(ctx context.Context, request orderservice_server_rest_server.GetHealthLiveRequestObject) (orderservice_server_rest_server.GetHealthLiveResponseObject, error) {
+	status := healthStatusOk
+	now := time.Now()
+
+	response := orderservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return orderservice_server_rest_server.GetHealthLive200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthReady(ctx context.Context, request orderservice_server_rest_server.GetHealthReadyRequestObject) (orderservice_server_rest_server.GetHealthReadyResponseObject, error) {
+	now := time.Now()
+
+	if s.shuttingDown.Load() {
+		status := healthStatusShuttingDown
+		response := orderservice_rest_types.HealthResponse{
+			Status:    &status,
+			Timestamp: &now,
+		}
+		return orderservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+
+	checks := []orderservice_rest_types.DependencyCheck{
+		checkDependency(ctx, databaseDependencyName, s.Store.Ping),
+	}
+
+	status := healthStatusOk
+	for _, check := range checks {
+		if check.Status != healthStatusOk {
+			status = healthStatusError
+		}
+	}
+
+	response := orderservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+		Checks:    &checks,
+	}
+
+	if status != healthStatusOk {
+		return orderservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+	return orderservice_server_rest_server.GetHealthReady200JSONResponse(response), nil
+}
+
+func (s Server) PostOrders(ctx context.Context, request orderservice_server_rest_server.PostOrdersRequestObject) (orderservice_server_rest_server.PostOrdersResponseObject, error) {
+	logrus.Infof("Post order request - OrderID: %s, UserID: %s, Status: %s, Lines: %d", request.Body.OrderId, request.Body.UserId, request.Body.Status, len(request.Body.Lines))
+	order, err := s.Store.CreateOrder(ctx, *request.Body)
+	if err != nil {
+		logrus.Infof("An error occurred storing the order in the store. Error: %s", err.Error())
+		return nil, err
+	}
+	return orderservice_server_rest_server.PostOrders200JSONResponse(*order), nil
+}
+
+func (s Server) GetOrders(ctx context.Context, request orderservice_server_rest_server.GetOrdersRequestObject) (orderservice_server_rest_server.GetOrdersResponseObject, error) {
+	orders, err := s.Store.ListOrders(ctx, request.Params.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := orderservice_rest_types.ListOrdersResponse{
+		Orders: orders,
+	}
+
+	return orderservice_server_rest_server.GetOrders200JSONResponse(response), nil
+}
+
+func (s Server) GetPurchases(ctx context.Context, request orderservice_server_rest_server.GetPurchasesRequestObject) (orderservice_server_rest_server.GetPurchasesResponseObject, error) {
+	purchases, err := s.Store.ListRecentPurchases(ctx, int(request.Params.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	response := orderservice_rest_types.ListPurchasesResponse{
+		Purchases: purchases,
+	}
+
+	return orderservice_server_rest_server.GetPurchases200JSONResponse(response), nil
+}
+
+func (s Server) GetOrdersOrderId(ctx context.Context, request orderservice_server_rest_server.GetOrdersOrderIdRequestObject) (orderservice_server_rest_server.GetOrdersOrderIdResponseObject, error) {
+	order, err := s.Store.GetOrder(ctx, request.OrderId)
+	if err != nil {
+		return nil, err
+	}
+	return orderservice_server_rest_server.GetOrdersOrderId200JSONResponse(*order), nil
+}
+
+func (s Server) PutOrdersOrderIdStatus(ctx context.Context, request orderservice_server_rest_server.PutOrdersOrderIdStatusRequestObject) (orderservice_server_rest_server.PutOrdersOrderIdStatusResponseObject, error) {
+	logrus.Infof("Put order status request - OrderID: %s, Status: %s", request.OrderId, request.Body.Status)
+	order, err := s.Store.UpdateOrderStatus(ctx, request.OrderId, *request.Body)
+	if err != nil {
+		logrus.Infof("An error occurred updating the order status in the store. Error: %s", err.Error())
+		return nil, err
+	}
+	return orderservice_server_rest_server.PutOrdersOrderIdStatus200JSONResponse(*order), nil
+}
+
+func (s Server) DeleteAdminFlowsFlowId(ctx context.Context, request orderservice_server_rest_server.DeleteAdminFlowsFlowIdRequestObject) (orderservice_server_rest_server.DeleteAdminFlowsFlowIdResponseObject, error) {
+	deletedOrders, err := s.Store.PurgeFlow(ctx, request.FlowId)
+	if err != nil {
+		return nil, err
+	}
+
+	response := orderservice_rest_types.PurgeFlowResponse{
+		FlowId:        request.FlowId,
+		DeletedOrders: deletedOrders,
+	}
+
+	return orderservice_server_rest_server.DeleteAdminFlowsFlowId200JSONResponse(response), nil
+}
+
+// checkDependency runs the check with the readiness timeout and reports its status and latency
+func checkDependency(ctx context.Context, name string, check func(ctx context.Context) error) orderservice_rest_types.DependencyCheck {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	dependencyCheck := orderservice_rest_types.DependencyCheck{
+		Name:      name,
+		Status:    healthStatusOk,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		logrus.Warnf("The readiness check of dependency '%s' failed. Error: %s", name, err)
+		errMsg := err.Error()
+		dependencyCheck.Status = healthStatusError
+		dependencyCheck.Error = &errMsg
+	}
+	return dependencyCheck
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/consts"
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
+	"github.com/kurtosis-tech/new-obd/src/libs/metrics"
+	"github.com/kurtosis-tech/new-obd/src/libs/rest"
+	orderservice_rest_client "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/client"
+	orderservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/server"
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/orderservice/orderstore"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUserID     = "test-user"
+	testOrderID    = "test-order"
+	testProductID  = "OLJCESPC7Z"
+	testFlowID     = "dev-flow-1"
+	testAdminToken = "test-admin-token"
+)
+
+func withFlowID(flowID string) orderservice_rest_client.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set(consts.KardinalFlowIdHeaderKey, flowID)
+		return nil
+	}
+}
+
+func withAdminToken(token string) orderservice_rest_client.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		return nil
+	}
+}
+
+func newTestClient(t *testing.T, server Server) *orderservice_rest_client.ClientWithResponses {
+	echoRouter := echo.New()
+	echoRouter.HTTPErrorHandler = rest.NewResponseInfoHTTPErrorHandler(statusCodeForError)
+	echoRouter.Use(metrics.Middleware)
+
+	requestValidatorMiddleware, err := rest.NewRequestValidatorMiddleware(orderservice_server_rest_server.GetSwagger, testAdminToken)
+	require.NoError(t, err)
+	echoRouter.Use(requestValidatorMiddleware)
+	echoRouter.Use(flow.NewMiddleware([]string{consts.KardinalFlowIdHeaderKey}))
+
+	metrics.RegisterHandler(echoRouter)
+
+	strictMiddlewares := []orderservice_server_rest_server.StrictMiddlewareFunc{rest.NewErrorMappingMiddleware(statusCodeForError)}
+	orderservice_server_rest_server.RegisterHandlers(echoRouter, orderservice_server_rest_server.NewStrictHandler(server, strictMiddlewares))
+
+	httpServer := httptest.NewServer(echoRouter)
+	t.Cleanup(httpServer.Close)
+
+	client, err := orderservice_rest_client.NewClientWithResponses(httpServer.URL)
+	require.NoError(t, err)
+	return client
+}
+
+func newTestCreateOrderRequest(orderID string) orderservice_rest_types.CreateOrderRequest {
+	paymentTransactionID := "test-transaction"
+	return orderservice_rest_types.CreateOrderRequest{
+		OrderId: orderID,
+		UserId:  testUserID,
+		Email:   "someone@example.com",
+		Status:  orderservice_rest_types.Paid,
+		ShippingAddress: orderservice_rest_types.Address{
+			StreetAddress: "1600 Amphitheatre Parkway",
+			City:          "Mountain View",
+			Country:       "United States",
+			ZipCode:       "94043",
+		},
+		ShippingCost: orderservice_rest_types.Money{CurrencyCode: "USD", Units: 8, Nanos: 990_000_000},
+		Lines: []orderservice_rest_types.OrderLine{
+			{
+				ProductId: testProductID,
+				Quantity:  2,
+				Cost:      orderservice_rest_types.Money{CurrencyCode: "USD", Units: 19, Nanos: 990_000_000},
+			},
+		},
+		TotalPaid:            orderservice_rest_types.Money{CurrencyCode: "USD", Units: 48, Nanos: 970_000_000},
+		PaymentTransactionId: &paymentTransactionID,
+		PlacedAt:             time.Now(),
+	}
+}
+
+func TestPostOrderAndGetOrders(t *testing.T) {
+	client := newTestClient(t, NewServer(orderstore.NewMemory()))
+	ctx := context.Background()
+
+	request := newTestCreateOrderRequest(testOrderID)
+	postResponse, err := client.PostOrdersWithResponse(ctx, request)
+	require.NoError(t, err)
+	require.NoError(t, orderservice_rest_client.CheckResponse(postResponse, postResponse.JSONDefault))
+	require.Equal(t, orderservice_rest_types.Paid, postResponse.JSON200.Status)
+
+	getResponse, err := client.GetOrdersOrderIdWithResponse(ctx, testOrderID)
+	require.NoError(t, err)
+	require.NoError(t, orderservice_rest_client.CheckResponse(getResponse, getResponse.JSONDefault))
+	require.Equal(t, request.Lines, getResponse.JSON200.Lines)
+	require.Equal(t, request.TotalPaid, getResponse.JSON200.TotalPaid)
+
+	listResponse, err := client.GetOrdersWithResponse(ctx, &orderservice_rest_types.GetOrdersParams{UserId: testUserID})
+	require.NoError(t, err)
+	require.NoError(t, orderservice_rest_client.CheckResponse(listResponse, listResponse.JSONDefault))
+	require.Len(t, listResponse.JSON200.Orders, 1)
+	require.Equal(t, testOrderID, listResponse.JSON200.Orders[0].OrderId)
+
+	// posting the same order again is a conflict
+	postResponse, err = client.PostOrdersWithResponse(ctx, request)
+	require.NoError(t, err)
+	require.ErrorIs(t, orderservice_rest_client.CheckResponse(postResponse, postResponse.JSONDefault), orderservice_rest_client.ErrConflict)
+}
+
+func TestGetPurchases(t *testing.T) {
+	server := NewServer(orderstore.NewMemory())
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	_, err := server.Store.CreateOrder(flow.ContextWithID(ctx, testFlowID), newTestCreateOrderRequest(testOrderID))
+	require.NoError(t, err)
+
+	response, err := client.GetPurchasesWithResponse(ctx, &orderservice_rest_types.GetPurchasesParams{Limit: 10}, withFlowID(testFlowID))
+	require.NoError(t, err)
+	require.NoError(t, orderservice_rest_client.CheckResponse(response, response.JSONDefault))
+	require.Len(t, response.JSON200.Purchases, 1)
+	require.Equal(t, testOrderID, response.JSON200.Purchases[0].OrderId)
+	require.Equal(t, []string{testProductID}, response.JSON200.Purchases[0].ProductIds)
+
+	// the purchases are those of the flow of the request
+	response, err = client.GetPurchasesWithResponse(ctx, &orderservice_rest_types.GetPurchasesParams{Limit: 10})
+	require.NoError(t, err)
+	require.NoError(t, orderservice_rest_client.CheckResponse(response, response.JSONDefault))
+	require.Empty(t, response.JSON200.Purchases)
+
+	for _, limit := range []int32{0, orderstore.MaxPurchasesLimit + 1} {
+		response, err = client.GetPurchasesWithResponse(ctx, &orderservice_rest_types.GetPurchasesParams{Limit: limit})
+		require.NoError(t, err)
+		require.ErrorIs(t, orderservice_rest_client.CheckResponse(response, response.JSONDefault), orderservice_rest_client.ErrInvalidArgument, "limit %d", limit)
+	}
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	server := NewServer(orderstore.NewMemory())
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	_, err := server.Store.CreateOrder(ctx, newTestCreateOrderRequest(testOrderID))
+	require.NoError(t, err)
+
+	trackingID := "AB-1234"
+	response, err := client.PutOrdersOrderIdStatusWithResponse(ctx, testOrderID, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status:             orderservice_rest_types.Shipped,
+		ShippingTrackingId: &trackingID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, orderservice_rest_client.CheckResponse(response, response.JSONDefault))
+	require.Equal(t, orderservice_rest_types.Shipped, response.JSON200.Status)
+	require.Equal(t, trackingID, response.JSON200.ShippingTrackingId)
+
+	// a shipped order can't be paid again
+	response, err = client.PutOrdersOrderIdStatusWithResponse(ctx, testOrderID, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Paid,
+	})
+	require.NoError(t, err)
+	require.ErrorIs(t, orderservice_rest_client.CheckResponse(response, response.JSONDefault), orderservice_rest_client.ErrConflict)
+	require.Equal(t, uint32(http.StatusConflict), response.JSONDefault.Code)
+
+	response, err = client.PutOrdersOrderIdStatusWithResponse(ctx, "unknown-order", orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Cancelled,
+	})
+	require.NoError(t, err)
+	require.ErrorIs(t, orderservice_rest_client.CheckResponse(response, response.JSONDefault), orderservice_rest_client.ErrNotFound)
+}
+
+func TestPostOrderWithMissingFieldsListsEveryViolation(t *testing.T) {
+	client := newTestClient(t, NewServer(orderstore.NewMemory()))
+	ctx := context.Background()
+
+	response, err := client.PostOrdersWithBodyWithResponse(ctx, echo.MIMEApplicationJSON, strings.NewReader(`{"order_id": "order-1", "status": "lost", "lines": []}`))
+	require.NoError(t, err)
+
+	err = orderservice_rest_client.CheckResponse(response, response.JSONDefault)
+	require.ErrorIs(t, err, orderservice_rest_client.ErrInvalidArgument)
+	require.NotNil(t, response.JSONDefault)
+	require.Equal(t, uint32(http.StatusBadRequest), response.JSONDefault.Code)
+	require.Contains(t, response.JSONDefault.Message, "user_id")
+	require.Contains(t, response.JSONDefault.Message, "status")
+	require.Contains(t, response.JSONDefault.Message, "lines")
+}
+
+func TestHealthReadyChecksTheStoreAndFailsWhileShuttingDown(t *testing.T) {
+	server := NewServer(orderstore.NewMemory())
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	response, err := client.GetHealthReadyWithResponse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode())
+	require.NotNil(t, response.JSON200.Checks)
+	require.Len(t, *response.JSON200.Checks, 1)
+	require.Equal(t, databaseDependencyName, (*response.JSON200.Checks)[0].Name)
+	require.Equal(t, healthStatusOk, (*response.JSON200.Checks)[0].Status)
+
+	server.MarkShuttingDown()
+
+	response, err = client.GetHealthReadyWithResponse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, response.StatusCode())
+	require.Equal(t, healthStatusShuttingDown, *response.JSON503.Status)
+
+	liveResponse, err := client.GetHealthLiveWithResponse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, liveResponse.StatusCode())
+}
+
+func TestMetricsRecordTheRequestsByRouteAndStatus(t *testing.T) {
+	client := newTestClient(t, NewServer(orderstore.NewMemory()))
+	ctx := context.Background()
+
+	postResponse, err := client.PostOrdersWithResponse(ctx, newTestCreateOrderRequest(testOrderID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, postResponse.StatusCode())
+
+	getResponse, err := client.GetOrdersOrderIdWithResponse(ctx, testOrderID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, getResponse.StatusCode())
+
+	getResponse, err = client.GetOrdersOrderIdWithResponse(ctx, "unknown-order")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, getResponse.StatusCode())
+
+	recorder := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, metrics.Path, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	scraped := recorder.Body.String()
+	// the route is the path template, so the order IDs don't create new series
+	require.Contains(t, scraped, `http_request_duration_seconds_count{method="GET",route="/orders/:order_id",status="200"}`)
+	require.Contains(t, scraped, `http_request_duration_seconds_count{method="GET",route="/orders/:order_id",status="404"}`)
+}
+
+func TestFlowOrdersAreIsolatedAndPurged(t *testing.T) {
+	client := newTestClient(t, NewServer(orderstore.NewMemory()))
+	ctx := context.Background()
+
+	for _, requestEditors := range [][]orderservice_rest_client.RequestEditorFn{nil, {withFlowID(testFlowID)}} {
+		postResponse, err := client.PostOrdersWithResponse(ctx, newTestCreateOrderRequest(testOrderID), requestEditors...)
+		require.NoError(t, err)
+		require.NoError(t, orderservice_rest_client.CheckResponse(postResponse, postResponse.JSONDefault))
+	}
+
+	// the flow cancels its copy of the order, the baseline one is still paid
+	putResponse, err := client.PutOrdersOrderIdStatusWithResponse(ctx, testOrderID, orderservice_rest_types.UpdateOrderStatusRequest{
+		Status: orderservice_rest_types.Cancelled,
+	}, withFlowID(testFlowID))
+	require.NoError(t, err)
+	require.NoError(t, orderservice_rest_client.CheckResponse(putResponse, putResponse.JSONDefault))
+
+	getResponse, err := client.GetOrdersOrderIdWithResponse(ctx, testOrderID)
+	require.NoError(t, err)
+	require.Equal(t, orderservice_rest_types.Paid, getResponse.JSON200.Status)
+
+	purgeResponse, err := client.DeleteAdminFlowsFlowIdWithResponse(ctx, testFlowID, withAdminToken(testAdminToken))
+	require.NoError(t, err)
+	require.NoError(t, orderservice_rest_client.CheckResponse(purgeResponse, purgeResponse.JSONDefault))
+	require.Equal(t, testFlowID, purgeResponse.JSON200.FlowId)
+	require.Equal(t, int64(1), purgeResponse.JSON200.DeletedOrders)
+
+	flowGetResponse, err := client.GetOrdersOrderIdWithResponse(ctx, testOrderID, withFlowID(testFlowID))
+	require.NoError(t, err)
+	require.ErrorIs(t, orderservice_rest_client.CheckResponse(flowGetResponse, flowGetResponse.JSONDefault), orderservice_rest_client.ErrNotFound)
+
+	listResponse, err := client.GetOrdersWithResponse(ctx, &orderservice_rest_types.GetOrdersParams{UserId: testUserID})
+	require.NoError(t, err)
+	require.Len(t, listResponse.JSON200.Orders, 1)
+}
+
+func TestPurgeFlowRequiresTheAdminToken(t *testing.T) {
+	client := newTestClient(t, NewServer(orderstore.NewMemory()))
+	ctx := context.Background()
+
+	for _, requestEditors := range [][]orderservice_rest_client.RequestEditorFn{nil, {withAdminToken("wrong-token")}} {
+		purgeResponse, err := client.DeleteAdminFlowsFlowIdWithResponse(ctx, testFlowID, requestEditors...)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusUnauthorized, purgeResponse.StatusCode())
+	}
+}
@@ -0,0 +1,67 @@
+package paymentservice_rest_client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	paymentservice_rest_types "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/types"
+)
+
+// The kinds of errors returned by the payment service, check them with errors.Is on the error returned by CheckResponse
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrPaymentDeclined = errors.New("payment declined")
+	ErrFraudHold       = errors.New("held for fraud review")
+	ErrTimeout         = errors.New("payment processor timeout")
+	ErrUnavailable     = errors.New("unavailable")
+)
+
+// ResponseError is a non-successful payment service response, with the ResponseInfo sent by the service if there is one
+type ResponseError struct {
+	StatusCode int
+	Info       *paymentservice_rest_types.ResponseInfo
+}
+
+// response is implemented by every <Operation>Response returned by ClientWithResponses
+type response interface {
+	StatusCode() int
+}
+
+// CheckResponse returns a *ResponseError if the response status code is not 2xx, nil otherwise
+// e.g. CheckResponse(resp, resp.JSONDefault)
+func CheckResponse(resp response, info *paymentservice_rest_types.ResponseInfo) error {
+	statusCode := resp.StatusCode()
+	if statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices {
+		return nil
+	}
+	return &ResponseError{
+		StatusCode: statusCode,
+		Info:       info,
+	}
+}
+
+func (e *ResponseError) Error() string {
+	if e.Info != nil {
+		return fmt.Sprintf("payment service responded with status code %d: %s", e.StatusCode, e.Info.Message)
+	}
+	return fmt.Sprintf("payment service responded with status code %d", e.StatusCode)
+}
+
+// Is maps the status code to the error kinds, so errors.Is(err, ErrPaymentDeclined) works on a *ResponseError
+func (e *ResponseError) Is(target error) bool {
+	switch target {
+	case ErrInvalidArgument:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrPaymentDeclined:
+		return e.StatusCode == http.StatusPaymentRequired
+	case ErrFraudHold:
+		return e.StatusCode == http.StatusForbidden
+	case ErrTimeout:
+		return e.StatusCode == http.StatusGatewayTimeout
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusServiceUnavailable
+	default:
+		return false
+	}
+}
@@ -0,0 +1,547 @@
+// Package paymentservice_server_rest_server provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.1-0.20240604070534-2f0ff757704b DO NOT EDIT.
+package paymentservice_server_rest_server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	. "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/types"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Charge credit card
+	// (POST /charge)
+	PostCharge(ctx echo.Context) error
+	// Void charge
+	// (POST /charge/{idempotency_key}/void)
+	PostChargeIdempotencyKeyVoid(ctx echo.Context, idempotencyKey string) error
+	// Health check endpoint
+	// (GET /health)
+	GetHealth(ctx echo.Context) error
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx echo.Context) error
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// PostCharge converts echo context to params.
+func (w *ServerInterfaceWrapper) PostCharge(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostCharge(ctx)
+	return err
+}
+
+// PostChargeIdempotencyKeyVoid converts echo context to params.
+func (w *ServerInterfaceWrapper) PostChargeIdempotencyKeyVoid(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "idempotency_key" -------------
+	var idempotencyKey string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "idempotency_key", ctx.Param("idempotency_key"), &idempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter idempotency_key: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostChargeIdempotencyKeyVoid(ctx, idempotencyKey)
+	return err
+}
+
+// GetHealth converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealth(ctx)
+	return err
+}
+
+// GetHealthLive converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthLive(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthLive(ctx)
+	return err
+}
+
+// GetHealthReady converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthReady(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthReady(ctx)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.POST(baseURL+"/charge", wrapper.PostCharge)
+	router.POST(baseURL+"/charge/:idempotency_key/void", wrapper.PostChargeIdempotencyKeyVoid)
+	router.GET(baseURL+"/health", wrapper.GetHealth)
+	router.GET(baseURL+"/health/live", wrapper.GetHealthLive)
+	router.GET(baseURL+"/health/ready", wrapper.GetHealthReady)
+
+}
+
+type NotOkJSONResponse ResponseInfo
+
+type PostChargeRequestObject struct {
+	Body *PostChargeJSONRequestBody
+}
+
+type PostChargeResponseObject interface {
+	VisitPostChargeResponse(w http.ResponseWriter) error
+}
+
+type PostCharge200JSONResponse ChargeResponse
+
+func (response PostCharge200JSONResponse) VisitPostChargeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostChargedefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostChargedefaultJSONResponse) VisitPostChargeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostChargeIdempotencyKeyVoidRequestObject struct {
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+type PostChargeIdempotencyKeyVoidResponseObject interface {
+	VisitPostChargeIdempotencyKeyVoidResponse(w http.ResponseWriter) error
+}
+
+type PostChargeIdempotencyKeyVoid204Response struct {
+}
+
+func (response PostChargeIdempotencyKeyVoid204Response) VisitPostChargeIdempotencyKeyVoidResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PostChargeIdempotencyKeyVoiddefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostChargeIdempotencyKeyVoiddefaultJSONResponse) VisitPostChargeIdempotencyKeyVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthRequestObject struct {
+}
+
+type GetHealthResponseObject interface {
+	VisitGetHealthResponse(w http.ResponseWriter) error
+}
+
+type GetHealth200JSONResponse HealthResponse
+
+func (response GetHealth200JSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthdefaultJSONResponse) VisitGetHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthLiveRequestObject struct {
+}
+
+type GetHealthLiveResponseObject interface {
+	VisitGetHealthLiveResponse(w http.ResponseWriter) error
+}
+
+type GetHealthLive200JSONResponse HealthResponse
+
+func (response GetHealthLive200JSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthLivedefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthLivedefaultJSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthReadyRequestObject struct {
+}
+
+type GetHealthReadyResponseObject interface {
+	VisitGetHealthReadyResponse(w http.ResponseWriter) error
+}
+
+type GetHealthReady200JSONResponse HealthResponse
+
+func (response GetHealthReady200JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReady503JSONResponse HealthResponse
+
+func (response GetHealthReady503JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReadydefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthReadydefaultJSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Charge credit card
+	// (POST /charge)
+	PostCharge(ctx context.Context, request PostChargeRequestObject) (PostChargeResponseObject, error)
+	// Void charge
+	// (POST /charge/{idempotency_key}/void)
+	PostChargeIdempotencyKeyVoid(ctx context.Context, request PostChargeIdempotencyKeyVoidRequestObject) (PostChargeIdempotencyKeyVoidResponseObject, error)
+	// Health check endpoint
+	// (GET /health)
+	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx context.Context, request GetHealthLiveRequestObject) (GetHealthLiveResponseObject, error)
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx context.Context, request GetHealthReadyRequestObject) (GetHealthReadyResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// PostCharge operation middleware
+func (sh *strictHandler) PostCharge(ctx echo.Context) error {
+	var request PostChargeRequestObject
+
+	var body PostChargeJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostCharge(ctx.Request().Context(), request.(PostChargeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostCharge")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostChargeResponseObject); ok {
+		return validResponse.VisitPostChargeResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostChargeIdempotencyKeyVoid operation middleware
+func (sh *strictHandler) PostChargeIdempotencyKeyVoid(ctx echo.Context, idempotencyKey string) error {
+	var request PostChargeIdempotencyKeyVoidRequestObject
+
+	request.IdempotencyKey = idempotencyKey
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostChargeIdempotencyKeyVoid(ctx.Request().Context(), request.(PostChargeIdempotencyKeyVoidRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostChargeIdempotencyKeyVoid")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostChargeIdempotencyKeyVoidResponseObject); ok {
+		return validResponse.VisitPostChargeIdempotencyKeyVoidResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealth operation middleware
+func (sh *strictHandler) GetHealth(ctx echo.Context) error {
+	var request GetHealthRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealth(ctx.Request().Context(), request.(GetHealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealth")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthResponseObject); ok {
+		return validResponse.VisitGetHealthResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthLive operation middleware
+func (sh *strictHandler) GetHealthLive(ctx echo.Context) error {
+	var request GetHealthLiveRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthLive(ctx.Request().Context(), request.(GetHealthLiveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthLive")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthLiveResponseObject); ok {
+		return validResponse.VisitGetHealthLiveResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthReady operation middleware
+func (sh *strictHandler) GetHealthReady(ctx echo.Context) error {
+	var request GetHealthReadyRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthReady(ctx.Request().Context(), request.(GetHealthReadyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthReady")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthReadyResponseObject); ok {
+		return validResponse.VisitGetHealthReadyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/8xXbW/jNhL+KwSvwH6RbcV2trf+lmZ7vaC93YWT7QFd5IKJOLJYS6RKUnaMwP/9MKRk",
+	"S5bi9LA9tIsFYtPz+swzw+EzT3RRaoXKWb545gZtqZVF/+WDdh/X9CHRyqFy9BHKMpcJOKnV5FerFZ3Z",
+	"JMMC6NM3BlO+4H+bHK1Owq92sqxN36hU8/1+H3GBNjGyJFt8wT8rfCoxcSgYGqMNJ5FamWxfgxHfGVCC",
+	"vqCqCr74wjfSAo94AdahScAIHnEo8IlHXEib6A0afh9xtyuRL7h1RqoV30f8OgOzwiX+VqH1eZVGl2ic",
+	"DJlDoSvlXsvoX1rhjqwlBoV0D97/KzrXXpRyIUUpsCi1Q5XsHta4I+UCnn5CtXIZX7ydR7yQqvl60ctj",
+	"H3GDv1XSoCAwTq1FTR7dCI946MdfMXFtPEKJ+oCQ4sNjg/7ZBA9l2kdBLQfrHlJdGVLFJyjKnJzH88s5",
+	"HyiNM6AsJMSKB+ndnc/6RD5qx9qLYDD3Y0n6eR9xe0g2Gy8AzqFRfMH/8yUevbt/nkXz/TdDmbSV8amU",
+	"xrfNQ6EVlfOZp9oU4PiCS+VmU0/jJ1kQsS+mvvL1l4NpqRyu0JyxvUMwg6bPW1BV8Ygn9ZnPZ9PR3+OL",
+	"y9Hbi8vpqC5Xi57TWZee09f4OeAx6iF8PrVXQB0sb2UMtcS1Fp7Y3alzc/uRzacX37KkFmMJyUUtID7f",
+	"vudRp+xXo1/un2fDRX+PJSrhHWaYrPuUCsOtT+uI5xB6t7CdSsyiTjnfzgfLqaDAjhoX4OARLA5FaR24",
+	"quuG6zV/rYLeyUG7E/EQ9P9EyF12ZqoQQv6TdFjY10bLKbT7g0swBnYv5fX50+CYkQVaB0XZFZ7G0/ko",
+	"/nY0fXcXxwv//xfeKoAAhyPSHcSqh0C4IvqJ12R7SGpSnp2obQL7Sitt+0x2GTL6iVVKOst0yugk3AAR",
+	"20qX+QMLBTIrV4qB9QdenEdnptG75l9rKI1ah30yBpuDIW4zneNQjPx30Px0oHRgbNw2CA0xsrOE9MtS",
+	"V+MQR/Xy+CzQWljhYCOHg9+3Dt2RbO9Oo8OjjyhEdi6hu9plsxh9v1x+XPKI33z4x0ce8X9fLT/cfPhh",
+	"YBci17JGo1ut5fe3d2mVs6tPN8yWmMi0XvxYqo0v3CfYFagcs2g2MsGISffGssqiYE4zqJwerVChAYcs",
+	"ySWJ3r7/8Y1loIRXQjOyUmAzc510vglP7PKIb9DYEFU8vhjHlLkuUUEp+YLPxvF4FkZ05us4Sfw6Qx9L",
+	"bV0/tZ8hl9TJoQPClcLoSvGRHTnpvwZjtSgYQWkyg64yKhzevG+Y3NpFxuwqWNSmMeYycExa9caxDQXA",
+	"pGXA5nEcMWACk1wqFHUYbB5P6Tg4ZxnmwsOeGqgEM7iRuPVSMx8jsNLoBK31lQHHhEbvCJTdomFSMRpa",
+	"DNhlPB+zuwxry/ZkMrR2SLbGHQNDSlaqVd6oROGvVCsGQWYFUnUQaeHQQJNKY11tYcyuUocmRHPAlRWw",
+	"I7Toj9KOZbBB9oioai0RsY2Wgvy6rB9pAWu0zFYGmaxhHnNPk7Ai3AhilrYu7Lo8dBxa950Wuz/sldN9",
+	"WOy7je1Mhf6g9ciaxvEf7jyYH3pk3VYJsYTauokiOhZgC7bBmnvVFKr8xWfQIY1JeCiSN1sVBZgdX9Qv",
+	"inZveYG6NSfPJ4+V/YRq+3LHXoNKMK+bMJguQOCRvad0eMRUeyq8scyicznxB8erMdtmqLyKNoJaw7ES",
+	"ZOiuRFe5oLZ5RFbmkKBvXy9buUQX2LC5DkFaVqm10ls1Zj/X3Dz0LPUhzbrcIIid5y7FQPPAR+j7lCBX",
+	"uEFzJLmfCkqPdHmOvzfHfH/EHTn3E9BAgQ6N5YsvpxDeDaDUSYfTTcAXfo7yZqMceFV2CR21yPk/PV7v",
+	"e50w79f9ZcIGkAm/gOxXM5YwbJDwVM38+krGVjhAyWVr4AVRFtbPBtX6+upX8Qd0YTXm/8dhcLJ8Dw2D",
+	"EB8RLsS/+2oMg1PmF3uGSpRaKtdGc5LLDb4KqV6zbSZz9DDWN5vvi0c60x5ZZPX8tv5Cbi684Jr0RPNc",
+	"kGjP1OAnCuivUgfw8HxtFSglRZCdqYMfSi8Wwj+wbA/GE2bXm1CpjbP+yg6TTqYMVDNbCpaCzC2NPdlR",
+	"pnRtVjlHQ1PQCH25Rksf7F+lSCFLp5nBBOXGrztpKhOq22U8+3OCOsI/HNhXEYrwl8OMIjm/yQ9dOSeL",
+	"PD0leMQrk/MFz5wr7WIyKYNMLcL39/v/DgCoddmflRYAAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,94 @@
+// Package paymentservice_rest_types provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.1-0.20240604070534-2f0ff757704b DO NOT EDIT.
+package paymentservice_rest_types
+
+import (
+	"time"
+)
+
+// Defines values for CardBrand.
+const (
+	Amex       CardBrand = "amex"
+	Discover   CardBrand = "discover"
+	Mastercard CardBrand = "mastercard"
+	Visa       CardBrand = "visa"
+)
+
+// Defines values for ResponseType.
+const (
+	ERROR   ResponseType = "ERROR"
+	INFO    ResponseType = "INFO"
+	WARNING ResponseType = "WARNING"
+)
+
+// CardBrand defines model for CardBrand.
+type CardBrand string
+
+// ChargeRequest defines model for ChargeRequest.
+type ChargeRequest struct {
+	Amount         Money      `json:"amount"`
+	CreditCard     CreditCard `json:"credit_card"`
+	IdempotencyKey string     `json:"idempotency_key"`
+}
+
+// ChargeResponse defines model for ChargeResponse.
+type ChargeResponse struct {
+	CardBrand     CardBrand `json:"card_brand"`
+	CardLastFour  string    `json:"card_last_four"`
+	TransactionId string    `json:"transaction_id"`
+}
+
+// CreditCard defines model for CreditCard.
+type CreditCard struct {
+	CreditCardCvv             string `json:"credit_card_cvv"`
+	CreditCardExpirationMonth int32  `json:"credit_card_expiration_month"`
+	CreditCardExpirationYear  int32  `json:"credit_card_expiration_year"`
+	CreditCardNumber          string `json:"credit_card_number"`
+}
+
+// CurrencyCode ISO 4217 currency code
+type CurrencyCode = string
+
+// DependencyCheck defines model for DependencyCheck.
+type DependencyCheck struct {
+	Error     *string `json:"error,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+}
+
+// HealthResponse defines model for HealthResponse.
+type HealthResponse struct {
+	Checks    *[]DependencyCheck `json:"checks,omitempty"`
+	Status    *string            `json:"status,omitempty"`
+	Timestamp *time.Time         `json:"timestamp,omitempty"`
+}
+
+// Money defines model for Money.
+type Money struct {
+	// CurrencyCode ISO 4217 currency code
+	CurrencyCode CurrencyCode `json:"currency_code"`
+
+	// Nanos the nano units of the amount, with the same sign as the units
+	Nanos int32 `json:"nanos"`
+
+	// Units the whole units of the amount
+	Units int64 `json:"units"`
+}
+
+// ResponseInfo defines model for ResponseInfo.
+type ResponseInfo struct {
+	Code    uint32       `json:"code"`
+	Message string       `json:"message"`
+	Type    ResponseType `json:"type"`
+}
+
+// ResponseType defines model for ResponseType.
+type ResponseType string
+
+// NotOk defines model for NotOk.
+type NotOk = ResponseInfo
+
+// PostChargeJSONRequestBody defines body for PostCharge for application/json ContentType.
+type PostChargeJSONRequestBody = ChargeRequest
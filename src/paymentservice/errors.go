@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kurtosis-tech/new-obd/src/paymentservice/payment"
+	"github.com/pkg/errors"
+)
+
+// statusCodeForError returns the status code of the errors returned by the handlers, based on the payment error kinds
+func statusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, payment.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, payment.ErrDeclined):
+		return http.StatusPaymentRequired
+	case errors.Is(err, payment.ErrFraudHold):
+		return http.StatusForbidden
+	case errors.Is(err, payment.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/kurtosis-tech/new-obd/src/libs/metrics"
+	"github.com/kurtosis-tech/new-obd/src/libs/rest"
+	"github.com/kurtosis-tech/new-obd/src/libs/shutdown"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	paymentservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/server"
+	"github.com/kurtosis-tech/new-obd/src/paymentservice/config"
+	"github.com/kurtosis-tech/new-obd/src/paymentservice/payment"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const (
+	serviceName = "paymentservice"
+
+	// the exit code of an invalid config, it follows the ones of the shutdown package
+	exitCodeInvalidConfig = 3
+)
+
+var (
+	defaultCORSOrigins = []string{"*"}
+	defaultCORSHeaders = []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept}
+)
+
+func main() {
+	cfg, commandLine, err := config.Load(os.Args[0], os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitCodeInvalidConfig)
+	}
+
+	if commandLine.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), serviceName, cfg.Tracing)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Info("Running REST API server...")
+
+	// This is how you set up a basic Echo router
+	echoRouter := echo.New()
+	echoRouter.HTTPErrorHandler = rest.NewResponseInfoHTTPErrorHandler(statusCodeForError)
+	// first, so the duration includes the other middlewares
+	echoRouter.Use(metrics.Middleware)
+	echoRouter.Use(otelecho.Middleware(serviceName, otelecho.WithSkipper(tracing.Skipper)))
+	echoRouter.Use(middleware.Logger())
+
+	echoRouter.Use(tracing.KardinalTraceIDMiddleware)
+
+	// CORS configuration
+	echoRouter.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: defaultCORSOrigins,
+		AllowHeaders: defaultCORSHeaders,
+	}))
+
+	requestValidatorMiddleware, err := rest.NewRequestValidatorMiddleware(paymentservice_server_rest_server.GetSwagger, "")
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	echoRouter.Use(requestValidatorMiddleware)
+
+	logrus.Info("Using the fake payment processor, no card is charged")
+	paymentService := payment.NewService(payment.NewFakeProcessor(), cfg.ProcessorTimeout)
+
+	server := NewServer(paymentService)
+
+	metrics.RegisterHandler(echoRouter)
+
+	strictMiddlewares := []paymentservice_server_rest_server.StrictMiddlewareFunc{rest.NewErrorMappingMiddleware(statusCodeForError)}
+	paymentservice_server_rest_server.RegisterHandlers(echoRouter, paymentservice_server_rest_server.NewStrictHandler(server, strictMiddlewares))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	exitCode := shutdown.ServeUntilShutdown(ctx, func() error {
+		return echoRouter.Start(cfg.Address())
+	}, echoRouter.Shutdown, shutdown.Options{
+		OnShutdownStart: server.MarkShuttingDown,
+		DrainDelay:      cfg.DrainDelay,
+		ShutdownTimeout: cfg.ShutdownTimeout,
+	})
+	stop()
+
+	shutdown.FlushTraces(shutdownTracing, cfg.ShutdownTimeout)
+	shutdown.FlushLogs()
+	os.Exit(exitCode)
+}
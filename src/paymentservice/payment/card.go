@@ -0,0 +1,137 @@
+package payment
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	paymentservice_rest_types "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/types"
+)
+
+const (
+	minCardNumberLength = 12
+	maxCardNumberLength = 19
+
+	cvvLength     = 3
+	amexCVVLength = 4
+
+	// maxYearsToExpiration rejects the expiration years that are most likely typos
+	maxYearsToExpiration = 20
+
+	lastFourLength = 4
+)
+
+// iinRange is a range of Issuer Identification Numbers, the first digits of the card numbers, assigned to a brand
+type iinRange struct {
+	prefixLength int
+	low, high    int
+	brand        paymentservice_rest_types.CardBrand
+	lengths      []int
+}
+
+// iinRanges are the card brands accepted, a number that isn't in any of the ranges is rejected
+var iinRanges = []iinRange{
+	{prefixLength: 1, low: 4, high: 4, brand: paymentservice_rest_types.Visa, lengths: []int{13, 16, 19}},
+	{prefixLength: 2, low: 51, high: 55, brand: paymentservice_rest_types.Mastercard, lengths: []int{16}},
+	{prefixLength: 4, low: 2221, high: 2720, brand: paymentservice_rest_types.Mastercard, lengths: []int{16}},
+	{prefixLength: 2, low: 34, high: 34, brand: paymentservice_rest_types.Amex, lengths: []int{15}},
+	{prefixLength: 2, low: 37, high: 37, brand: paymentservice_rest_types.Amex, lengths: []int{15}},
+	{prefixLength: 4, low: 6011, high: 6011, brand: paymentservice_rest_types.Discover, lengths: []int{16, 17, 18, 19}},
+	{prefixLength: 3, low: 644, high: 649, brand: paymentservice_rest_types.Discover, lengths: []int{16, 17, 18, 19}},
+	{prefixLength: 2, low: 65, high: 65, brand: paymentservice_rest_types.Discover, lengths: []int{16, 17, 18, 19}},
+	{prefixLength: 6, low: 622126, high: 622925, brand: paymentservice_rest_types.Discover, lengths: []int{16, 17, 18, 19}},
+}
+
+// Card is a credit card that passed the validation
+type Card struct {
+	// Number has the digits only, without the separators
+	Number string
+	Brand  paymentservice_rest_types.CardBrand
+}
+
+// LastFour returns the last digits of the number, the only ones that can be shown or logged
+func (c *Card) LastFour() string {
+	return c.Number[len(c.Number)-lastFourLength:]
+}
+
+// ValidateCard checks the number, the CVV and the expiration date of the card, a card expires at the end of its
+// expiration month
+func ValidateCard(card paymentservice_rest_types.CreditCard, now time.Time) (*Card, error) {
+	number := NormalizeCardNumber(card.CreditCardNumber)
+	if len(number) < minCardNumberLength || len(number) > maxCardNumberLength || !isDigits(number) {
+		return nil, newInvalidArgumentError("the card number must have between %d and %d digits", minCardNumberLength, maxCardNumberLength)
+	}
+	if !luhnValid(number) {
+		return nil, newInvalidArgumentError("the card number is not valid")
+	}
+
+	brand, found := detectBrand(number)
+	if !found {
+		return nil, newInvalidArgumentError("the card brand is not supported, the accepted brands are Visa, Mastercard, American Express and Discover")
+	}
+
+	expectedCVVLength := cvvLength
+	if brand == paymentservice_rest_types.Amex {
+		expectedCVVLength = amexCVVLength
+	}
+	if len(card.CreditCardCvv) != expectedCVVLength || !isDigits(card.CreditCardCvv) {
+		return nil, newInvalidArgumentError("the CVV of a %s card must have %d digits", brand, expectedCVVLength)
+	}
+
+	month := int(card.CreditCardExpirationMonth)
+	year := int(card.CreditCardExpirationYear)
+	if month < 1 || month > 12 {
+		return nil, newInvalidArgumentError("invalid expiration month %d", month)
+	}
+	if year < now.Year() || (year == now.Year() && time.Month(month) < now.Month()) {
+		return nil, newInvalidArgumentError("the card expired on %02d/%d", month, year)
+	}
+	if year > now.Year()+maxYearsToExpiration {
+		return nil, newInvalidArgumentError("invalid expiration year %d", year)
+	}
+
+	return &Card{Number: number, Brand: brand}, nil
+}
+
+// NormalizeCardNumber removes the spaces and dashes the card numbers are often typed with
+func NormalizeCardNumber(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(number)
+}
+
+// detectBrand returns the brand of the number, based on its IIN and its length
+func detectBrand(number string) (paymentservice_rest_types.CardBrand, bool) {
+	for _, r := range iinRanges {
+		prefix, err := strconv.Atoi(number[:r.prefixLength])
+		if err != nil || prefix < r.low || prefix > r.high {
+			continue
+		}
+		for _, length := range r.lengths {
+			if len(number) == length {
+				return r.brand, true
+			}
+		}
+	}
+	return "", false
+}
+
+// luhnValid checks the check digit of the number, it catches most of the typos
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func isDigits(value string) bool {
+	return value != "" && strings.Trim(value, "0123456789") == ""
+}
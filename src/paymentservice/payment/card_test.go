@@ -0,0 +1,74 @@
+package payment
+
+import (
+	"testing"
+	"time"
+
+	paymentservice_rest_types "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/types"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2026, time.June, 15, 12, 0, 0, 0, time.UTC)
+
+func newTestCard(number string, cvv string) paymentservice_rest_types.CreditCard {
+	return paymentservice_rest_types.CreditCard{
+		CreditCardNumber:          number,
+		CreditCardCvv:             cvv,
+		CreditCardExpirationMonth: 1,
+		CreditCardExpirationYear:  2030,
+	}
+}
+
+func TestValidateCardDetectsTheBrand(t *testing.T) {
+	tests := []struct {
+		number   string
+		cvv      string
+		expected paymentservice_rest_types.CardBrand
+	}{
+		{"4432-8015-6152-0454", "672", paymentservice_rest_types.Visa},
+		{"4111 1111 1111 1111", "672", paymentservice_rest_types.Visa},
+		{"5555555555554444", "672", paymentservice_rest_types.Mastercard},
+		{"2221000000000009", "672", paymentservice_rest_types.Mastercard},
+		{"378282246310005", "6720", paymentservice_rest_types.Amex},
+		{"6011111111111117", "672", paymentservice_rest_types.Discover},
+	}
+	for _, test := range tests {
+		t.Run(test.number, func(t *testing.T) {
+			card, err := ValidateCard(newTestCard(test.number, test.cvv), testNow)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, card.Brand)
+			require.Equal(t, NormalizeCardNumber(test.number), card.Number)
+		})
+	}
+}
+
+func TestValidateCardRejectsInvalidCards(t *testing.T) {
+	tests := []struct {
+		name string
+		card paymentservice_rest_types.CreditCard
+	}{
+		{"too short", newTestCard("44328015615", "672")},
+		{"not digits", newTestCard("4432801561520abc", "672")},
+		{"wrong check digit", newTestCard("4432801561520455", "672")},
+		{"unsupported brand", newTestCard("3530111333300000", "672")},
+		{"wrong length for the brand", newTestCard("55555555555544440", "672")},
+		{"short CVV", newTestCard("4432801561520454", "67")},
+		{"amex CVV", newTestCard("378282246310005", "672")},
+		{"invalid month", paymentservice_rest_types.CreditCard{CreditCardNumber: "4432801561520454", CreditCardCvv: "672", CreditCardExpirationMonth: 13, CreditCardExpirationYear: 2030}},
+		{"expired last month", paymentservice_rest_types.CreditCard{CreditCardNumber: "4432801561520454", CreditCardCvv: "672", CreditCardExpirationMonth: 5, CreditCardExpirationYear: 2026}},
+		{"expired last year", paymentservice_rest_types.CreditCard{CreditCardNumber: "4432801561520454", CreditCardCvv: "672", CreditCardExpirationMonth: 12, CreditCardExpirationYear: 2025}},
+		{"too far", paymentservice_rest_types.CreditCard{CreditCardNumber: "4432801561520454", CreditCardCvv: "672", CreditCardExpirationMonth: 1, CreditCardExpirationYear: 2100}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ValidateCard(test.card, testNow)
+			require.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+}
+
+func TestValidateCardAcceptsACardExpiringThisMonth(t *testing.T) {
+	card := paymentservice_rest_types.CreditCard{CreditCardNumber: "4432801561520454", CreditCardCvv: "672", CreditCardExpirationMonth: 6, CreditCardExpirationYear: 2026}
+	_, err := ValidateCard(card, testNow)
+	require.NoError(t, err)
+}
@@ -0,0 +1,37 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of errors returned by Charge, check them with errors.Is
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrDeclined        = errors.New("card declined")
+	ErrFraudHold       = errors.New("held for fraud review")
+	ErrTimeout         = errors.New("payment processor timeout")
+)
+
+// paymentError tags the cause with one of the error kinds, so callers can classify it without knowing which
+// check failed
+type paymentError struct {
+	kind  error
+	cause error
+}
+
+func newPaymentError(kind error, cause error) error {
+	return &paymentError{kind: kind, cause: cause}
+}
+
+func newInvalidArgumentError(format string, args ...interface{}) error {
+	return newPaymentError(ErrInvalidArgument, fmt.Errorf(format, args...))
+}
+
+func (e *paymentError) Error() string {
+	return fmt.Sprintf("%s: %s", e.kind.Error(), e.cause.Error())
+}
+
+func (e *paymentError) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
@@ -0,0 +1,82 @@
+// Package payment validates the credit cards and the amounts and charges them through a payment processor
+package payment
+
+import (
+	"context"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/money"
+	paymentservice_rest_types "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type Service struct {
+	processor Processor
+	// processorTimeout bounds the wait for the processor, the card may or may not be charged when it expires
+	processorTimeout time.Duration
+
+	now func() time.Time
+}
+
+func NewService(processor Processor, processorTimeout time.Duration) *Service {
+	return &Service{
+		processor:        processor,
+		processorTimeout: processorTimeout,
+		now:              time.Now,
+	}
+}
+
+// Charge validates the amount and the card and charges it, the processor is only called for valid charges. Charging
+// the same idempotency key again returns the transaction of the first charge.
+func (s *Service) Charge(ctx context.Context, request paymentservice_rest_types.ChargeRequest) (*paymentservice_rest_types.ChargeResponse, error) {
+	if request.IdempotencyKey == "" {
+		return nil, newInvalidArgumentError("the idempotency key of the charge is required")
+	}
+	amount := request.Amount
+	if !money.IsPositive(money.Money(amount)) {
+		return nil, newInvalidArgumentError("the amount to charge must be positive, got %d.%09d %s", amount.Units, amount.Nanos, amount.CurrencyCode)
+	}
+	card, err := ValidateCard(request.CreditCard, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	processorCtx, cancel := context.WithTimeout(ctx, s.processorTimeout)
+	defer cancel()
+	transactionID, err := s.processor.Authorize(processorCtx, request.IdempotencyKey, amount, card)
+	if err != nil {
+		// the processor may not notice its own deadline
+		if errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, ErrTimeout) {
+			err = newPaymentError(ErrTimeout, err)
+		}
+		return nil, errors.Wrapf(err, "An error occurred charging the %s card ending in %s with idempotency key '%s'", card.Brand, card.LastFour(), request.IdempotencyKey)
+	}
+
+	logrus.Infof("Charged %d.%09d %s to the %s card ending in %s, transaction '%s' with idempotency key '%s'", amount.Units, amount.Nanos, amount.CurrencyCode, card.Brand, card.LastFour(), transactionID, request.IdempotencyKey)
+	return &paymentservice_rest_types.ChargeResponse{
+		TransactionId: transactionID,
+		CardBrand:     card.Brand,
+		CardLastFour:  card.LastFour(),
+	}, nil
+}
+
+// Void cancels the charge made with the idempotency key, e.g. when the order it paid for couldn't be placed or the
+// outcome of the charge is unknown
+func (s *Service) Void(ctx context.Context, idempotencyKey string) error {
+	if idempotencyKey == "" {
+		return newInvalidArgumentError("the idempotency key of the charge to void is required")
+	}
+
+	processorCtx, cancel := context.WithTimeout(ctx, s.processorTimeout)
+	defer cancel()
+	if err := s.processor.Void(processorCtx, idempotencyKey); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, ErrTimeout) {
+			err = newPaymentError(ErrTimeout, err)
+		}
+		return errors.Wrapf(err, "An error occurred voiding the charge with idempotency key '%s'", idempotencyKey)
+	}
+
+	logrus.Infof("Voided the charge with idempotency key '%s'", idempotencyKey)
+	return nil
+}
@@ -0,0 +1,117 @@
+package payment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	paymentservice_rest_types "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testProcessorTimeout = 50 * time.Millisecond
+
+func newTestService() *Service {
+	service := NewService(NewFakeProcessor(), testProcessorTimeout)
+	service.now = func() time.Time { return testNow }
+	return service
+}
+
+func newTestChargeRequest(number string) paymentservice_rest_types.ChargeRequest {
+	return paymentservice_rest_types.ChargeRequest{
+		IdempotencyKey: "order-1",
+		Amount:         paymentservice_rest_types.Money{CurrencyCode: "USD", Units: 48, Nanos: 970000000},
+		CreditCard:     newTestCard(number, "672"),
+	}
+}
+
+func TestCharge(t *testing.T) {
+	response, err := newTestService().Charge(context.Background(), newTestChargeRequest("4432-8015-6152-0454"))
+	require.NoError(t, err)
+	require.NotEmpty(t, response.TransactionId)
+	require.Equal(t, paymentservice_rest_types.Visa, response.CardBrand)
+	require.Equal(t, "0454", response.CardLastFour)
+}
+
+func TestChargeRejectsInvalidAmounts(t *testing.T) {
+	amounts := []paymentservice_rest_types.Money{
+		{CurrencyCode: "USD", Units: 0, Nanos: 0},
+		{CurrencyCode: "USD", Units: -1, Nanos: 0},
+		{CurrencyCode: "USD", Units: 1, Nanos: -1},
+		{CurrencyCode: "", Units: 1, Nanos: 0},
+	}
+	for _, amount := range amounts {
+		request := newTestChargeRequest("4432801561520454")
+		request.Amount = amount
+		_, err := newTestService().Charge(context.Background(), request)
+		require.ErrorIs(t, err, ErrInvalidArgument)
+	}
+
+	request := newTestChargeRequest("4432801561520454")
+	request.IdempotencyKey = ""
+	_, err := newTestService().Charge(context.Background(), request)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestChargeIsIdempotent(t *testing.T) {
+	service := newTestService()
+	first, err := service.Charge(context.Background(), newTestChargeRequest("4432801561520454"))
+	require.NoError(t, err)
+
+	retry, err := service.Charge(context.Background(), newTestChargeRequest("4432801561520454"))
+	require.NoError(t, err)
+	require.Equal(t, first.TransactionId, retry.TransactionId)
+
+	otherRequest := newTestChargeRequest("4432801561520454")
+	otherRequest.IdempotencyKey = "order-2"
+	other, err := service.Charge(context.Background(), otherRequest)
+	require.NoError(t, err)
+	require.NotEqual(t, first.TransactionId, other.TransactionId)
+}
+
+func TestChargeFailsWithTheMagicNumbers(t *testing.T) {
+	tests := []struct {
+		number   string
+		expected error
+	}{
+		{FakeDeclinedCardNumber, ErrDeclined},
+		{FakeFraudHoldCardNumber, ErrFraudHold},
+		{FakeTimeoutCardNumber, ErrTimeout},
+	}
+	for _, test := range tests {
+		t.Run(test.number, func(t *testing.T) {
+			_, err := newTestService().Charge(context.Background(), newTestChargeRequest(test.number))
+			require.ErrorIs(t, err, test.expected)
+		})
+	}
+}
+
+func TestVoid(t *testing.T) {
+	service := newTestService()
+	request := newTestChargeRequest("4432801561520454")
+	_, err := service.Charge(context.Background(), request)
+	require.NoError(t, err)
+
+	require.NoError(t, service.Void(context.Background(), request.IdempotencyKey))
+	// voiding it again is a no-op
+	require.NoError(t, service.Void(context.Background(), request.IdempotencyKey))
+	// and a voided charge isn't charged again
+	_, err = service.Charge(context.Background(), request)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+
+	// a key that was never charged is a no-op too
+	require.NoError(t, service.Void(context.Background(), "order-2"))
+	require.ErrorIs(t, service.Void(context.Background(), ""), ErrInvalidArgument)
+}
+
+func TestVoidAfterATimeout(t *testing.T) {
+	service := newTestService()
+	request := newTestChargeRequest(FakeTimeoutCardNumber)
+	_, err := service.Charge(context.Background(), request)
+	require.ErrorIs(t, err, ErrTimeout)
+
+	// the card may have been charged, voiding the key makes sure it isn't
+	require.NoError(t, service.Void(context.Background(), request.IdempotencyKey))
+	_, err = service.Charge(context.Background(), request)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+}
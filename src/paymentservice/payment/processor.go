@@ -0,0 +1,88 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+	paymentservice_rest_types "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/types"
+)
+
+// The magic numbers of the fake processor, they're valid Visa numbers so they pass the card validation
+const (
+	FakeDeclinedCardNumber  = "4000000000000002"
+	FakeFraudHoldCardNumber = "4100000000000019"
+	// FakeTimeoutCardNumber is charged but its answer never arrives, the charge fails when the processor timeout expires
+	FakeTimeoutCardNumber = "4000000000000119"
+)
+
+// Processor authorizes the charges on the validated cards and returns the ID of the transaction, and voids them
+// before they're settled. The charges are identified by an idempotency key chosen by the caller: authorizing a key
+// again returns the transaction of the first authorization, and voiding a key that was never charged or is already
+// voided is a no-op, so a charge whose outcome is unknown can always be voided.
+type Processor interface {
+	Authorize(ctx context.Context, idempotencyKey string, amount paymentservice_rest_types.Money, card *Card) (string, error)
+	Void(ctx context.Context, idempotencyKey string) error
+}
+
+type fakeTransaction struct {
+	id     string
+	voided bool
+}
+
+// FakeProcessor accepts every card without charging it except the magic numbers, which always fail the same way,
+// so the failures of the checkout can be tested. It keeps the transactions in memory by idempotency key.
+type FakeProcessor struct {
+	mutex        sync.Mutex
+	transactions map[string]*fakeTransaction
+}
+
+func NewFakeProcessor() *FakeProcessor {
+	return &FakeProcessor{
+		mutex:        sync.Mutex{},
+		transactions: map[string]*fakeTransaction{},
+	}
+}
+
+func (p *FakeProcessor) Authorize(ctx context.Context, idempotencyKey string, amount paymentservice_rest_types.Money, card *Card) (string, error) {
+	switch card.Number {
+	case FakeDeclinedCardNumber:
+		return "", newPaymentError(ErrDeclined, errors.New("the issuer declined the charge"))
+	case FakeFraudHoldCardNumber:
+		return "", newPaymentError(ErrFraudHold, errors.New("the charge was flagged as suspicious"))
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	p.mutex.Lock()
+	transaction, found := p.transactions[idempotencyKey]
+	if !found {
+		transaction = &fakeTransaction{id: uuid.NewString(), voided: false}
+		p.transactions[idempotencyKey] = transaction
+	}
+	p.mutex.Unlock()
+	if transaction.voided {
+		return "", newInvalidArgumentError("the charge with idempotency key '%s' was voided", idempotencyKey)
+	}
+
+	if card.Number == FakeTimeoutCardNumber {
+		// the card is charged but the answer never arrives
+		<-ctx.Done()
+		return "", newPaymentError(ErrTimeout, ctx.Err())
+	}
+	return transaction.id, nil
+}
+
+func (p *FakeProcessor) Void(ctx context.Context, idempotencyKey string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if transaction, found := p.transactions[idempotencyKey]; found {
+		transaction.voided = true
+	}
+	return nil
+}
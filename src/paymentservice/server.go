@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	paymentservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/server"
+	paymentservice_rest_types "github.com/kurtosis-tech/new-obd/src/paymentservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/paymentservice/payment"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	healthStatusOk           = "ok"
+	healthStatusShuttingDown = "shutting down"
+)
+
+type Server struct {
+	Payments *payment.Service
+	// shuttingDown is a pointer so every copy of the server (the handlers have value receivers) sees the same flag
+	shuttingDown *atomic.Bool
+}
+
+func NewServer(paymentService *payment.Service) Server {
+	return Server{Payments: paymentService, shuttingDown: &atomic.Bool{}}
+}
+
+// MarkShuttingDown makes the readiness check fail, so the service stops receiving traffic while it shuts down
+func (s Server) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s Server) GetHealth(ctx context.Context, request paymentservice_server_rest_server.GetHealthRequestObject) (paymentservice_server_rest_server.GetHealthResponseObject, error) {
+
+	status := healthStatusOk
+	now := time.Now()
+
+	response := paymentservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return paymentservice_server_rest_server.GetHealth200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthLive(ctx context.Context, request paymentservice_server_rest_server.GetHealthLiveRequestObject) (paymentservice_server_rest_server.GetHealthLiveResponseObject, error) {
+	status := healthStatusOk
+	now := time.Now()
+
+	response := paymentservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return paymentservice_server_rest_server.GetHealthLive200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthReady(ctx context.Context, request paymentservice_server_rest_server.GetHealthReadyRequestObject) (paymentservice_server_rest_server.GetHealthReadyResponseObject, error) {
+	now := time.Now()
+
+	if s.shuttingDown.Load() {
+		status := healthStatusShuttingDown
+		response := paymentservice_rest_types.HealthResponse{
+			Status:    &status,
+			Timestamp: &now,
+		}
+		return paymentservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+
+	// the processor is checked by each charge, a processor down is a failed charge and not a reason to stop the traffic
+	checks := []paymentservice_rest_types.DependencyCheck{}
+	status := healthStatusOk
+
+	response := paymentservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+		Checks:    &checks,
+	}
+
+	return paymentservice_server_rest_server.GetHealthReady200JSONResponse(response), nil
+}
+
+func (s Server) PostCharge(ctx context.Context, request paymentservice_server_rest_server.PostChargeRequestObject) (paymentservice_server_rest_server.PostChargeResponseObject, error) {
+	amount := request.Body.Amount
+	logrus.Infof("Charge request - Idempotency key: %s, Amount: %d.%09d %s", request.Body.IdempotencyKey, amount.Units, amount.Nanos, amount.CurrencyCode)
+	response, err := s.Payments.Charge(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return paymentservice_server_rest_server.PostCharge200JSONResponse(*response), nil
+}
+
+func (s Server) PostChargeIdempotencyKeyVoid(ctx context.Context, request paymentservice_server_rest_server.PostChargeIdempotencyKeyVoidRequestObject) (paymentservice_server_rest_server.PostChargeIdempotencyKeyVoidResponseObject, error) {
+	logrus.Infof("Void request - Idempotency key: %s", request.IdempotencyKey)
+	if err := s.Payments.Void(ctx, request.IdempotencyKey); err != nil {
+		return nil, err
+	}
+	return paymentservice_server_rest_server.PostChargeIdempotencyKeyVoid204Response{}, nil
+}
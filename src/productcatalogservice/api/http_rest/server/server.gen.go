@@ -27,12 +27,30 @@ type ServerInterface interface {
 	// Health check endpoint
 	// (GET /health)
 	GetHealth(ctx echo.Context) error
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx echo.Context) error
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx echo.Context) error
 	// List products
 	// (GET /products)
 	GetProducts(ctx echo.Context) error
 	// Get product by id
 	// (GET /products/{id})
 	GetProductsId(ctx echo.Context, id Id) error
+	// Get product stock
+	// (GET /products/{id}/stock)
+	GetProductsIdStock(ctx echo.Context, id Id) error
+	// Reserve stock
+	// (POST /reservations)
+	PostReservations(ctx echo.Context) error
+	// Commit reservation
+	// (POST /reservations/{reservation_id}/commit)
+	PostReservationsReservationIdCommit(ctx echo.Context, reservationId ReservationId) error
+	// Release reservation
+	// (POST /reservations/{reservation_id}/release)
+	PostReservationsReservationIdRelease(ctx echo.Context, reservationId ReservationId) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -49,6 +67,24 @@ func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
 	return err
 }
 
+// GetHealthLive converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthLive(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthLive(ctx)
+	return err
+}
+
+// GetHealthReady converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealthReady(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealthReady(ctx)
+	return err
+}
+
 // GetProducts converts echo context to params.
 func (w *ServerInterfaceWrapper) GetProducts(ctx echo.Context) error {
 	var err error
@@ -74,6 +110,63 @@ func (w *ServerInterfaceWrapper) GetProductsId(ctx echo.Context) error {
 	return err
 }
 
+// GetProductsIdStock converts echo context to params.
+func (w *ServerInterfaceWrapper) GetProductsIdStock(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetProductsIdStock(ctx, id)
+	return err
+}
+
+// PostReservations converts echo context to params.
+func (w *ServerInterfaceWrapper) PostReservations(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostReservations(ctx)
+	return err
+}
+
+// PostReservationsReservationIdCommit converts echo context to params.
+func (w *ServerInterfaceWrapper) PostReservationsReservationIdCommit(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "reservation_id" -------------
+	var reservationId ReservationId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reservation_id", ctx.Param("reservation_id"), &reservationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reservation_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostReservationsReservationIdCommit(ctx, reservationId)
+	return err
+}
+
+// PostReservationsReservationIdRelease converts echo context to params.
+func (w *ServerInterfaceWrapper) PostReservationsReservationIdRelease(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "reservation_id" -------------
+	var reservationId ReservationId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "reservation_id", ctx.Param("reservation_id"), &reservationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter reservation_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostReservationsReservationIdRelease(ctx, reservationId)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -103,8 +196,14 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/health", wrapper.GetHealth)
+	router.GET(baseURL+"/health/live", wrapper.GetHealthLive)
+	router.GET(baseURL+"/health/ready", wrapper.GetHealthReady)
 	router.GET(baseURL+"/products", wrapper.GetProducts)
 	router.GET(baseURL+"/products/:id", wrapper.GetProductsId)
+	router.GET(baseURL+"/products/:id/stock", wrapper.GetProductsIdStock)
+	router.POST(baseURL+"/reservations", wrapper.PostReservations)
+	router.POST(baseURL+"/reservations/:reservation_id/commit", wrapper.PostReservationsReservationIdCommit)
+	router.POST(baseURL+"/reservations/:reservation_id/release", wrapper.PostReservationsReservationIdRelease)
 
 }
 
@@ -138,6 +237,71 @@ func (response GetHealthdefaultJSONResponse) VisitGetHealthResponse(w http.Respo
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type GetHealthLiveRequestObject struct {
+}
+
+type GetHealthLiveResponseObject interface {
+	VisitGetHealthLiveResponse(w http.ResponseWriter) error
+}
+
+type GetHealthLive200JSONResponse HealthResponse
+
+func (response GetHealthLive200JSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthLivedefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthLivedefaultJSONResponse) VisitGetHealthLiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetHealthReadyRequestObject struct {
+}
+
+type GetHealthReadyResponseObject interface {
+	VisitGetHealthReadyResponse(w http.ResponseWriter) error
+}
+
+type GetHealthReady200JSONResponse HealthResponse
+
+func (response GetHealthReady200JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReady503JSONResponse HealthResponse
+
+func (response GetHealthReady503JSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetHealthReadydefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetHealthReadydefaultJSONResponse) VisitGetHealthReadyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type GetProductsRequestObject struct {
 }
 
@@ -195,17 +359,151 @@ func (response GetProductsIddefaultJSONResponse) VisitGetProductsIdResponse(w ht
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type GetProductsIdStockRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type GetProductsIdStockResponseObject interface {
+	VisitGetProductsIdStockResponse(w http.ResponseWriter) error
+}
+
+type GetProductsIdStock200JSONResponse ProductStock
+
+func (response GetProductsIdStock200JSONResponse) VisitGetProductsIdStockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetProductsIdStockdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response GetProductsIdStockdefaultJSONResponse) VisitGetProductsIdStockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostReservationsRequestObject struct {
+	Body *PostReservationsJSONRequestBody
+}
+
+type PostReservationsResponseObject interface {
+	VisitPostReservationsResponse(w http.ResponseWriter) error
+}
+
+type PostReservations201JSONResponse Reservation
+
+func (response PostReservations201JSONResponse) VisitPostReservationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostReservationsdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostReservationsdefaultJSONResponse) VisitPostReservationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostReservationsReservationIdCommitRequestObject struct {
+	ReservationId ReservationId `json:"reservation_id"`
+}
+
+type PostReservationsReservationIdCommitResponseObject interface {
+	VisitPostReservationsReservationIdCommitResponse(w http.ResponseWriter) error
+}
+
+type PostReservationsReservationIdCommit200JSONResponse Reservation
+
+func (response PostReservationsReservationIdCommit200JSONResponse) VisitPostReservationsReservationIdCommitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostReservationsReservationIdCommitdefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostReservationsReservationIdCommitdefaultJSONResponse) VisitPostReservationsReservationIdCommitResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostReservationsReservationIdReleaseRequestObject struct {
+	ReservationId ReservationId `json:"reservation_id"`
+}
+
+type PostReservationsReservationIdReleaseResponseObject interface {
+	VisitPostReservationsReservationIdReleaseResponse(w http.ResponseWriter) error
+}
+
+type PostReservationsReservationIdRelease200JSONResponse Reservation
+
+func (response PostReservationsReservationIdRelease200JSONResponse) VisitPostReservationsReservationIdReleaseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostReservationsReservationIdReleasedefaultJSONResponse struct {
+	Body       ResponseInfo
+	StatusCode int
+}
+
+func (response PostReservationsReservationIdReleasedefaultJSONResponse) VisitPostReservationsReservationIdReleaseResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// Health check endpoint
 	// (GET /health)
 	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
+	// Liveness check endpoint
+	// (GET /health/live)
+	GetHealthLive(ctx context.Context, request GetHealthLiveRequestObject) (GetHealthLiveResponseObject, error)
+	// Readiness check endpoint
+	// (GET /health/ready)
+	GetHealthReady(ctx context.Context, request GetHealthReadyRequestObject) (GetHealthReadyResponseObject, error)
 	// List products
 	// (GET /products)
 	GetProducts(ctx context.Context, request GetProductsRequestObject) (GetProductsResponseObject, error)
 	// Get product by id
 	// (GET /products/{id})
 	GetProductsId(ctx context.Context, request GetProductsIdRequestObject) (GetProductsIdResponseObject, error)
+	// Get product stock
+	// (GET /products/{id}/stock)
+	GetProductsIdStock(ctx context.Context, request GetProductsIdStockRequestObject) (GetProductsIdStockResponseObject, error)
+	// Reserve stock
+	// (POST /reservations)
+	PostReservations(ctx context.Context, request PostReservationsRequestObject) (PostReservationsResponseObject, error)
+	// Commit reservation
+	// (POST /reservations/{reservation_id}/commit)
+	PostReservationsReservationIdCommit(ctx context.Context, request PostReservationsReservationIdCommitRequestObject) (PostReservationsReservationIdCommitResponseObject, error)
+	// Release reservation
+	// (POST /reservations/{reservation_id}/release)
+	PostReservationsReservationIdRelease(ctx context.Context, request PostReservationsReservationIdReleaseRequestObject) (PostReservationsReservationIdReleaseResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -243,6 +541,52 @@ func (sh *strictHandler) GetHealth(ctx echo.Context) error {
 	return nil
 }
 
+// GetHealthLive operation middleware
+func (sh *strictHandler) GetHealthLive(ctx echo.Context) error {
+	var request GetHealthLiveRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthLive(ctx.Request().Context(), request.(GetHealthLiveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthLive")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthLiveResponseObject); ok {
+		return validResponse.VisitGetHealthLiveResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetHealthReady operation middleware
+func (sh *strictHandler) GetHealthReady(ctx echo.Context) error {
+	var request GetHealthReadyRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetHealthReady(ctx.Request().Context(), request.(GetHealthReadyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetHealthReady")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetHealthReadyResponseObject); ok {
+		return validResponse.VisitGetHealthReadyResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetProducts operation middleware
 func (sh *strictHandler) GetProducts(ctx echo.Context) error {
 	var request GetProductsRequestObject
@@ -291,23 +635,142 @@ func (sh *strictHandler) GetProductsId(ctx echo.Context, id Id) error {
 	return nil
 }
 
+// GetProductsIdStock operation middleware
+func (sh *strictHandler) GetProductsIdStock(ctx echo.Context, id Id) error {
+	var request GetProductsIdStockRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetProductsIdStock(ctx.Request().Context(), request.(GetProductsIdStockRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetProductsIdStock")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetProductsIdStockResponseObject); ok {
+		return validResponse.VisitGetProductsIdStockResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostReservations operation middleware
+func (sh *strictHandler) PostReservations(ctx echo.Context) error {
+	var request PostReservationsRequestObject
+
+	var body PostReservationsJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostReservations(ctx.Request().Context(), request.(PostReservationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostReservations")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostReservationsResponseObject); ok {
+		return validResponse.VisitPostReservationsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostReservationsReservationIdCommit operation middleware
+func (sh *strictHandler) PostReservationsReservationIdCommit(ctx echo.Context, reservationId ReservationId) error {
+	var request PostReservationsReservationIdCommitRequestObject
+
+	request.ReservationId = reservationId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostReservationsReservationIdCommit(ctx.Request().Context(), request.(PostReservationsReservationIdCommitRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostReservationsReservationIdCommit")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostReservationsReservationIdCommitResponseObject); ok {
+		return validResponse.VisitPostReservationsReservationIdCommitResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostReservationsReservationIdRelease operation middleware
+func (sh *strictHandler) PostReservationsReservationIdRelease(ctx echo.Context, reservationId ReservationId) error {
+	var request PostReservationsReservationIdReleaseRequestObject
+
+	request.ReservationId = reservationId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostReservationsReservationIdRelease(ctx.Request().Context(), request.(PostReservationsReservationIdReleaseRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostReservationsReservationIdRelease")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostReservationsReservationIdReleaseResponseObject); ok {
+		return validResponse.VisitPostReservationsReservationIdReleaseResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7xWYYsbNxD9K2JayJe1d+sLLd1vgaZX0/bu8F0oNBxBkca2Eq+kjkYh5vB/L5LW6/N5",
-	"fQ2kLRyHLL+defNm5nkfQLnOO4uWA7QP4CXJDhkpfzI6/dcYFBnPxllowZPTUbEwGiow+UbyGiqwskNo",
-	"Id8T/hUNoYaWKWIFQa2xkykYb31CBSZjV7Db7RI4eGcD5pRXjq8/poNyltFyOkrvN0bJRKD+EBKLh0cR",
-	"vyVcQgvf1IdK6vJtqBd96LldupLsuJg3Fj97VIxaIJEjSJD+4RT7F5QbXu+jZIHIeSQ2hW1gyTGf8LPs",
-	"/CaV9uYGqqdlVsCmw8Cy88fgWTN7OWl+mMx+vGuaNv/9CRUsHXWSoQUtGSfp2dOYu+HGvf+AilOW353F",
-	"7SlNFYnQqu075TSOdCE1z7oMHTIbyxezQ1ZjGVdICRut4RPs9y9HsGMUb8r8jJCUjCtH/SfD2IVRqv2F",
-	"JJJbeNrREXwZ4pGKu3EpvFEc6cx3ZBS+i0H/0+CVRowKcDSTpyr0HRq0jecb0WEIcoXPqPRl23GXsGUT",
-	"92v7tgQ45KgKs/tnCrrrU6KNXYrwerG4XkAF86ufr6GCP14truZXl49CPDYB06txvJ+L17d3y7gRr27m",
-	"InhUZtn7gFg6ErxG0Y+TUJLlxq1EQPpkFFbC8IsgYkAt2AkZ2U1WaJEko1Abg5bF7U+/vghCWp0fQpoE",
-	"o1HkMtO+cl7QM/Ghgk9IobBspt9Nm6SE82ilN9DCxbSZXkCVvTH3tV5nK0nHFfJIpciRbMg1Fago5iLc",
-	"Ml/2iaeQ01BWYa6hhUvkYlPwxEpnTfOvGekTIxyx0tvCT5jQ8++Xcynjhs+FH/jWxfez/cauk7SFtndf",
-	"odaoPgq02jtjOWPq/lcoPNLzRJWbPeYrdRms6DmB9rZ2YlAjSkWlMIQ01zQI+pVS/WYCi0GUI4nqB6N3",
-	"X6LTXOeBPbwAvB1ncoDURsPu/j8cu0HV/0fFSxxEFO+36RUnJy72UAQ5JnHGHZJfQQWRNtDCmtmHtt73",
-	"o4f2yBp297u/AwAA//8OU3jphQkAAA==",
+	"H4sIAAAAAAAC/9xYf2/bOBL9KgTvgPyj2G7S6+H8X6/ttcZ108BJscAWQcCQY4uNRKrkKIkR+LsvSMr6",
+	"ScdOk26LBYJAlsiZN2/eDEe6p1znhVag0NLpPS2YYTkgGP9LCvdfgOVGFii1olNaGC1KjkQKmlDp7zBM",
+	"aUIVy4FOqb9v4FspDQg6RVNCQi1PIWfOWM7uPoJaYkqnr14mNJdq8/NFQnFVOBMWjVRLul47QxbMDXO+",
+	"L2NoWs+3IurZeDZ0AV6hlQXP1onGT9fugmuFoNBdsqLIJPe+x1+tg3zfcvdPAws6pf8YN0kYh6d2PK9M",
+	"z9RCB2fdyD8ruCuAIwgCxmjj6ao2O9tvDDCEeRP7HL6VYD2qwugCDMqAWyLk3YsduDYWZwg5XXuaZmFr",
+	"wxIzhq1oNIOPITmhiNmlBa6V8NAW2uQMnc4UHh/RxFmTeZnT6fGrycRbCz8bW1IhLMFUetqk/stQFyH+",
+	"i3qjvvoKHB2It1CAEqD46k0K/HrIYUjB9H6IP2Po9l0GZuGO5UUGdHqcdEJ59ZIO8W4U3NpGBUN2xSzQ",
+	"CFUWGZZdN1Rf03hdNTx4J/XuDuIYFx+AZZhu5DmkgjuG9tdTn9r1UEKxuD6fxhhAmYNFlhfdxUeTo5eH",
+	"k38fHv3nfDKZ+r8/aCsBgiEcur1RrgYM/KYVrCKBl8Z44rgWENWCYkrHVTxMfakkDtZGZRKDeBq6dAQk",
+	"Q1hq0y/9IZO9HHSaT2S9FNHbGwEPHhSSY2m2PDOSw2VpxS7thEQ8RMAZ6li5shsmM3aVwZ7JkOrSbixV",
+	"T6+0zoCpgNf7utzCQegzIPby1avNlumkhbpltAUuVq2tdj2kAe4KacBeMuxge6AckqefFrsPiAca256+",
+	"zsKGPTt+q/e1GNnBpg9nwGhXCo876b6VTKHEVfyY2/9c62imNrojnLOmxyrn5gtNIXMGuM5zieiVZiAD",
+	"ZkHURAl6EQmkM7oMO1DVHesQy+11l4O1bAkPdKj9hqhzt7bPkzfQ+EgCsi08NWZaFL2bzz/NaUJnJ//7",
+	"RBP6++v5yezkfYSTte8hgY3uGDd/d3a+KDPy+nRGbAFcLqpxkSy0IZgCqToZ4QxZppfEpUxySIjEA0tK",
+	"C4KgJqxEfbgEBYYhEJ5JUEjO3v7/wBKmhN8E5tBKAcSH6c5K9IfjFvs0oTdgbEA5Gb0YTRwTugDFCkmn",
+	"9Hg0GR3TxM/aPq/j1A8G7nIJGIkUsDTK+pjCUhLKjuiFv1k5HlHvxoQqE3RK3wOGoYP2Ju6jyeTZ5u3e",
+	"WBOZuM8CPiJthb86GBeszHCb+RrvOLweOLO2zHNmVnRazVLEj0wElCi0VOjXVGyOM3kDOynV1+Q2lRl4",
+	"GgujOVjrYLqzwonDZ5+Y8AJgnXKI0GDVAVau3T6xGcQk2Ady8NEB+lXywDw9T82CC0k5yh7IgwEmVlsT",
+	"4UdXO6Cxp2xPvIFCG7REaXfNxIrIBWFqVa3NyYLJzBJtiOxsduHatESUakmEvlUP5Gjuwf4qSQpRoiYG",
+	"OMgbIGjYYiG5y9u/Jsc/B1RDfxzYkwTl+JdbFVWdz7alpkEeTzdrnpjEvYa0ytlwOIswWHIO1roTy9RE",
+	"P7n8LJKalA5F43sp1vvwNBM06Xy8+hJH0iwZS0HXFz+wRmpW/xoW30NNIrlauY9hQybH9UvMzhPar9z0",
+	"r8pI4n9UE6Vrb25EdM7c7dZsbQkz4A6X+oUl2qua3IW3tF80gQHcT8iirRwndNwm1xkutI3k74PORC97",
+	"cANmRVwXIKVCmfVT5VphPeEnZDPg++MHSfU2FPIefohBoutNI3KiMXXHk6yk0TnZ6hKvZ4+U3QABpctl",
+	"GhAnbozhqT/ZCddqkUmOQ+2caovzNiNhrgeL/9Vi9Wz53/oFdb1e9z8irwc6fPGcX4I3CPaUYTJIM/ex",
+	"iGc42cIsuUWb4/vuK/Z6HMS1XbPn7BpsCy4Ir1ZLdIn1+BS0oRX3E+7qwAApmBQj8iZI12mONTruRO7E",
+	"RlQQZkKYqnWsFXTU79Qq0VbunbAtyixrmlhCYLQckdsUlIeljQBDbpn1YIiRyxTJFSy0gaZ4+iDrCmth",
+	"TFy59ZDdptpCheUWHBidieSxldG6nomA49FttpvRH9tyv1fq0dQ/WeyBsK7J3YqvMrxd8qclRhV/xfg1",
+	"kaot+a7gCrbKQWF4QxiRuXfUk1VXSdsLwbQ2N+xVNfHdEptXsf8dNRar3Gfop95oT2Nuif9gE8jrySf+",
+	"vcZ9QaIJLU1GpzRFLOx0vJn+qqXVyjFdX6z/HACSdS0V+R0AAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -7,6 +7,14 @@ import (
 	"time"
 )
 
+// Defines values for ReservationStatus.
+const (
+	Committed ReservationStatus = "committed"
+	Expired   ReservationStatus = "expired"
+	Held      ReservationStatus = "held"
+	Released  ReservationStatus = "released"
+)
+
 // Defines values for ResponseType.
 const (
 	ERROR   ResponseType = "ERROR"
@@ -14,10 +22,26 @@ const (
 	WARNING ResponseType = "WARNING"
 )
 
+// CreateReservationRequest defines model for CreateReservationRequest.
+type CreateReservationRequest struct {
+	Items         []ReservationItem `json:"items"`
+	ReservationId string            `json:"reservation_id"`
+	TtlSeconds    *int32            `json:"ttl_seconds,omitempty"`
+}
+
+// DependencyCheck defines model for DependencyCheck.
+type DependencyCheck struct {
+	Error     *string `json:"error,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+}
+
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
-	Status    *string    `json:"status,omitempty"`
-	Timestamp *time.Time `json:"timestamp,omitempty"`
+	Checks    *[]DependencyCheck `json:"checks,omitempty"`
+	Status    *string            `json:"status,omitempty"`
+	Timestamp *time.Time         `json:"timestamp,omitempty"`
 }
 
 // Money defines model for Money.
@@ -37,6 +61,31 @@ type Product struct {
 	PriceUsd    *Money    `json:"price_usd,omitempty"`
 }
 
+// ProductStock defines model for ProductStock.
+type ProductStock struct {
+	Available int32  `json:"available"`
+	InStock   bool   `json:"in_stock"`
+	ProductId string `json:"product_id"`
+	Reserved  int32  `json:"reserved"`
+}
+
+// Reservation defines model for Reservation.
+type Reservation struct {
+	ExpiresAt     time.Time         `json:"expires_at"`
+	Items         []ReservationItem `json:"items"`
+	ReservationId string            `json:"reservation_id"`
+	Status        ReservationStatus `json:"status"`
+}
+
+// ReservationItem defines model for ReservationItem.
+type ReservationItem struct {
+	ProductId string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// ReservationStatus defines model for ReservationStatus.
+type ReservationStatus string
+
 // ResponseInfo defines model for ResponseInfo.
 type ResponseInfo struct {
 	Code    uint32       `json:"code"`
@@ -50,5 +99,11 @@ type ResponseType string
 // Id defines model for id.
 type Id = string
 
+// ReservationId defines model for reservation_id.
+type ReservationId = string
+
 // NotOk defines model for NotOk.
 type NotOk = ResponseInfo
+
+// PostReservationsJSONRequestBody defines body for PostReservations for application/json ContentType.
+type PostReservationsJSONRequestBody = CreateReservationRequest
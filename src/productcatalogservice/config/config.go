@@ -0,0 +1,232 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/configloader"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+)
+
+const (
+	PostgresInventoryStore = "postgres"
+	MemoryInventoryStore   = "memory"
+
+	defaultHost        = "0.0.0.0"
+	defaultPort uint16 = 8070
+
+	defaultShutdownTimeout = 15 * time.Second
+	// the readiness probes of the manifests run every 10s
+	defaultDrainDelay     = 10 * time.Second
+	defaultReservationTTL = 10 * time.Minute
+
+	defaultDatabasePort = "5432"
+)
+
+// Config is the configuration of the product catalog service, run it with --help to list the settings
+type Config struct {
+	Host string
+	Port uint16
+	// ShutdownTimeout is the deadline to drain the in-flight requests when the service stops
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long the service keeps serving after it stops reporting ready, before it shuts down
+	DrainDelay time.Duration
+	// ReservationTTL is how long the stock is held for the reservations that don't set their own TTL
+	ReservationTTL time.Duration
+	// InventoryStore selects the inventory.Inventory implementation, PostgresInventoryStore or MemoryInventoryStore
+	InventoryStore   string
+	MigrateOnStartup bool
+	Database         DatabaseConfig
+	Tracing          tracing.Config
+}
+
+// DatabaseConfig is the Postgres connection, the URI takes precedence over the other fields
+type DatabaseConfig struct {
+	URI      string
+	Host     string
+	Port     string
+	Username string
+	Password string
+	Name     string
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Host:             defaultHost,
+		Port:             defaultPort,
+		ShutdownTimeout:  defaultShutdownTimeout,
+		DrainDelay:       defaultDrainDelay,
+		ReservationTTL:   defaultReservationTTL,
+		InventoryStore:   PostgresInventoryStore,
+		MigrateOnStartup: true,
+		Database: DatabaseConfig{
+			Port: defaultDatabasePort,
+		},
+		Tracing: tracing.DefaultConfig(),
+	}
+}
+
+// Address is the address the REST API server listens on
+func (cfg *Config) Address() string {
+	return net.JoinHostPort(cfg.Host, strconv.Itoa(int(cfg.Port)))
+}
+
+var settings = append([]setting{
+	{
+		Key:         "host",
+		EnvVar:      "HOST",
+		Description: "IP the REST API server listens on",
+		Get:         func(cfg *Config) string { return cfg.Host },
+		Set:         func(cfg *Config, value string) error { cfg.Host = value; return nil },
+	},
+	{
+		Key:         "port",
+		EnvVar:      "PORT",
+		Description: "port the REST API server listens on",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.Port)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.Port = port
+			return err
+		},
+	},
+	{
+		Key:         "shutdown-timeout",
+		EnvVar:      "SHUTDOWN_TIMEOUT",
+		Description: "deadline to drain the in-flight requests when the service stops",
+		Get:         func(cfg *Config) string { return cfg.ShutdownTimeout.String() },
+		Set: func(cfg *Config, value string) error {
+			timeout, err := configloader.ParseDuration(value)
+			cfg.ShutdownTimeout = timeout
+			return err
+		},
+	},
+	{
+		Key:         "drain-delay",
+		EnvVar:      "DRAIN_DELAY",
+		Description: "time the service keeps serving after it stops reporting ready on shutdown, at least the readiness probe period",
+		Get:         func(cfg *Config) string { return cfg.DrainDelay.String() },
+		Set: func(cfg *Config, value string) error {
+			delay, err := configloader.ParseDuration(value)
+			cfg.DrainDelay = delay
+			return err
+		},
+	},
+	{
+		Key:         "reservation-ttl",
+		EnvVar:      "RESERVATION_TTL",
+		Description: "how long the stock is held for the reservations that don't set their own TTL",
+		Get:         func(cfg *Config) string { return cfg.ReservationTTL.String() },
+		Set: func(cfg *Config, value string) error {
+			ttl, err := configloader.ParseDuration(value)
+			cfg.ReservationTTL = ttl
+			return err
+		},
+	},
+	{
+		Key:         "inventory-store",
+		EnvVar:      "INVENTORY_STORE",
+		Description: fmt.Sprintf("where the stock and its reservations are stored, '%s' or '%s'", PostgresInventoryStore, MemoryInventoryStore),
+		Get:         func(cfg *Config) string { return cfg.InventoryStore },
+		Set:         func(cfg *Config, value string) error { cfg.InventoryStore = value; return nil },
+	},
+	{
+		Key:         "db-migrate-on-startup",
+		EnvVar:      "DB_MIGRATE_ON_STARTUP",
+		Description: "apply the pending database migrations when the service starts",
+		Get:         func(cfg *Config) string { return strconv.FormatBool(cfg.MigrateOnStartup) },
+		Set: func(cfg *Config, value string) error {
+			migrateOnStartup, err := configloader.ParseBool(value)
+			cfg.MigrateOnStartup = migrateOnStartup
+			return err
+		},
+	},
+	{
+		Key:         "db-uri",
+		EnvVar:      "POSTGRES",
+		Description: "Postgres connection URI, it takes precedence over the other database settings",
+		Secret:      true,
+		Get:         func(cfg *Config) string { return cfg.Database.URI },
+		Set:         func(cfg *Config, value string) error { cfg.Database.URI = value; return nil },
+	},
+	{
+		Key:         "db-host",
+		EnvVar:      "DB_HOST",
+		Description: "Postgres host",
+		Get:         func(cfg *Config) string { return cfg.Database.Host },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Host = value; return nil },
+	},
+	{
+		Key:         "db-port",
+		EnvVar:      "DB_PORT",
+		Description: "Postgres port",
+		Get:         func(cfg *Config) string { return cfg.Database.Port },
+		Set: func(cfg *Config, value string) error {
+			if _, err := configloader.ParsePort(value); err != nil {
+				return err
+			}
+			cfg.Database.Port = value
+			return nil
+		},
+	},
+	{
+		Key:         "db-username",
+		EnvVar:      "DB_USERNAME",
+		Description: "Postgres user",
+		Get:         func(cfg *Config) string { return cfg.Database.Username },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Username = value; return nil },
+	},
+	{
+		Key:         "db-password",
+		EnvVar:      "DB_PASSWORD",
+		Description: "Postgres password",
+		Secret:      true,
+		Get:         func(cfg *Config) string { return cfg.Database.Password },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Password = value; return nil },
+	},
+	{
+		Key:         "db-name",
+		EnvVar:      "DB_NAME",
+		Description: "Postgres database",
+		Get:         func(cfg *Config) string { return cfg.Database.Name },
+		Set:         func(cfg *Config, value string) error { cfg.Database.Name = value; return nil },
+	},
+}, tracing.Settings(func(cfg *Config) *tracing.Config { return &cfg.Tracing })...)
+
+func (cfg *Config) validate() []string {
+	var violations []string
+	if net.ParseIP(cfg.Host) == nil {
+		violations = append(violations, fmt.Sprintf("'host' must be an IP address, got '%s'", cfg.Host))
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		violations = append(violations, fmt.Sprintf("'shutdown-timeout' must be positive, got '%s'", cfg.ShutdownTimeout))
+	}
+	if cfg.DrainDelay < 0 {
+		violations = append(violations, fmt.Sprintf("'drain-delay' can't be negative, got '%s'", cfg.DrainDelay))
+	}
+	if cfg.ReservationTTL <= 0 {
+		violations = append(violations, fmt.Sprintf("'reservation-ttl' must be positive, got '%s'", cfg.ReservationTTL))
+	}
+	switch cfg.InventoryStore {
+	case MemoryInventoryStore:
+	case PostgresInventoryStore:
+		if cfg.Database.URI == "" {
+			requiredSettings := []struct{ key, value string }{
+				{"db-host", cfg.Database.Host},
+				{"db-username", cfg.Database.Username},
+				{"db-name", cfg.Database.Name},
+			}
+			for _, required := range requiredSettings {
+				if required.value == "" {
+					violations = append(violations, fmt.Sprintf("'%s' is required when 'db-uri' isn't set", required.key))
+				}
+			}
+		}
+	default:
+		violations = append(violations, fmt.Sprintf("'inventory-store' must be '%s' or '%s', got '%s'", PostgresInventoryStore, MemoryInventoryStore, cfg.InventoryStore))
+	}
+	violations = append(violations, cfg.Tracing.Validate()...)
+	return violations
+}
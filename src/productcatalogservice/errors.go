@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kurtosis-tech/new-obd/src/productcatalogservice/inventory"
+	"github.com/pkg/errors"
+)
+
+// statusCodeForError returns the status code of the errors returned by the handlers, based on the inventory error kinds
+func statusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, inventory.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, inventory.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, inventory.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, inventory.ErrUnavailable), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
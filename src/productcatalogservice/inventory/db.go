@@ -0,0 +1,461 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+	productcatalogservice_rest_types "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Stock is a row of the stock table, the schema is defined by the SQL files in the migrations directory
+type Stock struct {
+	ProductID string `gorm:"primaryKey"`
+	// Quantity is the quantity of the product in the warehouse, the held reservations are still part of it
+	Quantity int32
+}
+
+func (Stock) TableName() string {
+	return "inventory.stock"
+}
+
+// Reservation is a row of the reservations table
+type Reservation struct {
+	ReservationID string `gorm:"primaryKey"`
+	Status        string
+	ExpiresAt     time.Time
+	// FinishedAt is when the reservation stopped holding the stock, nil while it's held. A held reservation that
+	// reached ExpiresAt is expired, whether or not its row says so.
+	FinishedAt *time.Time
+}
+
+func (Reservation) TableName() string {
+	return "inventory.reservations"
+}
+
+// ReservationItem is a row of the reservation_items table, a reservation has one per product
+type ReservationItem struct {
+	ReservationID string `gorm:"primaryKey"`
+	ProductID     string `gorm:"primaryKey"`
+	Quantity      int32
+}
+
+func (ReservationItem) TableName() string {
+	return "inventory.reservation_items"
+}
+
+// Db is an Inventory implementation that keeps the stock and the reservations in Postgres, so every replica of the
+// service shares them. The operations that hold or take the stock lock its rows, in order of product ID, until their
+// transaction ends, so two replicas can't sell the same items.
+type Db struct {
+	db *gorm.DB
+	// Migrator applies the migrations embedded in the binary
+	*database.Migrator
+
+	now func() time.Time
+}
+
+func NewDb(
+	uri string,
+	host string,
+	username string,
+	password string,
+	name string,
+	port string,
+) (*Db, error) {
+	db, err := database.Connect(database.DSN(uri, host, username, password, name, port))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := database.RegisterQueryMetricsCallbacks(db); err != nil {
+		return nil, err
+	}
+	if err := database.RegisterQueryTracingCallbacks(db); err != nil {
+		return nil, err
+	}
+
+	return &Db{
+		db:       db,
+		Migrator: database.NewMigrator(db, migrationsConfig),
+		now:      time.Now,
+	}, nil
+}
+
+// SetClock replaces the clock the reservations expire with, for the tests. It isn't safe to call it while the
+// inventory is in use.
+func (db *Db) SetClock(now func() time.Time) {
+	db.now = now
+}
+
+func (db *Db) Close() error {
+	return database.Close(db.db)
+}
+
+func (db *Db) Ping(ctx context.Context) error {
+	sqlDb, err := db.db.DB()
+	if err != nil {
+		return errors.Wrap(err, "An error occurred getting the database connection")
+	}
+
+	if err = sqlDb.PingContext(ctx); err != nil {
+		return errors.Wrap(classifyDbError(err), "An error occurred pinging the database")
+	}
+
+	return nil
+}
+
+// SeedStock adds the products that aren't in the stock yet, with their quantity. The stock of the products that are
+// already there isn't changed, so restarting the service doesn't undo the orders placed in the meantime.
+func (db *Db) SeedStock(ctx context.Context, stock map[string]int32) error {
+	if len(stock) == 0 {
+		return nil
+	}
+
+	rows := make([]Stock, 0, len(stock))
+	for productID, quantity := range stock {
+		rows = append(rows, Stock{ProductID: productID, Quantity: quantity})
+	}
+	sort.Slice(rows, func(a, b int) bool {
+		return rows[a].ProductID < rows[b].ProductID
+	})
+
+	result := db.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&rows)
+	if result.Error != nil {
+		return errors.Wrap(classifyDbError(result.Error), fmt.Sprintf("An internal error has occurred seeding the stock of %d products", len(rows)))
+	}
+	logrus.Infof("Seeded the stock of %d new products, the other %d were already in stock", result.RowsAffected, int64(len(rows))-result.RowsAffected)
+	return nil
+}
+
+func (db *Db) Stock(ctx context.Context, productID string) (*productcatalogservice_rest_types.ProductStock, error) {
+	var stock *productcatalogservice_rest_types.ProductStock
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []Stock
+		if err := tx.Where("product_id = ?", productID).Limit(1).Find(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return newInventoryError(ErrNotFound, "there's no product '%s'", productID)
+		}
+
+		reserved, err := reservedQuantities(tx, []string{productID}, db.now())
+		if err != nil {
+			return err
+		}
+		stock = newProductStock(productID, rows[0].Quantity, reserved[productID])
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(classifyDbError(err), fmt.Sprintf("An internal error has occurred getting the stock of product '%s'", productID))
+	}
+	return stock, nil
+}
+
+func (db *Db) Reserve(ctx context.Context, request productcatalogservice_rest_types.CreateReservationRequest, ttl time.Duration) (*productcatalogservice_rest_types.Reservation, error) {
+	items, err := validateReservation(request, ttl)
+	if err != nil {
+		return nil, err
+	}
+	db.forgetFinishedReservations(ctx)
+
+	var reservation *productcatalogservice_rest_types.Reservation
+	err = db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existingRow, err := findReservation(tx, request.ReservationId)
+		if err != nil {
+			return err
+		}
+		if existingRow != nil {
+			if !existingRow.forgotten(db.now()) {
+				return newInventoryError(ErrConflict, "there's already a reservation '%s'", request.ReservationId)
+			}
+			if err := tx.Where("reservation_id = ?", request.ReservationId).Delete(&Reservation{}).Error; err != nil {
+				return err
+			}
+		}
+
+		productIDs := itemProductIDs(items)
+		quantities, err := lockStock(tx, productIDs)
+		if err != nil {
+			return err
+		}
+		// read the clock once the stock is locked, so the reservations expire in the order the operations run
+		now := db.now()
+		reserved, err := reservedQuantities(tx, productIDs, now)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			quantity, found := quantities[item.ProductId]
+			if !found {
+				return newInventoryError(ErrNotFound, "there's no product '%s'", item.ProductId)
+			}
+			if available := quantity - reserved[item.ProductId]; item.Quantity > available {
+				return newInventoryError(ErrConflict, "product '%s' is out of stock, %d requested but %d available", item.ProductId, item.Quantity, available)
+			}
+		}
+
+		row := &Reservation{
+			ReservationID: request.ReservationId,
+			Status:        string(productcatalogservice_rest_types.Held),
+			// the precision of the database, so the expiration doesn't change once the reservation is stored
+			ExpiresAt: now.Add(ttl).Truncate(time.Microsecond),
+		}
+		itemRows := newReservationItemRows(request.ReservationId, items)
+		if err := tx.Create(row).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&itemRows).Error; err != nil {
+			return err
+		}
+		reservation = row.toRest(itemRows, now)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(classifyDbError(err), fmt.Sprintf("An internal error has occurred creating reservation '%s'", request.ReservationId))
+	}
+	return reservation, nil
+}
+
+func (db *Db) Commit(ctx context.Context, reservationID string) (*productcatalogservice_rest_types.Reservation, error) {
+	var reservation *productcatalogservice_rest_types.Reservation
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row, itemRows, err := db.getReservationForUpdate(tx, reservationID)
+		if err != nil {
+			return err
+		}
+
+		productIDs := make([]string, 0, len(itemRows))
+		for _, itemRow := range itemRows {
+			productIDs = append(productIDs, itemRow.ProductID)
+		}
+		quantities, err := lockStock(tx, productIDs)
+		if err != nil {
+			return err
+		}
+		now := db.now()
+
+		switch row.status(now) {
+		case productcatalogservice_rest_types.Held:
+		case productcatalogservice_rest_types.Expired:
+			reserved, err := reservedQuantities(tx, productIDs, now)
+			if err != nil {
+				return err
+			}
+			for _, itemRow := range itemRows {
+				if available := quantities[itemRow.ProductID] - reserved[itemRow.ProductID]; itemRow.Quantity > available {
+					return newInventoryError(ErrConflict, "reservation '%s' expired and product '%s' is out of stock, %d reserved but %d available", reservationID, itemRow.ProductID, itemRow.Quantity, available)
+				}
+			}
+			logrus.Warnf("Reservation '%s' is committed after it expired, its items are still in stock", reservationID)
+		case productcatalogservice_rest_types.Committed:
+			reservation = row.toRest(itemRows, now)
+			return nil
+		default:
+			return newInventoryError(ErrConflict, "reservation '%s' can't be committed, it's %s", reservationID, row.status(now))
+		}
+
+		for _, itemRow := range itemRows {
+			if err := tx.Model(&Stock{}).Where("product_id = ?", itemRow.ProductID).Update("quantity", gorm.Expr("quantity - ?", itemRow.Quantity)).Error; err != nil {
+				return err
+			}
+		}
+		if err := finishReservation(tx, row, productcatalogservice_rest_types.Committed, now); err != nil {
+			return err
+		}
+		reservation = row.toRest(itemRows, now)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(classifyDbError(err), fmt.Sprintf("An internal error has occurred committing reservation '%s'", reservationID))
+	}
+	return reservation, nil
+}
+
+func (db *Db) Release(ctx context.Context, reservationID string) (*productcatalogservice_rest_types.Reservation, error) {
+	var reservation *productcatalogservice_rest_types.Reservation
+	err := db.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row, itemRows, err := db.getReservationForUpdate(tx, reservationID)
+		if err != nil {
+			return err
+		}
+		now := db.now()
+
+		switch row.status(now) {
+		case productcatalogservice_rest_types.Held:
+			if err := finishReservation(tx, row, productcatalogservice_rest_types.Released, now); err != nil {
+				return err
+			}
+		case productcatalogservice_rest_types.Released, productcatalogservice_rest_types.Expired:
+		default:
+			return newInventoryError(ErrConflict, "reservation '%s' can't be released, it's %s", reservationID, row.status(now))
+		}
+		reservation = row.toRest(itemRows, now)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(classifyDbError(err), fmt.Sprintf("An internal error has occurred releasing reservation '%s'", reservationID))
+	}
+	return reservation, nil
+}
+
+// getReservationForUpdate reads the reservation and its items in the transaction, the reservation row is locked so two
+// concurrent commits or releases can't both finish it
+func (db *Db) getReservationForUpdate(tx *gorm.DB, reservationID string) (*Reservation, []ReservationItem, error) {
+	row, err := findReservation(tx, reservationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if row == nil || row.forgotten(db.now()) {
+		return nil, nil, newInventoryError(ErrNotFound, "there's no reservation '%s'", reservationID)
+	}
+
+	var itemRows []ReservationItem
+	if err := tx.Where("reservation_id = ?", reservationID).Order("product_id").Find(&itemRows).Error; err != nil {
+		return nil, nil, err
+	}
+	return row, itemRows, nil
+}
+
+// forgetFinishedReservations deletes the reservations that finished longer than FinishedReservationRetention ago.
+// They're ignored by the other operations anyway, so a failure is only logged.
+func (db *Db) forgetFinishedReservations(ctx context.Context) {
+	cutoff := db.now().Add(-FinishedReservationRetention)
+	result := db.db.WithContext(ctx).
+		Where("(status = ? AND expires_at < ?) OR finished_at < ?", string(productcatalogservice_rest_types.Held), cutoff, cutoff).
+		Delete(&Reservation{})
+	if result.Error != nil {
+		logrus.Warnf("An error occurred deleting the reservations finished before '%s'. Error: %s", cutoff, result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		logrus.Debugf("Deleted %d reservations finished before '%s'", result.RowsAffected, cutoff)
+	}
+}
+
+// findReservation returns the row of the reservation, or nil if there's none, and locks it until the transaction ends
+func findReservation(tx *gorm.DB, reservationID string) (*Reservation, error) {
+	var rows []Reservation
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("reservation_id = ?", reservationID).Limit(1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+// lockStock returns the quantity of the products found in the stock, and locks their rows until the transaction ends
+func lockStock(tx *gorm.DB, productIDs []string) (map[string]int32, error) {
+	var rows []Stock
+	// in order of product ID, so two transactions locking the same products can't deadlock
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("product_id IN ?", productIDs).Order("product_id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	quantities := make(map[string]int32, len(rows))
+	for _, row := range rows {
+		quantities[row.ProductID] = row.Quantity
+	}
+	return quantities, nil
+}
+
+// reservedQuantities returns the quantity of the products held by the reservations that haven't expired at now
+func reservedQuantities(tx *gorm.DB, productIDs []string, now time.Time) (map[string]int32, error) {
+	var rows []struct {
+		ProductID string
+		Reserved  int64
+	}
+	err := tx.Table(ReservationItem{}.TableName()+" AS items").
+		Select("items.product_id, SUM(items.quantity) AS reserved").
+		Joins("JOIN "+Reservation{}.TableName()+" AS reservations ON reservations.reservation_id = items.reservation_id").
+		Where("reservations.status = ? AND reservations.expires_at > ? AND items.product_id IN ?", string(productcatalogservice_rest_types.Held), now, productIDs).
+		Group("items.product_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	reserved := make(map[string]int32, len(rows))
+	for _, row := range rows {
+		reserved[row.ProductID] = int32(row.Reserved)
+	}
+	return reserved, nil
+}
+
+func finishReservation(tx *gorm.DB, row *Reservation, status productcatalogservice_rest_types.ReservationStatus, now time.Time) error {
+	finishedAt := now.Truncate(time.Microsecond)
+	row.Status = string(status)
+	row.FinishedAt = &finishedAt
+	return tx.Model(&Reservation{}).Where("reservation_id = ?", row.ReservationID).Updates(map[string]interface{}{
+		"status":      row.Status,
+		"finished_at": finishedAt,
+	}).Error
+}
+
+// status is the status of the reservation at now, a held reservation expires without its row being updated
+func (r *Reservation) status(now time.Time) productcatalogservice_rest_types.ReservationStatus {
+	if r.Status == string(productcatalogservice_rest_types.Held) && !now.Before(r.ExpiresAt) {
+		return productcatalogservice_rest_types.Expired
+	}
+	return productcatalogservice_rest_types.ReservationStatus(r.Status)
+}
+
+// forgotten reports whether the reservation finished longer than FinishedReservationRetention ago, it's then as if
+// it didn't exist
+func (r *Reservation) forgotten(now time.Time) bool {
+	finishedAt := r.ExpiresAt
+	switch {
+	case r.status(now) == productcatalogservice_rest_types.Held:
+		return false
+	case r.FinishedAt != nil:
+		finishedAt = *r.FinishedAt
+	}
+	return now.Sub(finishedAt) > FinishedReservationRetention
+}
+
+func (r *Reservation) toRest(itemRows []ReservationItem, now time.Time) *productcatalogservice_rest_types.Reservation {
+	items := make([]productcatalogservice_rest_types.ReservationItem, 0, len(itemRows))
+	for _, itemRow := range itemRows {
+		items = append(items, productcatalogservice_rest_types.ReservationItem{ProductId: itemRow.ProductID, Quantity: itemRow.Quantity})
+	}
+	return &productcatalogservice_rest_types.Reservation{
+		ReservationId: r.ReservationID,
+		Items:         items,
+		Status:        r.status(now),
+		ExpiresAt:     r.ExpiresAt,
+	}
+}
+
+func newReservationItemRows(reservationID string, items []productcatalogservice_rest_types.ReservationItem) []ReservationItem {
+	rows := make([]ReservationItem, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, ReservationItem{ReservationID: reservationID, ProductID: item.ProductId, Quantity: item.Quantity})
+	}
+	return rows
+}
+
+func itemProductIDs(items []productcatalogservice_rest_types.ReservationItem) []string {
+	productIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductId)
+	}
+	return productIDs
+}
+
+// classifyDbError tags the database errors with the matching error kind, the rest are returned untouched
+func classifyDbError(err error) error {
+	switch database.ClassifyError(err) {
+	case database.ConflictError:
+		return tagInventoryError(ErrConflict, err)
+	case database.InvalidArgumentError:
+		return tagInventoryError(ErrInvalidArgument, err)
+	case database.UnavailableError:
+		return tagInventoryError(ErrUnavailable, err)
+	}
+	return err
+}
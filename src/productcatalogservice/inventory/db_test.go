@@ -0,0 +1,63 @@
+package inventory_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	productcatalogservice_rest_types "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/productcatalogservice/inventory"
+	"github.com/kurtosis-tech/new-obd/src/productcatalogservice/inventory/inventorytest"
+	"github.com/stretchr/testify/require"
+)
+
+// the Postgres tests only run when this environment variable contains the DSN of a database they can write to
+const testPostgresDSNEnvVarKey = "PRODUCTCATALOGSERVICE_TEST_POSTGRES_DSN"
+
+func newTestDb(t *testing.T) *inventory.Db {
+	dsn := os.Getenv(testPostgresDSNEnvVarKey)
+	if dsn == "" {
+		t.Skipf("Skipping Postgres inventory test because the '%s' environment variable is not set", testPostgresDSNEnvVarKey)
+	}
+
+	db, err := inventory.NewDb(dsn, "", "", "", "", "")
+	require.NoError(t, err)
+	require.NoError(t, db.MigrateUp(context.Background()))
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	return db
+}
+
+func TestDbConformance(t *testing.T) {
+	inventorytest.RunInventoryConformance(t, func(t *testing.T, stock map[string]int32) (inventory.Inventory, func(time.Duration)) {
+		db := newTestDb(t)
+		require.NoError(t, db.SeedStock(context.Background(), stock))
+		now := time.Now()
+		db.SetClock(func() time.Time { return now })
+		return db, func(d time.Duration) { now = now.Add(d) }
+	})
+}
+
+func TestDbSeedStockKeepsTheStockOfTheKnownProducts(t *testing.T) {
+	db := newTestDb(t)
+	ctx := context.Background()
+	productID := "OLJCESPC7Z-" + time.Now().Format(time.RFC3339Nano)
+
+	require.NoError(t, db.SeedStock(ctx, map[string]int32{productID: 5}))
+	_, err := db.Reserve(ctx, productcatalogservice_rest_types.CreateReservationRequest{
+		ReservationId: "seed-" + productID,
+		Items:         []productcatalogservice_rest_types.ReservationItem{{ProductId: productID, Quantity: 2}},
+	}, time.Minute)
+	require.NoError(t, err)
+	_, err = db.Commit(ctx, "seed-"+productID)
+	require.NoError(t, err)
+
+	// the service restarts with the stock of the catalog file
+	require.NoError(t, db.SeedStock(ctx, map[string]int32{productID: 5}))
+	stock, err := db.Stock(ctx, productID)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), stock.Available)
+}
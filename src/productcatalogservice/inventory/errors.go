@@ -0,0 +1,38 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of errors returned by the Inventory, check them with errors.Is
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrConflict is returned when there isn't enough stock or the reservation isn't in a status that allows the operation
+	ErrConflict = errors.New("conflict")
+	// ErrUnavailable is returned when the database can't be reached or can't serve the request right now
+	ErrUnavailable = errors.New("unavailable")
+)
+
+// inventoryError tags the cause with one of the error kinds, so callers can classify it without knowing the details
+type inventoryError struct {
+	kind  error
+	cause error
+}
+
+func newInventoryError(kind error, format string, args ...interface{}) error {
+	return tagInventoryError(kind, fmt.Errorf(format, args...))
+}
+
+func tagInventoryError(kind error, cause error) error {
+	return &inventoryError{kind: kind, cause: cause}
+}
+
+func (e *inventoryError) Error() string {
+	return fmt.Sprintf("%s: %s", e.kind.Error(), e.cause.Error())
+}
+
+func (e *inventoryError) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
@@ -0,0 +1,124 @@
+// Package inventory keeps the stock of the products and the reservations that hold it while the orders are placed:
+// a reservation is held on checkout, committed once the order is paid, and released if the order fails or it expires
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	productcatalogservice_rest_types "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/types"
+	"github.com/pkg/errors"
+)
+
+// FinishedReservationRetention is how long the committed, released and expired reservations are kept, so the late
+// commits and releases of a checkout get a meaningful answer instead of a not found
+const FinishedReservationRetention = time.Hour
+
+// Inventory keeps the stock and its reservations, every implementation has to honor this contract
+// (which is enforced by inventorytest.RunInventoryConformance):
+//   - the held reservations are part of the stock but not available, the available quantity is never negative
+//   - a reservation holds all of its items or none of them, the items of the same product are added up
+//   - a held reservation expires at the end of its TTL and its items are available again
+//   - committing a reservation takes its items out of the stock, committing it again does nothing; an expired
+//     reservation is still committed if its items are available, the order may have been paid right before it expired
+//   - releasing a reservation makes its items available again, releasing a released or an expired one does nothing
+//   - the finished reservations are forgotten after FinishedReservationRetention
+//   - the methods are safe for concurrent use, concurrent reservations never hold more than the stock
+//   - the errors are tagged with the error kinds in errors.go (e.g. ErrConflict when the stock is too low)
+//
+// The stock is shared by every flow, a dev flow that needs its own stock gets its own database from Kardinal.
+type Inventory interface {
+	// Stock returns the stock of the product, the quantities held by the reservations aren't available
+	Stock(ctx context.Context, productID string) (*productcatalogservice_rest_types.ProductStock, error)
+	// Reserve holds the stock of the items for the TTL, either all of them are held or none is
+	Reserve(ctx context.Context, request productcatalogservice_rest_types.CreateReservationRequest, ttl time.Duration) (*productcatalogservice_rest_types.Reservation, error)
+	// Commit takes the reserved items out of the stock
+	Commit(ctx context.Context, reservationID string) (*productcatalogservice_rest_types.Reservation, error)
+	// Release puts the reserved items back in the stock
+	Release(ctx context.Context, reservationID string) (*productcatalogservice_rest_types.Reservation, error)
+	// Ping checks that the inventory is able to serve requests, it's used by the readiness check
+	Ping(ctx context.Context) error
+}
+
+// LoadStock reads the stock of every product from the catalog file, a product without a stock has none
+func LoadStock(catalogFilePath string) (map[string]int32, error) {
+	catalogJSON, err := os.ReadFile(catalogFilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "An error occurred reading the catalog file '%s'", catalogFilePath)
+	}
+
+	catalog := struct {
+		Products []struct {
+			Id    string `json:"id"`
+			Stock int32  `json:"stock"`
+		} `json:"products"`
+	}{}
+	if err := json.Unmarshal(catalogJSON, &catalog); err != nil {
+		return nil, errors.Wrapf(err, "An error occurred parsing the catalog file '%s'", catalogFilePath)
+	}
+
+	stock := make(map[string]int32, len(catalog.Products))
+	for _, product := range catalog.Products {
+		if product.Stock < 0 {
+			return nil, errors.Errorf("Product '%s' has a negative stock of %d in the catalog file '%s'", product.Id, product.Stock, catalogFilePath)
+		}
+		stock[product.Id] = product.Stock
+	}
+	return stock, nil
+}
+
+// validateReservation validates the request and its TTL, and returns its items with the quantities of the same
+// product added up, sorted by product ID
+func validateReservation(request productcatalogservice_rest_types.CreateReservationRequest, ttl time.Duration) ([]productcatalogservice_rest_types.ReservationItem, error) {
+	items, err := mergeItems(request)
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		return nil, newInventoryError(ErrInvalidArgument, "the TTL of reservation '%s' must be positive, got '%s'", request.ReservationId, ttl)
+	}
+	return items, nil
+}
+
+// mergeItems validates the items of the request and adds up the quantities of the same product, sorted by product ID
+func mergeItems(request productcatalogservice_rest_types.CreateReservationRequest) ([]productcatalogservice_rest_types.ReservationItem, error) {
+	if request.ReservationId == "" {
+		return nil, newInventoryError(ErrInvalidArgument, "the reservation ID can't be empty")
+	}
+	if len(request.Items) == 0 {
+		return nil, newInventoryError(ErrInvalidArgument, "reservation '%s' has no items", request.ReservationId)
+	}
+
+	quantities := map[string]int32{}
+	for _, item := range request.Items {
+		if item.ProductId == "" {
+			return nil, newInventoryError(ErrInvalidArgument, "reservation '%s' has an item without product ID", request.ReservationId)
+		}
+		if item.Quantity <= 0 {
+			return nil, newInventoryError(ErrInvalidArgument, "invalid quantity %d for product '%s' in reservation '%s', it must be greater than zero", item.Quantity, item.ProductId, request.ReservationId)
+		}
+		quantities[item.ProductId] += item.Quantity
+	}
+
+	items := make([]productcatalogservice_rest_types.ReservationItem, 0, len(quantities))
+	for productID, quantity := range quantities {
+		items = append(items, productcatalogservice_rest_types.ReservationItem{ProductId: productID, Quantity: quantity})
+	}
+	sort.Slice(items, func(a, b int) bool {
+		return items[a].ProductId < items[b].ProductId
+	})
+	return items, nil
+}
+
+func newProductStock(productID string, quantity int32, reserved int32) *productcatalogservice_rest_types.ProductStock {
+	available := quantity - reserved
+	return &productcatalogservice_rest_types.ProductStock{
+		ProductId: productID,
+		Available: available,
+		Reserved:  reserved,
+		InStock:   available > 0,
+	}
+}
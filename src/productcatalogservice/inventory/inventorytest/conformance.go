@@ -0,0 +1,275 @@
+// Package inventorytest contains the conformance suite that every inventory.Inventory implementation is tested against
+package inventorytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	productcatalogservice_rest_types "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/productcatalogservice/inventory"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTTL = 10 * time.Minute
+
+	concurrentReservations = 20
+)
+
+// Factory returns the Inventory under test holding the stock, and a function that moves its clock forward. It's called
+// once per test case, and every test case uses its own product and reservation IDs, so implementations can either
+// return a fresh inventory or add the stock to a shared one.
+type Factory func(t *testing.T, stock map[string]int32) (inventory.Inventory, func(time.Duration))
+
+var idsCounter uint64
+
+// testIDs are the product and reservation IDs of a test case
+type testIDs struct {
+	suffix string
+}
+
+func newTestIDs() testIDs {
+	return testIDs{suffix: fmt.Sprintf("%d-%d", time.Now().UnixNano(), atomic.AddUint64(&idsCounter, 1))}
+}
+
+func (ids testIDs) sunglasses() string {
+	return "OLJCESPC7Z-" + ids.suffix
+}
+
+func (ids testIDs) tankTop() string {
+	return "66VCHSJNUP-" + ids.suffix
+}
+
+func (ids testIDs) reservation(name string) string {
+	return name + "-" + ids.suffix
+}
+
+// newTestInventory returns an inventory with 5 sunglasses and 2 tank tops, and a function to move its clock forward
+func newTestInventory(t *testing.T, factory Factory, ids testIDs) (inventory.Inventory, func(time.Duration)) {
+	return factory(t, map[string]int32{ids.sunglasses(): 5, ids.tankTop(): 2})
+}
+
+// RunInventoryConformance runs the whole Inventory contract against the inventories returned by the factory
+func RunInventoryConformance(t *testing.T, factory Factory) {
+	t.Run("ReserveHoldsTheStock", func(t *testing.T) {
+		testReserveHoldsTheStock(t, factory)
+	})
+	t.Run("ReserveIsAllOrNothing", func(t *testing.T) {
+		testReserveIsAllOrNothing(t, factory)
+	})
+	t.Run("ReserveRejectsAnUnknownProductAndAReusedID", func(t *testing.T) {
+		testReserveRejectsAnUnknownProductAndAReusedID(t, factory)
+	})
+	t.Run("CommitTakesTheItemsOutOfTheStock", func(t *testing.T) {
+		testCommitTakesTheItemsOutOfTheStock(t, factory)
+	})
+	t.Run("ReleasePutsTheItemsBackInStock", func(t *testing.T) {
+		testReleasePutsTheItemsBackInStock(t, factory)
+	})
+	t.Run("AnExpiredReservationIsReleased", func(t *testing.T) {
+		testAnExpiredReservationIsReleased(t, factory)
+	})
+	t.Run("AnExpiredReservationIsCommittedWhileItsItemsAreInStock", func(t *testing.T) {
+		testAnExpiredReservationIsCommittedWhileItsItemsAreInStock(t, factory)
+	})
+	t.Run("ConcurrentReservationsNeverHoldMoreThanTheStock", func(t *testing.T) {
+		testConcurrentReservationsNeverHoldMoreThanTheStock(t, factory)
+	})
+}
+
+func newReservationRequest(reservationID string, items ...productcatalogservice_rest_types.ReservationItem) productcatalogservice_rest_types.CreateReservationRequest {
+	return productcatalogservice_rest_types.CreateReservationRequest{ReservationId: reservationID, Items: items}
+}
+
+func requireAvailable(t *testing.T, productInventory inventory.Inventory, productID string, expected int32) {
+	stock, err := productInventory.Stock(context.Background(), productID)
+	require.NoError(t, err)
+	require.Equal(t, expected, stock.Available)
+	require.Equal(t, expected > 0, stock.InStock)
+}
+
+func testReserveHoldsTheStock(t *testing.T, factory Factory) {
+	ids := newTestIDs()
+	productInventory, _ := newTestInventory(t, factory, ids)
+	ctx := context.Background()
+
+	reservation, err := productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-1"),
+		productcatalogservice_rest_types.ReservationItem{ProductId: ids.sunglasses(), Quantity: 2},
+		productcatalogservice_rest_types.ReservationItem{ProductId: ids.sunglasses(), Quantity: 1},
+	), testTTL)
+	require.NoError(t, err)
+	require.Equal(t, productcatalogservice_rest_types.Held, reservation.Status)
+	require.Equal(t, []productcatalogservice_rest_types.ReservationItem{{ProductId: ids.sunglasses(), Quantity: 3}}, reservation.Items)
+
+	stock, err := productInventory.Stock(ctx, ids.sunglasses())
+	require.NoError(t, err)
+	require.Equal(t, productcatalogservice_rest_types.ProductStock{ProductId: ids.sunglasses(), Available: 2, Reserved: 3, InStock: true}, *stock)
+}
+
+func testReserveIsAllOrNothing(t *testing.T, factory Factory) {
+	ids := newTestIDs()
+	productInventory, _ := newTestInventory(t, factory, ids)
+
+	_, err := productInventory.Reserve(context.Background(), newReservationRequest(ids.reservation("order-1"),
+		productcatalogservice_rest_types.ReservationItem{ProductId: ids.sunglasses(), Quantity: 1},
+		productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 3},
+	), testTTL)
+	require.ErrorIs(t, err, inventory.ErrConflict)
+
+	requireAvailable(t, productInventory, ids.sunglasses(), 5)
+	requireAvailable(t, productInventory, ids.tankTop(), 2)
+}
+
+func testReserveRejectsAnUnknownProductAndAReusedID(t *testing.T, factory Factory) {
+	ids := newTestIDs()
+	productInventory, _ := newTestInventory(t, factory, ids)
+	ctx := context.Background()
+
+	_, err := productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-1"), productcatalogservice_rest_types.ReservationItem{ProductId: "UNKNOWN-" + ids.suffix, Quantity: 1}), testTTL)
+	require.ErrorIs(t, err, inventory.ErrNotFound)
+	_, err = productInventory.Stock(ctx, "UNKNOWN-"+ids.suffix)
+	require.ErrorIs(t, err, inventory.ErrNotFound)
+
+	_, err = productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-1"), productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 1}), testTTL)
+	require.NoError(t, err)
+	_, err = productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-1"), productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 1}), testTTL)
+	require.ErrorIs(t, err, inventory.ErrConflict)
+	requireAvailable(t, productInventory, ids.tankTop(), 1)
+}
+
+func testCommitTakesTheItemsOutOfTheStock(t *testing.T, factory Factory) {
+	ids := newTestIDs()
+	productInventory, _ := newTestInventory(t, factory, ids)
+	ctx := context.Background()
+
+	_, err := productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-1"), productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 2}), testTTL)
+	require.NoError(t, err)
+	reservation, err := productInventory.Commit(ctx, ids.reservation("order-1"))
+	require.NoError(t, err)
+	require.Equal(t, productcatalogservice_rest_types.Committed, reservation.Status)
+
+	// committing it again doesn't take the items twice
+	_, err = productInventory.Commit(ctx, ids.reservation("order-1"))
+	require.NoError(t, err)
+	stock, err := productInventory.Stock(ctx, ids.tankTop())
+	require.NoError(t, err)
+	require.Equal(t, productcatalogservice_rest_types.ProductStock{ProductId: ids.tankTop(), Available: 0, Reserved: 0, InStock: false}, *stock)
+
+	_, err = productInventory.Release(ctx, ids.reservation("order-1"))
+	require.ErrorIs(t, err, inventory.ErrConflict)
+	_, err = productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-2"), productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 1}), testTTL)
+	require.ErrorIs(t, err, inventory.ErrConflict)
+}
+
+func testReleasePutsTheItemsBackInStock(t *testing.T, factory Factory) {
+	ids := newTestIDs()
+	productInventory, _ := newTestInventory(t, factory, ids)
+	ctx := context.Background()
+
+	_, err := productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-1"), productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 2}), testTTL)
+	require.NoError(t, err)
+	requireAvailable(t, productInventory, ids.tankTop(), 0)
+
+	reservation, err := productInventory.Release(ctx, ids.reservation("order-1"))
+	require.NoError(t, err)
+	require.Equal(t, productcatalogservice_rest_types.Released, reservation.Status)
+	requireAvailable(t, productInventory, ids.tankTop(), 2)
+
+	_, err = productInventory.Release(ctx, ids.reservation("order-1"))
+	require.NoError(t, err)
+	_, err = productInventory.Commit(ctx, ids.reservation("order-1"))
+	require.ErrorIs(t, err, inventory.ErrConflict)
+	_, err = productInventory.Release(ctx, ids.reservation("unknown"))
+	require.ErrorIs(t, err, inventory.ErrNotFound)
+}
+
+func testAnExpiredReservationIsReleased(t *testing.T, factory Factory) {
+	ids := newTestIDs()
+	productInventory, advance := newTestInventory(t, factory, ids)
+	ctx := context.Background()
+
+	_, err := productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-1"), productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 2}), testTTL)
+	require.NoError(t, err)
+	advance(testTTL - time.Second)
+	requireAvailable(t, productInventory, ids.tankTop(), 0)
+
+	advance(time.Second)
+	requireAvailable(t, productInventory, ids.tankTop(), 2)
+	reservation, err := productInventory.Release(ctx, ids.reservation("order-1"))
+	require.NoError(t, err)
+	require.Equal(t, productcatalogservice_rest_types.Expired, reservation.Status)
+
+	// the finished reservations are forgotten after a while, and their ID can be reused
+	advance(inventory.FinishedReservationRetention + time.Second)
+	_, err = productInventory.Release(ctx, ids.reservation("order-1"))
+	require.ErrorIs(t, err, inventory.ErrNotFound)
+	_, err = productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-1"), productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 1}), testTTL)
+	require.NoError(t, err)
+	requireAvailable(t, productInventory, ids.tankTop(), 1)
+}
+
+func testAnExpiredReservationIsCommittedWhileItsItemsAreInStock(t *testing.T, factory Factory) {
+	ids := newTestIDs()
+	productInventory, advance := newTestInventory(t, factory, ids)
+	ctx := context.Background()
+
+	_, err := productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-1"), productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 1}), testTTL)
+	require.NoError(t, err)
+	_, err = productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-2"), productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 1}), testTTL)
+	require.NoError(t, err)
+	advance(testTTL)
+
+	// the order was paid right before its reservation expired
+	reservation, err := productInventory.Commit(ctx, ids.reservation("order-1"))
+	require.NoError(t, err)
+	require.Equal(t, productcatalogservice_rest_types.Committed, reservation.Status)
+	requireAvailable(t, productInventory, ids.tankTop(), 1)
+
+	// the items of the other one were sold in the meantime
+	_, err = productInventory.Reserve(ctx, newReservationRequest(ids.reservation("order-3"), productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 1}), testTTL)
+	require.NoError(t, err)
+	_, err = productInventory.Commit(ctx, ids.reservation("order-2"))
+	require.ErrorIs(t, err, inventory.ErrConflict)
+	requireAvailable(t, productInventory, ids.tankTop(), 0)
+}
+
+func testConcurrentReservationsNeverHoldMoreThanTheStock(t *testing.T, factory Factory) {
+	ids := newTestIDs()
+	productInventory, _ := newTestInventory(t, factory, ids)
+	ctx := context.Background()
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, concurrentReservations)
+	)
+	for i := 0; i < concurrentReservations; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := productInventory.Reserve(ctx, newReservationRequest(ids.reservation(fmt.Sprintf("order-%d", i)),
+				productcatalogservice_rest_types.ReservationItem{ProductId: ids.sunglasses(), Quantity: 1},
+				productcatalogservice_rest_types.ReservationItem{ProductId: ids.tankTop(), Quantity: 1},
+			), testTTL)
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	held := 0
+	for err := range errs {
+		if err == nil {
+			held++
+			continue
+		}
+		require.ErrorIs(t, err, inventory.ErrConflict)
+	}
+	// there are only 2 tank tops
+	require.Equal(t, 2, held)
+	requireAvailable(t, productInventory, ids.tankTop(), 0)
+	requireAvailable(t, productInventory, ids.sunglasses(), 3)
+}
@@ -0,0 +1,223 @@
+package inventory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	productcatalogservice_rest_types "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/types"
+	"github.com/sirupsen/logrus"
+)
+
+// Memory is an Inventory implementation that keeps the stock and the reservations in a process-local map, so every
+// instance of the service has its own stock. It's useful to run the service without a database (e.g. locally or in
+// unit tests).
+type Memory struct {
+	mu sync.Mutex
+	// stock is the quantity of every product in the warehouse, the held reservations are still part of it
+	stock        map[string]int32
+	reservations map[string]*reservation
+
+	now func() time.Time
+}
+
+type reservation struct {
+	items     []productcatalogservice_rest_types.ReservationItem
+	status    productcatalogservice_rest_types.ReservationStatus
+	expiresAt time.Time
+	// finishedAt is when the reservation stopped holding the stock, i.e. it was committed, released or it expired
+	finishedAt time.Time
+}
+
+func NewMemory(stock map[string]int32) *Memory {
+	inventoryStock := make(map[string]int32, len(stock))
+	for productID, quantity := range stock {
+		inventoryStock[productID] = quantity
+	}
+	return &Memory{
+		stock:        inventoryStock,
+		reservations: map[string]*reservation{},
+		now:          time.Now,
+	}
+}
+
+// SetClock replaces the clock the reservations expire with, for the tests
+func (m *Memory) SetClock(now func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = now
+}
+
+// Ping never fails unless the context is done, the stock is always available
+func (m *Memory) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+// Stock returns the stock of the product, the quantities held by the reservations aren't available
+func (m *Memory) Stock(ctx context.Context, productID string) (*productcatalogservice_rest_types.ProductStock, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expireReservations()
+
+	quantity, found := m.stock[productID]
+	if !found {
+		return nil, newInventoryError(ErrNotFound, "there's no product '%s'", productID)
+	}
+	return newProductStock(productID, quantity, m.reserved(productID)), nil
+}
+
+// Reserve holds the stock of the items for the TTL, either all of them are held or none is
+func (m *Memory) Reserve(ctx context.Context, request productcatalogservice_rest_types.CreateReservationRequest, ttl time.Duration) (*productcatalogservice_rest_types.Reservation, error) {
+	items, err := validateReservation(request, ttl)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expireReservations()
+
+	if _, found := m.reservations[request.ReservationId]; found {
+		return nil, newInventoryError(ErrConflict, "there's already a reservation '%s'", request.ReservationId)
+	}
+	for _, item := range items {
+		quantity, found := m.stock[item.ProductId]
+		if !found {
+			return nil, newInventoryError(ErrNotFound, "there's no product '%s'", item.ProductId)
+		}
+		if available := quantity - m.reserved(item.ProductId); item.Quantity > available {
+			return nil, newInventoryError(ErrConflict, "product '%s' is out of stock, %d requested but %d available", item.ProductId, item.Quantity, available)
+		}
+	}
+
+	newReservation := &reservation{
+		items:     items,
+		status:    productcatalogservice_rest_types.Held,
+		expiresAt: m.now().Add(ttl),
+	}
+	m.reservations[request.ReservationId] = newReservation
+	return newReservation.toRest(request.ReservationId), nil
+}
+
+// Commit takes the reserved items out of the stock, committing a committed reservation does nothing. An expired
+// reservation is still committed if its items are available, the order may have been paid right before it expired.
+func (m *Memory) Commit(ctx context.Context, reservationID string) (*productcatalogservice_rest_types.Reservation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expireReservations()
+
+	existingReservation, err := m.getReservation(reservationID)
+	if err != nil {
+		return nil, err
+	}
+	switch existingReservation.status {
+	case productcatalogservice_rest_types.Held:
+		for _, item := range existingReservation.items {
+			m.stock[item.ProductId] -= item.Quantity
+		}
+		existingReservation.finish(productcatalogservice_rest_types.Committed, m.now())
+	case productcatalogservice_rest_types.Expired:
+		for _, item := range existingReservation.items {
+			if available := m.stock[item.ProductId] - m.reserved(item.ProductId); item.Quantity > available {
+				return nil, newInventoryError(ErrConflict, "reservation '%s' expired and product '%s' is out of stock, %d reserved but %d available", reservationID, item.ProductId, item.Quantity, available)
+			}
+		}
+		for _, item := range existingReservation.items {
+			m.stock[item.ProductId] -= item.Quantity
+		}
+		existingReservation.finish(productcatalogservice_rest_types.Committed, m.now())
+		logrus.Warnf("Reservation '%s' was committed after it expired, its items were still in stock", reservationID)
+	case productcatalogservice_rest_types.Committed:
+	default:
+		return nil, newInventoryError(ErrConflict, "reservation '%s' can't be committed, it's %s", reservationID, existingReservation.status)
+	}
+	return existingReservation.toRest(reservationID), nil
+}
+
+// Release puts the reserved items back in the stock, releasing a released or an expired reservation does nothing
+func (m *Memory) Release(ctx context.Context, reservationID string) (*productcatalogservice_rest_types.Reservation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expireReservations()
+
+	existingReservation, err := m.getReservation(reservationID)
+	if err != nil {
+		return nil, err
+	}
+	switch existingReservation.status {
+	case productcatalogservice_rest_types.Held:
+		existingReservation.finish(productcatalogservice_rest_types.Released, m.now())
+	case productcatalogservice_rest_types.Released, productcatalogservice_rest_types.Expired:
+	default:
+		return nil, newInventoryError(ErrConflict, "reservation '%s' can't be released, it's %s", reservationID, existingReservation.status)
+	}
+	return existingReservation.toRest(reservationID), nil
+}
+
+func (m *Memory) getReservation(reservationID string) (*reservation, error) {
+	existingReservation, found := m.reservations[reservationID]
+	if !found {
+		return nil, newInventoryError(ErrNotFound, "there's no reservation '%s'", reservationID)
+	}
+	return existingReservation, nil
+}
+
+// expireReservations releases the held reservations that reached their expiration and forgets the ones that finished
+// long ago, it's called by every operation before it reads the reservations so there's no need for a background job
+func (m *Memory) expireReservations() {
+	now := m.now()
+	for reservationID, existingReservation := range m.reservations {
+		if existingReservation.status == productcatalogservice_rest_types.Held && !now.Before(existingReservation.expiresAt) {
+			existingReservation.finish(productcatalogservice_rest_types.Expired, existingReservation.expiresAt)
+			logrus.Infof("Reservation '%s' expired, its items are back in stock", reservationID)
+		}
+		if existingReservation.status != productcatalogservice_rest_types.Held && now.Sub(existingReservation.finishedAt) > FinishedReservationRetention {
+			delete(m.reservations, reservationID)
+		}
+	}
+}
+
+// reserved returns the quantity of the product held by the reservations
+func (m *Memory) reserved(productID string) int32 {
+	reserved := int32(0)
+	for _, existingReservation := range m.reservations {
+		if existingReservation.status != productcatalogservice_rest_types.Held {
+			continue
+		}
+		for _, item := range existingReservation.items {
+			if item.ProductId == productID {
+				reserved += item.Quantity
+			}
+		}
+	}
+	return reserved
+}
+
+func (r *reservation) finish(status productcatalogservice_rest_types.ReservationStatus, finishedAt time.Time) {
+	r.status = status
+	r.finishedAt = finishedAt
+}
+
+func (r *reservation) toRest(reservationID string) *productcatalogservice_rest_types.Reservation {
+	return &productcatalogservice_rest_types.Reservation{
+		ReservationId: reservationID,
+		Items:         append([]productcatalogservice_rest_types.ReservationItem{}, r.items...),
+		Status:        r.status,
+		ExpiresAt:     r.expiresAt,
+	}
+}
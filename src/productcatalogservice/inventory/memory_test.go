@@ -0,0 +1,18 @@
+package inventory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/productcatalogservice/inventory"
+	"github.com/kurtosis-tech/new-obd/src/productcatalogservice/inventory/inventorytest"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	inventorytest.RunInventoryConformance(t, func(t *testing.T, stock map[string]int32) (inventory.Inventory, func(time.Duration)) {
+		now := time.Date(2024, 7, 29, 12, 0, 0, 0, time.UTC)
+		memory := inventory.NewMemory(stock)
+		memory.SetClock(func() time.Time { return now })
+		return memory, func(d time.Duration) { now = now.Add(d) }
+	})
+}
@@ -0,0 +1,24 @@
+package inventory
+
+import (
+	"embed"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+)
+
+// random key shared by every productcatalogservice replica, so only one of them migrates the database at a time
+const migrationsAdvisoryLockKey int64 = 6_397_605_727_035_171_790
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+var migrationsConfig = database.MigrationsConfig{
+	FS:              migrationsFS,
+	Schema:          "inventory",
+	AdvisoryLockKey: migrationsAdvisoryLockKey,
+}
+
+// Migrations returns the migrations embedded in the binary sorted by version
+func Migrations() ([]database.Migration, error) {
+	return database.Migrations(migrationsFS)
+}
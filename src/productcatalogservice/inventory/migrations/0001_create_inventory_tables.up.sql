@@ -0,0 +1,25 @@
+CREATE SCHEMA IF NOT EXISTS inventory;
+
+-- the quantity of every product in the warehouse, the held reservations are still part of it
+CREATE TABLE IF NOT EXISTS inventory.stock(
+    product_id TEXT PRIMARY KEY,
+    quantity INTEGER NOT NULL CHECK (quantity >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS inventory.reservations(
+    reservation_id TEXT PRIMARY KEY,
+    status TEXT NOT NULL CHECK (status IN ('held', 'committed', 'released', 'expired')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    -- when the reservation stopped holding the stock, NULL while it's held
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS idx_reservations_status_expires_at ON inventory.reservations (status, expires_at);
+CREATE INDEX IF NOT EXISTS idx_reservations_finished_at ON inventory.reservations (finished_at);
+
+CREATE TABLE IF NOT EXISTS inventory.reservation_items(
+    reservation_id TEXT NOT NULL REFERENCES inventory.reservations (reservation_id) ON DELETE CASCADE,
+    product_id TEXT NOT NULL REFERENCES inventory.stock (product_id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (reservation_id, product_id)
+);
+CREATE INDEX IF NOT EXISTS idx_reservation_items_product_id ON inventory.reservation_items (product_id);
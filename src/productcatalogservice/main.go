@@ -1,17 +1,33 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+	"github.com/kurtosis-tech/new-obd/src/libs/metrics"
+	"github.com/kurtosis-tech/new-obd/src/libs/rest"
+	"github.com/kurtosis-tech/new-obd/src/libs/shutdown"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
 	productcatalogservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/server"
+	"github.com/kurtosis-tech/new-obd/src/productcatalogservice/config"
+	"github.com/kurtosis-tech/new-obd/src/productcatalogservice/inventory"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"net"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 const (
-	restAPIPortAddr uint16 = 8070
-	restAPIHostIP   string = "0.0.0.0"
+	serviceName = "productcatalogservice"
+
+	// the exit code of an invalid config, it follows the ones of the shutdown package
+	exitCodeInvalidConfig = 3
 )
 
 var (
@@ -20,13 +36,49 @@ var (
 )
 
 func main() {
+	cfg, commandLine, err := config.Load(os.Args[0], os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitCodeInvalidConfig)
+	}
+
+	if commandLine.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+
+	if len(commandLine.Args) > 0 {
+		if commandLine.Args[0] != database.MigrateCommandName {
+			fmt.Fprintf(os.Stderr, "unknown command '%s', the only command is '%s'\n", commandLine.Args[0], database.MigrateCommandName)
+			os.Exit(exitCodeInvalidConfig)
+		}
+		if err := runMigrateCommand(cfg, commandLine.Args[1:]); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), serviceName, cfg.Tracing)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
 	logrus.Info("Running REST API server...")
 
 	// This is how you set up a basic Echo router
 	echoRouter := echo.New()
+	echoRouter.HTTPErrorHandler = rest.NewResponseInfoHTTPErrorHandler(statusCodeForError)
+	// first, so the duration includes the other middlewares
+	echoRouter.Use(metrics.Middleware)
+	echoRouter.Use(otelecho.Middleware(serviceName, otelecho.WithSkipper(tracing.Skipper)))
 	echoRouter.Use(middleware.Logger())
 
-	echoRouter.Use(KardinalTraceIDMiddleware)
+	echoRouter.Use(tracing.KardinalTraceIDMiddleware)
 
 	// CORS configuration
 	echoRouter.Use(middleware.CORSWithConfig(middleware.CORSConfig{
@@ -34,9 +86,75 @@ func main() {
 		AllowHeaders: defaultCORSHeaders,
 	}))
 
-	server := NewServer()
+	requestValidatorMiddleware, err := rest.NewRequestValidatorMiddleware(productcatalogservice_server_rest_server.GetSwagger, "")
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	echoRouter.Use(requestValidatorMiddleware)
+
+	productInventory, err := newInventory(cfg)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	server := NewServer(productInventory, cfg.ReservationTTL)
+
+	metrics.RegisterHandler(echoRouter)
 
-	productcatalogservice_server_rest_server.RegisterHandlers(echoRouter, productcatalogservice_server_rest_server.NewStrictHandler(server, nil))
+	productcatalogservice_server_rest_server.RegisterHandlers(echoRouter, productcatalogservice_server_rest_server.NewStrictHandler(server, []productcatalogservice_server_rest_server.StrictMiddlewareFunc{rest.NewErrorMappingMiddleware(statusCodeForError)}))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	exitCode := shutdown.ServeUntilShutdown(ctx, func() error {
+		return echoRouter.Start(cfg.Address())
+	}, echoRouter.Shutdown, shutdown.Options{
+		OnShutdownStart: server.MarkShuttingDown,
+		DrainDelay:      cfg.DrainDelay,
+		ShutdownTimeout: cfg.ShutdownTimeout,
+	})
+	stop()
+
+	// the in-memory inventory has nothing to release, the database one closes its connection pool
+	if closer, ok := productInventory.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logrus.Errorf("An error occurred closing the inventory. Error: %s", err)
+		}
+	}
+
+	shutdown.FlushTraces(shutdownTracing, cfg.ShutdownTimeout)
+	shutdown.FlushLogs()
+	os.Exit(exitCode)
+}
+
+func newInventory(cfg *config.Config) (inventory.Inventory, error) {
+	stock, err := inventory.LoadStock(catalogFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.InventoryStore {
+	case config.MemoryInventoryStore:
+		logrus.Info("Using the in-memory inventory, every replica has its own stock and it's reset when the service stops")
+		return inventory.NewMemory(stock), nil
+	case config.PostgresInventoryStore:
+		db, err := newDb(cfg.Database)
+		if err != nil {
+			return nil, err
+		}
+		// the migrations hold a lock, so it's safe to run them when several replicas start at once
+		if cfg.MigrateOnStartup {
+			if err := db.MigrateUp(context.Background()); err != nil {
+				return nil, err
+			}
+		}
+		if err := db.SeedStock(context.Background(), stock); err != nil {
+			return nil, err
+		}
+		return db, nil
+	default:
+		return nil, fmt.Errorf("unknown inventory store '%s', valid values are '%s' and '%s'", cfg.InventoryStore, config.PostgresInventoryStore, config.MemoryInventoryStore)
+	}
+}
 
-	echoRouter.Start(net.JoinHostPort(restAPIHostIP, fmt.Sprint(restAPIPortAddr)))
+func newDb(dbConfig config.DatabaseConfig) (*inventory.Db, error) {
+	return inventory.NewDb(dbConfig.URI, dbConfig.Host, dbConfig.Username, dbConfig.Password, dbConfig.Name, dbConfig.Port)
 }
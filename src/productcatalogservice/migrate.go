@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/database"
+	"github.com/kurtosis-tech/new-obd/src/productcatalogservice/config"
+	"github.com/sirupsen/logrus"
+)
+
+// runMigrateCommand runs the 'productcatalogservice migrate' subcommands against the configured database
+func runMigrateCommand(cfg *config.Config, args []string) error {
+	db, err := newDb(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logrus.Warnf("An error occurred closing the database connection. Error: %s", err.Error())
+		}
+	}()
+
+	return database.RunMigrateCommand(context.Background(), serviceName, db.Migrator, args)
+}
@@ -7,28 +7,55 @@ import (
 	"fmt"
 	productcatalogservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/server"
 	productcatalogservice_rest_types "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/productcatalogservice/inventory"
 	"github.com/sirupsen/logrus"
 	"io/ioutil"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	healthStatusOk           = "ok"
+	healthStatusError        = "error"
+	healthStatusShuttingDown = "shutting down"
+
+	catalogDependencyName   = "catalog"
+	inventoryDependencyName = "inventory"
+
+	readinessCheckTimeout = 2 * time.Second
+
+	catalogFilePath = "data/products.json"
+)
+
 type ListProductsResponse struct {
 	Products []productcatalogservice_rest_types.Product `json:"products,omitempty"`
 }
 
 type Server struct {
 	sync.Mutex
-	products []productcatalogservice_rest_types.Product
+	products  []productcatalogservice_rest_types.Product
+	inventory inventory.Inventory
+	// reservationTTL is how long the stock is held for the reservations that don't set their own TTL
+	reservationTTL time.Duration
+	shuttingDown   atomic.Bool
 }
 
-func NewServer() Server {
-	return Server{}
+func NewServer(productInventory inventory.Inventory, reservationTTL time.Duration) *Server {
+	return &Server{
+		inventory:      productInventory,
+		reservationTTL: reservationTTL,
+	}
+}
+
+// MarkShuttingDown makes the readiness check fail, so the service stops receiving traffic while it shuts down
+func (s *Server) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
 }
 
-func (s Server) GetHealth(ctx context.Context, request productcatalogservice_server_rest_server.GetHealthRequestObject) (productcatalogservice_server_rest_server.GetHealthResponseObject, error) {
+func (s *Server) GetHealth(ctx context.Context, request productcatalogservice_server_rest_server.GetHealthRequestObject) (productcatalogservice_server_rest_server.GetHealthResponseObject, error) {
 
-	status := "ok"
+	status := healthStatusOk
 	now := time.Now()
 
 	response := productcatalogservice_rest_types.HealthResponse{
@@ -39,13 +66,61 @@ func (s Server) GetHealth(ctx context.Context, request productcatalogservice_ser
 	return productcatalogservice_server_rest_server.GetHealth200JSONResponse(response), nil
 }
 
-func (s Server) GetProducts(ctx context.Context, request productcatalogservice_server_rest_server.GetProductsRequestObject) (productcatalogservice_server_rest_server.GetProductsResponseObject, error) {
+func (s *Server) GetHealthLive(ctx context.Context, request productcatalogservice_server_rest_server.GetHealthLiveRequestObject) (productcatalogservice_server_rest_server.GetHealthLiveResponseObject, error) {
+	status := healthStatusOk
+	now := time.Now()
+
+	response := productcatalogservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return productcatalogservice_server_rest_server.GetHealthLive200JSONResponse(response), nil
+}
+
+func (s *Server) GetHealthReady(ctx context.Context, request productcatalogservice_server_rest_server.GetHealthReadyRequestObject) (productcatalogservice_server_rest_server.GetHealthReadyResponseObject, error) {
+	now := time.Now()
+
+	if s.shuttingDown.Load() {
+		status := healthStatusShuttingDown
+		response := productcatalogservice_rest_types.HealthResponse{
+			Status:    &status,
+			Timestamp: &now,
+		}
+		return productcatalogservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+
+	checks := []productcatalogservice_rest_types.DependencyCheck{
+		checkDependency(ctx, catalogDependencyName, s.checkCatalog),
+		checkDependency(ctx, inventoryDependencyName, s.inventory.Ping),
+	}
+
+	status := healthStatusOk
+	for _, check := range checks {
+		if check.Status != healthStatusOk {
+			status = healthStatusError
+		}
+	}
+
+	response := productcatalogservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+		Checks:    &checks,
+	}
+
+	if status != healthStatusOk {
+		return productcatalogservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+	return productcatalogservice_server_rest_server.GetHealthReady200JSONResponse(response), nil
+}
+
+func (s *Server) GetProducts(ctx context.Context, request productcatalogservice_server_rest_server.GetProductsRequestObject) (productcatalogservice_server_rest_server.GetProductsResponseObject, error) {
 	products := s.parseCatalog()
 
 	return productcatalogservice_server_rest_server.GetProducts200JSONResponse(products), nil
 }
 
-func (s Server) GetProductsId(ctx context.Context, request productcatalogservice_server_rest_server.GetProductsIdRequestObject) (productcatalogservice_server_rest_server.GetProductsIdResponseObject, error) {
+func (s *Server) GetProductsId(ctx context.Context, request productcatalogservice_server_rest_server.GetProductsIdRequestObject) (productcatalogservice_server_rest_server.GetProductsIdResponseObject, error) {
 
 	var found productcatalogservice_rest_types.Product
 	products := s.parseCatalog()
@@ -61,10 +136,48 @@ func (s Server) GetProductsId(ctx context.Context, request productcatalogservice
 	return productcatalogservice_server_rest_server.GetProductsId200JSONResponse(found), nil
 }
 
-func (s Server) readCatalogFile() (*ListProductsResponse, error) {
+func (s *Server) GetProductsIdStock(ctx context.Context, request productcatalogservice_server_rest_server.GetProductsIdStockRequestObject) (productcatalogservice_server_rest_server.GetProductsIdStockResponseObject, error) {
+	stock, err := s.inventory.Stock(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return productcatalogservice_server_rest_server.GetProductsIdStock200JSONResponse(*stock), nil
+}
+
+func (s *Server) PostReservations(ctx context.Context, request productcatalogservice_server_rest_server.PostReservationsRequestObject) (productcatalogservice_server_rest_server.PostReservationsResponseObject, error) {
+	ttl := s.reservationTTL
+	if request.Body.TtlSeconds != nil {
+		ttl = time.Duration(*request.Body.TtlSeconds) * time.Second
+	}
+
+	reservation, err := s.inventory.Reserve(ctx, *request.Body, ttl)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Reserved the stock of %d products until %s for reservation '%s'", len(reservation.Items), reservation.ExpiresAt.Format(time.RFC3339), reservation.ReservationId)
+	return productcatalogservice_server_rest_server.PostReservations201JSONResponse(*reservation), nil
+}
+
+func (s *Server) PostReservationsReservationIdCommit(ctx context.Context, request productcatalogservice_server_rest_server.PostReservationsReservationIdCommitRequestObject) (productcatalogservice_server_rest_server.PostReservationsReservationIdCommitResponseObject, error) {
+	reservation, err := s.inventory.Commit(ctx, request.ReservationId)
+	if err != nil {
+		return nil, err
+	}
+	return productcatalogservice_server_rest_server.PostReservationsReservationIdCommit200JSONResponse(*reservation), nil
+}
+
+func (s *Server) PostReservationsReservationIdRelease(ctx context.Context, request productcatalogservice_server_rest_server.PostReservationsReservationIdReleaseRequestObject) (productcatalogservice_server_rest_server.PostReservationsReservationIdReleaseResponseObject, error) {
+	reservation, err := s.inventory.Release(ctx, request.ReservationId)
+	if err != nil {
+		return nil, err
+	}
+	return productcatalogservice_server_rest_server.PostReservationsReservationIdRelease200JSONResponse(*reservation), nil
+}
+
+func (s *Server) readCatalogFile() (*ListProductsResponse, error) {
 	s.Lock()
 	defer s.Unlock()
-	catalogJSON, err := ioutil.ReadFile("data/products.json")
+	catalogJSON, err := ioutil.ReadFile(catalogFilePath)
 	if err != nil {
 		logrus.Errorf("failed to open product catalog json file: %v", err)
 		return nil, err
@@ -79,7 +192,7 @@ func (s Server) readCatalogFile() (*ListProductsResponse, error) {
 	return catalog, nil
 }
 
-func (s Server) parseCatalog() []productcatalogservice_rest_types.Product {
+func (s *Server) parseCatalog() []productcatalogservice_rest_types.Product {
 	if len(s.products) == 0 {
 		catalog, err := s.readCatalogFile()
 		if err != nil {
@@ -89,3 +202,39 @@ func (s Server) parseCatalog() []productcatalogservice_rest_types.Product {
 	}
 	return s.products
 }
+
+// checkCatalog verifies that the catalog file can be loaded and has products
+func (s *Server) checkCatalog(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	catalog, err := s.readCatalogFile()
+	if err != nil {
+		return err
+	}
+	if len(catalog.Products) == 0 {
+		return errors.New("the product catalog is empty")
+	}
+	return nil
+}
+
+// checkDependency runs the check with the readiness timeout and reports its status and latency
+func checkDependency(ctx context.Context, name string, check func(ctx context.Context) error) productcatalogservice_rest_types.DependencyCheck {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	dependencyCheck := productcatalogservice_rest_types.DependencyCheck{
+		Name:      name,
+		Status:    healthStatusOk,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		logrus.Warnf("The readiness check of dependency '%s' failed. Error: %s", name, err)
+		errMsg := err.Error()
+		dependencyCheck.Status = healthStatusError
+		dependencyCheck.Error = &errMsg
+	}
+	return dependencyCheck
+}
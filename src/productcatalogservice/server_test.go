@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/rest"
+	productcatalogservice_rest_client "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/client"
+	productcatalogservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/server"
+	productcatalogservice_rest_types "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/productcatalogservice/inventory"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sunglassesID = "OLJCESPC7Z"
+	tankTopID    = "66VCHSJNUP"
+
+	testReservationTTL = 10 * time.Minute
+)
+
+// newTestServer returns a server with 5 sunglasses and 2 tank tops in stock
+func newTestServer() *Server {
+	return NewServer(inventory.NewMemory(map[string]int32{sunglassesID: 5, tankTopID: 2}), testReservationTTL)
+}
+
+func newTestClient(t *testing.T, server *Server) *productcatalogservice_rest_client.ClientWithResponses {
+	echoRouter := echo.New()
+	echoRouter.HTTPErrorHandler = rest.NewResponseInfoHTTPErrorHandler(statusCodeForError)
+
+	requestValidatorMiddleware, err := rest.NewRequestValidatorMiddleware(productcatalogservice_server_rest_server.GetSwagger, "")
+	require.NoError(t, err)
+	echoRouter.Use(requestValidatorMiddleware)
+
+	strictMiddlewares := []productcatalogservice_server_rest_server.StrictMiddlewareFunc{rest.NewErrorMappingMiddleware(statusCodeForError)}
+	productcatalogservice_server_rest_server.RegisterHandlers(echoRouter, productcatalogservice_server_rest_server.NewStrictHandler(server, strictMiddlewares))
+
+	httpServer := httptest.NewServer(echoRouter)
+	t.Cleanup(httpServer.Close)
+
+	client, err := productcatalogservice_rest_client.NewClientWithResponses(httpServer.URL)
+	require.NoError(t, err)
+	return client
+}
+
+func newTestReservationRequest(reservationID string, items ...productcatalogservice_rest_types.ReservationItem) productcatalogservice_rest_types.CreateReservationRequest {
+	return productcatalogservice_rest_types.CreateReservationRequest{ReservationId: reservationID, Items: items}
+}
+
+func requireStock(t *testing.T, client *productcatalogservice_rest_client.ClientWithResponses, productID string, available int32, reserved int32) {
+	response, err := client.GetProductsIdStockWithResponse(context.Background(), productID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode())
+	require.Equal(t, productcatalogservice_rest_types.ProductStock{
+		ProductId: productID,
+		Available: available,
+		Reserved:  reserved,
+		InStock:   available > 0,
+	}, *response.JSON200)
+}
+
+func TestGetProductStock(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+
+	requireStock(t, client, sunglassesID, 5, 0)
+
+	response, err := client.GetProductsIdStockWithResponse(context.Background(), "UNKNOWN")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, response.StatusCode())
+	require.ErrorIs(t, productcatalogservice_rest_client.CheckResponse(response, response.JSONDefault), productcatalogservice_rest_client.ErrNotFound)
+}
+
+func TestReservationsHoldAndCommitTheStock(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+	ctx := context.Background()
+
+	createResponse, err := client.PostReservationsWithResponse(ctx, newTestReservationRequest("order-1",
+		productcatalogservice_rest_types.ReservationItem{ProductId: sunglassesID, Quantity: 2},
+		productcatalogservice_rest_types.ReservationItem{ProductId: tankTopID, Quantity: 2},
+	))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, createResponse.StatusCode())
+	require.Equal(t, productcatalogservice_rest_types.Held, createResponse.JSON201.Status)
+	requireStock(t, client, sunglassesID, 3, 2)
+	requireStock(t, client, tankTopID, 0, 2)
+
+	commitResponse, err := client.PostReservationsReservationIdCommitWithResponse(ctx, "order-1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, commitResponse.StatusCode())
+	require.Equal(t, productcatalogservice_rest_types.Committed, commitResponse.JSON200.Status)
+	requireStock(t, client, sunglassesID, 3, 0)
+	requireStock(t, client, tankTopID, 0, 0)
+
+	// a committed reservation can't be released anymore
+	releaseResponse, err := client.PostReservationsReservationIdReleaseWithResponse(ctx, "order-1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, releaseResponse.StatusCode())
+}
+
+func TestReservationsReleaseTheStock(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+	ctx := context.Background()
+
+	createResponse, err := client.PostReservationsWithResponse(ctx, newTestReservationRequest("order-1", productcatalogservice_rest_types.ReservationItem{ProductId: tankTopID, Quantity: 1}))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, createResponse.StatusCode())
+
+	releaseResponse, err := client.PostReservationsReservationIdReleaseWithResponse(ctx, "order-1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, releaseResponse.StatusCode())
+	require.Equal(t, productcatalogservice_rest_types.Released, releaseResponse.JSON200.Status)
+	requireStock(t, client, tankTopID, 2, 0)
+
+	commitResponse, err := client.PostReservationsReservationIdCommitWithResponse(ctx, "order-1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, commitResponse.StatusCode())
+
+	unknownResponse, err := client.PostReservationsReservationIdReleaseWithResponse(ctx, "unknown")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, unknownResponse.StatusCode())
+}
+
+func TestReservationsRejectMoreThanTheStock(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+	ctx := context.Background()
+
+	response, err := client.PostReservationsWithResponse(ctx, newTestReservationRequest("order-1",
+		productcatalogservice_rest_types.ReservationItem{ProductId: sunglassesID, Quantity: 1},
+		productcatalogservice_rest_types.ReservationItem{ProductId: tankTopID, Quantity: 3},
+	))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusConflict, response.StatusCode())
+	require.ErrorIs(t, productcatalogservice_rest_client.CheckResponse(response, response.JSONDefault), productcatalogservice_rest_client.ErrConflict)
+
+	// nothing is held
+	requireStock(t, client, sunglassesID, 5, 0)
+
+	unknownResponse, err := client.PostReservationsWithResponse(ctx, newTestReservationRequest("order-2", productcatalogservice_rest_types.ReservationItem{ProductId: "UNKNOWN", Quantity: 1}))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, unknownResponse.StatusCode())
+}
+
+func TestPostReservationWithInvalidFieldsListsEveryViolation(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+	ctx := context.Background()
+
+	response, err := client.PostReservationsWithBodyWithResponse(ctx, echo.MIMEApplicationJSON, strings.NewReader(`{"items": [{"product_id": "", "quantity": 0}], "ttl_seconds": 7200}`))
+	require.NoError(t, err)
+
+	err = productcatalogservice_rest_client.CheckResponse(response, response.JSONDefault)
+	require.ErrorIs(t, err, productcatalogservice_rest_client.ErrInvalidArgument)
+	require.NotNil(t, response.JSONDefault)
+	require.Equal(t, productcatalogservice_rest_types.ERROR, response.JSONDefault.Type)
+	require.Equal(t, uint32(http.StatusBadRequest), response.JSONDefault.Code)
+	require.Contains(t, response.JSONDefault.Message, "reservation_id")
+	require.Contains(t, response.JSONDefault.Message, "items.0.product_id")
+	require.Contains(t, response.JSONDefault.Message, "items.0.quantity")
+	require.Contains(t, response.JSONDefault.Message, "ttl_seconds")
+}
+
+func TestPostReservationWithoutItemsIsABadRequest(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+	ctx := context.Background()
+
+	response, err := client.PostReservationsWithResponse(ctx, newTestReservationRequest("order-1"))
+	require.NoError(t, err)
+
+	err = productcatalogservice_rest_client.CheckResponse(response, response.JSONDefault)
+	require.ErrorIs(t, err, productcatalogservice_rest_client.ErrInvalidArgument)
+	require.Contains(t, response.JSONDefault.Message, "items")
+
+	// the rejected requests don't reach the inventory
+	releaseResponse, err := client.PostReservationsReservationIdReleaseWithResponse(ctx, "order-1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, releaseResponse.StatusCode())
+}
+
+func TestTooLongIDsAreABadRequest(t *testing.T) {
+	client := newTestClient(t, newTestServer())
+	ctx := context.Background()
+	tooLongID := strings.Repeat("A", 65)
+
+	stockResponse, err := client.GetProductsIdStockWithResponse(ctx, tooLongID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, stockResponse.StatusCode())
+	require.Contains(t, stockResponse.JSONDefault.Message, "path parameter 'id'")
+
+	commitResponse, err := client.PostReservationsReservationIdCommitWithResponse(ctx, tooLongID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, commitResponse.StatusCode())
+	require.Contains(t, commitResponse.JSONDefault.Message, "path parameter 'reservation_id'")
+}
+
+func TestHealthReadyChecksTheCatalogAndTheInventoryAndFailsWhileShuttingDown(t *testing.T) {
+	server := newTestServer()
+	client := newTestClient(t, server)
+	ctx := context.Background()
+
+	response, err := client.GetHealthReadyWithResponse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode())
+	require.NotNil(t, response.JSON200.Checks)
+	require.Len(t, *response.JSON200.Checks, 2)
+	require.Equal(t, catalogDependencyName, (*response.JSON200.Checks)[0].Name)
+	require.Equal(t, inventoryDependencyName, (*response.JSON200.Checks)[1].Name)
+	for _, check := range *response.JSON200.Checks {
+		require.Equal(t, healthStatusOk, check.Status)
+	}
+
+	server.MarkShuttingDown()
+
+	response, err = client.GetHealthReadyWithResponse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, response.StatusCode())
+	require.Equal(t, healthStatusShuttingDown, *response.JSON503.Status)
+
+	liveResponse, err := client.GetHealthLiveWithResponse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, liveResponse.StatusCode())
+}
+
+func TestHealthReadyFailsWithoutTheCatalog(t *testing.T) {
+	// the catalog file is read relative to the working directory, and there's none in an empty one
+	workingDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(workingDir)) })
+
+	response, err := newTestClient(t, newTestServer()).GetHealthReadyWithResponse(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, response.StatusCode())
+	require.Equal(t, healthStatusError, *response.JSON503.Status)
+	require.Len(t, *response.JSON503.Checks, 2)
+	require.Equal(t, healthStatusError, (*response.JSON503.Checks)[0].Status)
+	require.NotNil(t, (*response.JSON503.Checks)[0].Error)
+}
+
+func TestCheckCatalog(t *testing.T) {
+	server := newTestServer()
+	require.NoError(t, server.checkCatalog(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, server.checkCatalog(ctx), context.Canceled)
+}
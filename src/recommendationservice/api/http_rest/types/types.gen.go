@@ -0,0 +1,77 @@
+// Package recommendationservice_rest_types provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.1-0.20240604070534-2f0ff757704b DO NOT EDIT.
+package recommendationservice_rest_types
+
+import (
+	"time"
+)
+
+// Defines values for ResponseType.
+const (
+	ERROR   ResponseType = "ERROR"
+	INFO    ResponseType = "INFO"
+	WARNING ResponseType = "WARNING"
+)
+
+// DependencyCheck defines model for DependencyCheck.
+type DependencyCheck struct {
+	Error     *string `json:"error,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+}
+
+// HealthResponse defines model for HealthResponse.
+type HealthResponse struct {
+	Checks    *[]DependencyCheck `json:"checks,omitempty"`
+	Status    *string            `json:"status,omitempty"`
+	Timestamp *time.Time         `json:"timestamp,omitempty"`
+}
+
+// ListRecommendationsRequest defines model for ListRecommendationsRequest.
+type ListRecommendationsRequest struct {
+	// Limit the maximum number of products returned, the service's default when it's not set
+	Limit *int32 `json:"limit,omitempty"`
+
+	// ProductIds the products to recommend from, e.g. the ones in the cart or the one being viewed
+	ProductIds []string `json:"product_ids"`
+}
+
+// ListRecommendationsResponse defines model for ListRecommendationsResponse.
+type ListRecommendationsResponse struct {
+	Products []Product `json:"products"`
+}
+
+// Money defines model for Money.
+type Money struct {
+	CurrencyCode string `json:"currency_code"`
+	Nanos        int32  `json:"nanos"`
+	Units        int64  `json:"units"`
+}
+
+// Product defines model for Product.
+type Product struct {
+	Categories  []string `json:"categories"`
+	Description string   `json:"description"`
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Picture     string   `json:"picture"`
+	PriceUsd    Money    `json:"price_usd"`
+}
+
+// ResponseInfo defines model for ResponseInfo.
+type ResponseInfo struct {
+	Code    uint32       `json:"code"`
+	Message string       `json:"message"`
+	Type    ResponseType `json:"type"`
+}
+
+// ResponseType defines model for ResponseType.
+type ResponseType string
+
+// NotOk defines model for NotOk.
+type NotOk = ResponseInfo
+
+// PostRecommendationsJSONRequestBody defines body for PostRecommendations for application/json ContentType.
+type PostRecommendationsJSONRequestBody = ListRecommendationsRequest
@@ -0,0 +1,338 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/configloader"
+	"github.com/kurtosis-tech/new-obd/src/libs/consts"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	"github.com/kurtosis-tech/new-obd/src/recommendationservice/recommendation"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultHost        = "0.0.0.0"
+	defaultPort uint16 = 8020
+
+	defaultShutdownTimeout = 15 * time.Second
+	// the readiness probes of the manifests run every 10s
+	defaultDrainDelay = 10 * time.Second
+
+	defaultProductCatalogServicePort uint16 = 8070
+	defaultOrderServicePort          uint16 = 8000
+
+	defaultRecommendationLimit = 4
+
+	defaultStatsOrders = recommendation.MaxStatsOrders
+	defaultStatsTTL    = time.Minute
+
+	headerListSeparator = ","
+)
+
+// defaultPropagatedHeaders are the headers of the requests forwarded to the product catalog and order services, so the
+// Kardinal flow and trace reach them
+var defaultPropagatedHeaders = []string{
+	consts.KardinalTraceIdHeaderKey,
+	consts.KardinalFlowIdHeaderKey,
+	consts.RequestIdHeaderKey,
+	consts.BaggageHeaderKey,
+}
+
+// defaultFlowIDHeaders are the same as the order service's, so the statistics of a flow are built from its own orders
+var defaultFlowIDHeaders = []string{consts.KardinalFlowIdHeaderKey, consts.KardinalTraceIdHeaderKey}
+
+// Config is the configuration of the recommendation service, run it with --help to list the settings
+type Config struct {
+	Host string
+	Port uint16
+	// ShutdownTimeout is the deadline to drain the in-flight requests when the service stops
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long the service keeps serving after it stops reporting ready, before it shuts down
+	DrainDelay time.Duration
+
+	ProductCatalogServiceHost string
+	ProductCatalogServicePort uint16
+	OrderServiceHost          string
+	OrderServicePort          uint16
+
+	// RecommendationLimit is the number of products recommended when the request doesn't set a limit
+	RecommendationLimit int
+	// Weights rank the recommended products, see recommendation.Weights
+	Weights recommendation.Weights
+	// StatsOrders is the number of last orders the co-purchase statistics of a flow are built from
+	StatsOrders int
+	// StatsTTL is how long the statistics of a flow are used before they're built again from the orders
+	StatsTTL time.Duration
+	// FlowIDHeaders are the headers the Kardinal flow of a request is read from, the first one set wins
+	FlowIDHeaders []string
+
+	// PropagatedHeaders are the headers of the inbound requests that are set on all the outbound ones
+	PropagatedHeaders []string
+	Tracing           tracing.Config
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Host:                      defaultHost,
+		Port:                      defaultPort,
+		ShutdownTimeout:           defaultShutdownTimeout,
+		DrainDelay:                defaultDrainDelay,
+		ProductCatalogServicePort: defaultProductCatalogServicePort,
+		OrderServicePort:          defaultOrderServicePort,
+		RecommendationLimit:       defaultRecommendationLimit,
+		Weights:                   recommendation.DefaultWeights(),
+		StatsOrders:               defaultStatsOrders,
+		StatsTTL:                  defaultStatsTTL,
+		FlowIDHeaders:             defaultFlowIDHeaders,
+		PropagatedHeaders:         defaultPropagatedHeaders,
+		Tracing:                   tracing.DefaultConfig(),
+	}
+}
+
+// Address is the address the REST API server listens on
+func (cfg *Config) Address() string {
+	return net.JoinHostPort(cfg.Host, strconv.Itoa(int(cfg.Port)))
+}
+
+// ProductCatalogServiceURL is the base URL of the product catalog service REST API
+func (cfg *Config) ProductCatalogServiceURL() string {
+	return serviceURL(cfg.ProductCatalogServiceHost, cfg.ProductCatalogServicePort)
+}
+
+// OrderServiceURL is the base URL of the order service REST API
+func (cfg *Config) OrderServiceURL() string {
+	return serviceURL(cfg.OrderServiceHost, cfg.OrderServicePort)
+}
+
+func serviceURL(host string, port uint16) string {
+	return fmt.Sprintf("http://%s", net.JoinHostPort(host, strconv.Itoa(int(port))))
+}
+
+var settings = append([]setting{
+	{
+		Key:         "host",
+		EnvVar:      "HOST",
+		Description: "IP the REST API server listens on",
+		Get:         func(cfg *Config) string { return cfg.Host },
+		Set:         func(cfg *Config, value string) error { cfg.Host = value; return nil },
+	},
+	{
+		Key:         "port",
+		EnvVar:      "PORT",
+		Description: "port the REST API server listens on",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.Port)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.Port = port
+			return err
+		},
+	},
+	{
+		Key:         "shutdown-timeout",
+		EnvVar:      "SHUTDOWN_TIMEOUT",
+		Description: "deadline to drain the in-flight requests when the service stops",
+		Get:         func(cfg *Config) string { return cfg.ShutdownTimeout.String() },
+		Set: func(cfg *Config, value string) error {
+			timeout, err := configloader.ParseDuration(value)
+			cfg.ShutdownTimeout = timeout
+			return err
+		},
+	},
+	{
+		Key:         "drain-delay",
+		EnvVar:      "DRAIN_DELAY",
+		Description: "time the service keeps serving after it stops reporting ready on shutdown, at least the readiness probe period",
+		Get:         func(cfg *Config) string { return cfg.DrainDelay.String() },
+		Set: func(cfg *Config, value string) error {
+			delay, err := configloader.ParseDuration(value)
+			cfg.DrainDelay = delay
+			return err
+		},
+	},
+	{
+		Key:         "product-catalog-service-host",
+		EnvVar:      "PRODUCTCATALOGSERVICEHOST",
+		Description: "host of the product catalog service",
+		Get:         func(cfg *Config) string { return cfg.ProductCatalogServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.ProductCatalogServiceHost = value; return nil },
+	},
+	{
+		Key:         "product-catalog-service-port",
+		EnvVar:      "PRODUCTCATALOGSERVICEPORT",
+		Description: "port of the product catalog service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.ProductCatalogServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.ProductCatalogServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "order-service-host",
+		EnvVar:      "ORDERSERVICEHOST",
+		Description: "host of the order service",
+		Get:         func(cfg *Config) string { return cfg.OrderServiceHost },
+		Set:         func(cfg *Config, value string) error { cfg.OrderServiceHost = value; return nil },
+	},
+	{
+		Key:         "order-service-port",
+		EnvVar:      "ORDERSERVICEPORT",
+		Description: "port of the order service",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.OrderServicePort)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.OrderServicePort = port
+			return err
+		},
+	},
+	{
+		Key:         "recommendation-limit",
+		EnvVar:      "RECOMMENDATION_LIMIT",
+		Description: "number of products recommended when the request doesn't set a limit",
+		Get:         func(cfg *Config) string { return strconv.Itoa(cfg.RecommendationLimit) },
+		Set: func(cfg *Config, value string) error {
+			limit, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return errors.Errorf("'%s' is not a number", value)
+			}
+			cfg.RecommendationLimit = limit
+			return nil
+		},
+	},
+	{
+		Key:         "co-purchase-weight",
+		EnvVar:      "CO_PURCHASE_WEIGHT",
+		Description: "score of a product for every order it was bought in with the given products",
+		Get:         func(cfg *Config) string { return formatWeight(cfg.Weights.CoPurchase) },
+		Set: func(cfg *Config, value string) error {
+			weight, err := parseWeight(value)
+			cfg.Weights.CoPurchase = weight
+			return err
+		},
+	},
+	{
+		Key:         "category-weight",
+		EnvVar:      "CATEGORY_WEIGHT",
+		Description: "score of a product for every category it shares with the given products",
+		Get:         func(cfg *Config) string { return formatWeight(cfg.Weights.Category) },
+		Set: func(cfg *Config, value string) error {
+			weight, err := parseWeight(value)
+			cfg.Weights.Category = weight
+			return err
+		},
+	},
+	{
+		Key:         "stats-orders",
+		EnvVar:      "STATS_ORDERS",
+		Description: "number of last orders the co-purchase statistics of a flow are built from",
+		Get:         func(cfg *Config) string { return strconv.Itoa(cfg.StatsOrders) },
+		Set: func(cfg *Config, value string) error {
+			orders, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return errors.Errorf("'%s' is not a number", value)
+			}
+			cfg.StatsOrders = orders
+			return nil
+		},
+	},
+	{
+		Key:         "stats-ttl",
+		EnvVar:      "STATS_TTL",
+		Description: "time the co-purchase statistics of a flow are used before they're built again from its orders",
+		Get:         func(cfg *Config) string { return cfg.StatsTTL.String() },
+		Set: func(cfg *Config, value string) error {
+			ttl, err := configloader.ParseDuration(value)
+			cfg.StatsTTL = ttl
+			return err
+		},
+	},
+	{
+		Key:         "flow-id-headers",
+		EnvVar:      "FLOW_ID_HEADERS",
+		Description: "comma-separated headers the Kardinal flow of a request is read from, the same as the order service's",
+		Get:         func(cfg *Config) string { return strings.Join(cfg.FlowIDHeaders, headerListSeparator) },
+		Set: func(cfg *Config, value string) error {
+			headers, err := parseHeaderList(value)
+			cfg.FlowIDHeaders = headers
+			return err
+		},
+	},
+	{
+		Key:         "propagated-headers",
+		EnvVar:      "PROPAGATED_HEADERS",
+		Description: "comma-separated headers of the inbound requests forwarded to the product catalog and order services",
+		Get:         func(cfg *Config) string { return strings.Join(cfg.PropagatedHeaders, headerListSeparator) },
+		Set: func(cfg *Config, value string) error {
+			headers, err := parseHeaderList(value)
+			cfg.PropagatedHeaders = headers
+			return err
+		},
+	},
+}, tracing.Settings(func(cfg *Config) *tracing.Config { return &cfg.Tracing })...)
+
+func (cfg *Config) validate() []string {
+	var violations []string
+	if net.ParseIP(cfg.Host) == nil {
+		violations = append(violations, fmt.Sprintf("'host' must be an IP address, got '%s'", cfg.Host))
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		violations = append(violations, fmt.Sprintf("'shutdown-timeout' must be positive, got '%s'", cfg.ShutdownTimeout))
+	}
+	if cfg.DrainDelay < 0 {
+		violations = append(violations, fmt.Sprintf("'drain-delay' can't be negative, got '%s'", cfg.DrainDelay))
+	}
+	if cfg.ProductCatalogServiceHost == "" {
+		violations = append(violations, "'product-catalog-service-host' is required")
+	}
+	if cfg.OrderServiceHost == "" {
+		violations = append(violations, "'order-service-host' is required")
+	}
+	if cfg.RecommendationLimit < 1 || cfg.RecommendationLimit > recommendation.MaxLimit {
+		violations = append(violations, fmt.Sprintf("'recommendation-limit' must be between 1 and %d, got %d", recommendation.MaxLimit, cfg.RecommendationLimit))
+	}
+	if cfg.Weights.CoPurchase == 0 && cfg.Weights.Category == 0 {
+		violations = append(violations, "'co-purchase-weight' and 'category-weight' can't both be zero")
+	}
+	if cfg.StatsOrders < 1 || cfg.StatsOrders > recommendation.MaxStatsOrders {
+		violations = append(violations, fmt.Sprintf("'stats-orders' must be between 1 and %d, got %d", recommendation.MaxStatsOrders, cfg.StatsOrders))
+	}
+	if cfg.StatsTTL <= 0 {
+		violations = append(violations, fmt.Sprintf("'stats-ttl' must be positive, got '%s'", cfg.StatsTTL))
+	}
+	violations = append(violations, cfg.Tracing.Validate()...)
+	return violations
+}
+
+func parseHeaderList(value string) ([]string, error) {
+	headers := []string{}
+	for _, header := range strings.Split(value, headerListSeparator) {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		if strings.ContainsAny(header, " \t:") {
+			return nil, errors.Errorf("'%s' is not a valid header name", header)
+		}
+		headers = append(headers, textproto.CanonicalMIMEHeaderKey(header))
+	}
+	return headers, nil
+}
+
+// parseWeight accepts the non-negative numbers, e.g. 2 or 0.5
+func parseWeight(value string) (float64, error) {
+	weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(weight) || math.IsInf(weight, 0) || weight < 0 {
+		return 0, errors.Errorf("'%s' is not a non-negative number", value)
+	}
+	return weight, nil
+}
+
+func formatWeight(weight float64) string {
+	return strconv.FormatFloat(weight, 'f', -1, 64)
+}
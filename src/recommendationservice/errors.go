@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kurtosis-tech/new-obd/src/recommendationservice/recommendation"
+	"github.com/pkg/errors"
+)
+
+// statusCodeForError returns the status code of the errors returned by the handlers, based on the recommendation error kinds
+func statusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, recommendation.ErrInvalidArgument):
+		return http.StatusBadRequest
+	case errors.Is(err, recommendation.ErrUnavailable), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
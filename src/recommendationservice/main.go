@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
+	"github.com/kurtosis-tech/new-obd/src/libs/headerpropagation"
+	"github.com/kurtosis-tech/new-obd/src/libs/metrics"
+	"github.com/kurtosis-tech/new-obd/src/libs/rest"
+	"github.com/kurtosis-tech/new-obd/src/libs/shutdown"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	orderservice_rest_client "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/client"
+	productcatalogservice_rest_client "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/client"
+	recommendationservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/recommendationservice/api/http_rest/server"
+	"github.com/kurtosis-tech/new-obd/src/recommendationservice/config"
+	"github.com/kurtosis-tech/new-obd/src/recommendationservice/recommendation"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+)
+
+const (
+	serviceName = "recommendationservice"
+
+	// the services the requests are sent to, they label the metrics and the spans of the requests
+	productCatalogServiceName = "productcatalogservice"
+	orderServiceName          = "orderservice"
+
+	// the exit code of an invalid config, it follows the ones of the shutdown package
+	exitCodeInvalidConfig = 3
+)
+
+var (
+	defaultCORSOrigins = []string{"*"}
+	defaultCORSHeaders = []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept}
+)
+
+func main() {
+	cfg, commandLine, err := config.Load(os.Args[0], os.Args[1:], os.LookupEnv)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitCodeInvalidConfig)
+	}
+
+	if commandLine.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logrus.Fatal(err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), serviceName, cfg.Tracing)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	logrus.Info("Running REST API server...")
+
+	// This is how you set up a basic Echo router
+	echoRouter := echo.New()
+	echoRouter.HTTPErrorHandler = rest.NewResponseInfoHTTPErrorHandler(statusCodeForError)
+	// first, so the duration includes the other middlewares
+	echoRouter.Use(metrics.Middleware)
+	echoRouter.Use(otelecho.Middleware(serviceName, otelecho.WithSkipper(tracing.Skipper)))
+	echoRouter.Use(middleware.Logger())
+
+	echoRouter.Use(tracing.KardinalTraceIDMiddleware)
+	echoRouter.Use(echo.WrapMiddleware(headerpropagation.NewMiddleware(cfg.PropagatedHeaders)))
+	echoRouter.Use(flow.NewMiddleware(cfg.FlowIDHeaders))
+
+	// CORS configuration
+	echoRouter.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: defaultCORSOrigins,
+		AllowHeaders: defaultCORSHeaders,
+	}))
+
+	recommendationService, err := newRecommendationService(cfg)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	requestValidatorMiddleware, err := rest.NewRequestValidatorMiddleware(recommendationservice_server_rest_server.GetSwagger, "")
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	echoRouter.Use(requestValidatorMiddleware)
+
+	server := NewServer(recommendationService)
+
+	metrics.RegisterHandler(echoRouter)
+
+	strictMiddlewares := []recommendationservice_server_rest_server.StrictMiddlewareFunc{rest.NewErrorMappingMiddleware(statusCodeForError)}
+	recommendationservice_server_rest_server.RegisterHandlers(echoRouter, recommendationservice_server_rest_server.NewStrictHandler(server, strictMiddlewares))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	exitCode := shutdown.ServeUntilShutdown(ctx, func() error {
+		return echoRouter.Start(cfg.Address())
+	}, echoRouter.Shutdown, shutdown.Options{
+		OnShutdownStart: server.MarkShuttingDown,
+		DrainDelay:      cfg.DrainDelay,
+		ShutdownTimeout: cfg.ShutdownTimeout,
+	})
+	stop()
+
+	shutdown.FlushTraces(shutdownTracing, cfg.ShutdownTimeout)
+	shutdown.FlushLogs()
+	os.Exit(exitCode)
+}
+
+func newRecommendationService(cfg *config.Config) (*recommendation.Service, error) {
+	productCatalogServiceClient, err := productcatalogservice_rest_client.NewClientWithResponses(cfg.ProductCatalogServiceURL(), productcatalogservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(productCatalogServiceName)))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred creating the product catalog service client")
+	}
+
+	orderServiceClient, err := orderservice_rest_client.NewClientWithResponses(cfg.OrderServiceURL(), orderservice_rest_client.WithHTTPClient(metrics.NewInstrumentedHTTPClient(orderServiceName)))
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred creating the order service client")
+	}
+
+	return recommendation.NewService(
+		recommendation.NewProductCatalogClient(productCatalogServiceClient),
+		recommendation.NewOrderServiceClient(orderServiceClient),
+		cfg.Weights,
+		cfg.RecommendationLimit,
+		cfg.StatsOrders,
+		cfg.StatsTTL,
+	), nil
+}
@@ -0,0 +1,99 @@
+package recommendation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	orderservice_rest_client "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/client"
+	orderservice_rest_types "github.com/kurtosis-tech/new-obd/src/orderservice/api/http_rest/types"
+	productcatalogservice_rest_client "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/client"
+	productcatalogservice_rest_types "github.com/kurtosis-tech/new-obd/src/productcatalogservice/api/http_rest/types"
+	recommendationservice_rest_types "github.com/kurtosis-tech/new-obd/src/recommendationservice/api/http_rest/types"
+)
+
+// ProductCatalogClient is the ProductCatalog backed by the product catalog service REST API
+type ProductCatalogClient struct {
+	client *productcatalogservice_rest_client.ClientWithResponses
+}
+
+func NewProductCatalogClient(client *productcatalogservice_rest_client.ClientWithResponses) *ProductCatalogClient {
+	return &ProductCatalogClient{client: client}
+}
+
+func (c *ProductCatalogClient) ListProducts(ctx context.Context) ([]recommendationservice_rest_types.Product, error) {
+	response, err := c.client.GetProductsWithResponse(ctx)
+	if err != nil {
+		return nil, newRecommendationError(ErrUnavailable, err)
+	}
+	if response.StatusCode() >= http.StatusMultipleChoices {
+		return nil, newRecommendationError(ErrUnavailable, fmt.Errorf("product catalog service responded with status code %d", response.StatusCode()))
+	}
+	if response.JSON200 == nil {
+		return nil, nil
+	}
+
+	products := make([]recommendationservice_rest_types.Product, 0, len(*response.JSON200))
+	for _, product := range *response.JSON200 {
+		// a product without an ID can't be linked to
+		if product.Id == nil {
+			continue
+		}
+		products = append(products, recommendationservice_rest_types.Product{
+			Id:          *product.Id,
+			Name:        valueOrEmpty(product.Name),
+			Description: valueOrEmpty(product.Description),
+			Picture:     valueOrEmpty(product.Picture),
+			PriceUsd:    toMoney(product.PriceUsd),
+			Categories:  valueOrEmpty(product.Categories),
+		})
+	}
+	return products, nil
+}
+
+// OrderServiceClient is the OrderHistory backed by the order service REST API, the flow reaches it through the
+// propagated headers
+type OrderServiceClient struct {
+	client *orderservice_rest_client.ClientWithResponses
+}
+
+func NewOrderServiceClient(client *orderservice_rest_client.ClientWithResponses) *OrderServiceClient {
+	return &OrderServiceClient{client: client}
+}
+
+func (c *OrderServiceClient) ListRecentPurchases(ctx context.Context, limit int) ([]Purchase, error) {
+	response, err := c.client.GetPurchasesWithResponse(ctx, &orderservice_rest_types.GetPurchasesParams{Limit: int32(limit)})
+	if err != nil {
+		return nil, newRecommendationError(ErrUnavailable, err)
+	}
+	if err := orderservice_rest_client.CheckResponse(response, response.JSONDefault); err != nil {
+		return nil, newRecommendationError(ErrUnavailable, err)
+	}
+
+	purchases := make([]Purchase, 0, len(response.JSON200.Purchases))
+	for _, purchase := range response.JSON200.Purchases {
+		purchases = append(purchases, Purchase{
+			ProductIDs: purchase.ProductIds,
+		})
+	}
+	return purchases, nil
+}
+
+func toMoney(m *productcatalogservice_rest_types.Money) recommendationservice_rest_types.Money {
+	if m == nil {
+		return recommendationservice_rest_types.Money{}
+	}
+	return recommendationservice_rest_types.Money{
+		CurrencyCode: valueOrEmpty(m.CurrencyCode),
+		Units:        valueOrEmpty(m.Units),
+		Nanos:        valueOrEmpty(m.Nanos),
+	}
+}
+
+func valueOrEmpty[T any](value *T) T {
+	var empty T
+	if value == nil {
+		return empty
+	}
+	return *value
+}
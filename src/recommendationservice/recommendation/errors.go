@@ -0,0 +1,34 @@
+package recommendation
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of errors returned by the service, check them with errors.Is
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+	ErrUnavailable     = errors.New("unavailable")
+)
+
+// recommendationError tags the cause with one of the error kinds
+type recommendationError struct {
+	kind  error
+	cause error
+}
+
+func newRecommendationError(kind error, cause error) error {
+	return &recommendationError{kind: kind, cause: cause}
+}
+
+func newInvalidArgumentError(format string, args ...interface{}) error {
+	return newRecommendationError(ErrInvalidArgument, fmt.Errorf(format, args...))
+}
+
+func (e *recommendationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.kind.Error(), e.cause.Error())
+}
+
+func (e *recommendationError) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
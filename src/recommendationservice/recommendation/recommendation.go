@@ -0,0 +1,164 @@
+// Package recommendation recommends the products of the catalog related to other products, based on the products
+// bought together in the last orders and on the categories they share
+package recommendation
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
+	recommendationservice_rest_types "github.com/kurtosis-tech/new-obd/src/recommendationservice/api/http_rest/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultCoPurchaseWeight = 2
+	defaultCategoryWeight   = 1
+
+	// MaxLimit is the maximum number of products returned by ListRecommendations
+	MaxLimit = 20
+	// MaxStatsOrders is the maximum number of orders the statistics are built from, the order service doesn't list more
+	MaxStatsOrders = 1000
+)
+
+// ProductCatalog lists the products that can be recommended
+type ProductCatalog interface {
+	ListProducts(ctx context.Context) ([]recommendationservice_rest_types.Product, error)
+}
+
+// OrderHistory lists the products bought in the last orders of the flow of the context, at most limit of them
+type OrderHistory interface {
+	ListRecentPurchases(ctx context.Context, limit int) ([]Purchase, error)
+}
+
+// Weights rank the products: the score of a product is the number of orders it was bought in with the given products
+// times CoPurchase, plus the number of categories it shares with them times Category
+type Weights struct {
+	CoPurchase float64
+	Category   float64
+}
+
+// DefaultWeights make a product bought with the given ones rank above a product only sharing their categories
+func DefaultWeights() Weights {
+	return Weights{
+		CoPurchase: defaultCoPurchaseWeight,
+		Category:   defaultCategoryWeight,
+	}
+}
+
+type Service struct {
+	catalog ProductCatalog
+	orders  OrderHistory
+	weights Weights
+	// defaultLimit is the number of products returned when the request doesn't set a limit
+	defaultLimit int
+	// statsOrders is the number of last orders the statistics of a flow are built from
+	statsOrders int
+	stats       *statsCache
+}
+
+// NewService returns a service whose statistics are built from the last statsOrders orders of each flow and
+// rebuilt once they're older than statsTTL
+func NewService(catalog ProductCatalog, orders OrderHistory, weights Weights, defaultLimit int, statsOrders int, statsTTL time.Duration) *Service {
+	return &Service{
+		catalog:      catalog,
+		orders:       orders,
+		weights:      weights,
+		defaultLimit: defaultLimit,
+		statsOrders:  statsOrders,
+		stats:        newStatsCache(statsTTL),
+	}
+}
+
+// candidate is a product that can be recommended, with what it's ranked by
+type candidate struct {
+	product   recommendationservice_rest_types.Product
+	score     float64
+	purchases int
+}
+
+// ListRecommendations returns the products with the best scores, the most bought first when the scores are equal and
+// then in the order of the catalog, so there are recommendations even before the first order
+func (s *Service) ListRecommendations(ctx context.Context, request recommendationservice_rest_types.ListRecommendationsRequest) (*recommendationservice_rest_types.ListRecommendationsResponse, error) {
+	limit := s.defaultLimit
+	if request.Limit != nil {
+		limit = int(*request.Limit)
+	}
+	if limit < 1 || limit > MaxLimit {
+		return nil, newInvalidArgumentError("the limit must be between 1 and %d, got %d", MaxLimit, limit)
+	}
+
+	products, err := s.catalog.ListProducts(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred listing the products of the catalog")
+	}
+
+	given := toSet(request.ProductIds)
+	givenCategories := map[string]struct{}{}
+	for _, product := range products {
+		if _, found := given[product.Id]; found {
+			for _, category := range product.Categories {
+				givenCategories[category] = struct{}{}
+			}
+		}
+	}
+	coPurchases, purchases := s.flowStats(ctx).counts(given)
+
+	candidates := make([]candidate, 0, len(products))
+	for _, product := range products {
+		if _, found := given[product.Id]; found {
+			continue
+		}
+		candidates = append(candidates, candidate{
+			product:   product,
+			score:     s.weights.CoPurchase*float64(coPurchases[product.Id]) + s.weights.Category*float64(sharedCategories(product, givenCategories)),
+			purchases: purchases[product.Id],
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].purchases > candidates[j].purchases
+	})
+
+	recommended := make([]recommendationservice_rest_types.Product, 0, limit)
+	for _, candidate := range candidates {
+		if len(recommended) == limit {
+			break
+		}
+		recommended = append(recommended, candidate.product)
+	}
+	return &recommendationservice_rest_types.ListRecommendationsResponse{Products: recommended}, nil
+}
+
+// flowStats returns the statistics of the flow of the context. They're empty while the orders can't be listed, the
+// products are then only ranked by their categories.
+func (s *Service) flowStats(ctx context.Context) *Stats {
+	flowID := flow.IDFromContext(ctx)
+	if stats, found := s.stats.get(flowID); found {
+		return stats
+	}
+
+	purchases, err := s.orders.ListRecentPurchases(ctx, s.statsOrders)
+	if err != nil {
+		// not cached, so the next request lists the orders again
+		logrus.Warnf("The last orders of flow '%s' couldn't be listed, the recommendations are only based on the categories. Error: %s", flowID, err)
+		return NewStats(nil)
+	}
+	stats := NewStats(purchases)
+	s.stats.put(flowID, stats)
+	return stats
+}
+
+func sharedCategories(product recommendationservice_rest_types.Product, categories map[string]struct{}) int {
+	shared := 0
+	for _, category := range product.Categories {
+		if _, found := categories[category]; found {
+			shared++
+		}
+	}
+	return shared
+}
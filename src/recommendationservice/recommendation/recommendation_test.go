@@ -0,0 +1,220 @@
+package recommendation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/flow"
+	recommendationservice_rest_types "github.com/kurtosis-tech/new-obd/src/recommendationservice/api/http_rest/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testStatsTTL = time.Minute
+
+type fakeCatalog struct {
+	products []recommendationservice_rest_types.Product
+	err      error
+}
+
+func (c *fakeCatalog) ListProducts(ctx context.Context) ([]recommendationservice_rest_types.Product, error) {
+	return c.products, c.err
+}
+
+// fakeOrders keeps the purchases of each flow, the most recent first like the order service
+type fakeOrders struct {
+	mutex     sync.Mutex
+	purchases map[string][]Purchase
+	err       error
+	// listed is the number of times the purchases were listed
+	listed int
+}
+
+func newFakeOrders() *fakeOrders {
+	return &fakeOrders{purchases: map[string][]Purchase{}}
+}
+
+func (o *fakeOrders) ListRecentPurchases(ctx context.Context, limit int) ([]Purchase, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.listed++
+	if o.err != nil {
+		return nil, o.err
+	}
+	purchases := o.purchases[flow.IDFromContext(ctx)]
+	if len(purchases) > limit {
+		purchases = purchases[:limit]
+	}
+	return purchases, nil
+}
+
+func (o *fakeOrders) addPurchase(flowID string, productIDs ...string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.purchases[flowID] = append([]Purchase{{ProductIDs: productIDs}}, o.purchases[flowID]...)
+}
+
+func newTestProduct(id string, categories ...string) recommendationservice_rest_types.Product {
+	return recommendationservice_rest_types.Product{Id: id, Name: id, Categories: categories}
+}
+
+func newTestService(orders *fakeOrders) *Service {
+	catalog := &fakeCatalog{products: []recommendationservice_rest_types.Product{
+		newTestProduct("sunglasses", "accessories"),
+		newTestProduct("tank-top", "clothing", "tops"),
+		newTestProduct("watch", "accessories"),
+		newTestProduct("loafers", "footwear"),
+		newTestProduct("hairdryer", "hair", "beauty"),
+		newTestProduct("candle-holder", "decor", "home"),
+	}}
+	return NewService(catalog, orders, DefaultWeights(), 3, MaxStatsOrders, testStatsTTL)
+}
+
+func listRecommendations(t *testing.T, service *Service, productIDs ...string) []string {
+	return listFlowRecommendations(t, service, context.Background(), productIDs...)
+}
+
+func listFlowRecommendations(t *testing.T, service *Service, ctx context.Context, productIDs ...string) []string {
+	response, err := service.ListRecommendations(ctx, recommendationservice_rest_types.ListRecommendationsRequest{
+		ProductIds: productIDs,
+	})
+	require.NoError(t, err)
+	ids := []string{}
+	for _, product := range response.Products {
+		ids = append(ids, product.Id)
+	}
+	return ids
+}
+
+func TestListRecommendationsRanksTheSharedCategoriesFirst(t *testing.T) {
+	service := newTestService(newFakeOrders())
+
+	// without orders, the products sharing a category come first and the others keep the catalog order
+	require.Equal(t, []string{"watch", "tank-top", "loafers"}, listRecommendations(t, service, "sunglasses"))
+}
+
+func TestListRecommendationsRanksTheCoPurchasesAboveTheCategories(t *testing.T) {
+	orders := newFakeOrders()
+	orders.addPurchase(flow.BaselineID, "sunglasses", "hairdryer")
+	orders.addPurchase(flow.BaselineID, "sunglasses", "hairdryer", "loafers")
+	service := newTestService(orders)
+
+	require.Equal(t, []string{"hairdryer", "loafers", "watch"}, listRecommendations(t, service, "sunglasses"))
+}
+
+func TestListRecommendationsWithoutProductsReturnsTheMostBought(t *testing.T) {
+	orders := newFakeOrders()
+	orders.addPurchase(flow.BaselineID, "candle-holder")
+	orders.addPurchase(flow.BaselineID, "candle-holder", "watch")
+	orders.addPurchase(flow.BaselineID, "candle-holder", "watch")
+	service := newTestService(orders)
+
+	require.Equal(t, []string{"candle-holder", "watch", "sunglasses"}, listRecommendations(t, service))
+}
+
+func TestListRecommendationsNeverReturnsTheGivenProducts(t *testing.T) {
+	orders := newFakeOrders()
+	orders.addPurchase(flow.BaselineID, "sunglasses", "watch")
+	service := newTestService(orders)
+
+	recommended := listRecommendations(t, service, "sunglasses", "watch", "unknown")
+	require.NotContains(t, recommended, "sunglasses")
+	require.NotContains(t, recommended, "watch")
+	require.Len(t, recommended, 3)
+}
+
+func TestListRecommendationsRejectsAnInvalidLimit(t *testing.T) {
+	service := newTestService(newFakeOrders())
+
+	for _, limit := range []int32{0, MaxLimit + 1} {
+		_, err := service.ListRecommendations(context.Background(), recommendationservice_rest_types.ListRecommendationsRequest{
+			ProductIds: []string{},
+			Limit:      &limit,
+		})
+		require.ErrorIs(t, err, ErrInvalidArgument)
+	}
+}
+
+func TestListRecommendationsFailsWhenTheCatalogFails(t *testing.T) {
+	catalogErr := newRecommendationError(ErrUnavailable, errors.New("connection refused"))
+	service := NewService(&fakeCatalog{err: catalogErr}, newFakeOrders(), DefaultWeights(), 3, MaxStatsOrders, testStatsTTL)
+
+	_, err := service.ListRecommendations(context.Background(), recommendationservice_rest_types.ListRecommendationsRequest{})
+	require.ErrorIs(t, err, ErrUnavailable)
+}
+
+func TestListRecommendationsFallsBackToTheCategoriesWhenTheOrdersAreUnavailable(t *testing.T) {
+	orders := newFakeOrders()
+	orders.addPurchase(flow.BaselineID, "sunglasses", "hairdryer")
+	orders.err = newRecommendationError(ErrUnavailable, errors.New("connection refused"))
+	service := newTestService(orders)
+
+	require.Equal(t, []string{"watch", "tank-top", "loafers"}, listRecommendations(t, service, "sunglasses"))
+
+	// the fallback isn't cached, the co-purchases are back as soon as the orders are
+	orders.err = nil
+	require.Equal(t, []string{"hairdryer", "watch", "tank-top"}, listRecommendations(t, service, "sunglasses"))
+}
+
+func TestListRecommendationsUsesTheOrdersOfTheFlow(t *testing.T) {
+	orders := newFakeOrders()
+	orders.addPurchase(flow.BaselineID, "sunglasses", "hairdryer")
+	orders.addPurchase("dev-flow", "sunglasses", "candle-holder")
+	service := newTestService(orders)
+
+	require.Equal(t, []string{"hairdryer", "watch", "tank-top"}, listRecommendations(t, service, "sunglasses"))
+	flowCtx := flow.ContextWithID(context.Background(), "dev-flow")
+	require.Equal(t, []string{"candle-holder", "watch", "tank-top"}, listFlowRecommendations(t, service, flowCtx, "sunglasses"))
+}
+
+func TestListRecommendationsRebuildsTheStatsOnceTheyExpire(t *testing.T) {
+	orders := newFakeOrders()
+	service := newTestService(orders)
+	now := time.Now()
+	service.stats.now = func() time.Time { return now }
+
+	require.Equal(t, []string{"watch", "tank-top", "loafers"}, listRecommendations(t, service, "sunglasses"))
+	orders.addPurchase(flow.BaselineID, "sunglasses", "hairdryer")
+
+	// the statistics are cached, the new order only counts once they expire
+	require.Equal(t, []string{"watch", "tank-top", "loafers"}, listRecommendations(t, service, "sunglasses"))
+	require.Equal(t, 1, orders.listed)
+
+	now = now.Add(testStatsTTL)
+	require.Equal(t, []string{"hairdryer", "watch", "tank-top"}, listRecommendations(t, service, "sunglasses"))
+	require.Equal(t, 2, orders.listed)
+}
+
+func TestNewStatsCountsAProductOncePerOrder(t *testing.T) {
+	stats := NewStats([]Purchase{
+		{ProductIDs: []string{"sunglasses", "loafers", "loafers"}},
+		{ProductIDs: []string{"sunglasses", "hairdryer"}},
+	})
+
+	coPurchases, purchases := stats.counts(map[string]struct{}{"sunglasses": {}})
+	require.Equal(t, map[string]int{"loafers": 1, "hairdryer": 1}, coPurchases)
+	require.Equal(t, map[string]int{"sunglasses": 2, "loafers": 1, "hairdryer": 1}, purchases)
+}
+
+func TestStatsCacheHoldsAtMostMaxCachedFlows(t *testing.T) {
+	cache := newStatsCache(testStatsTTL)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < maxCachedFlows+10; i++ {
+		cache.put(fmt.Sprintf("flow-%d", i), NewStats(nil))
+		now = now.Add(time.Millisecond)
+	}
+	require.Len(t, cache.entries, maxCachedFlows)
+
+	// the oldest flows were dropped first
+	_, found := cache.get("flow-0")
+	require.False(t, found)
+	_, found = cache.get(fmt.Sprintf("flow-%d", maxCachedFlows+9))
+	require.True(t, found)
+}
@@ -0,0 +1,125 @@
+package recommendation
+
+import (
+	"sync"
+	"time"
+)
+
+// maxCachedFlows bounds the statistics kept in memory, a flow whose statistics were dropped only has them built again
+const maxCachedFlows = 100
+
+// Purchase are the products bought together in an order
+type Purchase struct {
+	ProductIDs []string
+}
+
+// Stats are the co-purchase statistics of a set of orders, they're never modified once built
+type Stats struct {
+	// purchases is the number of orders with each product
+	purchases map[string]int
+	// coPurchases is the number of orders with both products, in both directions
+	coPurchases map[string]map[string]int
+}
+
+// NewStats counts the products bought together in the purchases. A product listed twice in a purchase is counted once.
+func NewStats(purchases []Purchase) *Stats {
+	stats := &Stats{
+		purchases:   map[string]int{},
+		coPurchases: map[string]map[string]int{},
+	}
+	for _, purchase := range purchases {
+		products := toSet(purchase.ProductIDs)
+		for productID := range products {
+			stats.purchases[productID]++
+			for otherID := range products {
+				if otherID == productID {
+					continue
+				}
+				if stats.coPurchases[productID] == nil {
+					stats.coPurchases[productID] = map[string]int{}
+				}
+				stats.coPurchases[productID][otherID]++
+			}
+		}
+	}
+	return stats
+}
+
+// counts returns, for every product, the number of orders it was bought in with each of the given products, summed,
+// and the number of orders it was bought in
+func (s *Stats) counts(productIDs map[string]struct{}) (map[string]int, map[string]int) {
+	coPurchases := map[string]int{}
+	for productID := range productIDs {
+		for otherID, count := range s.coPurchases[productID] {
+			coPurchases[otherID] += count
+		}
+	}
+	return coPurchases, s.purchases
+}
+
+// statsCache keeps the statistics of each flow for the TTL, so the orders aren't listed on every request. It holds at
+// most maxCachedFlows flows, the expired statistics are dropped first and then the oldest ones.
+type statsCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedStats
+
+	now func() time.Time
+}
+
+type cachedStats struct {
+	stats   *Stats
+	builtAt time.Time
+}
+
+func newStatsCache(ttl time.Duration) *statsCache {
+	return &statsCache{
+		ttl:     ttl,
+		entries: map[string]cachedStats{},
+		now:     time.Now,
+	}
+}
+
+// get returns the statistics of the flow unless they're missing or expired
+func (c *statsCache) get(flowID string) (*Stats, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, found := c.entries[flowID]
+	if !found || c.now().Sub(entry.builtAt) >= c.ttl {
+		return nil, false
+	}
+	return entry.stats, true
+}
+
+func (c *statsCache) put(flowID string, stats *Stats) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	if _, found := c.entries[flowID]; !found && len(c.entries) >= maxCachedFlows {
+		oldestFlowID := ""
+		var oldestBuiltAt time.Time
+		for cachedFlowID, entry := range c.entries {
+			if now.Sub(entry.builtAt) >= c.ttl {
+				delete(c.entries, cachedFlowID)
+				continue
+			}
+			if oldestBuiltAt.IsZero() || entry.builtAt.Before(oldestBuiltAt) {
+				oldestFlowID, oldestBuiltAt = cachedFlowID, entry.builtAt
+			}
+		}
+		if len(c.entries) >= maxCachedFlows {
+			delete(c.entries, oldestFlowID)
+		}
+	}
+	c.entries[flowID] = cachedStats{stats: stats, builtAt: now}
+}
+
+func toSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	return set
+}
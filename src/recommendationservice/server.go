@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	recommendationservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/recommendationservice/api/http_rest/server"
+	recommendationservice_rest_types "github.com/kurtosis-tech/new-obd/src/recommendationservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/recommendationservice/recommendation"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	healthStatusOk           = "ok"
+	healthStatusShuttingDown = "shutting down"
+)
+
+type Server struct {
+	Recommendation *recommendation.Service
+	// shuttingDown is a pointer so every copy of the server (the handlers have value receivers) sees the same flag
+	shuttingDown *atomic.Bool
+}
+
+func NewServer(recommendationService *recommendation.Service) Server {
+	return Server{Recommendation: recommendationService, shuttingDown: &atomic.Bool{}}
+}
+
+// MarkShuttingDown makes the readiness check fail, so the service stops receiving traffic while it shuts down
+func (s Server) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s Server) GetHealth(ctx context.Context, request recommendationservice_server_rest_server.GetHealthRequestObject) (recommendationservice_server_rest_server.GetHealthResponseObject, error) {
+
+	status := healthStatusOk
+	now := time.Now()
+
+	response := recommendationservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return recommendationservice_server_rest_server.GetHealth200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthLive(ctx context.Context, request recommendationservice_server_rest_server.GetHealthLiveRequestObject) (recommendationservice_server_rest_server.GetHealthLiveResponseObject, error) {
+	status := healthStatusOk
+	now := time.Now()
+
+	response := recommendationservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return recommendationservice_server_rest_server.GetHealthLive200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthReady(ctx context.Context, request recommendationservice_server_rest_server.GetHealthReadyRequestObject) (recommendationservice_server_rest_server.GetHealthReadyResponseObject, error) {
+	now := time.Now()
+
+	if s.shuttingDown.Load() {
+		status := healthStatusShuttingDown
+		response := recommendationservice_rest_types.HealthResponse{
+			Status:    &status,
+			Timestamp: &now,
+		}
+		return recommendationservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+
+	// the statistics are kept in memory and the catalog is checked by its own service, there's no dependency to check
+	checks := []recommendationservice_rest_types.DependencyCheck{}
+	status := healthStatusOk
+
+	response := recommendationservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+		Checks:    &checks,
+	}
+
+	return recommendationservice_server_rest_server.GetHealthReady200JSONResponse(response), nil
+}
+
+func (s Server) PostRecommendations(ctx context.Context, request recommendationservice_server_rest_server.PostRecommendationsRequestObject) (recommendationservice_server_rest_server.PostRecommendationsResponseObject, error) {
+	logrus.Infof("List recommendations request - Products: %d", len(request.Body.ProductIds))
+	response, err := s.Recommendation.ListRecommendations(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return recommendationservice_server_rest_server.PostRecommendations200JSONResponse(*response), nil
+}
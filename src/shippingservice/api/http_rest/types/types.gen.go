@@ -0,0 +1,101 @@
+// Package shippingservice_rest_types provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.2.1-0.20240604070534-2f0ff757704b DO NOT EDIT.
+package shippingservice_rest_types
+
+import (
+	"time"
+)
+
+// Defines values for ResponseType.
+const (
+	ERROR   ResponseType = "ERROR"
+	INFO    ResponseType = "INFO"
+	WARNING ResponseType = "WARNING"
+)
+
+// Address defines model for Address.
+type Address struct {
+	City          string  `json:"city"`
+	Country       string  `json:"country"`
+	State         *string `json:"state,omitempty"`
+	StreetAddress string  `json:"street_address"`
+	ZipCode       string  `json:"zip_code"`
+}
+
+// CartItem defines model for CartItem.
+type CartItem struct {
+	ProductId string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// CurrencyCode ISO 4217 currency code
+type CurrencyCode = string
+
+// DependencyCheck defines model for DependencyCheck.
+type DependencyCheck struct {
+	Error     *string `json:"error,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+}
+
+// HealthResponse defines model for HealthResponse.
+type HealthResponse struct {
+	Checks    *[]DependencyCheck `json:"checks,omitempty"`
+	Status    *string            `json:"status,omitempty"`
+	Timestamp *time.Time         `json:"timestamp,omitempty"`
+}
+
+// Money defines model for Money.
+type Money struct {
+	// CurrencyCode ISO 4217 currency code
+	CurrencyCode CurrencyCode `json:"currency_code"`
+
+	// Nanos the nano units of the amount, with the same sign as the units
+	Nanos int32 `json:"nanos"`
+
+	// Units the whole units of the amount
+	Units int64 `json:"units"`
+}
+
+// QuoteRequest defines model for QuoteRequest.
+type QuoteRequest struct {
+	Address *Address   `json:"address,omitempty"`
+	Items   []CartItem `json:"items"`
+}
+
+// QuoteResponse defines model for QuoteResponse.
+type QuoteResponse struct {
+	CostUsd Money `json:"cost_usd"`
+}
+
+// ResponseInfo defines model for ResponseInfo.
+type ResponseInfo struct {
+	Code    uint32       `json:"code"`
+	Message string       `json:"message"`
+	Type    ResponseType `json:"type"`
+}
+
+// ResponseType defines model for ResponseType.
+type ResponseType string
+
+// ShipOrderRequest defines model for ShipOrderRequest.
+type ShipOrderRequest struct {
+	Address Address    `json:"address"`
+	Items   []CartItem `json:"items"`
+}
+
+// ShipOrderResponse defines model for ShipOrderResponse.
+type ShipOrderResponse struct {
+	TrackingId string `json:"tracking_id"`
+}
+
+// NotOk defines model for NotOk.
+type NotOk = ResponseInfo
+
+// PostQuoteJSONRequestBody defines body for PostQuote for application/json ContentType.
+type PostQuoteJSONRequestBody = QuoteRequest
+
+// PostShipJSONRequestBody defines body for PostShip for application/json ContentType.
+type PostShipJSONRequestBody = ShipOrderRequest
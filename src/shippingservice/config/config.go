@@ -0,0 +1,246 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/configloader"
+	"github.com/kurtosis-tech/new-obd/src/libs/tracing"
+	shippingservice_rest_types "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/shippingservice/shipping"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultHost        = "0.0.0.0"
+	defaultPort uint16 = 8040
+
+	defaultShutdownTimeout = 15 * time.Second
+	// the readiness probes of the manifests run every 10s
+	defaultDrainDelay = 10 * time.Second
+
+	listSeparator          = ","
+	tierSeparator          = ":"
+	maxFractionDigits      = 9
+	minPrintFractionDigits = 2
+)
+
+// Config is the configuration of the shipping service, run it with --help to list the settings
+type Config struct {
+	Host string
+	Port uint16
+	// ShutdownTimeout is the deadline to drain the in-flight requests when the service stops
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long the service keeps serving after it stops reporting ready, before it shuts down
+	DrainDelay time.Duration
+	// QuoteRules compute the shipping costs, see shipping.Rules
+	QuoteRules shipping.Rules
+	Tracing    tracing.Config
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Host:            defaultHost,
+		Port:            defaultPort,
+		ShutdownTimeout: defaultShutdownTimeout,
+		DrainDelay:      defaultDrainDelay,
+		QuoteRules:      shipping.DefaultRules(),
+		Tracing:         tracing.DefaultConfig(),
+	}
+}
+
+// Address is the address the REST API server listens on
+func (cfg *Config) Address() string {
+	return net.JoinHostPort(cfg.Host, strconv.Itoa(int(cfg.Port)))
+}
+
+var settings = append([]setting{
+	{
+		Key:         "host",
+		EnvVar:      "HOST",
+		Description: "IP the REST API server listens on",
+		Get:         func(cfg *Config) string { return cfg.Host },
+		Set:         func(cfg *Config, value string) error { cfg.Host = value; return nil },
+	},
+	{
+		Key:         "port",
+		EnvVar:      "PORT",
+		Description: "port the REST API server listens on",
+		Get:         func(cfg *Config) string { return strconv.Itoa(int(cfg.Port)) },
+		Set: func(cfg *Config, value string) error {
+			port, err := configloader.ParsePort(value)
+			cfg.Port = port
+			return err
+		},
+	},
+	{
+		Key:         "shutdown-timeout",
+		EnvVar:      "SHUTDOWN_TIMEOUT",
+		Description: "deadline to drain the in-flight requests when the service stops",
+		Get:         func(cfg *Config) string { return cfg.ShutdownTimeout.String() },
+		Set: func(cfg *Config, value string) error {
+			timeout, err := configloader.ParseDuration(value)
+			cfg.ShutdownTimeout = timeout
+			return err
+		},
+	},
+	{
+		Key:         "drain-delay",
+		EnvVar:      "DRAIN_DELAY",
+		Description: "time the service keeps serving after it stops reporting ready on shutdown, at least the readiness probe period",
+		Get:         func(cfg *Config) string { return cfg.DrainDelay.String() },
+		Set: func(cfg *Config, value string) error {
+			delay, err := configloader.ParseDuration(value)
+			cfg.DrainDelay = delay
+			return err
+		},
+	},
+	{
+		Key:         "quote-tiers",
+		EnvVar:      "QUOTE_TIERS",
+		Description: "comma-separated shipping costs in USD by number of items, e.g. 2:4.99 for the orders of up to 2 items",
+		Get:         func(cfg *Config) string { return formatTiers(cfg.QuoteRules.Tiers) },
+		Set: func(cfg *Config, value string) error {
+			tiers, err := parseTiers(value)
+			cfg.QuoteRules.Tiers = tiers
+			return err
+		},
+	},
+	{
+		Key:         "quote-extra-item-cost",
+		EnvVar:      "QUOTE_EXTRA_ITEM_COST",
+		Description: "shipping cost in USD of every item above the last tier",
+		Get:         func(cfg *Config) string { return formatUSD(cfg.QuoteRules.ExtraItemCost) },
+		Set: func(cfg *Config, value string) error {
+			cost, err := parseUSD(value)
+			cfg.QuoteRules.ExtraItemCost = cost
+			return err
+		},
+	},
+	{
+		Key:         "domestic-countries",
+		EnvVar:      "DOMESTIC_COUNTRIES",
+		Description: "comma-separated destination countries without the international surcharge",
+		Get:         func(cfg *Config) string { return strings.Join(cfg.QuoteRules.DomesticCountries, listSeparator) },
+		Set: func(cfg *Config, value string) error {
+			cfg.QuoteRules.DomesticCountries = parseList(value)
+			return nil
+		},
+	},
+	{
+		Key:         "international-surcharge",
+		EnvVar:      "INTERNATIONAL_SURCHARGE",
+		Description: "shipping cost in USD added for the destinations outside the domestic countries",
+		Get:         func(cfg *Config) string { return formatUSD(cfg.QuoteRules.InternationalSurcharge) },
+		Set: func(cfg *Config, value string) error {
+			cost, err := parseUSD(value)
+			cfg.QuoteRules.InternationalSurcharge = cost
+			return err
+		},
+	},
+}, tracing.Settings(func(cfg *Config) *tracing.Config { return &cfg.Tracing })...)
+
+func (cfg *Config) validate() []string {
+	var violations []string
+	if net.ParseIP(cfg.Host) == nil {
+		violations = append(violations, fmt.Sprintf("'host' must be an IP address, got '%s'", cfg.Host))
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		violations = append(violations, fmt.Sprintf("'shutdown-timeout' must be positive, got '%s'", cfg.ShutdownTimeout))
+	}
+	if cfg.DrainDelay < 0 {
+		violations = append(violations, fmt.Sprintf("'drain-delay' can't be negative, got '%s'", cfg.DrainDelay))
+	}
+	if len(cfg.QuoteRules.Tiers) == 0 {
+		violations = append(violations, "'quote-tiers' requires at least one tier")
+	}
+	violations = append(violations, cfg.Tracing.Validate()...)
+	return violations
+}
+
+// parseTiers parses the max-items:cost tiers, which must be sorted by number of items
+func parseTiers(value string) ([]shipping.Tier, error) {
+	tiers := []shipping.Tier{}
+	for _, tierValue := range parseList(value) {
+		maxItemsValue, costValue, found := strings.Cut(tierValue, tierSeparator)
+		if !found {
+			return nil, errors.Errorf("'%s' is not a valid tier, use the max-items%scost format (e.g. 2%s4.99)", tierValue, tierSeparator, tierSeparator)
+		}
+		maxItems, err := strconv.ParseInt(strings.TrimSpace(maxItemsValue), 10, 32)
+		if err != nil || maxItems <= 0 {
+			return nil, errors.Errorf("'%s' is not a valid number of items", maxItemsValue)
+		}
+		if len(tiers) > 0 && int32(maxItems) <= tiers[len(tiers)-1].MaxItems {
+			return nil, errors.Errorf("the tiers must be sorted by number of items, '%s' comes after %d items", tierValue, tiers[len(tiers)-1].MaxItems)
+		}
+		cost, err := parseUSD(costValue)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, shipping.Tier{MaxItems: int32(maxItems), Cost: cost})
+	}
+	return tiers, nil
+}
+
+func formatTiers(tiers []shipping.Tier) string {
+	tierValues := make([]string, 0, len(tiers))
+	for _, tier := range tiers {
+		tierValues = append(tierValues, fmt.Sprintf("%d%s%s", tier.MaxItems, tierSeparator, formatUSD(tier.Cost)))
+	}
+	return strings.Join(tierValues, listSeparator)
+}
+
+// parseUSD parses a non-negative decimal amount of USD, e.g. 4.99
+func parseUSD(value string) (shippingservice_rest_types.Money, error) {
+	value = strings.TrimSpace(value)
+	invalidErr := errors.Errorf("'%s' is not a valid amount, use a non-negative decimal number (e.g. 4.99)", value)
+
+	unitsValue, fractionValue, hasFraction := strings.Cut(value, ".")
+	if len(fractionValue) > maxFractionDigits || !isDigits(unitsValue) || (hasFraction && !isDigits(fractionValue)) {
+		return shippingservice_rest_types.Money{}, invalidErr
+	}
+	units, err := strconv.ParseInt(unitsValue, 10, 64)
+	if err != nil {
+		return shippingservice_rest_types.Money{}, invalidErr
+	}
+	var nanos int64
+	if hasFraction {
+		// right-pad the fraction to nanos, e.g. 5 is 500000000
+		nanos, _ = strconv.ParseInt(fractionValue+strings.Repeat("0", maxFractionDigits-len(fractionValue)), 10, 32)
+	}
+	return shipping.USD(units, int32(nanos)), nil
+}
+
+func formatUSD(m shippingservice_rest_types.Money) string {
+	fraction := strings.TrimRight(fmt.Sprintf("%09d", m.Nanos), "0")
+	if len(fraction) < minPrintFractionDigits {
+		fraction += strings.Repeat("0", minPrintFractionDigits-len(fraction))
+	}
+	return fmt.Sprintf("%d.%s", m.Units, fraction)
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseList splits the comma-separated value, ignoring the blank entries
+func parseList(value string) []string {
+	entries := []string{}
+	for _, entry := range strings.Split(value, listSeparator) {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
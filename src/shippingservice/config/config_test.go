@@ -0,0 +1,76 @@
+package config
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kurtosis-tech/new-obd/src/shippingservice/shipping"
+	"github.com/stretchr/testify/require"
+)
+
+const testProgramName = "shippingservice"
+
+func newLookupEnv(env map[string]string) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, found := env[key]
+		return value, found
+	}
+}
+
+func TestLoadParsesTheQuoteRules(t *testing.T) {
+	env := newLookupEnv(map[string]string{
+		"QUOTE_TIERS":             "1:3, 4:6.5,20:10.125",
+		"QUOTE_EXTRA_ITEM_COST":   "0.05",
+		"DOMESTIC_COUNTRIES":      "France, FR,",
+		"INTERNATIONAL_SURCHARGE": "20",
+	})
+
+	cfg, _, err := Load(testProgramName, []string{}, env)
+	require.NoError(t, err)
+
+	require.Equal(t, shipping.Rules{
+		Tiers: []shipping.Tier{
+			{MaxItems: 1, Cost: shipping.USD(3, 0)},
+			{MaxItems: 4, Cost: shipping.USD(6, 500000000)},
+			{MaxItems: 20, Cost: shipping.USD(10, 125000000)},
+		},
+		ExtraItemCost:          shipping.USD(0, 50000000),
+		DomesticCountries:      []string{"France", "FR"},
+		InternationalSurcharge: shipping.USD(20, 0),
+	}, cfg.QuoteRules)
+}
+
+func TestLoadRejectsInvalidQuoteRules(t *testing.T) {
+	tests := map[string]string{
+		"QUOTE_TIERS":           "4:6.50,2:4.99",
+		"QUOTE_EXTRA_ITEM_COST": "-1",
+	}
+	for envVar, value := range tests {
+		t.Run(envVar, func(t *testing.T) {
+			_, _, err := Load(testProgramName, []string{}, newLookupEnv(map[string]string{envVar: value}))
+			require.ErrorContains(t, err, envVar)
+		})
+	}
+
+	invalidValues := []string{"2", "0:4.99", "two:4.99", "2:4.", "2:.99", "2:4.9999999999", "2:4,99"}
+	for _, value := range invalidValues {
+		t.Run(value, func(t *testing.T) {
+			_, err := parseTiers(value)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPrintedQuoteRulesCanBeLoaded(t *testing.T) {
+	cfg, _, err := Load(testProgramName, []string{}, newLookupEnv(nil))
+	require.NoError(t, err)
+
+	var printed bytes.Buffer
+	require.NoError(t, cfg.Print(&printed))
+	require.Contains(t, printed.String(), "quote-tiers: 2:4.99,5:8.99,10:12.99\n")
+	require.Contains(t, printed.String(), "quote-extra-item-cost: 0.75\n")
+
+	tiers, err := parseTiers(formatTiers(cfg.QuoteRules.Tiers))
+	require.NoError(t, err)
+	require.Equal(t, cfg.QuoteRules.Tiers, tiers)
+}
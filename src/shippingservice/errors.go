@@ -0,0 +1,18 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/kurtosis-tech/new-obd/src/shippingservice/shipping"
+	"github.com/pkg/errors"
+)
+
+// statusCodeForError returns the status code of the errors returned by the handlers, based on the shipping error kinds
+func statusCodeForError(err error) int {
+	switch {
+	case errors.Is(err, shipping.ErrInvalidArgument):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	shippingservice_server_rest_server "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/server"
+	shippingservice_rest_types "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/types"
+	"github.com/kurtosis-tech/new-obd/src/shippingservice/shipping"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	healthStatusOk           = "ok"
+	healthStatusShuttingDown = "shutting down"
+)
+
+type Server struct {
+	Shipping *shipping.Service
+	// shuttingDown is a pointer so every copy of the server (the handlers have value receivers) sees the same flag
+	shuttingDown *atomic.Bool
+}
+
+func NewServer(shippingService *shipping.Service) Server {
+	return Server{Shipping: shippingService, shuttingDown: &atomic.Bool{}}
+}
+
+// MarkShuttingDown makes the readiness check fail, so the service stops receiving traffic while it shuts down
+func (s Server) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s Server) GetHealth(ctx context.Context, request shippingservice_server_rest_server.GetHealthRequestObject) (shippingservice_server_rest_server.GetHealthResponseObject, error) {
+
+	status := healthStatusOk
+	now := time.Now()
+
+	response := shippingservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return shippingservice_server_rest_server.GetHealth200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthLive(ctx context.Context, request shippingservice_server_rest_server.GetHealthLiveRequestObject) (shippingservice_server_rest_server.GetHealthLiveResponseObject, error) {
+	status := healthStatusOk
+	now := time.Now()
+
+	response := shippingservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+	}
+
+	return shippingservice_server_rest_server.GetHealthLive200JSONResponse(response), nil
+}
+
+func (s Server) GetHealthReady(ctx context.Context, request shippingservice_server_rest_server.GetHealthReadyRequestObject) (shippingservice_server_rest_server.GetHealthReadyResponseObject, error) {
+	now := time.Now()
+
+	if s.shuttingDown.Load() {
+		status := healthStatusShuttingDown
+		response := shippingservice_rest_types.HealthResponse{
+			Status:    &status,
+			Timestamp: &now,
+		}
+		return shippingservice_server_rest_server.GetHealthReady503JSONResponse(response), nil
+	}
+
+	// the quotes and the tracking IDs are computed in the service, there's no dependency to check
+	checks := []shippingservice_rest_types.DependencyCheck{}
+	status := healthStatusOk
+
+	response := shippingservice_rest_types.HealthResponse{
+		Status:    &status,
+		Timestamp: &now,
+		Checks:    &checks,
+	}
+
+	return shippingservice_server_rest_server.GetHealthReady200JSONResponse(response), nil
+}
+
+func (s Server) PostQuote(ctx context.Context, request shippingservice_server_rest_server.PostQuoteRequestObject) (shippingservice_server_rest_server.PostQuoteResponseObject, error) {
+	logrus.Infof("Quote request - Items: %d", len(request.Body.Items))
+	response, err := s.Shipping.Quote(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return shippingservice_server_rest_server.PostQuote200JSONResponse(*response), nil
+}
+
+func (s Server) PostShip(ctx context.Context, request shippingservice_server_rest_server.PostShipRequestObject) (shippingservice_server_rest_server.PostShipResponseObject, error) {
+	logrus.Infof("Ship order request - Items: %d", len(request.Body.Items))
+	response, err := s.Shipping.ShipOrder(ctx, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+	return shippingservice_server_rest_server.PostShip200JSONResponse(*response), nil
+}
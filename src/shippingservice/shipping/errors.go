@@ -0,0 +1,34 @@
+package shipping
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of errors returned by Quote and ShipOrder, check them with errors.Is
+var (
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+// shippingError tags the cause with one of the error kinds, so callers can classify it without knowing which
+// check failed
+type shippingError struct {
+	kind  error
+	cause error
+}
+
+func newShippingError(kind error, cause error) error {
+	return &shippingError{kind: kind, cause: cause}
+}
+
+func newInvalidArgumentError(format string, args ...interface{}) error {
+	return newShippingError(ErrInvalidArgument, fmt.Errorf(format, args...))
+}
+
+func (e *shippingError) Error() string {
+	return fmt.Sprintf("%s: %s", e.kind.Error(), e.cause.Error())
+}
+
+func (e *shippingError) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
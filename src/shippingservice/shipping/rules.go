@@ -0,0 +1,91 @@
+package shipping
+
+import (
+	"strings"
+
+	"github.com/kurtosis-tech/new-obd/src/libs/money"
+	shippingservice_rest_types "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/types"
+)
+
+// USDCurrencyCode is the currency of the quotes, the callers convert them into the user's currency
+const USDCurrencyCode = "USD"
+
+// Tier is the shipping cost of the orders of up to MaxItems items
+type Tier struct {
+	MaxItems int32
+	Cost     shippingservice_rest_types.Money
+}
+
+// Rules compute the shipping quotes, all the amounts are in USD
+type Rules struct {
+	// Tiers are sorted by MaxItems, an order costs the first tier its items fit in
+	Tiers []Tier
+	// ExtraItemCost is added for every item above the MaxItems of the last tier
+	ExtraItemCost shippingservice_rest_types.Money
+	// DomesticCountries are the destinations without the InternationalSurcharge, they're compared case-insensitively
+	DomesticCountries      []string
+	InternationalSurcharge shippingservice_rest_types.Money
+}
+
+// DefaultRules are the rules of the demo store, which ships from the United States
+func DefaultRules() Rules {
+	return Rules{
+		Tiers: []Tier{
+			{MaxItems: 2, Cost: USD(4, 990000000)},
+			{MaxItems: 5, Cost: USD(8, 990000000)},
+			{MaxItems: 10, Cost: USD(12, 990000000)},
+		},
+		ExtraItemCost:          USD(0, 750000000),
+		DomesticCountries:      []string{"United States", "US", "USA"},
+		InternationalSurcharge: USD(15, 0),
+	}
+}
+
+// USD returns the amount in USD
+func USD(units int64, nanos int32) shippingservice_rest_types.Money {
+	return shippingservice_rest_types.Money{CurrencyCode: USDCurrencyCode, Units: units, Nanos: nanos}
+}
+
+// Quote returns the cost of shipping the number of items, to the country if it's known
+func (r Rules) Quote(itemCount int32, country *string) (shippingservice_rest_types.Money, error) {
+	cost := money.Zero(USDCurrencyCode)
+	if itemCount == 0 {
+		return shippingservice_rest_types.Money(cost), nil
+	}
+
+	var err error
+	if cost, err = r.itemsCost(itemCount); err != nil {
+		return shippingservice_rest_types.Money{}, err
+	}
+	if country != nil && !r.isDomestic(*country) {
+		if cost, err = money.Sum(cost, money.Money(r.InternationalSurcharge)); err != nil {
+			return shippingservice_rest_types.Money{}, err
+		}
+	}
+	return shippingservice_rest_types.Money(cost), nil
+}
+
+func (r Rules) itemsCost(itemCount int32) (money.Money, error) {
+	for _, tier := range r.Tiers {
+		if itemCount <= tier.MaxItems {
+			return money.Money(tier.Cost), nil
+		}
+	}
+
+	lastTier := r.Tiers[len(r.Tiers)-1]
+	extraItemsCost, err := money.Multiply(money.Money(r.ExtraItemCost), uint32(itemCount-lastTier.MaxItems))
+	if err != nil {
+		return money.Money{}, err
+	}
+	return money.Sum(money.Money(lastTier.Cost), extraItemsCost)
+}
+
+func (r Rules) isDomestic(country string) bool {
+	country = strings.TrimSpace(country)
+	for _, domesticCountry := range r.DomesticCountries {
+		if strings.EqualFold(country, domesticCountry) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+// Package shipping quotes the shipping cost of the carts and ships the orders
+package shipping
+
+import (
+	"context"
+
+	shippingservice_rest_types "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type Service struct {
+	rules Rules
+
+	newTrackingID func() (string, error)
+}
+
+func NewService(rules Rules) *Service {
+	return &Service{
+		rules:         rules,
+		newTrackingID: NewTrackingID,
+	}
+}
+
+// Quote returns the cost in USD of shipping the items, the destination is optional since the cart page quotes
+// before the user enters the address
+func (s *Service) Quote(ctx context.Context, request shippingservice_rest_types.QuoteRequest) (*shippingservice_rest_types.QuoteResponse, error) {
+	itemCount, err := countItems(request.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	var country *string
+	if request.Address != nil {
+		country = &request.Address.Country
+	}
+	cost, err := s.rules.Quote(itemCount, country)
+	if err != nil {
+		return nil, errors.Wrapf(err, "An error occurred quoting the shipping of %d items", itemCount)
+	}
+
+	return &shippingservice_rest_types.QuoteResponse{CostUsd: cost}, nil
+}
+
+// ShipOrder ships the items to the address and returns the tracking ID of the shipment
+func (s *Service) ShipOrder(ctx context.Context, request shippingservice_rest_types.ShipOrderRequest) (*shippingservice_rest_types.ShipOrderResponse, error) {
+	itemCount, err := countItems(request.Items)
+	if err != nil {
+		return nil, err
+	}
+	if itemCount == 0 {
+		return nil, newInvalidArgumentError("there are no items to ship")
+	}
+
+	trackingID, err := s.newTrackingID()
+	if err != nil {
+		return nil, errors.Wrap(err, "An error occurred generating the tracking ID")
+	}
+
+	logrus.Infof("Shipped %d items to %s, %s, tracking ID '%s'", itemCount, request.Address.City, request.Address.Country, trackingID)
+	return &shippingservice_rest_types.ShipOrderResponse{TrackingId: trackingID}, nil
+}
+
+// countItems returns the total quantity of the items
+func countItems(items []shippingservice_rest_types.CartItem) (int32, error) {
+	var itemCount int32
+	for _, item := range items {
+		if item.ProductId == "" {
+			return 0, newInvalidArgumentError("an item has no product ID")
+		}
+		if item.Quantity <= 0 {
+			return 0, newInvalidArgumentError("invalid quantity %d for product '%s'", item.Quantity, item.ProductId)
+		}
+		itemCount += item.Quantity
+	}
+	return itemCount, nil
+}
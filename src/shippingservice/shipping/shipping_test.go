@@ -0,0 +1,76 @@
+package shipping
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	shippingservice_rest_types "github.com/kurtosis-tech/new-obd/src/shippingservice/api/http_rest/types"
+	"github.com/stretchr/testify/require"
+)
+
+var trackingIDPattern = regexp.MustCompile(`^OB-[2-9A-HJ-NP-Z]{4}-[2-9A-HJ-NP-Z]{8}$`)
+
+func newTestItems(quantities ...int32) []shippingservice_rest_types.CartItem {
+	items := make([]shippingservice_rest_types.CartItem, 0, len(quantities))
+	for _, quantity := range quantities {
+		items = append(items, shippingservice_rest_types.CartItem{ProductId: "OLJCESPC7Z", Quantity: quantity})
+	}
+	return items
+}
+
+func newTestAddress(country string) shippingservice_rest_types.Address {
+	return shippingservice_rest_types.Address{
+		StreetAddress: "1600 Amphitheatre Parkway",
+		City:          "Mountain View",
+		Country:       country,
+		ZipCode:       "94043",
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []shippingservice_rest_types.CartItem
+		country  string
+		expected shippingservice_rest_types.Money
+	}{
+		{"empty cart", newTestItems(), "", USD(0, 0)},
+		{"first tier", newTestItems(1, 1), "", USD(4, 990000000)},
+		{"second tier", newTestItems(2, 1), "", USD(8, 990000000)},
+		{"last tier", newTestItems(10), "", USD(12, 990000000)},
+		{"above the last tier", newTestItems(9, 4), "", USD(15, 240000000)},
+		{"domestic", newTestItems(1), "united states", USD(4, 990000000)},
+		{"international", newTestItems(1), "France", USD(19, 990000000)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := shippingservice_rest_types.QuoteRequest{Items: test.items}
+			if test.country != "" {
+				address := newTestAddress(test.country)
+				request.Address = &address
+			}
+			response, err := NewService(DefaultRules()).Quote(context.Background(), request)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, response.CostUsd)
+		})
+	}
+}
+
+func TestQuoteRejectsInvalidQuantities(t *testing.T) {
+	_, err := NewService(DefaultRules()).Quote(context.Background(), shippingservice_rest_types.QuoteRequest{Items: newTestItems(1, 0)})
+	require.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestShipOrder(t *testing.T) {
+	request := shippingservice_rest_types.ShipOrderRequest{Address: newTestAddress("United States"), Items: newTestItems(2)}
+	response, err := NewService(DefaultRules()).ShipOrder(context.Background(), request)
+	require.NoError(t, err)
+	require.Regexp(t, trackingIDPattern, response.TrackingId)
+}
+
+func TestShipOrderRejectsAnEmptyOrder(t *testing.T) {
+	request := shippingservice_rest_types.ShipOrderRequest{Address: newTestAddress("United States"), Items: newTestItems()}
+	_, err := NewService(DefaultRules()).ShipOrder(context.Background(), request)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+}
@@ -0,0 +1,42 @@
+package shipping
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+const (
+	trackingIDPrefix = "OB"
+	// without 0, O, 1 and I, so the IDs can be read out and typed without mistakes
+	trackingIDAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+)
+
+// trackingIDGroupLengths are the lengths of the random groups after the prefix
+var trackingIDGroupLengths = []int{4, 8}
+
+// NewTrackingID returns a random tracking ID like OB-4K8M-X7M2Q9TZ
+func NewTrackingID() (string, error) {
+	groups := []string{trackingIDPrefix}
+	for _, length := range trackingIDGroupLengths {
+		group, err := randomString(length)
+		if err != nil {
+			return "", err
+		}
+		groups = append(groups, group)
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+func randomString(length int) (string, error) {
+	alphabetSize := big.NewInt(int64(len(trackingIDAlphabet)))
+	var builder strings.Builder
+	for i := 0; i < length; i++ {
+		index, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteByte(trackingIDAlphabet[index.Int64()])
+	}
+	return builder.String(), nil
+}